@@ -7,18 +7,27 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"regexp"
 	"strings"
+
+	"github.com/berth-dev/berth/internal/ui"
 )
 
 // Bead represents a single unit of work tracked by the beads system.
 type Bead struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Status      string   `json:"status"` // "open", "in_progress", "done", "stuck"
-	DependsOn   []string `json:"depends_on"`
-	Files       []string `json:"files"`
-	VerifyExtra []string `json:"verify_extra,omitempty"`
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Status      string            `json:"status"` // "open", "in_progress", "done", "stuck"
+	DependsOn   []string          `json:"depends_on"`
+	Files       []string          `json:"files"`
+	VerifyExtra []string          `json:"verify_extra,omitempty"`
+	Meta        map[string]string `json:"meta,omitempty"`
+
+	NoFiles      bool `json:"no_files,omitempty"`      // files explicitly declared as "none" in the plan
+	AttemptsUsed int  `json:"attempts_used,omitempty"` // set by the executor once RetryBead returns
+
+	Commits []string `json:"commits,omitempty"` // SHAs of commits made while executing the bead
 }
 
 // ErrBDNotInstalled is returned when the bd CLI is not found in PATH.
@@ -153,6 +162,22 @@ func Close(id, reason string) error {
 	return nil
 }
 
+// Reopen sets a closed bead's status back to open and clears its close
+// reason, so the next run picks it up again.
+func Reopen(id string) error {
+	if err := ensureBD(); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("bd", "reopen", id)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("bd reopen failed: %w: %s", err, output)
+	}
+
+	return nil
+}
+
 // UpdateStatus updates a bead's status.
 func UpdateStatus(id, status string) error {
 	if err := ensureBD(); err != nil {
@@ -183,6 +208,38 @@ func AddDependency(child, parent string) error {
 	return nil
 }
 
+// Show returns the bead with the given ID, failing when bd knows no such
+// bead.
+func Show(id string) (*Bead, error) {
+	if err := ensureBD(); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("bd", "show", id, "--json")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("bd show failed: %w: %s", err, output)
+	}
+
+	// bd prints a list of the beads asked for, or the bead alone in older
+	// versions.
+	trimmed := strings.TrimSpace(string(output))
+	var shown []Bead
+	if strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal([]byte(trimmed), &shown)
+	} else {
+		shown = make([]Bead, 1)
+		err = json.Unmarshal([]byte(trimmed), &shown[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("bd show: failed to parse JSON: %w: %s", err, trimmed)
+	}
+	if len(shown) == 0 || shown[0].ID != id {
+		return nil, fmt.Errorf("bd show: no bead %s", id)
+	}
+	return &shown[0], nil
+}
+
 // List returns all open/in-progress beads in the current project.
 func List() ([]Bead, error) {
 	return listBeads(false)
@@ -241,16 +298,30 @@ func Init() error {
 // maxSummaryLength is the maximum character length for a close reason summary.
 const maxSummaryLength = 500
 
+// conventionalCommitRe matches a conventional-commit style line such as
+// "feat: add login" or "fix(api)!: handle timeouts".
+var conventionalCommitRe = regexp.MustCompile(`^(feat|fix|docs|refactor|perf|test|ci|build|style|chore|revert)(\([\w./-]+\))?!?: \S`)
+
 // ExtractSummary extracts a meaningful summary from Claude's output for the
-// close reason. It attempts to find a summary in the output text, truncating
-// if necessary. Falls back to the bead title if no output is available.
+// close reason. It prefers, in order: a conventional-commit line anywhere in
+// the output, the final message (last paragraph), then the whole output.
+// ANSI escape codes are stripped first so they never reach the close reason.
+// The result is truncated if necessary. Falls back to the bead title if no
+// output is available.
 func ExtractSummary(claudeOutput string, beadTitle string) string {
+	claudeOutput = ui.StripANSI(claudeOutput)
+
 	// Fall back to title if no output.
 	if strings.TrimSpace(claudeOutput) == "" {
 		return "Completed: " + beadTitle
 	}
 
 	summary := strings.TrimSpace(claudeOutput)
+	if line := findConventionalCommitLine(summary); line != "" {
+		summary = line
+	} else if para := lastParagraph(summary); para != "" {
+		summary = para
+	}
 
 	// Truncate if too long, preserving word boundaries where possible.
 	if len(summary) > maxSummaryLength {
@@ -264,3 +335,31 @@ func ExtractSummary(claudeOutput string, beadTitle string) string {
 
 	return summary
 }
+
+// findConventionalCommitLine returns the first line of output that looks like
+// a conventional-commit subject, with surrounding markdown decoration
+// (bullets, backticks, bold markers) stripped. Returns "" if none is found.
+func findConventionalCommitLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		trimmed = strings.TrimLeft(trimmed, "-*> ")
+		trimmed = strings.Trim(trimmed, "`*_ ")
+		if conventionalCommitRe.MatchString(trimmed) {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+// lastParagraph returns the final blank-line-separated block of output,
+// which is typically the closing message Claude writes after its work.
+func lastParagraph(output string) string {
+	normalized := strings.ReplaceAll(output, "\r\n", "\n")
+	paragraphs := strings.Split(normalized, "\n\n")
+	for i := len(paragraphs) - 1; i >= 0; i-- {
+		if p := strings.TrimSpace(paragraphs[i]); p != "" {
+			return p
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package beads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		title  string
+		want   string
+	}{
+		{
+			name:   "empty output falls back to title",
+			output: "",
+			title:  "Add auth",
+			want:   "Completed: Add auth",
+		},
+		{
+			name: "conventional commit line buried in prose",
+			output: "I looked at the existing handlers and added the middleware.\n\n" +
+				"Suggested commit message:\n" +
+				"feat(auth): add JWT middleware to API routes\n\n" +
+				"All tests pass and the linter is clean.",
+			title: "Add auth",
+			want:  "feat(auth): add JWT middleware to API routes",
+		},
+		{
+			name:   "conventional commit wrapped in markdown",
+			output: "Done.\n\n- `fix: handle nil config in loader`\n",
+			title:  "Fix loader",
+			want:   "fix: handle nil config in loader",
+		},
+		{
+			name:   "breaking change marker",
+			output: "Summary below.\nrefactor(api)!: rename Client.Do to Client.Send",
+			title:  "Rename",
+			want:   "refactor(api)!: rename Client.Do to Client.Send",
+		},
+		{
+			name:   "final paragraph when no commit line",
+			output: "Reading files...\nEditing main.go...\n\nImplemented the retry loop with exponential backoff.",
+			title:  "Retry",
+			want:   "Implemented the retry loop with exponential backoff.",
+		},
+		{
+			name:   "prose colon is not a commit line",
+			output: "Note: the fix required two changes.",
+			title:  "Fix",
+			want:   "Note: the fix required two changes.",
+		},
+		{
+			name:   "single paragraph is preserved",
+			output: "  Successfully implemented JWT authentication  ",
+			title:  "Add auth",
+			want:   "Successfully implemented JWT authentication",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractSummary(tt.output, tt.title)
+			if got != tt.want {
+				t.Errorf("ExtractSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSummaryTruncatesLongFinalMessage(t *testing.T) {
+	output := "Intro paragraph.\n\n" + strings.Repeat("word ", 200)
+	got := ExtractSummary(output, "Big task")
+
+	if len(got) > maxSummaryLength+3 {
+		t.Errorf("ExtractSummary length = %d, want <= %d", len(got), maxSummaryLength+3)
+	}
+	if !strings.HasSuffix(got, "...") {
+		t.Errorf("ExtractSummary = %q, want truncation suffix", got)
+	}
+	if strings.Contains(got, "Intro") {
+		t.Errorf("ExtractSummary = %q, should use the final paragraph", got)
+	}
+}
+
+func TestExtractSummaryStripsANSI(t *testing.T) {
+	output := "\x1b[1mWorking...\x1b[0m\n\n\x1b[32m✓\x1b[0m Added \x1b[1;34mlogin handler\x1b[0m with tests\x1b]0;claude\x07"
+	got := ExtractSummary(output, "Add login")
+	want := "✓ Added login handler with tests"
+	if got != want {
+		t.Errorf("ExtractSummary() = %q, want %q", got, want)
+	}
+
+	commit := "\x1b[33mfeat(auth): add login\x1b[0m\nmore details"
+	if got := ExtractSummary(commit, "Add login"); got != "feat(auth): add login" {
+		t.Errorf("ExtractSummary() = %q, want conventional commit without escapes", got)
+	}
+}
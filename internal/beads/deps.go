@@ -0,0 +1,147 @@
+// deps.go orders beads by their dependencies and checks those dependencies
+// for cycles. It is shared by planning and execution.
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Levels groups allBeads by dependency level using topological sort
+// (Kahn's algorithm): the beads of a level depend on no bead of the same or
+// a later level, so they can run in parallel. Levels are returned in
+// execution order and each lists its bead IDs sorted.
+// Dependencies on beads that are closed, or not in allBeads, are already
+// satisfied, so a reopened bead does not wait on the beads it built on.
+// The beads of a cycle are lumped into a final level.
+func Levels(allBeads []Bead) [][]string {
+	if len(allBeads) == 0 {
+		return nil
+	}
+
+	// Build the set of unfinished beads a dependency can still wait on.
+	beadSet := make(map[string]bool, len(allBeads))
+	for _, b := range allBeads {
+		if b.Status != "closed" && b.Status != "done" {
+			beadSet[b.ID] = true
+		}
+	}
+
+	// Build dependency graph: inDegree tracks how many unresolved dependencies each bead has.
+	inDegree := make(map[string]int, len(allBeads))
+	// rdeps maps bead ID to the list of beads that depend on it.
+	rdeps := make(map[string][]string, len(allBeads))
+
+	for _, b := range allBeads {
+		inDegree[b.ID] = 0
+	}
+
+	for _, b := range allBeads {
+		for _, dep := range b.DependsOn {
+			// Only count dependencies that are still to be done.
+			if beadSet[dep] {
+				inDegree[b.ID]++
+				rdeps[dep] = append(rdeps[dep], b.ID)
+			}
+		}
+	}
+
+	// Track remaining beads to process.
+	remaining := make(map[string]bool, len(allBeads))
+	for _, b := range allBeads {
+		remaining[b.ID] = true
+	}
+
+	var levels [][]string
+
+	// Process beads level by level using Kahn's algorithm.
+	for len(remaining) > 0 {
+		// Find all beads with inDegree == 0 (no unresolved dependencies).
+		var ready []string
+		for id := range remaining {
+			if inDegree[id] == 0 {
+				ready = append(ready, id)
+			}
+		}
+
+		// If no beads are ready but some remain, there's a cycle.
+		// Include all remaining beads in final level to avoid infinite loop.
+		if len(ready) == 0 {
+			for id := range remaining {
+				ready = append(ready, id)
+			}
+		}
+
+		// Sort for deterministic ordering.
+		sort.Strings(ready)
+
+		levels = append(levels, ready)
+
+		// Remove processed beads from remaining and update inDegree for dependents.
+		for _, id := range ready {
+			delete(remaining, id)
+			// Decrement inDegree for all beads that depend on this one.
+			for _, dependent := range rdeps[id] {
+				if remaining[dependent] {
+					inDegree[dependent]--
+				}
+			}
+		}
+	}
+
+	return levels
+}
+
+// ValidateDependencies reports a dependency cycle among allBeads, naming the
+// beads in it (e.g. "dependency cycle: bt-1 -> bt-2 -> bt-1"). Levels would
+// otherwise lump the cycle's beads into a final level and run them with
+// their dependencies unmet. Dependencies on beads outside allBeads are
+// ignored, as they are by Levels.
+func ValidateDependencies(allBeads []Bead) error {
+	index := make(map[string]int, len(allBeads))
+	for i, b := range allBeads {
+		index[b.ID] = i
+	}
+
+	// Depth-first search; path holds the beads being visited, in order.
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(allBeads))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			start := 0
+			for start < len(path) && path[start] != allBeads[i].ID {
+				start++
+			}
+			cycle := append(append([]string(nil), path[start:]...), allBeads[i].ID)
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		case done:
+			return nil
+		}
+		state[i] = visiting
+		path = append(path, allBeads[i].ID)
+		for _, dep := range allBeads[i].DependsOn {
+			if j, ok := index[dep]; ok {
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+		return nil
+	}
+	for i := range allBeads {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
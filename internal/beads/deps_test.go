@@ -0,0 +1,61 @@
+package beads
+
+import "testing"
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		beads []Bead
+		want  string // error; empty for acyclic dependencies
+	}{
+		{
+			name: "acyclic",
+			beads: []Bead{
+				{ID: "bt-1"},
+				{ID: "bt-2", DependsOn: []string{"bt-1"}},
+				{ID: "bt-3", DependsOn: []string{"bt-1", "bt-2"}},
+			},
+		},
+		{
+			name:  "dependency outside the set ignored",
+			beads: []Bead{{ID: "bt-2", DependsOn: []string{"bt-1"}}},
+		},
+		{
+			name:  "self-loop",
+			beads: []Bead{{ID: "bt-1"}, {ID: "bt-2", DependsOn: []string{"bt-2"}}},
+			want:  "dependency cycle: bt-2 -> bt-2",
+		},
+		{
+			name: "two beads",
+			beads: []Bead{
+				{ID: "bt-1", DependsOn: []string{"bt-2"}},
+				{ID: "bt-2", DependsOn: []string{"bt-1"}},
+			},
+			want: "dependency cycle: bt-1 -> bt-2 -> bt-1",
+		},
+		{
+			name: "cycle behind an acyclic prefix",
+			beads: []Bead{
+				{ID: "bt-1", DependsOn: []string{"bt-2"}},
+				{ID: "bt-2", DependsOn: []string{"bt-4"}},
+				{ID: "bt-3", DependsOn: []string{"bt-2"}},
+				{ID: "bt-4", DependsOn: []string{"bt-3"}},
+			},
+			want: "dependency cycle: bt-2 -> bt-4 -> bt-3 -> bt-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDependencies(tt.beads)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("ValidateDependencies = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("ValidateDependencies = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// idempotency.go computes and checks per-bead idempotency markers so that a
+// bead whose work is already applied is not executed a second time.
+package beads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SpecHash returns a stable hash of a bead's spec (title, description, files
+// and verify_extra). It changes whenever the work the bead describes changes.
+func SpecHash(b *Bead) string {
+	files := append([]string(nil), b.Files...)
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, part := range []string{
+		b.Title,
+		b.Description,
+		strings.Join(files, "\x00"),
+		strings.Join(b.VerifyExtra, "\x00"),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0x1e})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// RecordApplied stores the bead's idempotency key and the current content
+// hashes of its files in the sidecar metadata. Existing sidecar fields are
+// preserved.
+func RecordApplied(projectRoot string, b *Bead) error {
+	meta, err := ReadBeadMeta(projectRoot, b.ID)
+	if err != nil {
+		meta = &BeadMeta{Files: b.Files, VerifyExtra: b.VerifyExtra, Meta: b.Meta, NoFiles: b.NoFiles}
+	}
+	meta.IdempotencyKey = SpecHash(b)
+	meta.AppliedFiles = hashFiles(projectRoot, b.Files)
+	if b.AttemptsUsed > 0 {
+		meta.AttemptsUsed = b.AttemptsUsed
+	}
+	if len(b.Commits) > 0 {
+		meta.Commits = b.Commits
+	}
+	return WriteBeadMeta(projectRoot, b.ID, *meta)
+}
+
+// ClearApplied removes the bead's idempotency marker so IsApplied no longer
+// reports its work as present, e.g. once it is reopened for another pass.
+// A bead without sidecar metadata has nothing to clear.
+func ClearApplied(projectRoot, beadID string) error {
+	meta, err := ReadBeadMeta(projectRoot, beadID)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	meta.IdempotencyKey = ""
+	meta.AppliedFiles = nil
+	return WriteBeadMeta(projectRoot, beadID, *meta)
+}
+
+// IsApplied reports whether the bead's work is already present in the tree:
+// the sidecar carries an idempotency key matching the current spec, and none
+// of the files recorded at close time have changed since. A bead without
+// files is never reported as applied, since nothing shows its work is there.
+func IsApplied(projectRoot string, b *Bead) bool {
+	if len(b.Files) == 0 {
+		return false
+	}
+	meta, err := ReadBeadMeta(projectRoot, b.ID)
+	if err != nil || meta.IdempotencyKey == "" {
+		return false
+	}
+	if meta.IdempotencyKey != SpecHash(b) {
+		return false
+	}
+
+	current := hashFiles(projectRoot, b.Files)
+	if len(current) != len(meta.AppliedFiles) {
+		return false
+	}
+	for file, sum := range meta.AppliedFiles {
+		if current[file] != sum {
+			return false
+		}
+	}
+	return true
+}
+
+// hashFiles returns a map of file path to content hash. Missing files are
+// recorded with an empty hash so that their later creation is detected.
+func hashFiles(projectRoot string, files []string) map[string]string {
+	if len(files) == 0 {
+		return nil
+	}
+	sums := make(map[string]string, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(projectRoot, f))
+		if err != nil {
+			sums[f] = ""
+			continue
+		}
+		sum := sha256.Sum256(data)
+		sums[f] = hex.EncodeToString(sum[:])
+	}
+	return sums
+}
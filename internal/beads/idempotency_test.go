@@ -0,0 +1,121 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsAppliedSkipPath(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "auth.go"), []byte("package auth\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bead := &Bead{
+		ID:          "bd-1",
+		Title:       "Add auth",
+		Description: "Create the auth package",
+		Files:       []string{"auth.go"},
+	}
+	if err := WriteBeadMeta(root, bead.ID, BeadMeta{Files: bead.Files}); err != nil {
+		t.Fatal(err)
+	}
+
+	if IsApplied(root, bead) {
+		t.Fatal("bead should not be applied before RecordApplied")
+	}
+
+	if err := RecordApplied(root, bead); err != nil {
+		t.Fatalf("RecordApplied: %v", err)
+	}
+	if !IsApplied(root, bead) {
+		t.Fatal("bead should be applied after RecordApplied with unchanged files")
+	}
+
+	// Other sidecar fields are preserved.
+	meta, err := ReadBeadMeta(root, bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.Files) != 1 || meta.Files[0] != "auth.go" {
+		t.Errorf("Files = %v, want [auth.go]", meta.Files)
+	}
+}
+
+func TestIsAppliedDetectsChanges(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "auth.go")
+	if err := os.WriteFile(path, []byte("package auth\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bead := &Bead{ID: "bd-2", Title: "Add auth", Files: []string{"auth.go"}}
+	if err := RecordApplied(root, bead); err != nil {
+		t.Fatalf("RecordApplied: %v", err)
+	}
+
+	// Changing the spec invalidates the marker.
+	changed := *bead
+	changed.Description = "Different work"
+	if IsApplied(root, &changed) {
+		t.Error("bead with a changed spec should not be applied")
+	}
+
+	// Changing a file invalidates the marker.
+	if err := os.WriteFile(path, []byte("package auth\n\nfunc Login() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if IsApplied(root, bead) {
+		t.Error("bead with a modified file should not be applied")
+	}
+}
+
+func TestSpecHashIgnoresFileOrder(t *testing.T) {
+	a := &Bead{Title: "T", Files: []string{"a.go", "b.go"}}
+	b := &Bead{Title: "T", Files: []string{"b.go", "a.go"}}
+	if SpecHash(a) != SpecHash(b) {
+		t.Error("SpecHash should not depend on file order")
+	}
+	c := &Bead{Title: "T", Files: []string{"a.go"}, VerifyExtra: []string{"go test"}}
+	if SpecHash(a) == SpecHash(c) {
+		t.Error("SpecHash should differ for different specs")
+	}
+}
+
+func TestClearApplied(t *testing.T) {
+	root := t.TempDir()
+	bead := &Bead{ID: "bd-1", Title: "Add auth", Files: []string{"auth.go"}, Commits: []string{"abc123"}}
+	if err := RecordApplied(root, bead); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ClearApplied(root, bead.ID); err != nil {
+		t.Fatalf("ClearApplied: %v", err)
+	}
+	if IsApplied(root, bead) {
+		t.Error("bead still applied after ClearApplied")
+	}
+	meta, err := ReadBeadMeta(root, bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.Files) != 1 || len(meta.Commits) != 1 {
+		t.Errorf("ClearApplied dropped other fields: %+v", meta)
+	}
+
+	if err := ClearApplied(root, "bd-none"); err != nil {
+		t.Errorf("ClearApplied without metadata = %v, want nil", err)
+	}
+}
+
+func TestIsAppliedNeverForFilelessBeads(t *testing.T) {
+	root := t.TempDir()
+	bead := &Bead{ID: "bd-3", Title: "Document the API", NoFiles: true}
+	if err := RecordApplied(root, bead); err != nil {
+		t.Fatal(err)
+	}
+	if IsApplied(root, bead) {
+		t.Error("bead without files reported as applied")
+	}
+}
@@ -2,18 +2,45 @@ package beads
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 )
 
 // BeadMeta holds plan-level metadata that the bd CLI can't store.
 type BeadMeta struct {
-	Files       []string `json:"files"`
-	VerifyExtra []string `json:"verify_extra"`
+	Files       []string          `json:"files"`
+	VerifyExtra []string          `json:"verify_extra"`
+	Meta        map[string]string `json:"meta,omitempty"`
+	NoFiles     bool              `json:"no_files,omitempty"` // files explicitly declared as "none"
+
+	// Idempotency marker recorded on successful close (see RecordApplied).
+	IdempotencyKey string            `json:"idempotency_key,omitempty"`
+	AppliedFiles   map[string]string `json:"applied_files,omitempty"` // file -> sha256 at close time
+	AttemptsUsed   int               `json:"attempts_used,omitempty"` // attempt that passed verification
+	Commits        []string          `json:"commits,omitempty"`       // SHAs of commits the bead produced
+}
+
+// beadIDRe matches the bead IDs bd generates, e.g. "bt-1" or "bt-a3f8.2".
+var beadIDRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidateID rejects a bead ID that bd cannot have generated, in particular
+// one that would put its sidecar outside .berth/bead-meta/.
+func ValidateID(beadID string) error {
+	if !beadIDRe.MatchString(beadID) || strings.Contains(beadID, "..") {
+		return fmt.Errorf("invalid bead ID %q", beadID)
+	}
+	return nil
 }
 
 // WriteBeadMeta writes sidecar metadata for a bead into .berth/bead-meta/.
 func WriteBeadMeta(projectRoot, beadID string, meta BeadMeta) error {
+	if err := ValidateID(beadID); err != nil {
+		return err
+	}
 	dir := filepath.Join(projectRoot, ".berth", "bead-meta")
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
@@ -27,6 +54,9 @@ func WriteBeadMeta(projectRoot, beadID string, meta BeadMeta) error {
 
 // ReadBeadMeta reads sidecar metadata for a bead from .berth/bead-meta/.
 func ReadBeadMeta(projectRoot, beadID string) (*BeadMeta, error) {
+	if err := ValidateID(beadID); err != nil {
+		return nil, err
+	}
 	path := filepath.Join(projectRoot, ".berth", "bead-meta", beadID+".json")
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -38,3 +68,29 @@ func ReadBeadMeta(projectRoot, beadID string) (*BeadMeta, error) {
 	}
 	return &meta, nil
 }
+
+// NormalizeFiles cleans a declared file list into repo-relative slash paths,
+// dropping blanks and duplicates. Absolute paths and paths that leave the
+// project root are rejected.
+func NormalizeFiles(files []string) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+	for _, f := range files {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if filepath.IsAbs(f) || strings.HasPrefix(f, "/") {
+			return nil, fmt.Errorf("file %q must be relative to the project root", f)
+		}
+		clean := path.Clean(filepath.ToSlash(f))
+		if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return nil, fmt.Errorf("file %q is outside the project root", f)
+		}
+		if !seen[clean] {
+			seen[clean] = true
+			out = append(out, clean)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,112 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBeadMetaRoundTrip(t *testing.T) {
+	root := t.TempDir()
+
+	want := BeadMeta{
+		Files:       []string{"src/auth.go"},
+		VerifyExtra: []string{"go test ./auth/..."},
+		Meta:        map[string]string{"jira": "PROJ-123", "team": "core"},
+	}
+	if err := WriteBeadMeta(root, "bd-1", want); err != nil {
+		t.Fatalf("WriteBeadMeta: %v", err)
+	}
+
+	got, err := ReadBeadMeta(root, "bd-1")
+	if err != nil {
+		t.Fatalf("ReadBeadMeta: %v", err)
+	}
+	if len(got.Files) != 1 || got.Files[0] != "src/auth.go" {
+		t.Errorf("Files = %v, want %v", got.Files, want.Files)
+	}
+	if len(got.VerifyExtra) != 1 || got.VerifyExtra[0] != "go test ./auth/..." {
+		t.Errorf("VerifyExtra = %v, want %v", got.VerifyExtra, want.VerifyExtra)
+	}
+	if len(got.Meta) != 2 || got.Meta["jira"] != "PROJ-123" || got.Meta["team"] != "core" {
+		t.Errorf("Meta = %v, want %v", got.Meta, want.Meta)
+	}
+}
+
+func TestReadBeadMetaWithoutMetaField(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ".berth", "bead-meta")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Sidecar written before the meta field existed.
+	legacy := `{"files":["a.go"],"verify_extra":null}`
+	if err := os.WriteFile(filepath.Join(dir, "bd-2.json"), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadBeadMeta(root, "bd-2")
+	if err != nil {
+		t.Fatalf("ReadBeadMeta: %v", err)
+	}
+	if got.Meta != nil {
+		t.Errorf("Meta = %v, want nil", got.Meta)
+	}
+	if len(got.Files) != 1 || got.Files[0] != "a.go" {
+		t.Errorf("Files = %v, want [a.go]", got.Files)
+	}
+}
+
+func TestBeadMetaRoundTripPreservesMarker(t *testing.T) {
+	root := t.TempDir()
+
+	want := BeadMeta{
+		NoFiles:        true,
+		IdempotencyKey: "abc123",
+		AppliedFiles:   map[string]string{"a.go": "sum"},
+		AttemptsUsed:   2,
+		Commits:        []string{"deadbeef"},
+	}
+	if err := WriteBeadMeta(root, "bd-3", want); err != nil {
+		t.Fatalf("WriteBeadMeta: %v", err)
+	}
+
+	got, err := ReadBeadMeta(root, "bd-3")
+	if err != nil {
+		t.Fatalf("ReadBeadMeta: %v", err)
+	}
+	if !got.NoFiles || got.IdempotencyKey != "abc123" || got.AppliedFiles["a.go"] != "sum" ||
+		got.AttemptsUsed != 2 || len(got.Commits) != 1 || got.Commits[0] != "deadbeef" {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// Rewriting an edited sidecar replaces it.
+	got.Files, got.NoFiles = []string{"b.go"}, false
+	if err := WriteBeadMeta(root, "bd-3", *got); err != nil {
+		t.Fatalf("WriteBeadMeta: %v", err)
+	}
+	again, err := ReadBeadMeta(root, "bd-3")
+	if err != nil {
+		t.Fatalf("ReadBeadMeta: %v", err)
+	}
+	if again.NoFiles || len(again.Files) != 1 || again.Files[0] != "b.go" || again.IdempotencyKey != "abc123" {
+		t.Errorf("after edit got %+v", again)
+	}
+}
+
+func TestNormalizeFiles(t *testing.T) {
+	got, err := NormalizeFiles([]string{" src/auth.go ", "./src/auth.go", "src/../cmd/main.go", ""})
+	if err != nil {
+		t.Fatalf("NormalizeFiles: %v", err)
+	}
+	if strings.Join(got, ",") != "src/auth.go,cmd/main.go" {
+		t.Errorf("NormalizeFiles = %v, want [src/auth.go cmd/main.go]", got)
+	}
+
+	for _, bad := range []string{"/etc/passwd", "../outside.go", "src/../../x.go", "."} {
+		if _, err := NormalizeFiles([]string{bad}); err == nil {
+			t.Errorf("NormalizeFiles(%q) succeeded, want an error", bad)
+		}
+	}
+}
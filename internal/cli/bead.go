@@ -0,0 +1,205 @@
+// bead.go implements "berth bead show/set/reopen" for inspecting and
+// correcting a single bead without re-planning.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/spf13/cobra"
+)
+
+var beadCmd = &cobra.Command{
+	Use:   "bead",
+	Short: "Inspect, edit or reopen a bead",
+	Long: `Inspect or edit the metadata berth keeps for a bead in
+.berth/bead-meta/<id>.json: the files it may touch and its extra
+verification commands.
+
+Use "berth bead set" to fix a mis-planned file list before re-running
+the bead, instead of regenerating the whole plan, and "berth bead reopen"
+to run a closed bead again.`,
+}
+
+var beadShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Print a bead's sidecar metadata",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBeadShow,
+}
+
+var beadSetCmd = &cobra.Command{
+	Use:   "set <id>",
+	Short: "Change a bead's files or extra verification commands",
+	Long: `Change a bead's sidecar metadata. Only the flags given are changed.
+
+--files takes a comma-separated list of paths relative to the project
+root, or "none" for a bead that touches no files. --verify-extra may be
+repeated, once per command; pass it with an empty value to clear them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadSet,
+}
+
+var beadReopenCmd = &cobra.Command{
+	Use:   "reopen <id>",
+	Short: "Reopen a closed bead so the next run executes it again",
+	Long: `Set a closed bead back to open and clear its close reason, for a bead
+that was closed but turned out to be wrong. Its recorded idempotency
+marker is cleared too, so the next "berth run" executes it instead of
+skipping it as already applied. Beads that depend on it are not reopened.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadReopen,
+}
+
+func init() {
+	beadSetCmd.Flags().StringSlice("files", nil, `Files the bead may touch (comma-separated, or "none")`)
+	beadSetCmd.Flags().StringArray("verify-extra", nil, "Extra verification command (repeatable)")
+	beadCmd.AddCommand(beadShowCmd)
+	beadCmd.AddCommand(beadSetCmd)
+	beadCmd.AddCommand(beadReopenCmd)
+}
+
+func runBeadShow(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(".berth"); os.IsNotExist(err) {
+		return fmt.Errorf(".berth/ not found. Run 'berth init' first")
+	}
+
+	meta, err := beads.ReadBeadMeta(".", args[0])
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no metadata for bead %s", args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("reading metadata for bead %s: %w", args[0], err)
+	}
+	fmt.Print(formatBeadMeta(args[0], meta))
+	return nil
+}
+
+func runBeadSet(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(".berth"); os.IsNotExist(err) {
+		return fmt.Errorf(".berth/ not found. Run 'berth init' first")
+	}
+	flags := cmd.Flags()
+	if !flags.Changed("files") && !flags.Changed("verify-extra") {
+		return fmt.Errorf("nothing to change; pass --files and/or --verify-extra")
+	}
+
+	files, _ := flags.GetStringSlice("files")
+	verifyExtra, _ := flags.GetStringArray("verify-extra")
+	meta, err := setBeadMeta(".", args[0], files, flags.Changed("files"), verifyExtra, flags.Changed("verify-extra"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Updated bead %s\n", args[0])
+	fmt.Print(formatBeadMeta(args[0], meta))
+	return nil
+}
+
+func runBeadReopen(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(".berth"); os.IsNotExist(err) {
+		return fmt.Errorf(".berth/ not found. Run 'berth init' first")
+	}
+
+	if err := beads.Reopen(args[0]); err != nil {
+		return fmt.Errorf("reopening bead %s: %w", args[0], err)
+	}
+	if err := beads.ClearApplied(".", args[0]); err != nil {
+		return fmt.Errorf("clearing applied marker of bead %s: %w", args[0], err)
+	}
+	fmt.Printf("Reopened bead %s; the next berth run will execute it again\n", args[0])
+	return nil
+}
+
+// setBeadMeta applies the given edits to a bead's sidecar, creating it if the
+// bead has none yet, and writes it back. The bead must exist in bd, so a
+// mistyped ID does not leave an orphan sidecar. Files are validated with
+// beads.NormalizeFiles; "none" marks the bead as touching no files.
+func setBeadMeta(projectRoot, beadID string, files []string, setFiles bool, verifyExtra []string, setVerify bool) (*beads.BeadMeta, error) {
+	if err := beads.ValidateID(beadID); err != nil {
+		return nil, err
+	}
+	if _, err := beads.Show(beadID); err != nil {
+		return nil, fmt.Errorf("bead %s not found: %w", beadID, err)
+	}
+
+	meta, err := beads.ReadBeadMeta(projectRoot, beadID)
+	if os.IsNotExist(err) {
+		meta = &beads.BeadMeta{}
+	} else if err != nil {
+		return nil, fmt.Errorf("reading metadata for bead %s: %w", beadID, err)
+	}
+
+	if setFiles {
+		if len(files) == 1 && strings.EqualFold(strings.TrimSpace(files[0]), "none") {
+			meta.Files, meta.NoFiles = nil, true
+		} else {
+			normalized, err := beads.NormalizeFiles(files)
+			if err != nil {
+				return nil, err
+			}
+			meta.Files, meta.NoFiles = normalized, false
+		}
+	}
+	if setVerify {
+		meta.VerifyExtra = nil
+		for _, c := range verifyExtra {
+			if c = strings.TrimSpace(c); c != "" {
+				meta.VerifyExtra = append(meta.VerifyExtra, c)
+			}
+		}
+	}
+
+	if err := beads.WriteBeadMeta(projectRoot, beadID, *meta); err != nil {
+		return nil, fmt.Errorf("writing metadata for bead %s: %w", beadID, err)
+	}
+	return meta, nil
+}
+
+// formatBeadMeta renders a bead's sidecar metadata for "berth bead show".
+func formatBeadMeta(beadID string, meta *beads.BeadMeta) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Bead %s\n", beadID)
+
+	files := strings.Join(meta.Files, ", ")
+	switch {
+	case meta.NoFiles:
+		files = "none"
+	case files == "":
+		files = "(not declared)"
+	}
+	fmt.Fprintf(&b, "  Files:        %s\n", files)
+
+	if len(meta.VerifyExtra) == 0 {
+		b.WriteString("  Verify extra: (none)\n")
+	} else {
+		b.WriteString("  Verify extra:\n")
+		for _, c := range meta.VerifyExtra {
+			fmt.Fprintf(&b, "    - %s\n", c)
+		}
+	}
+
+	if len(meta.Meta) > 0 {
+		keys := make([]string, 0, len(meta.Meta))
+		for k := range meta.Meta {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, len(keys))
+		for i, k := range keys {
+			pairs[i] = k + "=" + meta.Meta[k]
+		}
+		fmt.Fprintf(&b, "  Meta:         %s\n", strings.Join(pairs, ", "))
+	}
+
+	if meta.IdempotencyKey != "" {
+		fmt.Fprintf(&b, "  Applied:      key %s, attempt %d", meta.IdempotencyKey, meta.AttemptsUsed)
+		if len(meta.Commits) > 0 {
+			fmt.Fprintf(&b, ", commits %s", strings.Join(meta.Commits, " "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
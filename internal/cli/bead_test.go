@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+// fakeBDShow puts a fake bd on PATH whose "bd show" knows only ids.
+func fakeBDShow(t *testing.T, ids ...string) {
+	t.Helper()
+	var script string
+	for _, id := range ids {
+		script += "[ \"$1\" = show ] && [ \"$2\" = " + id + " ] && echo '[{\"id\":\"" + id + "\",\"status\":\"open\"}]' && exit 0\n"
+	}
+	script += "echo \"Error: no issue found matching $2\" >&2\nexit 1\n"
+	testutil.FakeCommand(t, "bd", script)
+}
+
+func TestSetBeadMetaKeepsUnchangedFields(t *testing.T) {
+	fakeBDShow(t, "bt-1")
+	root := t.TempDir()
+	if err := beads.WriteBeadMeta(root, "bt-1", beads.BeadMeta{
+		Files:       []string{"old.go"},
+		VerifyExtra: []string{"go test ./auth/..."},
+		Meta:        map[string]string{"jira": "PROJ-1"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := setBeadMeta(root, "bt-1", []string{"./src/auth.go", "src/session.go"}, true, nil, false)
+	if err != nil {
+		t.Fatalf("setBeadMeta: %v", err)
+	}
+	if strings.Join(meta.Files, ",") != "src/auth.go,src/session.go" {
+		t.Errorf("Files = %v, want normalized [src/auth.go src/session.go]", meta.Files)
+	}
+
+	got, err := beads.ReadBeadMeta(root, "bt-1")
+	if err != nil {
+		t.Fatalf("ReadBeadMeta: %v", err)
+	}
+	if len(got.Files) != 2 || len(got.VerifyExtra) != 1 || got.Meta["jira"] != "PROJ-1" {
+		t.Errorf("sidecar = %+v, want new files and the old verify_extra and meta", got)
+	}
+
+	out := formatBeadMeta("bt-1", got)
+	for _, want := range []string{"Files:        src/auth.go, src/session.go", "- go test ./auth/...", "jira=PROJ-1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("show output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSetBeadMetaFilesNoneAndInvalid(t *testing.T) {
+	fakeBDShow(t, "bt-2")
+	root := t.TempDir()
+
+	meta, err := setBeadMeta(root, "bt-2", []string{"none"}, true, []string{""}, true)
+	if err != nil {
+		t.Fatalf("setBeadMeta: %v", err)
+	}
+	if !meta.NoFiles || meta.Files != nil || meta.VerifyExtra != nil {
+		t.Errorf("meta = %+v, want no files and no verify_extra", meta)
+	}
+
+	if _, err := setBeadMeta(root, "bt-2", []string{"../escape.go"}, true, nil, false); err == nil {
+		t.Error("setBeadMeta accepted a path outside the project root")
+	}
+	if got, _ := beads.ReadBeadMeta(root, "bt-2"); got == nil || !got.NoFiles {
+		t.Errorf("sidecar = %+v, want it unchanged after the rejected edit", got)
+	}
+}
+
+func TestSetBeadMetaRejectsUnknownAndInvalidIDs(t *testing.T) {
+	fakeBDShow(t, "bt-1")
+	root := t.TempDir()
+
+	for _, id := range []string{"bt-9", "../x", "a/b", ""} {
+		if _, err := setBeadMeta(root, id, []string{"main.go"}, true, nil, false); err == nil {
+			t.Errorf("setBeadMeta(%q) succeeded, want an error", id)
+		}
+	}
+	if entries, _ := os.ReadDir(filepath.Join(root, ".berth", "bead-meta")); len(entries) != 0 {
+		t.Errorf("sidecars written for rejected IDs: %v", entries)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".berth", "x.json")); err == nil {
+		t.Error("sidecar written outside .berth/bead-meta")
+	}
+}
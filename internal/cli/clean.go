@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 
 	"github.com/berth-dev/berth/internal/cleanup"
-	"github.com/berth-dev/berth/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -45,7 +44,7 @@ func runClean(cmd *cobra.Command, args []string) error {
 	if keepFlag > 0 {
 		pruned, err = cleanup.PruneKeepRecent(runsDir, keepFlag, dryRunFlag)
 	} else {
-		cfg, cfgErr := config.ReadConfig(".")
+		cfg, cfgErr := readConfig(".")
 		if cfgErr != nil {
 			return fmt.Errorf("reading config: %w", cfgErr)
 		}
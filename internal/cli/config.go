@@ -0,0 +1,132 @@
+// config.go implements "berth config get/set" for reading and editing single
+// settings in .berth/config.yaml, and "berth config validate" for checking it.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read or change settings in .berth/config.yaml",
+	Long: `Read or change individual settings in .berth/config.yaml.
+
+Keys are dotted YAML paths, e.g. execution.parallel_mode or tui.theme.
+Values are parsed according to the setting's type and validated before the
+file is written. List settings take a comma-separated value.`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change the value of a setting",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config and suggest fixes",
+	Long: `Check .berth/config.yaml (merged with the selected profile) for invalid
+settings and verify commands that cannot be found. Each problem is printed
+with its key and a suggested fix. Exits non-zero if any problem would stop
+berth from running.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(".berth"); os.IsNotExist(err) {
+		return fmt.Errorf(".berth/ not found. Run 'berth init' first")
+	}
+
+	cfg, err := readConfig(".")
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	val, err := config.GetValue(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println(val)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(".berth"); os.IsNotExist(err) {
+		return fmt.Errorf(".berth/ not found. Run 'berth init' first")
+	}
+
+	if err := config.SetValue(".", args[0], args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("Set %s = %s\n", args[0], args[1])
+	return nil
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(".berth"); os.IsNotExist(err) {
+		return fmt.Errorf(".berth/ not found. Run 'berth init' first")
+	}
+
+	p := profile
+	if p == "" {
+		p = os.Getenv(config.ProfileEnv)
+	}
+	problems, err := config.CheckConfigProfile(".", p)
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("Config OK")
+		return nil
+	}
+	fmt.Print(formatProblems(problems))
+
+	fatal := 0
+	for _, pr := range problems {
+		if pr.Fatal {
+			fatal++
+		}
+	}
+	if fatal > 0 {
+		return &reportedError{fmt.Errorf("config has %d error(s)", fatal)}
+	}
+	return nil
+}
+
+// formatProblems renders one entry per problem: its severity and key, then
+// the suggested fix on an indented line.
+func formatProblems(problems []config.Problem) string {
+	var b strings.Builder
+	for _, p := range problems {
+		severity := "warning"
+		if p.Fatal {
+			severity = "error"
+		}
+		fmt.Fprintf(&b, "%s: %s: %s\n", severity, p.Key, p.Message)
+		if p.Fix != "" {
+			fmt.Fprintf(&b, "  fix: %s\n", p.Fix)
+		}
+	}
+	return b.String()
+}
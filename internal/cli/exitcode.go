@@ -0,0 +1,49 @@
+// exitcode.go maps command errors onto berth's exit codes, so scripts and CI
+// can tell a run that left beads stuck from one that crashed.
+package cli
+
+import (
+	"errors"
+
+	"github.com/berth-dev/berth/internal/execute"
+)
+
+// Exit codes returned by berth.
+const (
+	ExitOK         = 0 // every bead completed (or nothing to do)
+	ExitError      = 1 // berth itself failed
+	ExitIncomplete = 2 // the run finished with stuck or skipped beads
+	ExitAborted    = 3 // the run was stopped by the circuit breaker or the user
+)
+
+// reportedError is a command error that has already been printed, so
+// Execute exits with its code without printing it again.
+type reportedError struct{ err error }
+
+func (e *reportedError) Error() string { return e.err.Error() }
+func (e *reportedError) Unwrap() error { return e.err }
+
+// exitCode returns the exit code for err, the error a command returned.
+func exitCode(err error) int {
+	var incomplete *execute.IncompleteError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, execute.ErrAborted):
+		return ExitAborted
+	case errors.As(err, &incomplete):
+		return ExitIncomplete
+	default:
+		return ExitError
+	}
+}
+
+// executeResult is what a command that drove the execution phase returns
+// once its report is printed: nil on success, otherwise execErr (already
+// shown to the user) so the process exits with its code.
+func executeResult(execErr error) error {
+	if execErr == nil {
+		return nil
+	}
+	return &reportedError{execErr}
+}
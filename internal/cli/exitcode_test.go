@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/execute"
+)
+
+func TestExitCodeForPoolOutcome(t *testing.T) {
+	tests := []struct {
+		name                      string
+		completed, stuck, skipped int
+		want                      int
+	}{
+		{"all completed", 3, 0, 0, ExitOK},
+		{"nothing to do", 0, 0, 0, ExitOK},
+		{"stuck", 2, 1, 0, ExitIncomplete},
+		{"skipped", 2, 0, 1, ExitIncomplete},
+		{"stuck and skipped", 1, 1, 1, ExitIncomplete},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := execute.NewExecutionPool(tt.completed + tt.stuck + tt.skipped)
+			for range tt.completed {
+				pool.RecordCompletion()
+			}
+			for range tt.stuck {
+				pool.RecordStuck()
+			}
+			for range tt.skipped {
+				pool.RecordSkip()
+			}
+
+			err := pool.Outcome()
+			if got := exitCode(err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", err, got, tt.want)
+			}
+			// The run command reports the error before returning it.
+			if got := exitCode(executeResult(err)); got != tt.want {
+				t.Errorf("exitCode(executeResult(%v)) = %d, want %d", err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeForErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"circuit breaker", fmt.Errorf("%w by circuit breaker after 3 consecutive failures", execute.ErrAborted), ExitAborted},
+		{"user abort", fmt.Errorf("execute: %w", fmt.Errorf("%w at bead bt-2", execute.ErrAborted)), ExitAborted},
+		{"internal", errors.New("reading config: no such file"), ExitError},
+		{"reported internal", executeResult(errors.New("listing beads: bd not found")), ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// explain.go implements the "berth explain" command for inspecting the code
+// graph as it was at a given commit.
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/graph"
+	"github.com/spf13/cobra"
+)
+
+var explainAtFlag string
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [file]",
+	Short: "Show the code graph at a specific commit",
+	Long: `Show the functions, types, and imports the knowledge graph saw at a
+given commit, optionally limited to one file. Use this to reproduce the
+analysis a past run was working from.
+
+The commit's files are extracted to a temporary directory and analysed
+with the grep fallback, leaving the knowledge graph on the live tree.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	explainCmd.Flags().StringVar(&explainAtFlag, "at", "", "Commit (SHA or ref) to analyse (required)")
+	_ = explainCmd.MarkFlagRequired("at")
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	cfg, err := readConfig(".")
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	var file string
+	if len(args) > 0 {
+		file = filepath.ToSlash(filepath.Clean(args[0]))
+	}
+
+	snap, err := graph.IndexAtCommit(explainAtFlag)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snap.Close() }()
+
+	fmt.Printf("Code graph at %s\n\n", snap.Commit)
+
+	return printGrepSnapshot(snap.Dir, cfg.Project.Language, file)
+}
+
+// printGrepSnapshot lists the symbols and imports found in dir by the grep
+// fallback, limited to file when it is non-empty.
+func printGrepSnapshot(dir, lang, file string) error {
+	if lang == "" {
+		return fmt.Errorf("project language unknown; run 'berth init' so the grep fallback knows what to search for")
+	}
+
+	funcs, err := graph.GrepFunctions(dir, lang)
+	if err != nil {
+		return err
+	}
+	types, err := graph.GrepTypes(dir, lang)
+	if err != nil {
+		return err
+	}
+	imports, err := graph.GrepImports(dir, lang)
+	if err != nil {
+		return err
+	}
+
+	rel := func(path string) string {
+		if r, err := filepath.Rel(dir, path); err == nil {
+			return filepath.ToSlash(r)
+		}
+		return path
+	}
+
+	fmt.Println("Symbols:")
+	for _, s := range append(types, funcs...) {
+		if f := rel(s.File); file == "" || f == file {
+			fmt.Printf("  %-8s %s  (%s:%d)\n", s.Kind, s.Name, f, s.Line)
+		}
+	}
+
+	fmt.Println("\nImports:")
+	for _, imp := range imports {
+		if f := rel(imp.SourceFile); file == "" || f == file {
+			line := fmt.Sprintf("  %s -> %s", f, imp.TargetPath)
+			if len(imp.Names) > 0 {
+				line += " (" + strings.Join(imp.Names, ", ") + ")"
+			}
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// graph.go implements "berth graph export", which writes the dashboard's
+// architecture diagram as Mermaid or Graphviz DOT.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/graph"
+	"github.com/berth-dev/berth/internal/tui/diagram"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphFormatFlag string
+	graphRootFlag   string
+	graphDepthFlag  int
+	graphOutputFlag string
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Inspect the knowledge graph",
+}
+
+var graphExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the architecture diagram as Mermaid or DOT",
+	Long: `Export the architecture diagram the dashboard shows: the files that
+import --root, the files that import those, and so on up to --depth levels.
+Each file becomes a box labeled with its exports, with an edge from each
+importer to the file it imports.
+
+Requires the knowledge graph. Writes to stdout unless -o is given.`,
+	Args: cobra.NoArgs,
+	RunE: runGraphExport,
+}
+
+func init() {
+	graphExportCmd.Flags().StringVar(&graphFormatFlag, "format", "mermaid", "Output format: mermaid or dot")
+	graphExportCmd.Flags().StringVar(&graphRootFlag, "root", "", "File to start the diagram from, e.g. main.go (required)")
+	graphExportCmd.Flags().IntVar(&graphDepthFlag, "depth", 3, "Levels of importers to include")
+	graphExportCmd.Flags().StringVarP(&graphOutputFlag, "output", "o", "", "Write the diagram to this file instead of stdout")
+	_ = graphExportCmd.MarkFlagRequired("root")
+
+	graphCmd.AddCommand(graphExportCmd)
+}
+
+func runGraphExport(cmd *cobra.Command, args []string) error {
+	var render func(map[string]graph.ArchitectureNode) string
+	switch graphFormatFlag {
+	case "mermaid":
+		render = diagram.GenerateMermaid
+	case "dot":
+		render = diagram.GenerateDOT
+	default:
+		return fmt.Errorf("unknown format %q: use mermaid or dot", graphFormatFlag)
+	}
+
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+
+	cfg, err := readConfig(".")
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	if cfg.KnowledgeGraph.Enabled == "never" {
+		return fmt.Errorf("the knowledge graph is disabled (knowledge_graph.enabled: never)")
+	}
+	kgClient, err := graph.EnsureMCPAlive(projectRoot, cfg.KnowledgeGraph, nil)
+	if err != nil {
+		return fmt.Errorf("knowledge graph unavailable: %w", err)
+	}
+	defer func() { _ = kgClient.Close() }()
+
+	root := filepath.ToSlash(filepath.Clean(graphRootFlag))
+	nodes, err := kgClient.GetArchitectureDiagram(root, graphDepthFlag)
+	if err != nil {
+		return fmt.Errorf("building architecture diagram: %w", err)
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("the knowledge graph has nothing for %s", root)
+	}
+
+	out := render(nodes)
+	if graphOutputFlag == "" {
+		fmt.Print(out)
+		return nil
+	}
+	if err := os.WriteFile(graphOutputFlag, []byte(out), 0644); err != nil {
+		return fmt.Errorf("writing diagram: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote a diagram of %d files to %s\n", len(nodes), graphOutputFlag)
+	return nil
+}
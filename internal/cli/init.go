@@ -87,17 +87,15 @@ func runInit(cmd *cobra.Command, args []string) error {
 		cfg.Project.PackageManager = stackInfo.PackageManager
 
 		// Build verify pipeline from detected commands.
-		var pipeline []string
-		if stackInfo.BuildCmd != "" {
-			pipeline = append(pipeline, stackInfo.BuildCmd)
-		}
-		if stackInfo.LintCmd != "" {
-			pipeline = append(pipeline, stackInfo.LintCmd)
-		}
-		if stackInfo.TestCmd != "" {
-			pipeline = append(pipeline, stackInfo.TestCmd)
+		cfg.VerifyPipeline = stackInfo.Commands()
+
+		// Stacks in subdirectories (e.g. a frontend next to a Go backend)
+		// get pipelines of their own, run from their directory.
+		for _, sub := range detect.DetectStacks(dir) {
+			if cmds := sub.Commands(); sub.Path != "." && len(cmds) > 0 {
+				cfg.VerifyPipelines = append(cfg.VerifyPipelines, config.PathPipeline{Path: sub.Path, Commands: cmds})
+			}
 		}
-		cfg.VerifyPipeline = pipeline
 
 		// Guided mode: allow overrides.
 		if guidedFlag {
@@ -149,6 +147,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 		if stackInfo.LintCmd != "" {
 			fmt.Printf("  Lint Command:    %s\n", stackInfo.LintCmd)
 		}
+		for _, pp := range cfg.VerifyPipelines {
+			fmt.Printf("  Verify (%s/): %s\n", pp.Path, strings.Join(pp.Commands, ", "))
+		}
 		fmt.Println()
 		fmt.Println("Configuration written to .berth/config.yaml")
 		fmt.Println("Ready to run: berth run \"your task description\"")
@@ -332,7 +333,9 @@ func ensureGitignore(dir string) error {
 		".berth/log.jsonl",
 		".berth/mcp.pid",
 		".berth/mcp.log",
+		".berth/anonymize.key",
 		".berth/runs/",
+		".berth/sessions.db*",
 		// Beads runtime (stealth mode handles this via .git/info/exclude,
 		// but belt-and-suspenders in case user runs bd init manually)
 		".beads/",
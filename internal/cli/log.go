@@ -0,0 +1,125 @@
+// log.go implements the "berth log" command for replaying the event log.
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/berth-dev/berth/internal/log"
+	berthreport "github.com/berth-dev/berth/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show a timeline of logged events",
+	Long: `Print the events in .berth/log.jsonl as a timeline, oldest first.
+
+--run limits the timeline to one run, given as a run directory or the name
+of a run under .berth/runs. --since takes a time such as "2026-01-02",
+"2026-01-02 15:04" or RFC 3339, or a duration such as "2h" meaning that
+long ago. --event keeps only the named event types and may be repeated or
+comma-separated. Read-only; the output is plain text.`,
+	Args: cobra.NoArgs,
+	RunE: runLog,
+}
+
+var (
+	logRunFlag    string
+	logSinceFlag  string
+	logEventFlags []string
+)
+
+func init() {
+	logCmd.Flags().StringVar(&logRunFlag, "run", "", "Show only the events of this run")
+	logCmd.Flags().StringVar(&logSinceFlag, "since", "", "Show only events at or after this time or duration ago")
+	logCmd.Flags().StringSliceVar(&logEventFlags, "event", nil, "Show only events of these types (e.g. task_completed)")
+}
+
+// logTimeLayouts are the absolute times --since accepts, in local time
+// unless they carry a zone.
+var logTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	filter := log.EventFilter{Events: logEventFlags}
+
+	if logRunFlag != "" {
+		runDir := resolveRunDir(logRunFlag)
+		if info, err := os.Stat(runDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("run %s not found", logRunFlag)
+		}
+		filter.Since, filter.Until = berthreport.RunWindow(runDir)
+	}
+	if logSinceFlag != "" {
+		since, err := parseLogSince(logSinceFlag, time.Now())
+		if err != nil {
+			return err
+		}
+		if since.After(filter.Since) {
+			filter.Since = since
+		}
+	}
+
+	events, err := log.ReadEvents(".", filter)
+	if err != nil {
+		return fmt.Errorf("reading log: %w", err)
+	}
+	writeLogTimeline(cmd.OutOrStdout(), events)
+	return nil
+}
+
+// parseLogSince parses a --since value: an absolute time in one of
+// logTimeLayouts, or a duration before now.
+func parseLogSince(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range logTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: want a time like 2006-01-02 15:04 or a duration like 2h", s)
+}
+
+// writeLogTimeline prints one line per event with its local time.
+func writeLogTimeline(w io.Writer, events []log.LogEvent) {
+	if len(events) == 0 {
+		fmt.Fprintln(w, "No matching events.")
+		return
+	}
+	for _, e := range events {
+		fmt.Fprintf(w, "%s  %s\n", e.Time.Local().Format("2006-01-02 15:04:05"), formatLogEvent(e))
+	}
+}
+
+// formatLogEvent renders an event as status does, followed by the details
+// that distinguish one occurrence from another.
+func formatLogEvent(e log.LogEvent) string {
+	parts := []string{formatStatusEvent(e)}
+	if e.Attempt > 0 {
+		parts = append(parts, fmt.Sprintf("attempt %d", e.Attempt))
+	}
+	if e.Step != "" {
+		parts = append(parts, "step: "+e.Step)
+	}
+	if e.Reason != "" {
+		parts = append(parts, "reason: "+e.Reason)
+	}
+	if e.Branch != "" {
+		parts = append(parts, "branch: "+e.Branch)
+	}
+	if len(e.Commits) > 0 {
+		parts = append(parts, fmt.Sprintf("%d commit(s)", len(e.Commits)))
+	}
+	if e.Total > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d completed, %d stuck, %d skipped", e.Completed, e.Total, e.Stuck, e.Skipped))
+	}
+	if e.DurationMs > 0 {
+		parts = append(parts, (time.Duration(e.DurationMs) * time.Millisecond).String())
+	}
+	return strings.Join(parts, "  ")
+}
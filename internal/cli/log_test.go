@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/log"
+)
+
+func TestParseLogSince(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2h", now.Add(-2 * time.Hour)},
+		{"2026-01-01", time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)},
+		{"2026-01-01 15:04", time.Date(2026, 1, 1, 15, 4, 0, 0, time.Local)},
+		{"2026-01-01T15:04:05Z", time.Date(2026, 1, 1, 15, 4, 5, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseLogSince(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseLogSince(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseLogSince("yesterday", now); err == nil {
+		t.Error("parseLogSince accepted an unknown format")
+	}
+}
+
+func TestWriteLogTimeline(t *testing.T) {
+	var out strings.Builder
+	writeLogTimeline(&out, []log.LogEvent{
+		{Time: time.Now(), Event: log.EventTaskRetry, BeadID: "bt-1", Attempt: 2, Step: "test"},
+		{Time: time.Now(), Event: log.EventRunComplete, Completed: 3, Total: 4, Stuck: 1},
+	})
+	got := out.String()
+	for _, want := range []string{
+		"task_retry  bt-1  attempt 2  step: test",
+		"run_complete  3/4 completed, 1 stuck, 0 skipped",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("timeline missing %q:\n%s", want, got)
+		}
+	}
+
+	out.Reset()
+	writeLogTimeline(&out, nil)
+	if !strings.Contains(out.String(), "No matching events") {
+		t.Errorf("empty timeline = %q", out.String())
+	}
+}
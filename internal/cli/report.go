@@ -28,7 +28,7 @@ func runReport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Read config.
-	cfg, err := config.ReadConfig(".")
+	cfg, err := readConfig(".")
 	if err != nil {
 		// Use default config if none exists.
 		cfg = config.DefaultConfig()
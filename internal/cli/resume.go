@@ -2,26 +2,33 @@
 package cli
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/berth-dev/berth/internal/beads"
-	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/execute"
 	"github.com/berth-dev/berth/internal/git"
 	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/plan"
 	"github.com/berth-dev/berth/internal/report"
 	"github.com/spf13/cobra"
 )
 
 var resumeCmd = &cobra.Command{
-	Use:   "resume",
+	Use:   "resume [run-id]",
 	Short: "Resume an interrupted run",
-	Long: `Resume a previously interrupted berth run. Finds the latest run
-directory, restores branch state, handles stuck beads, and resumes
-the execution loop.`,
+	Long: `Resume a previously interrupted berth run. With a run ID (the name of a
+directory under .berth/runs), resumes that run from its checkpoint. Without
+one, lists the runs that have a checkpoint and asks which to resume.
+Restores branch state, handles stuck beads, and resumes the execution loop.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runResume,
 }
 
@@ -43,20 +50,66 @@ func runResume(cmd *cobra.Command, args []string) error {
 	}
 
 	// Read config.
-	cfg, err := config.ReadConfig(".")
+	cfg, err := readConfig(".")
 	if err != nil {
 		return fmt.Errorf("reading config: %w", err)
 	}
 
-	// Find latest run directory.
-	runDir, err := findLatestRunDir()
-	if err != nil {
-		return fmt.Errorf("finding latest run: %w", err)
+	if err := execute.CheckAgent(*cfg); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	// Pick the run: the one named on the command line, or one chosen from
+	// the runs that left a checkpoint.
+	var runDir string
+	if len(args) > 0 {
+		runDir = filepath.Join(".berth", "runs", args[0])
+		if info, statErr := os.Stat(runDir); statErr != nil || !info.IsDir() {
+			return fmt.Errorf("run %s not found in .berth/runs/", args[0])
+		}
+	} else {
+		runs, listErr := listRunCheckpoints(filepath.Join(".berth", "runs"))
+		if listErr != nil {
+			return fmt.Errorf("listing runs: %w", listErr)
+		}
+		if len(runs) == 0 {
+			// No checkpoints to choose from: fall back to the latest run.
+			runDir, err = findLatestRunDir()
+			if err != nil {
+				return fmt.Errorf("finding latest run: %w", err)
+			}
+		} else {
+			chosen, chooseErr := chooseRunCheckpoint(runs, reader, os.Stdout)
+			if chooseErr != nil {
+				return chooseErr
+			}
+			runDir = chosen.Dir
+		}
 	}
 	fmt.Printf("Resuming run from: %s\n", runDir)
 
-	// Determine the expected branch name from config.
-	branchName := cfg.Execution.BranchPrefix + cfg.Project.Name
+	// Load checkpoint to restore execution state.
+	checkpoint, checkpointErr := execute.LoadCheckpoint(runDir)
+	if errors.Is(checkpointErr, execute.ErrCheckpointCorrupted) {
+		// Starting over would re-run beads the checkpoint recorded as done.
+		return fmt.Errorf("loading checkpoint of %s: %w", runDir, checkpointErr)
+	}
+	if checkpointErr != nil {
+		// Checkpoint unreadable: warn user but continue with fresh state.
+		fmt.Fprintf(os.Stderr, "Warning: failed to load checkpoint (continuing with fresh state): %v\n", checkpointErr)
+		checkpoint = nil
+	}
+
+	// Determine the expected branch name: the one the checkpoint recorded,
+	// else from config.
+	var branchName string
+	if checkpoint != nil && checkpoint.RunID != "" {
+		branchName = checkpoint.RunID
+	} else {
+		branchName = cfg.Execution.BranchPrefix + cfg.Project.Name
+	}
 	if branchName == cfg.Execution.BranchPrefix {
 		// Project name not set; try to detect from current branch.
 		current, branchErr := git.CurrentBranch()
@@ -77,27 +130,29 @@ func runResume(cmd *cobra.Command, args []string) error {
 			if switchErr := git.SwitchBranch(branchName); switchErr != nil {
 				return fmt.Errorf("switching to branch %s: %w", branchName, switchErr)
 			}
+		} else if confirmRecreateBranch(branchName, currentBranch, reader) {
+			if createErr := git.CreateBranch(branchName); createErr != nil {
+				return fmt.Errorf("recreating branch %s: %w", branchName, createErr)
+			}
+			fmt.Printf("Recreated branch %s from %s\n", branchName, currentBranch)
 		} else {
 			fmt.Printf("Warning: expected branch %s not found, continuing on %s\n", branchName, currentBranch)
+			branchName = currentBranch
 		}
 	}
 
-	// Load checkpoint to restore execution state.
-	checkpoint, checkpointErr := execute.LoadCheckpoint(runDir)
-	if checkpointErr != nil {
-		// Checkpoint corrupted: warn user but continue with fresh state.
-		fmt.Fprintf(os.Stderr, "Warning: failed to load checkpoint (continuing with fresh state): %v\n", checkpointErr)
-		checkpoint = nil
-	}
-
 	// Prepare execution state from checkpoint.
 	var execState *execute.ExecuteState
 	if checkpoint != nil {
-		fmt.Printf("Restored checkpoint state: %d completed, %d failed, %d consecutive failures\n",
-			len(checkpoint.CompletedBeads), len(checkpoint.FailedBeads), checkpoint.ConsecFailures)
+		fmt.Printf("Restored checkpoint state: %d completed, %d failed, %d consecutive failures, %d tokens used\n",
+			len(checkpoint.CompletedBeads), len(checkpoint.FailedBeads), checkpoint.ConsecFailures, checkpoint.TokensUsed)
 		execState = &execute.ExecuteState{
 			RetryCount:     checkpoint.RetryCount,
 			ConsecFailures: checkpoint.ConsecFailures,
+			CompletedBeads: checkpoint.CompletedBeads,
+			FailedBeads:    checkpoint.FailedBeads,
+			Beads:          checkpoint.Beads,
+			TokensUsed:     checkpoint.TokensUsed,
 		}
 	}
 
@@ -107,6 +162,20 @@ func runResume(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("listing beads: %w", err)
 	}
 
+	// Without a checkpoint the run may have stopped before its beads were
+	// created; recreate them from the run's plan.
+	if checkpoint == nil {
+		created, restoreErr := restorePlanBeads(runDir, projectRoot, allBeads)
+		if restoreErr != nil {
+			return fmt.Errorf("restoring beads from the run's plan: %w", restoreErr)
+		}
+		if created {
+			if allBeads, err = beads.List(); err != nil {
+				return fmt.Errorf("listing beads: %w", err)
+			}
+		}
+	}
+
 	stuckCount := 0
 	inProgressCount := 0
 
@@ -141,7 +210,7 @@ func runResume(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create logger.
-	logger, err := log.NewLogger(projectRoot)
+	logger, err := log.NewLoggerWithPrivacy(projectRoot, cfg.Log.Privacy())
 	if err != nil {
 		return fmt.Errorf("creating logger: %w", err)
 	}
@@ -157,7 +226,8 @@ func runResume(cmd *cobra.Command, args []string) error {
 
 	// Resume execution with restored state.
 	fmt.Println("\nResuming execution...")
-	if execErr := execute.RunExecuteWithState(*cfg, projectRoot, runDir, branchName, Verbose(), execState, nil); execErr != nil {
+	execErr := execute.RunExecuteWithState(*cfg, projectRoot, runDir, branchName, Verbose(), execState, nil, nil)
+	if execErr != nil {
 		fmt.Fprintf(os.Stderr, "Execute phase error: %v\n", execErr)
 		// Continue to report phase.
 	}
@@ -173,7 +243,40 @@ func runResume(cmd *cobra.Command, args []string) error {
 		fmt.Print(report.FormatReport(r))
 	}
 
-	return nil
+	return executeResult(execErr)
+}
+
+// restorePlanBeads creates the beads of the plan saved in runDir when none
+// of them exist, as happens when a run is interrupted after its plan was
+// approved but before its beads were created. Beads are matched by title
+// since bd assigns new IDs. Reports whether it created any.
+func restorePlanBeads(runDir, projectRoot string, existing []beads.Bead) (bool, error) {
+	p, err := plan.LoadPlan(runDir)
+	if errors.Is(err, plan.ErrNoPlan) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(p.Beads) == 0 {
+		return false, nil
+	}
+
+	titles := make(map[string]bool, len(existing))
+	for _, b := range existing {
+		titles[b.Title] = true
+	}
+	for _, spec := range p.Beads {
+		if titles[spec.Title] {
+			return false, nil
+		}
+	}
+
+	fmt.Printf("No beads found for %s; creating %d from its plan\n", runDir, len(p.Beads))
+	if err := plan.CreateBeads(p, projectRoot); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // findLatestRunDir finds the most recent run directory in .berth/runs/.
@@ -202,3 +305,95 @@ func findLatestRunDir() (string, error) {
 
 	return "", fmt.Errorf("no run directories found in .berth/runs/")
 }
+
+// runCheckpoint is a run directory that has a checkpoint to resume from.
+type runCheckpoint struct {
+	ID         string // directory name under .berth/runs
+	Dir        string
+	Checkpoint *execute.Checkpoint // nil when Corrupted
+	Corrupted  bool                // the checkpoint exists but cannot be loaded
+}
+
+// listRunCheckpoints returns the runs under runsDir that have a checkpoint,
+// newest checkpoint first. Runs whose checkpoint is corrupted are listed
+// last, newest run first, so the user sees why they cannot be resumed.
+func listRunCheckpoints(runsDir string) ([]runCheckpoint, error) {
+	entries, err := os.ReadDir(runsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading runs directory: %w", err)
+	}
+
+	var runs []runCheckpoint
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(runsDir, entry.Name())
+		cp, err := execute.LoadCheckpoint(dir)
+		if errors.Is(err, execute.ErrCheckpointCorrupted) {
+			runs = append(runs, runCheckpoint{ID: entry.Name(), Dir: dir, Corrupted: true})
+			continue
+		}
+		if err != nil || cp == nil {
+			continue
+		}
+		runs = append(runs, runCheckpoint{ID: entry.Name(), Dir: dir, Checkpoint: cp})
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		if runs[i].Corrupted || runs[j].Corrupted {
+			if runs[i].Corrupted != runs[j].Corrupted {
+				return runs[j].Corrupted
+			}
+			return runs[i].ID > runs[j].ID
+		}
+		return runs[i].Checkpoint.Timestamp.After(runs[j].Checkpoint.Timestamp)
+	})
+	return runs, nil
+}
+
+// chooseRunCheckpoint lists runs on out and reads the user's choice from
+// reader. An empty answer picks the newest run.
+func chooseRunCheckpoint(runs []runCheckpoint, reader *bufio.Reader, out io.Writer) (runCheckpoint, error) {
+	fmt.Fprintln(out, "Runs with a checkpoint:")
+	for i, r := range runs {
+		if r.Corrupted {
+			fmt.Fprintf(out, "  [%d] %s  checkpoint corrupted, cannot resume\n", i+1, r.ID)
+			continue
+		}
+		cp := r.Checkpoint
+		fmt.Fprintf(out, "  [%d] %s  %s  %d completed, %d failed",
+			i+1, r.ID, cp.Timestamp.Format("2006-01-02 15:04"), len(cp.CompletedBeads), len(cp.FailedBeads))
+		if cp.RunID != "" {
+			fmt.Fprintf(out, "  (%s)", cp.RunID)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Resume which run? [1] > ")
+
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return runCheckpoint{}, fmt.Errorf("reading choice: %w", err)
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return runs[0], nil
+	}
+	n, convErr := strconv.Atoi(line)
+	if convErr != nil || n < 1 || n > len(runs) {
+		return runCheckpoint{}, fmt.Errorf("invalid choice %q (expected 1-%d)", line, len(runs))
+	}
+	return runs[n-1], nil
+}
+
+// confirmRecreateBranch asks whether to recreate a run's branch that no
+// longer exists, starting it from the current branch. Defaults to yes.
+func confirmRecreateBranch(branch, from string, reader *bufio.Reader) bool {
+	fmt.Printf("Branch %s no longer exists. Recreate it from %s? [Y/n] ", branch, from)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "" || answer == "y" || answer == "yes"
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/plan"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+func writeCheckpoint(t *testing.T, runsDir, name, checkpoint string) {
+	t.Helper()
+	dir := filepath.Join(runsDir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint == "" {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(dir, "checkpoint.json"), []byte(checkpoint), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListRunCheckpoints(t *testing.T) {
+	runsDir := t.TempDir()
+	writeCheckpoint(t, runsDir, "20260101-100000", `{"run_id": "berth/old", "timestamp": "2026-01-01T10:05:00Z"}`)
+	writeCheckpoint(t, runsDir, "20260103-100000", `{"run_id": "berth/new", "completed_beads": ["bt-1"], "timestamp": "2026-01-03T10:05:00Z"}`)
+	writeCheckpoint(t, runsDir, "20260102-100000", "") // no checkpoint
+	writeCheckpoint(t, runsDir, "20260104-100000", `{not json`)
+
+	runs, err := listRunCheckpoints(runsDir)
+	if err != nil {
+		t.Fatalf("listRunCheckpoints: %v", err)
+	}
+	if len(runs) != 3 {
+		t.Fatalf("got %d runs, want 3: %+v", len(runs), runs)
+	}
+	if runs[0].ID != "20260103-100000" || runs[1].ID != "20260101-100000" {
+		t.Errorf("runs not sorted newest first: %s, %s", runs[0].ID, runs[1].ID)
+	}
+	if runs[2].ID != "20260104-100000" || !runs[2].Corrupted {
+		t.Errorf("runs[2] = %+v, want the corrupted run listed last", runs[2])
+	}
+
+	var out strings.Builder
+	if _, err := chooseRunCheckpoint(runs, bufio.NewReader(strings.NewReader("\n")), &out); err != nil {
+		t.Fatalf("chooseRunCheckpoint: %v", err)
+	}
+	if !strings.Contains(out.String(), "[3] 20260104-100000  checkpoint corrupted") {
+		t.Errorf("listing does not mark the corrupted run:\n%s", out.String())
+	}
+	if runs[0].Checkpoint.RunID != "berth/new" {
+		t.Errorf("Checkpoint.RunID = %q, want berth/new", runs[0].Checkpoint.RunID)
+	}
+}
+
+func TestChooseRunCheckpoint(t *testing.T) {
+	runsDir := t.TempDir()
+	writeCheckpoint(t, runsDir, "20260101-100000", `{"run_id": "berth/old", "timestamp": "2026-01-01T10:05:00Z"}`)
+	writeCheckpoint(t, runsDir, "20260103-100000", `{"run_id": "berth/new", "timestamp": "2026-01-03T10:05:00Z"}`)
+	runs, err := listRunCheckpoints(runsDir)
+	if err != nil {
+		t.Fatalf("listRunCheckpoints: %v", err)
+	}
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"\n", "20260103-100000", false},
+		{"2\n", "20260101-100000", false},
+		{"3\n", "", true},
+		{"old\n", "", true},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		got, err := chooseRunCheckpoint(runs, bufio.NewReader(strings.NewReader(tt.input)), &out)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("input %q: expected error, got %s", tt.input, got.ID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("input %q: %v", tt.input, err)
+			continue
+		}
+		if got.ID != tt.want {
+			t.Errorf("input %q: chose %s, want %s", tt.input, got.ID, tt.want)
+		}
+		if !strings.Contains(out.String(), "[2] 20260101-100000") {
+			t.Errorf("listing missing second run:\n%s", out.String())
+		}
+	}
+
+	if _, err := chooseRunCheckpoint(runs, bufio.NewReader(strings.NewReader("")), io.Discard); err != nil {
+		t.Errorf("EOF should pick the newest run, got error: %v", err)
+	}
+}
+
+func TestRestorePlanBeads(t *testing.T) {
+	calls := filepath.Join(t.TempDir(), "calls")
+	testutil.FakeCommand(t, "bd", "echo \"$@\" >> "+calls+"\n[ \"$1\" = create ] && echo 'Created issue: bt-new'\nexit 0\n")
+
+	root := t.TempDir()
+	runDir := t.TempDir()
+	if created, err := restorePlanBeads(runDir, root, nil); created || err != nil {
+		t.Errorf("without a plan: created = %v, err = %v; want nothing done", created, err)
+	}
+
+	p := &plan.Plan{Title: "Auth", Beads: []plan.BeadSpec{{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}}}}
+	if err := plan.WritePlanJSON(runDir, p); err != nil {
+		t.Fatal(err)
+	}
+	if created, err := restorePlanBeads(runDir, root, []beads.Bead{{ID: "bt-9", Title: "Add model"}}); created || err != nil {
+		t.Errorf("with the beads present: created = %v, err = %v; want nothing done", created, err)
+	}
+	if _, err := os.Stat(calls); err == nil {
+		t.Fatal("bd was called although the plan's beads exist")
+	}
+
+	created, err := restorePlanBeads(runDir, root, nil)
+	if err != nil || !created {
+		t.Fatalf("restorePlanBeads = %v, %v; want the beads created", created, err)
+	}
+	if data, _ := os.ReadFile(calls); !strings.Contains(string(data), "create --title Add model") {
+		t.Errorf("bd calls = %q, want the plan's bead created", data)
+	}
+	if meta, err := beads.ReadBeadMeta(root, "bt-new"); err != nil || len(meta.Files) != 1 {
+		t.Errorf("sidecar = %+v, %v; want the plan's files", meta, err)
+	}
+}
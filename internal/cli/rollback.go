@@ -0,0 +1,188 @@
+// rollback.go implements the "berth rollback" command for undoing a run.
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/git"
+	"github.com/berth-dev/berth/internal/log"
+	berthreport "github.com/berth-dev/berth/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback [run-name]",
+	Short: "Undo the commits a run made",
+	Long: `Undo the commits recorded for a run's beads, by default those of the most
+recent run. The argument is a run directory or the name of a run under
+.berth/runs.
+
+By default each of the run's commits is reverted with git revert, newest
+first, so history is kept. With --hard the branch is instead reset to
+where it was before the run, after confirmation, discarding the commits.
+
+Rollback refuses when commits berth did not make for the run sit on top of
+it, since reverting could conflict with them and --hard would discard
+them. Use --force to roll back anyway.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRollback,
+}
+
+var (
+	rollbackHardFlag  bool
+	rollbackForceFlag bool
+	rollbackYesFlag   bool
+)
+
+func init() {
+	rollbackCmd.Flags().BoolVar(&rollbackHardFlag, "hard", false, "Reset the branch to before the run instead of reverting")
+	rollbackCmd.Flags().BoolVar(&rollbackForceFlag, "force", false, "Roll back even when other commits follow the run")
+	rollbackCmd.Flags().BoolVarP(&rollbackYesFlag, "yes", "y", false, "Skip the --hard confirmation")
+}
+
+// bookkeepingPrefixes start the subjects of commits berth makes around a
+// bead's own: metadata commits and worktree and merge queue merges.
+var bookkeepingPrefixes = []string{"chore(berth): update metadata for ", "Merge bead ", "merge(berth): integrate bead "}
+
+// rollbackPlan is what undoing a run involves.
+type rollbackPlan struct {
+	base    string       // the commit the run started from
+	revert  []string     // the run's commits on the branch, newest first
+	foreign []git.Commit // commits since base that berth did not make for the run
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	var runDir string
+	if len(args) == 1 {
+		runDir = resolveRunDir(args[0])
+		if info, err := os.Stat(runDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("run %s not found", args[0])
+		}
+	} else {
+		var err error
+		if runDir, err = findLatestRunDir(); err != nil {
+			return err
+		}
+	}
+
+	logger, err := log.NewLogger(".")
+	if err != nil {
+		return fmt.Errorf("opening log: %w", err)
+	}
+	events, err := logger.ReadAll()
+	if err != nil {
+		return fmt.Errorf("reading log: %w", err)
+	}
+	plan, err := planRollback(berthreport.RunCommits(runDir, events))
+	if err != nil {
+		return err
+	}
+
+	if len(plan.foreign) > 0 && !rollbackForceFlag {
+		var lines []string
+		for _, c := range plan.foreign {
+			lines = append(lines, fmt.Sprintf("  %.7s %s", c.SHA, c.Subject))
+		}
+		return fmt.Errorf("%d commit(s) not made by the run follow it:\n%s\nrerun with --force to roll back anyway",
+			len(plan.foreign), strings.Join(lines, "\n"))
+	}
+	if dirty, err := git.HasChanges(); err != nil {
+		return err
+	} else if dirty {
+		return fmt.Errorf("working tree has uncommitted changes; commit or stash them before rolling back")
+	}
+
+	if rollbackHardFlag {
+		if !rollbackYesFlag && !confirmHardRollback(plan, bufio.NewReader(os.Stdin)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+		if err := git.ResetHard(plan.base); err != nil {
+			return err
+		}
+		fmt.Printf("Reset to %.7s, before run %s.\n", plan.base, filepath.Base(runDir))
+		return nil
+	}
+
+	if err := git.Revert(plan.revert); err != nil {
+		return err
+	}
+	fmt.Printf("Reverted %d commit(s) of run %s.\n", len(plan.revert), filepath.Base(runDir))
+	return nil
+}
+
+// planRollback works out how to undo a run from the commits its beads
+// recorded. Commits no longer on the current branch are ignored. The run's
+// base is the common ancestor of the parents of its commits, so beads
+// merged from parallel worktrees are covered too.
+func planRollback(beadCommits map[string][]string) (*rollbackPlan, error) {
+	recorded := make(map[string]bool)
+	var parents []string
+	for _, shas := range beadCommits {
+		for _, sha := range shas {
+			if !recorded[sha] && git.IsAncestor(sha) {
+				recorded[sha] = true
+				parents = append(parents, sha+"^")
+			}
+		}
+	}
+	if len(parents) == 0 {
+		return nil, fmt.Errorf("none of the run's recorded commits are on the current branch")
+	}
+	sort.Strings(parents)
+
+	base, err := git.MergeBase(parents...)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := git.LogSince(base)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &rollbackPlan{base: base}
+	for _, c := range commits {
+		switch {
+		case recorded[c.SHA]:
+			// Reverting a bead's own commits undoes its merges as well.
+			if !c.Merge {
+				plan.revert = append([]string{c.SHA}, plan.revert...)
+			}
+		case isBookkeeping(c.Subject):
+		default:
+			plan.foreign = append(plan.foreign, c)
+		}
+	}
+	return plan, nil
+}
+
+// isBookkeeping reports whether subject is that of a commit berth makes
+// around a bead's own commits.
+func isBookkeeping(subject string) bool {
+	for _, prefix := range bookkeepingPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmHardRollback asks before resetting the branch. Defaults to no.
+func confirmHardRollback(plan *rollbackPlan, reader *bufio.Reader) bool {
+	fmt.Printf("Reset the branch to %.7s, discarding %d commit(s) of the run", plan.base, len(plan.revert))
+	if len(plan.foreign) > 0 {
+		fmt.Printf(" and %d commit(s) after it", len(plan.foreign))
+	}
+	fmt.Print("? [y/N] ")
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/log"
+)
+
+// gitRun runs git in the current directory and returns its trimmed output.
+func gitRun(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commitFile writes name and commits it with message, returning the SHA.
+func commitFile(t *testing.T, name, message string) string {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(message+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, "add", name)
+	gitRun(t, "commit", "-q", "-m", message)
+	return gitRun(t, "rev-parse", "HEAD")
+}
+
+// setupRolledRun creates a repository holding a run of two beads, each
+// with a recorded commit and a metadata commit, and returns the SHA the run
+// started from.
+func setupRolledRun(t *testing.T) string {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	gitRun(t, "init", "-q")
+	gitRun(t, "config", "user.email", "test@example.com")
+	gitRun(t, "config", "user.name", "Test")
+	base := commitFile(t, ".gitignore", ".berth/")
+
+	start := time.Now().Add(-time.Minute).Truncate(time.Second)
+	if err := os.MkdirAll(filepath.Join(".berth", "runs", start.Format("20060102-150405")), 0755); err != nil {
+		t.Fatal(err)
+	}
+	logger, err := log.NewLogger(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, bead := range []string{"bt-1", "bt-2"} {
+		sha := commitFile(t, bead+".go", "feat(berth): "+bead)
+		commitFile(t, bead+".meta", "chore(berth): update metadata for "+bead)
+		if err := logger.Append(log.LogEvent{
+			Time:    start.Add(time.Duration(i+1) * time.Second),
+			Event:   log.EventTaskCompleted,
+			BeadID:  bead,
+			Commits: []string{sha},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return base
+}
+
+func TestRollbackRevertsRunCommits(t *testing.T) {
+	setupRolledRun(t)
+
+	if err := runRollback(rollbackCmd, nil); err != nil {
+		t.Fatalf("runRollback: %v", err)
+	}
+	for _, f := range []string{"bt-1.go", "bt-2.go"} {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after the rollback", f)
+		}
+	}
+	if _, err := os.Stat("bt-1.meta"); err != nil {
+		t.Errorf("metadata commit was undone: %v", err)
+	}
+	if history := gitRun(t, "log", "--format=%s"); !strings.Contains(history, "\nfeat(berth): bt-2\n") {
+		t.Errorf("run's commits missing from history, want them kept:\n%s", history)
+	}
+	if subject := gitRun(t, "log", "-1", "--format=%s"); !strings.Contains(subject, `Revert "feat(berth): bt-1"`) {
+		t.Errorf("last commit %q, want the revert of bt-1's commit", subject)
+	}
+}
+
+func TestRollbackRefusesLaterCommits(t *testing.T) {
+	base := setupRolledRun(t)
+	commitFile(t, "manual.go", "fix: hand-written change")
+
+	err := runRollback(rollbackCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "hand-written change") {
+		t.Fatalf("runRollback error = %v, want a refusal naming the later commit", err)
+	}
+
+	rollbackForceFlag, rollbackHardFlag, rollbackYesFlag = true, true, true
+	defer func() { rollbackForceFlag, rollbackHardFlag, rollbackYesFlag = false, false, false }()
+	if err := runRollback(rollbackCmd, nil); err != nil {
+		t.Fatalf("runRollback --hard --force: %v", err)
+	}
+	if head := gitRun(t, "rev-parse", "HEAD"); head != base {
+		t.Errorf("HEAD = %s after --hard, want the run's base %s", head, base)
+	}
+}
+
+func TestRollbackRevertsMergeQueueRun(t *testing.T) {
+	t.Chdir(t.TempDir())
+	gitRun(t, "init", "-q")
+	gitRun(t, "config", "user.email", "test@example.com")
+	gitRun(t, "config", "user.name", "Test")
+	base := commitFile(t, ".gitignore", ".berth/")
+	trunk := gitRun(t, "rev-parse", "--abbrev-ref", "HEAD")
+
+	start := time.Now().Add(-time.Minute).Truncate(time.Second)
+	if err := os.MkdirAll(filepath.Join(".berth", "runs", start.Format("20060102-150405")), 0755); err != nil {
+		t.Fatal(err)
+	}
+	logger, err := log.NewLogger(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each bead commits on its own worker branch, forked from the run's
+	// base, and the merge queue integrates the branches into trunk. Only
+	// the beads' own commits are recorded.
+	for i, bead := range []string{"bt-1", "bt-2"} {
+		gitRun(t, "checkout", "-q", "-b", "berth/"+bead, base)
+		sha := commitFile(t, bead+".go", "feat(berth): "+bead)
+		gitRun(t, "checkout", "-q", trunk)
+		gitRun(t, "merge", "-q", "--no-ff", "berth/"+bead, "-m", "merge(berth): integrate bead "+bead+" - "+bead)
+		commitFile(t, bead+".meta", "chore(berth): update metadata for "+bead)
+		if err := logger.Append(log.LogEvent{
+			Time:    start.Add(time.Duration(i+1) * time.Second),
+			Event:   log.EventTaskCompleted,
+			BeadID:  bead,
+			Commits: []string{sha},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := runRollback(rollbackCmd, nil); err != nil {
+		t.Fatalf("runRollback: %v", err)
+	}
+	for _, f := range []string{"bt-1.go", "bt-2.go"} {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after the rollback", f)
+		}
+	}
+}
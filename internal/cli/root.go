@@ -3,8 +3,10 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
@@ -16,6 +18,7 @@ import (
 var (
 	verbose bool
 	debug   bool
+	profile string
 	version = "dev" // set via ldflags at build time
 )
 
@@ -40,24 +43,28 @@ Claude processes, and verifies each step before moving on.`,
 			return fmt.Errorf("getting current directory: %w", err)
 		}
 
-		// Try to read config, use defaults if not initialized
-		cfg, err := config.ReadConfig(projectRoot)
+		cfg, err := tuiConfig(projectRoot)
 		if err != nil {
-			// Config not found or invalid, use defaults
-			cfg = config.DefaultConfig()
+			return err
 		}
 
 		// Create and run the TUI app
 		tuiApp := app.New(cfg, projectRoot)
+		tuiApp.SetDryRun(runDryRunFlag)
+		tuiApp.SetExplainPlan(explainPlanFlag)
 		return tui.Run(tuiApp)
 	},
 }
 
-// Execute runs the root command. Called from main.
+// Execute runs the root command and exits with the code exitCode maps its
+// error to. Called from main.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		var reported *reportedError
+		if !errors.As(err, &reported) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -66,17 +73,52 @@ func Verbose() bool {
 	return verbose
 }
 
+// readConfig reads the project config with the profile selected by
+// --profile (or BERTH_PROFILE) merged over it.
+func readConfig(dir string) (*config.Config, error) {
+	p := profile
+	if p == "" {
+		p = os.Getenv(config.ProfileEnv)
+	}
+	return config.ReadConfigProfile(dir, p)
+}
+
+// tuiConfig reads the config the TUI starts with. An uninitialized project
+// starts on the defaults, since the TUI offers to initialize it; a config
+// that exists but cannot be read, or an invalid --profile, is an error as in
+// the other commands.
+func tuiConfig(projectRoot string) (*config.Config, error) {
+	cfg, err := readConfig(projectRoot)
+	if err == nil {
+		return cfg, nil
+	}
+	if _, statErr := os.Stat(filepath.Join(projectRoot, ".berth", "config.yaml")); os.IsNotExist(statErr) {
+		return config.DefaultConfig(), nil
+	}
+	return nil, fmt.Errorf("reading config: %w", err)
+}
+
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Stream Claude output instead of progress bar")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Pass --mcp-debug to Claude processes for MCP troubleshooting")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "Merge .berth/config.<profile>.yaml over config.yaml (default: $BERTH_PROFILE)")
+	rootCmd.Flags().BoolVar(&runDryRunFlag, "dry-run", false, "Show the approved plan's beads and execution groups instead of executing them")
+	rootCmd.Flags().BoolVar(&explainPlanFlag, "explain-plan", false, "Annotate each bead on the approval screen with its dependency reasoning")
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(logCmd)
 	rootCmd.AddCommand(reportCmd)
 	rootCmd.AddCommand(prCmd)
 	rootCmd.AddCommand(resumeCmd)
 	rootCmd.AddCommand(cleanCmd)
+	rootCmd.AddCommand(runsCmd)
+	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(explainCmd)
+	rootCmd.AddCommand(graphCmd)
 	rootCmd.AddCommand(bridgeCmd)
+	rootCmd.AddCommand(beadCmd)
 }
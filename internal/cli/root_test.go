@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+func TestTUIConfigRejectsAnInvalidProfile(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.ProfileEnv, "")
+	t.Cleanup(func() { profile = "" })
+
+	// An uninitialized project starts on the defaults.
+	cfg, err := tuiConfig(root)
+	if err != nil || cfg == nil {
+		t.Fatalf("tuiConfig before init = %v, %v; want the defaults", cfg, err)
+	}
+
+	if err := config.WriteConfig(root, config.DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	profile = "missing"
+	if cfg, err := tuiConfig(root); err == nil {
+		t.Fatalf("tuiConfig with an unknown profile = %+v, want an error", cfg)
+	}
+}
@@ -3,6 +3,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,13 +11,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/cleanup"
-	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/detect"
 	"github.com/berth-dev/berth/internal/execute"
+	"github.com/berth-dev/berth/internal/graph"
 	"github.com/berth-dev/berth/internal/log"
 	"github.com/berth-dev/berth/internal/plan"
 	"github.com/berth-dev/berth/internal/report"
+	"github.com/berth-dev/berth/internal/session"
+	"github.com/berth-dev/berth/internal/trace"
 	"github.com/berth-dev/berth/internal/understand"
 	"github.com/spf13/cobra"
 )
@@ -25,27 +29,41 @@ var runCmd = &cobra.Command{
 	Use:   "run [description]",
 	Short: "Run a full development task",
 	Long: `Run the full berth pipeline: understand requirements, generate a plan,
-execute beads, and produce a report. Requires a task description or --prd flag.`,
+execute beads, and produce a report. Requires a task description, --prd or --requirements.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runRun,
 }
 
 var (
 	prdFlag            string
+	requirementsFlag   string
 	skipUnderstandFlag bool
 	skipApproveFlag    bool
 	reindexFlag        bool
 	branchFlag         string
 	parallelFlag       bool
+	maxBeadsFlag       int
+	jsonFlag           bool
+	runDryRunFlag      bool
+	explainPlanFlag    bool
+	acceptDefaultsFlag bool
 )
 
 func init() {
 	runCmd.Flags().StringVar(&prdFlag, "prd", "", "Path to a PRD file (skips UNDERSTAND phase)")
+	runCmd.Flags().StringVar(&requirementsFlag, "requirements", "", "Path to a finished requirements markdown file; skips the interview and goes straight to planning")
 	runCmd.Flags().BoolVar(&skipUnderstandFlag, "skip-understand", false, "Skip the interview loop")
 	runCmd.Flags().BoolVar(&skipApproveFlag, "skip-approve", false, "Auto-approve the generated plan")
 	runCmd.Flags().BoolVar(&reindexFlag, "reindex", false, "Force full Knowledge Graph reindex")
 	runCmd.Flags().StringVar(&branchFlag, "branch", "", "Custom branch name (default: berth/{sanitized-description})")
 	runCmd.Flags().BoolVar(&parallelFlag, "parallel", false, "Enable parallel bead execution")
+	runCmd.Flags().IntVar(&maxBeadsFlag, "max-beads", 0, "Refuse plans with more than this many beads (overrides execution.max_beads)")
+	runCmd.Flags().BoolVar(&jsonFlag, "json", false, "Run without prompts and print a JSON execution summary to stdout (implies --skip-understand and --skip-approve)")
+	runCmd.Flags().BoolVar(&runDryRunFlag, "dry-run", false, "Stop after planning and print the beads and execution groups without creating a branch or beads")
+	runCmd.Flags().BoolVar(&acceptDefaultsFlag, "accept-defaults", false, "Answer interview questions with their recommended option instead of prompting (same as understand.accept_defaults)")
+	runCmd.Flags().BoolVar(&explainPlanFlag, "explain-plan", false, "Annotate each bead on the approval screen with its dependencies, dependents, and execution group")
+	runCmd.MarkFlagsMutuallyExclusive("json", "dry-run")
+	runCmd.MarkFlagsMutuallyExclusive("prd", "requirements")
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
@@ -59,8 +77,18 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		description = args[0]
 	}
-	if description == "" && prdFlag == "" {
-		return fmt.Errorf("provide a task description or use --prd flag")
+	if description == "" && prdFlag == "" && requirementsFlag == "" {
+		return fmt.Errorf("provide a task description or use --prd or --requirements")
+	}
+
+	// JSON mode: stdout carries only the final summary, so everything
+	// human-readable goes to stderr, and nothing may prompt.
+	jsonOut := os.Stdout
+	if jsonFlag {
+		os.Stdout = os.Stderr
+		defer func() { os.Stdout = jsonOut }()
+		skipUnderstandFlag = true
+		skipApproveFlag = true
 	}
 
 	// Validate: must be in a git repo.
@@ -75,14 +103,24 @@ func runRun(cmd *cobra.Command, args []string) error {
 	}
 
 	// Read config.
-	cfg, err := config.ReadConfig(".")
+	cfg, err := readConfig(".")
 	if err != nil {
 		return fmt.Errorf("reading config: %w", err)
 	}
 
+	if err := execute.CheckAgent(*cfg); err != nil {
+		return err
+	}
+
 	if parallelFlag {
 		cfg.Execution.ParallelMode = "always"
 	}
+	if maxBeadsFlag > 0 {
+		cfg.Execution.MaxBeads = maxBeadsFlag
+	}
+	if acceptDefaultsFlag {
+		cfg.Understand.AcceptDefaults = true
+	}
 
 	// Detect stack info.
 	stackInfo := detect.DetectStack(projectRoot)
@@ -108,20 +146,33 @@ func runRun(cmd *cobra.Command, args []string) error {
 	// Determine branch name for execute phase.
 	branchSuffix := branchFlag
 	if branchSuffix == "" {
-		if description != "" {
+		switch {
+		case description != "":
 			branchSuffix = sanitizeBranchName(description)
-		} else {
+		case requirementsFlag != "":
+			branchSuffix = sanitizeBranchName(filepath.Base(requirementsFlag))
+		default:
 			branchSuffix = sanitizeBranchName(filepath.Base(prdFlag))
 		}
 	}
 	branchName := cfg.Execution.BranchPrefix + branchSuffix
 
 	// Create logger.
-	logger, err := log.NewLogger(projectRoot)
+	logger, err := log.NewLoggerWithPrivacy(projectRoot, cfg.Log.Privacy())
 	if err != nil {
 		return fmt.Errorf("creating logger: %w", err)
 	}
 
+	// Trace the run when a collector is configured.
+	if cfg.Telemetry.Endpoint != "" {
+		trace.Init(trace.NewOTLPExporter(cfg.Telemetry.Endpoint), "berth.run", trace.String("branch", branchName))
+		defer func() {
+			if err := trace.Shutdown(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to export trace: %v\n", err)
+			}
+		}()
+	}
+
 	fmt.Printf("Starting berth run: %s\n", branchName)
 	fmt.Printf("Run directory: %s\n\n", runDir)
 
@@ -139,16 +190,36 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Println("Phase 1 UNDERSTAND: skipped (using PRD file)")
 	} else {
-		fmt.Println("Phase 1 UNDERSTAND: gathering requirements...")
+		if requirementsFlag != "" {
+			fmt.Printf("Phase 1 UNDERSTAND: using requirements from %s\n", requirementsFlag)
+		} else {
+			fmt.Println("Phase 1 UNDERSTAND: gathering requirements...")
+		}
+		span := trace.Start("understand")
+		var recorder *understand.ChatRecorder
+		if !skipUnderstandFlag && requirementsFlag == "" {
+			recorder = openChatRecorder(projectRoot, cfg.Project.Name, description)
+			if recorder != nil {
+				defer func() { _ = recorder.Store.Close() }()
+			}
+		}
+		graphSummary := ""
+		if !skipUnderstandFlag && requirementsFlag == "" {
+			graphSummary = graph.ProjectSummary(projectRoot, cfg.KnowledgeGraph)
+		}
 		reqs, err = understand.RunUnderstand(
 			*cfg,
 			stackInfo,
 			description,
 			skipUnderstandFlag,
+			requirementsFlag,
 			runDir,
-			"", // graphSummary - empty for now
+			graphSummary,
 			logger,
+			recorder,
 		)
+		span.SetError(err)
+		span.End()
 		if err != nil {
 			return fmt.Errorf("understand phase: %w", err)
 		}
@@ -174,13 +245,37 @@ func runRun(cmd *cobra.Command, args []string) error {
 	}
 
 	isGreenfield := !detect.HasExistingCode(projectRoot)
-	p, err := plan.RunPlan(*cfg, planReqs, "", runDir, isGreenfield)
+	planSpan := trace.Start("plan")
+	var p *plan.Plan
+	if skipApproveFlag {
+		p, err = plan.RunPlanNonInteractive(context.Background(), *cfg, planReqs, "", runDir, isGreenfield, "")
+	} else {
+		p, err = plan.RunPlan(*cfg, planReqs, "", runDir, isGreenfield, explainPlanFlag)
+	}
+	planSpan.SetError(err)
+	if err == nil {
+		planSpan.Set(trace.Int("beads", len(p.Beads)))
+	}
+	planSpan.End()
 	if err != nil {
 		return fmt.Errorf("plan phase: %w", err)
 	}
 
+	// Catch a dependency cycle before any beads are created for it.
+	executionBeads := plan.ConvertToExecutionBeads(p.Beads)
+	if err := beads.ValidateDependencies(executionBeads); err != nil {
+		return fmt.Errorf("plan phase: %w", err)
+	}
+
 	fmt.Printf("Phase 2 PLAN: approved (%d beads)\n", len(p.Beads))
 
+	if runDryRunFlag {
+		fmt.Printf("\nDry run: %s\n", p.Title)
+		fmt.Print(execute.FormatDryRun(*cfg, executionBeads))
+		fmt.Println("\nNo branch, beads, or worktrees were created.")
+		return nil
+	}
+
 	// Create beads from the plan.
 	if beadErr := plan.CreateBeads(p, projectRoot); beadErr != nil {
 		return fmt.Errorf("creating beads: %w", beadErr)
@@ -198,7 +293,16 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 	// Phase 3: EXECUTE
 	fmt.Println("Phase 3 EXECUTE: running beads...")
-	if execErr := execute.RunExecute(*cfg, projectRoot, runDir, branchName, Verbose()); execErr != nil {
+	execSpan := trace.Start("execute")
+	var execErr error
+	if jsonFlag {
+		execErr = execute.RunExecuteJSON(*cfg, projectRoot, runDir, branchName, Verbose(), jsonOut)
+	} else {
+		execErr = execute.RunExecute(*cfg, projectRoot, runDir, branchName, Verbose())
+	}
+	execSpan.SetError(execErr)
+	execSpan.End()
+	if execErr != nil {
 		fmt.Fprintf(os.Stderr, "Execute phase error: %v\n", execErr)
 		// Continue to report phase even if execute had errors.
 	}
@@ -215,7 +319,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 		fmt.Print(report.FormatReport(r))
 	}
 
-	return nil
+	return executeResult(execErr)
 }
 
 // sanitizeBranchName converts a description into a valid git branch name.
@@ -247,3 +351,25 @@ func sanitizeBranchName(s string) string {
 
 	return s
 }
+
+// openChatRecorder opens the session store and attaches to the run's
+// session so requirements chat messages are persisted: the project's active
+// session for the same task, as when a run is restarted, or a new one. Returns nil (chat still works, it just isn't saved) if the store can't
+// be opened.
+func openChatRecorder(projectRoot, project, task string) *understand.ChatRecorder {
+	store, err := session.NewStore(session.DefaultPath(projectRoot))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: session store unavailable, chat will not be saved: %v\n", err)
+		return nil
+	}
+	sess, err := store.GetActiveForTask(project, task)
+	if err == nil && sess == nil {
+		sess, err = store.CreateSession(project, task)
+	}
+	if err != nil {
+		_ = store.Close()
+		fmt.Fprintf(os.Stderr, "Warning: failed to create session, chat will not be saved: %v\n", err)
+		return nil
+	}
+	return &understand.ChatRecorder{Store: store, SessionID: sess.ID}
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenChatRecorderReusesTheRunsSession(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".berth"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	first := openChatRecorder(root, "shop", "Add auth")
+	if first == nil {
+		t.Fatal("no recorder")
+	}
+	_ = first.Store.Close()
+
+	again := openChatRecorder(root, "shop", "Add auth")
+	if again == nil || again.SessionID != first.SessionID {
+		t.Fatalf("restarted run recorded into %+v, want session %s", again, first.SessionID)
+	}
+	_ = again.Store.Close()
+
+	other := openChatRecorder(root, "shop", "Add billing")
+	if other == nil || other.SessionID == first.SessionID {
+		t.Fatalf("a different task recorded into session %s too", first.SessionID)
+	}
+	_ = other.Store.Close()
+}
@@ -0,0 +1,67 @@
+// runs.go implements "berth runs diff" for comparing the outcomes of two runs.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	berthreport "github.com/berth-dev/berth/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "Inspect past runs in .berth/runs",
+}
+
+var runsDiffCmd = &cobra.Command{
+	Use:   "diff <runDirA> <runDirB>",
+	Short: "Compare the outcomes of two runs",
+	Long: `Compare two runs: beads that appear in only one of them, beads whose
+status changed, and deltas for tokens, cost, and duration.
+
+Each argument is a run directory or the name of a run under .berth/runs.
+Runs are compared using the summary.json written when the run's report is
+generated.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := runsDiff(resolveRunDir(args[0]), resolveRunDir(args[1]))
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+		return nil
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsDiffCmd)
+}
+
+// runsDiff reads the summaries of two run directories and formats the
+// comparison from A to B.
+func runsDiff(dirA, dirB string) (string, error) {
+	a, err := berthreport.ReadSummary(dirA)
+	if err != nil {
+		return "", err
+	}
+	b, err := berthreport.ReadSummary(dirB)
+	if err != nil {
+		return "", err
+	}
+	return berthreport.FormatRunDiff(filepath.Base(dirA), filepath.Base(dirB), a, b), nil
+}
+
+// resolveRunDir maps a bare run name to .berth/runs/<name> when arg is not
+// itself an existing path.
+func resolveRunDir(arg string) string {
+	if _, err := os.Stat(arg); err == nil {
+		return arg
+	}
+	candidate := filepath.Join(".berth", "runs", arg)
+	if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+		return candidate
+	}
+	return arg
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRunSummary(t *testing.T, name, summary string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "summary.json"), []byte(summary), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRunsDiff(t *testing.T) {
+	dirA := writeRunSummary(t, "20260101-100000", `{
+  "duration_ms": 300000, "cost_usd": 1.5, "tokens": 12000,
+  "beads": [
+    {"id": "bt-1", "title": "Add login form", "status": "completed", "attempts": 1},
+    {"id": "bt-2", "title": "Wire auth API", "status": "stuck"},
+    {"id": "bt-3", "title": "Old cleanup", "status": "completed"}
+  ]
+}`)
+	dirB := writeRunSummary(t, "20260102-100000", `{
+  "duration_ms": 240000, "cost_usd": 1.25, "tokens": 9000,
+  "beads": [
+    {"id": "bt-7", "title": "Add login form", "status": "completed", "attempts": 1},
+    {"id": "bt-8", "title": "Wire auth API", "status": "completed", "attempts": 2},
+    {"id": "bt-9", "title": "Add logout", "status": "completed"}
+  ]
+}`)
+
+	out, err := runsDiff(dirA, dirB)
+	if err != nil {
+		t.Fatalf("runsDiff: %v", err)
+	}
+
+	for _, want := range []string{
+		"Run diff: 20260101-100000 -> 20260102-100000",
+		"+ Add logout (completed)",
+		"- Old cleanup (completed)",
+		"~ Wire auth API: stuck -> completed",
+		"Completed: 2 -> 3 (+1)",
+		"Stuck:     1 -> 0 (-1)",
+		"Tokens:    12000 -> 9000 (-3000)",
+		"Cost:      $1.50 -> $1.25 (-$0.25)",
+		"Duration:  5m 0s -> 4m 0s (-1m 0s)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Add login form") {
+		t.Errorf("unchanged bead listed in diff:\n%s", out)
+	}
+}
+
+func TestRunsDiffMissingSummary(t *testing.T) {
+	dirA := writeRunSummary(t, "a", `{"beads": []}`)
+	if _, err := runsDiff(dirA, t.TempDir()); err == nil {
+		t.Fatal("expected an error for a run without summary.json")
+	}
+}
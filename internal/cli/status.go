@@ -3,71 +3,182 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/execute"
 	"github.com/berth-dev/berth/internal/git"
+	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/tui"
 	"github.com/spf13/cobra"
 )
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current run progress",
-	Long: `Display the status of the current or most recent Berth run,
-including all beads and their states.`,
+	Long: `Display the status of the current or most recent Berth run: bead
+counts by status, the current branch, whether the latest run left a
+checkpoint to resume, all beads and their states, and the last few events
+from .berth/log.jsonl. Read-only; the output is plain text.`,
 	RunE: runStatus,
 }
 
+// statusRecentEvents is how many of the latest log events status shows.
+const statusRecentEvents = 5
+
+// statusBeadOrder is the order status counts are listed in; any other
+// status follows in the order first seen.
+var statusBeadOrder = []string{"open", "in_progress", "blocked", "closed"}
+
+// statusReport is everything "berth status" prints.
+type statusReport struct {
+	Branch     string
+	Beads      []beads.Bead
+	RunID      string // latest directory under .berth/runs, if any
+	Checkpoint *execute.Checkpoint
+	Events     []log.LogEvent // most recent last
+	Plain      bool           // output is piped: separate counts with commas
+}
+
 func runStatus(cmd *cobra.Command, args []string) error {
-	// Find latest run directory in .berth/runs/.
-	runsDir := filepath.Join(".berth", "runs")
-	entries, err := os.ReadDir(runsDir)
-	if err != nil || len(entries) == 0 {
-		return fmt.Errorf("no runs found; start one with: berth run")
+	if _, err := os.Stat(".berth"); os.IsNotExist(err) {
+		return fmt.Errorf(".berth/ not found. Run 'berth init' first")
 	}
 
-	// Load all beads.
-	allBeads, err := beads.List()
+	// Load all beads, closed ones included, so the counts cover the run.
+	allBeads, err := beads.ListAll()
 	if err != nil {
 		return fmt.Errorf("failed to list beads: %w", err)
 	}
-	if len(allBeads) == 0 {
-		return fmt.Errorf("no runs found; start one with: berth run")
-	}
+
+	r := statusReport{Beads: allBeads, Plain: !tui.IsTTY()}
 
 	// Get current branch (best-effort).
-	branch, branchErr := git.CurrentBranch()
+	if branch, branchErr := git.CurrentBranch(); branchErr == nil {
+		r.Branch = branch
+	}
 
-	fmt.Println("Berth Status")
-	if branchErr == nil && branch != "" {
-		fmt.Printf("Branch: %s\n", branch)
+	if runDir, runErr := findLatestRunDir(); runErr == nil {
+		r.RunID = filepath.Base(runDir)
+		cp, cpErr := execute.LoadCheckpoint(runDir)
+		if cpErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring unreadable checkpoint in %s: %v\n", runDir, cpErr)
+		}
+		r.Checkpoint = cp
 	}
-	fmt.Println()
 
-	doneCount := 0
-	total := len(allBeads)
+	events, err := log.ReadEvents(".", log.EventFilter{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read event log: %v\n", err)
+	}
+	if len(events) > statusRecentEvents {
+		events = events[len(events)-statusRecentEvents:]
+	}
+	r.Events = events
 
-	for _, b := range allBeads {
-		status := normalizeStatus(b.Status)
-		extra := formatBeadExtra(b)
+	writeStatus(cmd.OutOrStdout(), r)
+	return nil
+}
 
-		fmt.Printf("  %-6s  %-13s  %s", b.ID, status, b.Title)
-		if extra != "" {
-			fmt.Printf("  %s", extra)
+// writeStatus prints the status report as plain text.
+func writeStatus(w io.Writer, r statusReport) {
+	fmt.Fprintln(w, "Berth Status")
+	if r.Branch != "" {
+		fmt.Fprintf(w, "Branch: %s\n", r.Branch)
+	}
+	switch {
+	case r.RunID == "":
+		fmt.Fprintln(w, "Last run: none")
+	case r.Checkpoint != nil:
+		fmt.Fprintf(w, "Last run: %s (checkpoint saved %s; resume with: berth resume %s)\n",
+			r.RunID, r.Checkpoint.Timestamp.Format("2006-01-02 15:04"), r.RunID)
+	default:
+		fmt.Fprintf(w, "Last run: %s (no checkpoint)\n", r.RunID)
+	}
+	fmt.Fprintln(w)
+
+	if len(r.Beads) == 0 {
+		fmt.Fprintln(w, "No beads; start a run with: berth run")
+	} else {
+		fmt.Fprintf(w, "Beads: %s\n\n", formatStatusCounts(r.Beads, r.Plain))
+
+		doneCount := 0
+		for _, b := range r.Beads {
+			status := normalizeStatus(b.Status)
+			extra := formatBeadExtra(b)
+
+			fmt.Fprintf(w, "  %-6s  %-13s  %s", b.ID, status, b.Title)
+			if extra != "" {
+				fmt.Fprintf(w, "  %s", extra)
+			}
+			fmt.Fprintln(w)
+
+			if b.Status == "done" || b.Status == "closed" {
+				doneCount++
+			}
 		}
-		fmt.Println()
 
-		if b.Status == "done" {
-			doneCount++
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Progress: %d/%d beads complete\n", doneCount, len(r.Beads))
+	}
+
+	if len(r.Events) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Recent events:")
+		for _, e := range r.Events {
+			fmt.Fprintf(w, "  %s  %s\n", e.Time.Local().Format("2006-01-02 15:04:05"), formatStatusEvent(e))
 		}
 	}
+}
 
-	fmt.Println()
-	fmt.Printf("Progress: %d/%d beads complete\n", doneCount, total)
+// formatStatusCounts renders bead totals by status, e.g.
+// "3 total · 1 open · 2 closed", or "3 total, 1 open, 2 closed" when plain.
+// A "done" bead counts as closed.
+func formatStatusCounts(all []beads.Bead, plain bool) string {
+	order := append([]string(nil), statusBeadOrder...)
+	counts := make(map[string]int)
+	for _, b := range all {
+		status := b.Status
+		if status == "done" {
+			status = "closed"
+		}
+		if _, seen := counts[status]; !seen && !slices.Contains(order, status) {
+			order = append(order, status)
+		}
+		counts[status]++
+	}
 
-	return nil
+	parts := []string{fmt.Sprintf("%d total", len(all))}
+	for _, status := range order {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	sep := " · "
+	if plain {
+		sep = ", "
+	}
+	return strings.Join(parts, sep)
+}
+
+// formatStatusEvent renders one log event's name with its bead and
+// error, if any.
+func formatStatusEvent(e log.LogEvent) string {
+	parts := []string{e.Event}
+	if e.BeadID != "" {
+		parts = append(parts, e.BeadID)
+	}
+	if e.Title != "" {
+		parts = append(parts, e.Title)
+	}
+	if e.Error != "" {
+		parts = append(parts, "error: "+e.Error)
+	}
+	return strings.Join(parts, "  ")
 }
 
 // normalizeStatus maps internal bead statuses to display-friendly labels.
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/execute"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+func TestWriteStatus(t *testing.T) {
+	var out strings.Builder
+	writeStatus(&out, statusReport{
+		Branch: "berth/auth",
+		Beads: []beads.Bead{
+			{ID: "bt-1", Title: "Add login form", Status: "closed"},
+			{ID: "bt-2", Title: "Wire auth API", Status: "in_progress"},
+			{ID: "bt-3", Title: "Add logout", Status: "open", DependsOn: []string{"bt-2"}},
+			{ID: "bt-4", Title: "Old cleanup", Status: "done"},
+			{ID: "bt-5", Title: "Rate limit", Status: "stuck"},
+		},
+		RunID:      "20260101-100000",
+		Checkpoint: &execute.Checkpoint{Timestamp: time.Date(2026, 1, 1, 10, 30, 0, 0, time.Local)},
+		Events: []log.LogEvent{
+			{Time: time.Now(), Event: log.EventTaskCompleted, BeadID: "bt-1", Title: "Add login form"},
+			{Time: time.Now(), Event: log.EventTaskStuck, BeadID: "bt-5", Error: "tests failed"},
+		},
+	})
+	got := out.String()
+
+	for _, want := range []string{
+		"Branch: berth/auth",
+		"Last run: 20260101-100000 (checkpoint saved 2026-01-01 10:30; resume with: berth resume 20260101-100000)",
+		"Beads: 5 total · 1 open · 1 in_progress · 2 closed · 1 stuck",
+		"[blocked by bt-2]",
+		"Progress: 2/5 beads complete",
+		"task_completed  bt-1  Add login form",
+		"task_stuck  bt-5  error: tests failed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("status output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteStatusWithoutRuns(t *testing.T) {
+	var out strings.Builder
+	writeStatus(&out, statusReport{})
+	got := out.String()
+
+	for _, want := range []string{"Last run: none", "No beads; start a run with: berth run"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("status output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Recent events") {
+		t.Errorf("status output lists events with none logged:\n%s", got)
+	}
+}
+
+func TestWriteStatusPlainSeparators(t *testing.T) {
+	var out strings.Builder
+	writeStatus(&out, statusReport{
+		Beads: []beads.Bead{{ID: "bt-1", Status: "open"}, {ID: "bt-2", Status: "closed"}},
+		Plain: true,
+	})
+	if got := out.String(); !strings.Contains(got, "Beads: 2 total, 1 open, 1 closed") || strings.Contains(got, "·") {
+		t.Errorf("piped status output should use plain separators:\n%s", got)
+	}
+}
@@ -2,25 +2,35 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
+	"github.com/berth-dev/berth/internal/log"
 	"gopkg.in/yaml.v3"
 )
 
 // Config is the top-level structure for .berth/config.yaml.
 type Config struct {
-	Version        int             `yaml:"version"`
-	Project        ProjectConfig   `yaml:"project"`
-	Model          string          `yaml:"model"`
-	Execution      ExecutionConfig `yaml:"execution"`
-	VerifyPipeline []string        `yaml:"verify_pipeline"`
-	Verify         VerifyConfig    `yaml:"verify"`
-	KnowledgeGraph KGConfig        `yaml:"knowledge_graph"`
-	Beads          BeadsConfig     `yaml:"beads"`
-	Cleanup        CleanupConfig   `yaml:"cleanup"`
-	TUI            TUIConfig       `yaml:"tui"`
+	Version         int              `yaml:"version"`
+	Project         ProjectConfig    `yaml:"project"`
+	Model           string           `yaml:"model"`
+	Agent           AgentConfig      `yaml:"agent,omitempty"`
+	Execution       ExecutionConfig  `yaml:"execution"`
+	Understand      UnderstandConfig `yaml:"understand,omitempty"`
+	Plan            PlanConfig       `yaml:"plan,omitempty"`
+	VerifyPipeline  []string         `yaml:"verify_pipeline"`
+	VerifyPipelines []PathPipeline   `yaml:"verify_pipelines,omitempty"`
+	Verify          VerifyConfig     `yaml:"verify"`
+	KnowledgeGraph  KGConfig         `yaml:"knowledge_graph"`
+	Beads           BeadsConfig      `yaml:"beads"`
+	Cleanup         CleanupConfig    `yaml:"cleanup"`
+	TUI             TUIConfig        `yaml:"tui"`
+	Telemetry       TelemetryConfig  `yaml:"telemetry,omitempty"`
+	Log             LogConfig        `yaml:"log,omitempty"`
 }
 
 // ProjectConfig holds project metadata detected or supplied during init.
@@ -31,6 +41,35 @@ type ProjectConfig struct {
 	PackageManager string `yaml:"package_manager"`
 }
 
+// PathPipeline is the verify pipeline of one stack in a polyglot repo,
+// e.g. a frontend under web/. Its commands run from Path, and only for
+// beads touching files under it (or listing no files at all).
+type PathPipeline struct {
+	Path     string   `yaml:"path"` // directory relative to the project root
+	Commands []string `yaml:"commands"`
+}
+
+// AgentConfig controls how the Claude CLI is invoked.
+type AgentConfig struct {
+	Command   string   `yaml:"command,omitempty"`    // path or name of the Claude CLI (default "claude")
+	ExtraArgs []string `yaml:"extra_args,omitempty"` // appended to every Claude invocation
+}
+
+// Binary returns the Claude CLI command, defaulting to "claude".
+func (a AgentConfig) Binary() string {
+	if a.Command == "" {
+		return "claude"
+	}
+	return a.Command
+}
+
+// Cmd builds the command running the Claude CLI with args followed by
+// ExtraArgs. Every Claude invocation goes through it.
+func (a AgentConfig) Cmd(ctx context.Context, args ...string) *exec.Cmd {
+	full := append(append([]string{}, args...), a.ExtraArgs...)
+	return exec.CommandContext(ctx, a.Binary(), full...)
+}
+
 // ExecutionConfig controls bead execution behaviour.
 type ExecutionConfig struct {
 	MaxRetries              int    `yaml:"max_retries"`
@@ -43,6 +82,74 @@ type ExecutionConfig struct {
 	ParallelThreshold       int    `yaml:"parallel_threshold"`        // min beads for auto-parallel
 	MergeStrategy           string `yaml:"merge_strategy"`            // "merge" (default)
 	CircuitBreakerThreshold int    `yaml:"circuit_breaker_threshold"` // default 3, consecutive failures before pausing
+	MaxBeads                int    `yaml:"max_beads"`                 // default 50, plans with more beads are refused
+
+	CircuitBreakerPolicy       string `yaml:"circuit_breaker_policy,omitempty"`        // "prompt" (default) | "cooldown" | "abort"
+	CircuitBreakerCooldown     int    `yaml:"circuit_breaker_cooldown,omitempty"`      // seconds to wait before resuming (cooldown policy), default 60
+	CircuitBreakerMaxCooldowns int    `yaml:"circuit_breaker_max_cooldowns,omitempty"` // cooldowns before aborting, default 3
+
+	BeadTimeout int `yaml:"bead_timeout,omitempty"` // seconds for a bead's whole retry loop, diagnostic included (0 = no limit)
+
+	ProtectedFiles []string `yaml:"protected_files,omitempty"` // globs beads may never modify (e.g. ".github/**", "LICENSE")
+
+	StuckPolicy string `yaml:"stuck_policy,omitempty"` // "prompt" (default) | "skip" | "abort" | "rescue": resolve stuck beads without the menu
+
+	VerifyContainer string `yaml:"verify_container,omitempty"` // docker image to run verification in (empty = run on host)
+	VerifyFailFast  *bool  `yaml:"verify_fail_fast,omitempty"` // stop at the first failing step (default true); false runs every step
+
+	SnapshotInterval int `yaml:"snapshot_interval,omitempty"` // tag the branch every N completed beads (0 = off)
+	SnapshotMinutes  int `yaml:"snapshot_minutes,omitempty"`  // tag the branch when a bead completes M+ minutes after the last tag (0 = off)
+
+	MergeWorkers int `yaml:"merge_workers,omitempty"` // concurrent merges in parallel mode (default 1); only beads with disjoint files merge together
+
+	LockTTL          int `yaml:"lock_ttl,omitempty"`           // seconds without a heartbeat before a parallel bead's file lock is released (default 300)
+	LockReapInterval int `yaml:"lock_reap_interval,omitempty"` // seconds between checks for stale file locks (default 30)
+	LockReapGrace    int `yaml:"lock_reap_grace,omitempty"`    // seconds after the coordinator starts before stale locks are reaped (default 60)
+
+	CoordinatorPort int `yaml:"coordinator_port,omitempty"` // preferred coordinator port in parallel mode; the next free port is used when taken (0 = random)
+
+	PostRunHook       string `yaml:"post_run_hook,omitempty"`        // shell command run after execution (receives BERTH_* env vars)
+	PostRunHookAlways bool   `yaml:"post_run_hook_always,omitempty"` // run the hook even if the run failed or beads are stuck
+
+	IncludeGitContext bool `yaml:"include_git_context,omitempty"` // list recent commits touching each bead file in the executor prompt
+
+	TokenBudget int `yaml:"token_budget,omitempty"` // stop the run once its beads have used more than N tokens in total (0 = no budget)
+
+	SequentialWorktrees bool `yaml:"sequential_worktrees,omitempty"` // run each sequential bead in its own worktree and merge it back before the next
+}
+
+// FailFast reports whether verification stops at the first failing step.
+// Unset means true.
+func (e ExecutionConfig) FailFast() bool {
+	return e.VerifyFailFast == nil || *e.VerifyFailFast
+}
+
+// LogConfig controls what .berth/log.jsonl and run summaries reveal, for
+// sharing run artifacts outside the team.
+type LogConfig struct {
+	AnonymizePaths bool `yaml:"anonymize_paths,omitempty"` // replace file paths with per-project pseudonyms
+	RedactTitles   bool `yaml:"redact_titles,omitempty"`   // drop bead titles, descriptions, and requirements
+}
+
+// Privacy returns the log redaction settings.
+func (l LogConfig) Privacy() log.Privacy {
+	return log.Privacy{AnonymizePaths: l.AnonymizePaths, RedactTitles: l.RedactTitles}
+}
+
+// UnderstandConfig controls how interview responses are parsed.
+type UnderstandConfig struct {
+	TrailingJSON         string `yaml:"trailing_json,omitempty"`           // "last" (default) | "first": which object wins when a response contains several
+	MaxQuestionsPerRound int    `yaml:"max_questions_per_round,omitempty"` // default 5, extra questions are deferred to later rounds
+	HideContext          bool   `yaml:"hide_context,omitempty"`            // don't show the Knowledge Graph summary before the first question
+	AcceptDefaults       bool   `yaml:"accept_defaults,omitempty"`         // answer each question with its recommended (else first) option; questions without options are still asked
+	SpawnTimeout         int    `yaml:"spawn_timeout,omitempty"`           // seconds each interview Claude call may run (default 300)
+	MaxRequirementsBytes int    `yaml:"max_requirements_bytes,omitempty"`  // requirements.md is truncated past this size (default 524288)
+}
+
+// PlanConfig controls plan generation.
+type PlanConfig struct {
+	SpawnTimeout int `yaml:"spawn_timeout,omitempty"`  // seconds each Claude call that generates or breaks down a plan may run (default 600)
+	MaxPlanBytes int `yaml:"max_plan_bytes,omitempty"` // plan.md is truncated past this size (default 1048576)
 }
 
 // KGConfig controls the Knowledge Graph MCP server integration.
@@ -66,8 +173,15 @@ type CleanupConfig struct {
 
 // TUIConfig controls terminal UI settings.
 type TUIConfig struct {
-	Enabled bool   `yaml:"enabled"` // Use TUI when available
-	Theme   string `yaml:"theme"`   // "dark", "light"
+	Enabled        bool   `yaml:"enabled"`                    // Use TUI when available
+	Theme          string `yaml:"theme"`                      // "dark", "light"
+	MaxOutputLines int    `yaml:"max_output_lines,omitempty"` // default 2000, older streamed output is dropped
+	SubmitKey      string `yaml:"submit_key,omitempty"`       // "enter" (default) or "ctrl-enter", where Enter inserts a newline
+}
+
+// TelemetryConfig controls optional trace export.
+type TelemetryConfig struct {
+	Endpoint string `yaml:"endpoint,omitempty"` // OTLP/HTTP collector URL, e.g. http://localhost:4318 (empty = tracing off)
 }
 
 // VerifyConfig controls the verification pipeline settings.
@@ -79,6 +193,17 @@ type VerifyConfig struct {
 const configDir = ".berth"
 const configFile = "config.yaml"
 
+// DefaultMaxBeads is the plan size cap used when execution.max_beads is unset.
+const DefaultMaxBeads = 50
+
+// DefaultMaxQuestionsPerRound is the interview batch size used when
+// understand.max_questions_per_round is unset.
+const DefaultMaxQuestionsPerRound = 5
+
+// DefaultMaxOutputLines is how much streamed bead output the TUI keeps when
+// tui.max_output_lines is unset.
+const DefaultMaxOutputLines = 2000
+
 // ReadConfig reads .berth/config.yaml from the given project directory.
 // dir is the project root (not .berth/ itself).
 // Returns an error if the file is not found or YAML is malformed.
@@ -98,6 +223,59 @@ func ReadConfig(dir string) (*Config, error) {
 	return &cfg, nil
 }
 
+// ProfileEnv is the environment variable that selects a config profile when
+// --profile is not given.
+const ProfileEnv = "BERTH_PROFILE"
+
+// ReadConfigProfile reads .berth/config.yaml and, when profile is non-empty,
+// merges .berth/config.<profile>.yaml over it. Only settings present in the
+// overlay change; lists in the overlay replace the base list. The merged
+// config is validated.
+func ReadConfigProfile(dir, profile string) (*Config, error) {
+	cfg, err := readProfile(dir, profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// CheckConfigProfile reads the config as ReadConfigProfile does, without
+// rejecting it, and returns every problem Check finds. The error is only
+// set when the files cannot be read or parsed.
+func CheckConfigProfile(dir, profile string) ([]Problem, error) {
+	cfg, err := readProfile(dir, profile)
+	if err != nil {
+		return nil, err
+	}
+	return Check(dir, cfg), nil
+}
+
+// readProfile reads config.yaml and merges the profile overlay over it.
+func readProfile(dir, profile string) (*Config, error) {
+	cfg, err := ReadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile != "" {
+		if strings.ContainsAny(profile, `/\`) || strings.HasPrefix(profile, ".") {
+			return nil, fmt.Errorf("invalid config profile %q", profile)
+		}
+		path := filepath.Join(dir, configDir, "config."+profile+".yaml")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config profile %q: %w", profile, err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing config profile %q: %w", profile, err)
+		}
+	}
+	return cfg, nil
+}
+
 // WriteConfig writes cfg to .berth/config.yaml in the given project directory.
 // Creates the .berth/ directory if it does not exist.
 func WriteConfig(dir string, cfg *Config) error {
@@ -135,6 +313,7 @@ func DefaultConfig() *Config {
 			ParallelThreshold:       4,
 			MergeStrategy:           "merge",
 			CircuitBreakerThreshold: 3,
+			MaxBeads:                DefaultMaxBeads,
 		},
 		Verify: VerifyConfig{
 			Security: "", // disabled by default
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -93,3 +94,94 @@ cleanup:
 		t.Error("config should not be nil")
 	}
 }
+
+func TestReadConfigProfileMergesOverlay(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := `version: 1
+model: sonnet
+execution:
+  max_retries: 3
+  parallel_mode: never
+  max_parallel: 2
+tui:
+  theme: light
+`
+	ci := `model: opus
+execution:
+  parallel_mode: always
+`
+	configPath := filepath.Join(tmpDir, ".berth")
+	if err := os.MkdirAll(configPath, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configPath, "config.yaml"), []byte(base), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configPath, "config.ci.yaml"), []byte(ci), 0644); err != nil {
+		t.Fatalf("failed to write profile: %v", err)
+	}
+
+	cfg, err := ReadConfigProfile(tmpDir, "ci")
+	if err != nil {
+		t.Fatalf("ReadConfigProfile failed: %v", err)
+	}
+
+	// Overridden by the profile.
+	if cfg.Model != "opus" {
+		t.Errorf("Model = %q, want opus", cfg.Model)
+	}
+	if cfg.Execution.ParallelMode != "always" {
+		t.Errorf("ParallelMode = %q, want always", cfg.Execution.ParallelMode)
+	}
+	// Inherited from the base config.
+	if cfg.Execution.MaxRetries != 3 {
+		t.Errorf("MaxRetries = %d, want 3", cfg.Execution.MaxRetries)
+	}
+	if cfg.Execution.MaxParallel != 2 {
+		t.Errorf("MaxParallel = %d, want 2", cfg.Execution.MaxParallel)
+	}
+	if cfg.TUI.Theme != "light" {
+		t.Errorf("TUI.Theme = %q, want light", cfg.TUI.Theme)
+	}
+
+	// Without a profile the base is read unchanged.
+	cfg, err = ReadConfigProfile(tmpDir, "")
+	if err != nil {
+		t.Fatalf("ReadConfigProfile without profile failed: %v", err)
+	}
+	if cfg.Execution.ParallelMode != "never" {
+		t.Errorf("ParallelMode = %q, want never", cfg.Execution.ParallelMode)
+	}
+
+	// The merged result is validated.
+	if err := os.WriteFile(filepath.Join(configPath, "config.bad.yaml"), []byte("execution:\n  parallel_mode: sometimes\n"), 0644); err != nil {
+		t.Fatalf("failed to write profile: %v", err)
+	}
+	if _, err := ReadConfigProfile(tmpDir, "bad"); err == nil {
+		t.Error("expected validation error for invalid profile value")
+	}
+	if _, err := ReadConfigProfile(tmpDir, "missing"); err == nil {
+		t.Error("expected error for missing profile file")
+	}
+}
+
+func TestAgentCmdDefaultsToClaude(t *testing.T) {
+	cmd := AgentConfig{}.Cmd(context.Background(), "-p", "hi")
+	if filepath.Base(cmd.Args[0]) != "claude" {
+		t.Errorf("Args[0] = %q, want claude", cmd.Args[0])
+	}
+	if len(cmd.Args) != 3 {
+		t.Errorf("Args = %v, want no extra args", cmd.Args)
+	}
+}
+
+func TestAgentCmdDoesNotWriteIntoCallerArgs(t *testing.T) {
+	agent := AgentConfig{ExtraArgs: []string{"--add-dir", "/shared"}}
+	args := make([]string, 2, 4)
+	copy(args, []string{"-p", "hi"})
+
+	agent.Cmd(context.Background(), args...)
+	if spare := args[:4]; spare[2] != "" || spare[3] != "" {
+		t.Errorf("caller's backing array = %v, want extra args not written into it", spare)
+	}
+}
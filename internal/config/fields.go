@@ -0,0 +1,217 @@
+// fields.go implements dotted-path access to individual config settings,
+// used by "berth config get/set".
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GetValue returns the value of the setting at key (e.g.
+// "execution.parallel_mode") formatted for display. Lists are returned one
+// item per line; nested sections are returned as YAML. An optional setting
+// that is not set returns an empty string.
+func GetValue(cfg *Config, key string) (string, error) {
+	field, err := lookupField(reflect.ValueOf(cfg).Elem(), key)
+	if err != nil {
+		return "", err
+	}
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return "", nil
+		}
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.Struct:
+		data, err := yaml.Marshal(field.Interface())
+		if err != nil {
+			return "", fmt.Errorf("marshalling %s: %w", key, err)
+		}
+		return strings.TrimRight(string(data), "\n"), nil
+	case reflect.Slice:
+		items := make([]string, field.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(field.Index(i).Interface())
+		}
+		return strings.Join(items, "\n"), nil
+	default:
+		return fmt.Sprint(field.Interface()), nil
+	}
+}
+
+// SetValue parses value according to the type of the setting at key, checks
+// the result with Validate, and writes it to .berth/config.yaml in dir.
+// Only the edited value changes in the file; comments and the order of other
+// keys are preserved. List settings take a comma-separated value, and an
+// empty value clears the list.
+func SetValue(dir, key, value string) error {
+	path := filepath.Join(dir, configDir, configFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("parsing config: %w", err)
+	}
+
+	field, err := lookupField(reflect.ValueOf(&cfg).Elem(), key)
+	if err != nil {
+		return err
+	}
+	if err := setField(field, key, value); err != nil {
+		return err
+	}
+	if err := Validate(&cfg); err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing config: %w", err)
+	}
+	if err := setNode(&doc, strings.Split(key, "."), field.Interface()); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(4)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("marshalling config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("marshalling config: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// lookupField walks v along the dotted key using yaml struct tags.
+func lookupField(v reflect.Value, key string) (reflect.Value, error) {
+	if key == "" {
+		return reflect.Value{}, fmt.Errorf("empty config key")
+	}
+
+	for _, part := range strings.Split(key, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("unknown config key %q", key)
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			if yamlName(v.Type().Field(i)) == part {
+				v = v.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}, fmt.Errorf("unknown config key %q", key)
+		}
+	}
+	return v, nil
+}
+
+// yamlName returns the YAML key for a struct field.
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+// setField parses value into field according to its kind. Optional
+// (pointer) settings are allocated and set through the pointer.
+func setField(field reflect.Value, key, value string) error {
+	switch field.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		if err := setField(elem.Elem(), key, value); err != nil {
+			return err
+		}
+		field.Set(elem)
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: expected an integer, got %q", key, value)
+		}
+		field.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: expected true or false, got %q", key, value)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%s: unsupported list type", key)
+		}
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s is a section, not a single setting", key)
+	}
+	return nil
+}
+
+// setNode replaces the value at path in a YAML document, creating missing
+// mapping keys. The line comment on an existing value is kept.
+func setNode(doc *yaml.Node, path []string, value any) error {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+
+	node := doc.Content[0]
+	for i, part := range path {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("config: %s is not a mapping", strings.Join(path[:i], "."))
+		}
+
+		var child *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == part {
+				child = node.Content[j+1]
+				break
+			}
+		}
+		if child == nil {
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: part}
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content = append(node.Content, keyNode, child)
+		}
+
+		if i == len(path)-1 {
+			var encoded yaml.Node
+			if err := encoded.Encode(value); err != nil {
+				return fmt.Errorf("encoding %s: %w", strings.Join(path, "."), err)
+			}
+			encoded.LineComment = child.LineComment
+			*child = encoded
+			return nil
+		}
+		node = child
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetValue(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.VerifyPipeline = []string{"go vet ./...", "go test ./..."}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"execution.parallel_mode", "auto"},
+		{"execution.max_parallel", "5"},
+		{"tui.enabled", "true"},
+		{"verify_pipeline", "go vet ./...\ngo test ./..."},
+	}
+	for _, tt := range tests {
+		got, err := GetValue(cfg, tt.key)
+		if err != nil {
+			t.Errorf("GetValue(%q): %v", tt.key, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("GetValue(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if got, err := GetValue(cfg, "execution.verify_fail_fast"); err != nil || got != "" {
+		t.Errorf("GetValue(verify_fail_fast) unset = %q, %v; want empty", got, err)
+	}
+	failFast := false
+	cfg.Execution.VerifyFailFast = &failFast
+	if got, err := GetValue(cfg, "execution.verify_fail_fast"); err != nil || got != "false" {
+		t.Errorf("GetValue(verify_fail_fast) = %q, %v; want false", got, err)
+	}
+
+	if _, err := GetValue(cfg, "execution.no_such_key"); err == nil {
+		t.Error("expected error for unknown key")
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteConfig(dir, DefaultConfig()); err != nil {
+		t.Fatalf("WriteConfig: %v", err)
+	}
+
+	sets := map[string]string{
+		"execution.parallel_mode":    "never",
+		"execution.max_parallel":     "8",
+		"knowledge_graph.mcp_debug":  "true",
+		"execution.protected_files":  ".github/**, LICENSE",
+		"execution.verify_fail_fast": "false",
+	}
+	for key, val := range sets {
+		if err := SetValue(dir, key, val); err != nil {
+			t.Fatalf("SetValue(%q, %q): %v", key, val, err)
+		}
+	}
+
+	cfg, err := ReadConfig(dir)
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+	if cfg.Execution.ParallelMode != "never" {
+		t.Errorf("ParallelMode = %q, want never", cfg.Execution.ParallelMode)
+	}
+	if cfg.Execution.MaxParallel != 8 {
+		t.Errorf("MaxParallel = %d, want 8", cfg.Execution.MaxParallel)
+	}
+	if !cfg.KnowledgeGraph.MCPDebug {
+		t.Error("MCPDebug should be true")
+	}
+	if len(cfg.Execution.ProtectedFiles) != 2 || cfg.Execution.ProtectedFiles[1] != "LICENSE" {
+		t.Errorf("ProtectedFiles = %v, want [.github/** LICENSE]", cfg.Execution.ProtectedFiles)
+	}
+	if cfg.Execution.FailFast() {
+		t.Error("VerifyFailFast should be false")
+	}
+	// Untouched settings keep their values.
+	if cfg.Beads.Prefix != "bt" {
+		t.Errorf("Beads.Prefix = %q, want bt", cfg.Beads.Prefix)
+	}
+}
+
+func TestSetValueRejectsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteConfig(dir, DefaultConfig()); err != nil {
+		t.Fatalf("WriteConfig: %v", err)
+	}
+
+	tests := []struct {
+		key, value string
+	}{
+		{"execution.parallel_mode", "sometimes"},
+		{"execution.max_parallel", "lots"},
+		{"execution.max_parallel", "-1"},
+		{"tui.enabled", "maybe"},
+		{"execution.unknown", "x"},
+		{"execution", "x"},
+	}
+	for _, tt := range tests {
+		if err := SetValue(dir, tt.key, tt.value); err == nil {
+			t.Errorf("SetValue(%q, %q) should fail", tt.key, tt.value)
+		}
+	}
+
+	cfg, err := ReadConfig(dir)
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+	if cfg.Execution.ParallelMode != "auto" || cfg.Execution.MaxParallel != 5 {
+		t.Errorf("config changed after rejected sets: %+v", cfg.Execution)
+	}
+}
+
+func TestSetValuePreservesComments(t *testing.T) {
+	dir := t.TempDir()
+	content := "# project settings\nversion: 1\nexecution:\n    parallel_mode: auto # how beads run\n    max_parallel: 5\n"
+	if err := os.MkdirAll(filepath.Join(dir, ".berth"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ".berth", "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetValue(dir, "execution.parallel_mode", "always"); err != nil {
+		t.Fatalf("SetValue: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{"# project settings", "parallel_mode: always # how beads run", "max_parallel: 5"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config missing %q after set:\n%s", want, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(DefaultConfig()); err != nil {
+		t.Errorf("default config should be valid: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.TUI.Theme = "neon"
+	cfg.Execution.MaxRetries = -2
+	cfg.Execution.CoordinatorPort = 70000
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, key := range []string{"tui.theme", "execution.max_retries", "execution.coordinator_port"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error should name %s, got: %v", key, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "web", "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "web", "scripts", "lint.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Execution.ParallelMode = "sometimes"
+	cfg.Execution.MaxParallel = 0
+	cfg.VerifyPipeline = []string{"CGO_ENABLED=0 sh -c true", "cd web && true", "berth-no-such-tool --all"}
+	cfg.VerifyPipelines = []PathPipeline{{Path: "web", Commands: []string{"./scripts/lint.sh", "./scripts/missing.sh"}}}
+
+	byKey := make(map[string]Problem)
+	for _, p := range Check(dir, cfg) {
+		byKey[p.Key] = p
+	}
+	if len(byKey) != 4 {
+		t.Errorf("got %d problems, want 4: %+v", len(byKey), byKey)
+	}
+
+	if p := byKey["execution.parallel_mode"]; !p.Fatal || !strings.Contains(p.Fix, "berth config set execution.parallel_mode") {
+		t.Errorf("parallel_mode problem = %+v, want fatal with a config set fix", p)
+	}
+	if p, ok := byKey["execution.max_parallel"]; !ok || p.Fatal {
+		t.Errorf("max_parallel problem = %+v, want a warning", p)
+	}
+	if p := byKey["verify_pipeline[2]"]; !p.Fatal || !strings.Contains(p.Message, "berth-no-such-tool") {
+		t.Errorf("verify_pipeline[2] problem = %+v, want fatal naming the command", p)
+	}
+	if p := byKey["verify_pipelines[0].commands[1]"]; !p.Fatal {
+		t.Errorf("missing script problem = %+v, want fatal", p)
+	}
+
+	// Commands run in the container may exist only in the image.
+	cfg.Execution.VerifyContainer = "golang:1.25"
+	for _, p := range Check(dir, cfg) {
+		if p.Key == "verify_pipeline[2]" && (p.Fatal || !strings.Contains(p.Fix, "golang:1.25")) {
+			t.Errorf("verify_pipeline[2] problem with a container = %+v, want a warning naming the image", p)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MatchProtected returns the first glob in globs that matches filePath, or ""
+// if none match. Globs use path.Match syntax against the slash-separated
+// project-relative path. A glob without a slash also matches the file's base
+// name anywhere in the tree (e.g. "LICENSE"), and a glob ending in "/**"
+// matches everything beneath that directory (e.g. ".github/**").
+func MatchProtected(filePath string, globs []string) string {
+	p := filepath.ToSlash(filepath.Clean(filePath))
+	p = strings.TrimPrefix(p, "./")
+
+	for _, g := range globs {
+		g = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(g)), "./")
+		if g == "" {
+			continue
+		}
+		if dir, ok := strings.CutSuffix(g, "/**"); ok {
+			if p == dir || strings.HasPrefix(p, dir+"/") {
+				return g
+			}
+			continue
+		}
+		if ok, _ := path.Match(g, p); ok {
+			return g
+		}
+		if !strings.Contains(g, "/") {
+			if ok, _ := path.Match(g, path.Base(p)); ok {
+				return g
+			}
+		}
+	}
+	return ""
+}
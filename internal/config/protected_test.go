@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestMatchProtected(t *testing.T) {
+	globs := []string{".github/**", "LICENSE", "*.pem", "migrations/*.sql"}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{".github/workflows/ci.yml", ".github/**"},
+		{"./.github/CODEOWNERS", ".github/**"},
+		{"LICENSE", "LICENSE"},
+		{"vendor/foo/LICENSE", "LICENSE"},
+		{"certs/server.pem", "*.pem"},
+		{"migrations/001_init.sql", "migrations/*.sql"},
+		{"db/migrations/001_init.sql", ""},
+		{"internal/github/client.go", ""},
+		{"LICENSE.md", ""},
+	}
+
+	for _, tt := range tests {
+		if got := MatchProtected(tt.path, globs); got != tt.want {
+			t.Errorf("MatchProtected(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchProtectedNoGlobs(t *testing.T) {
+	if got := MatchProtected("LICENSE", nil); got != "" {
+		t.Errorf("MatchProtected with no globs = %q, want empty", got)
+	}
+}
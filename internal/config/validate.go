@@ -0,0 +1,238 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// enumFields lists the allowed values for string settings with a fixed set
+// of choices, keyed by dotted YAML path. An empty value is always accepted
+// and means "use the default".
+var enumFields = map[string][]string{
+	"execution.parallel_mode":          {"auto", "always", "never"},
+	"execution.merge_strategy":         {"merge"},
+	"execution.circuit_breaker_policy": {"prompt", "cooldown", "abort"},
+	"execution.stuck_policy":           {"prompt", "skip", "abort", "rescue"},
+	"understand.trailing_json":         {"last", "first"},
+	"knowledge_graph.enabled":          {"auto", "always", "never"},
+	"tui.theme":                        {"dark", "light"},
+	"tui.submit_key":                   {"enter", "ctrl-enter"},
+}
+
+// Problem is one issue found in a config, located by its dotted YAML key.
+// Fatal problems stop berth from running; the rest are warnings.
+type Problem struct {
+	Key     string
+	Message string
+	Fix     string // suggested fix, shown by "berth config validate"
+	Fatal   bool
+}
+
+// Validate checks cfg for out-of-range numbers and unknown enum values.
+// It returns all problems found, joined into a single error.
+func Validate(cfg *Config) error {
+	var errs []error
+	for _, p := range settingProblems(cfg) {
+		if p.Fatal {
+			errs = append(errs, fmt.Errorf("%s: %s", p.Key, p.Message))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check runs every check berth knows about on cfg: the checks Validate
+// makes, warnings for settings that silently fall back to a default, and
+// whether each verify command can be found. dir is the project root, used
+// to resolve relative command paths.
+func Check(dir string, cfg *Config) []Problem {
+	problems := settingProblems(cfg)
+
+	positive := []struct {
+		key string
+		val int
+	}{
+		{"execution.parallel_threshold", cfg.Execution.ParallelThreshold},
+		{"execution.max_parallel", cfg.Execution.MaxParallel},
+		{"execution.circuit_breaker_threshold", cfg.Execution.CircuitBreakerThreshold},
+	}
+	for _, f := range positive {
+		if f.val == 0 {
+			problems = append(problems, Problem{
+				Key:     f.key,
+				Message: "is 0, so berth falls back to its built-in default",
+				Fix:     fmt.Sprintf("berth config set %s <n> with n greater than 0", f.key),
+			})
+		}
+	}
+
+	for i, c := range cfg.VerifyPipeline {
+		problems = append(problems, commandProblems(fmt.Sprintf("verify_pipeline[%d]", i), dir, c, cfg.Execution.VerifyContainer)...)
+	}
+	for i, pp := range cfg.VerifyPipelines {
+		for j, c := range pp.Commands {
+			key := fmt.Sprintf("verify_pipelines[%d].commands[%d]", i, j)
+			problems = append(problems, commandProblems(key, filepath.Join(dir, pp.Path), c, cfg.Execution.VerifyContainer)...)
+		}
+	}
+	return problems
+}
+
+// settingProblems checks enum and numeric settings. Every problem it
+// returns is fatal.
+func settingProblems(cfg *Config) []Problem {
+	var problems []Problem
+
+	enums := []struct {
+		key string
+		val string
+	}{
+		{"execution.parallel_mode", cfg.Execution.ParallelMode},
+		{"execution.merge_strategy", cfg.Execution.MergeStrategy},
+		{"execution.circuit_breaker_policy", cfg.Execution.CircuitBreakerPolicy},
+		{"execution.stuck_policy", cfg.Execution.StuckPolicy},
+		{"understand.trailing_json", cfg.Understand.TrailingJSON},
+		{"knowledge_graph.enabled", cfg.KnowledgeGraph.Enabled},
+		{"tui.theme", cfg.TUI.Theme},
+		{"tui.submit_key", cfg.TUI.SubmitKey},
+	}
+	for _, f := range enums {
+		if f.val != "" && !contains(enumFields[f.key], f.val) {
+			allowed := enumFields[f.key]
+			problems = append(problems, Problem{
+				Key:     f.key,
+				Message: fmt.Sprintf("invalid value %q (allowed: %s)", f.val, strings.Join(allowed, ", ")),
+				Fix:     fmt.Sprintf("berth config set %s %s", f.key, allowed[0]),
+				Fatal:   true,
+			})
+		}
+	}
+
+	nonNegative := []struct {
+		key string
+		val int
+	}{
+		{"execution.max_retries", cfg.Execution.MaxRetries},
+		{"execution.timeout_per_bead", cfg.Execution.TimeoutPerBead},
+		{"execution.bead_timeout", cfg.Execution.BeadTimeout},
+		{"execution.max_parallel", cfg.Execution.MaxParallel},
+		{"execution.parallel_threshold", cfg.Execution.ParallelThreshold},
+		{"execution.circuit_breaker_threshold", cfg.Execution.CircuitBreakerThreshold},
+		{"execution.max_beads", cfg.Execution.MaxBeads},
+		{"execution.token_budget", cfg.Execution.TokenBudget},
+		{"execution.circuit_breaker_cooldown", cfg.Execution.CircuitBreakerCooldown},
+		{"execution.circuit_breaker_max_cooldowns", cfg.Execution.CircuitBreakerMaxCooldowns},
+		{"execution.merge_workers", cfg.Execution.MergeWorkers},
+		{"execution.lock_ttl", cfg.Execution.LockTTL},
+		{"execution.lock_reap_interval", cfg.Execution.LockReapInterval},
+		{"execution.lock_reap_grace", cfg.Execution.LockReapGrace},
+		{"execution.coordinator_port", cfg.Execution.CoordinatorPort},
+		{"execution.snapshot_interval", cfg.Execution.SnapshotInterval},
+		{"execution.snapshot_minutes", cfg.Execution.SnapshotMinutes},
+		{"understand.max_questions_per_round", cfg.Understand.MaxQuestionsPerRound},
+		{"understand.spawn_timeout", cfg.Understand.SpawnTimeout},
+		{"understand.max_requirements_bytes", cfg.Understand.MaxRequirementsBytes},
+		{"plan.spawn_timeout", cfg.Plan.SpawnTimeout},
+		{"plan.max_plan_bytes", cfg.Plan.MaxPlanBytes},
+		{"knowledge_graph.mcp_timeout", cfg.KnowledgeGraph.MCPTimeout},
+		{"knowledge_graph.tool_call_timeout", cfg.KnowledgeGraph.ToolCallTimeout},
+		{"cleanup.max_age_days", cfg.Cleanup.MaxAgeDays},
+		{"tui.max_output_lines", cfg.TUI.MaxOutputLines},
+	}
+	for _, f := range nonNegative {
+		if f.val < 0 {
+			problems = append(problems, Problem{
+				Key:     f.key,
+				Message: fmt.Sprintf("must not be negative, got %d", f.val),
+				Fix:     fmt.Sprintf("berth config set %s 0 to use the default", f.key),
+				Fatal:   true,
+			})
+		}
+	}
+	if cfg.Execution.CoordinatorPort > 65535 {
+		problems = append(problems, Problem{
+			Key:     "execution.coordinator_port",
+			Message: fmt.Sprintf("not a TCP port, got %d", cfg.Execution.CoordinatorPort),
+			Fix:     "berth config set execution.coordinator_port 0 to use a random port",
+			Fatal:   true,
+		})
+	}
+
+	return problems
+}
+
+// shellBuiltins are command words that never resolve on PATH but still run
+// under sh -c.
+var shellBuiltins = []string{"cd", "export", "set", "source", ".", "test", "[", "true", "false", "exit"}
+
+// commandProblems reports a verify command whose program cannot be found.
+// Leading VAR=value assignments are skipped; a program containing a slash
+// is resolved against dir instead of PATH. A program missing from PATH is
+// only a warning when the commands run in the container image.
+func commandProblems(key, dir, command, container string) []Problem {
+	var prog string
+	for _, word := range strings.Fields(command) {
+		if !strings.Contains(word, "=") {
+			prog = word
+			break
+		}
+	}
+	if prog == "" {
+		return []Problem{{
+			Key:     key,
+			Message: "empty command",
+			Fix:     "remove the entry or give it a command to run",
+			Fatal:   true,
+		}}
+	}
+	if contains(shellBuiltins, prog) {
+		return nil
+	}
+
+	if strings.Contains(prog, "/") {
+		path := prog
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
+		return []Problem{{
+			Key:     key,
+			Message: fmt.Sprintf("%s: no such file", prog),
+			Fix:     "fix the path; relative paths are resolved from the pipeline's directory",
+			Fatal:   true,
+		}}
+	}
+
+	if _, err := exec.LookPath(prog); err != nil {
+		if container != "" {
+			// The command runs in the container, whose PATH berth cannot
+			// see, so only warn.
+			return []Problem{{
+				Key:     key,
+				Message: fmt.Sprintf("command %q not found on the host PATH", prog),
+				Fix:     fmt.Sprintf("make sure the %s image provides %s", container, prog),
+			}}
+		}
+		return []Problem{{
+			Key:     key,
+			Message: fmt.Sprintf("command %q not found on PATH", prog),
+			Fix:     fmt.Sprintf("install %s or change the command", prog),
+			Fatal:   true,
+		}}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
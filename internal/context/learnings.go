@@ -34,6 +34,16 @@ func ReadLearnings(dir string) []string {
 	return learnings
 }
 
+// ReadRecentLearnings returns at most the n most recent learning entries from
+// .berth/learnings.md, oldest first. If n <= 0, all entries are returned.
+func ReadRecentLearnings(dir string, n int) []string {
+	learnings := ReadLearnings(dir)
+	if n > 0 && len(learnings) > n {
+		return learnings[len(learnings)-n:]
+	}
+	return learnings
+}
+
 // AppendLearning appends a new learning entry to .berth/learnings.md in the
 // given directory. Creates the file and .berth/ directory if they do not exist.
 func AppendLearning(dir string, learning string) error {
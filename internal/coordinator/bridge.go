@@ -71,6 +71,7 @@ var toolNameToEndpoint = map[string]string{
 	"acquire_lock":     "/acquire_lock",
 	"release_lock":     "/release_lock",
 	"check_lock":       "/check_lock",
+	"list_locks":       "/list_locks",
 	"heartbeat":        "/heartbeat",
 	"write_decision":   "/write_decision",
 	"read_decisions":   "/read_decisions",
@@ -118,6 +119,14 @@ var coordinatorTools = []toolDef{
 			Required: []string{"file_path"},
 		},
 	},
+	{
+		Name:        "list_locks",
+		Description: "List every file lock with its holder and acquire time, and the agents waiting for one",
+		InputSchema: toolDefInputSchema{
+			Type:       "object",
+			Properties: map[string]toolDefProperty{},
+		},
+	},
 	{
 		Name:        "heartbeat",
 		Description: "Send heartbeat to keep locks alive (call periodically)",
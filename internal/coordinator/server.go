@@ -2,12 +2,31 @@ package coordinator
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"sort"
+	"strconv"
+	"syscall"
 	"time"
 )
 
+// ErrPortInUse is returned when the requested coordinator address is already
+// bound by another process.
+var ErrPortInUse = errors.New("coordinator: port already in use")
+
+// Defaults for StartLockReaper when the configuration leaves them unset.
+const (
+	DefaultLockTTL          = 5 * time.Minute
+	DefaultLockReapInterval = 30 * time.Second
+	DefaultLockReapGrace    = time.Minute
+)
+
+// maxPortAttempts is how many consecutive ports NewServerPreferAddr tries
+// before falling back to a random port.
+const maxPortAttempts = 10
+
 // Server is the coordinator HTTP server that agents use for file locking,
 // decision broadcasting, intent announcements, and artifact publishing.
 type Server struct {
@@ -15,19 +34,72 @@ type Server struct {
 	listener net.Listener
 	server   *http.Server
 	stopCh   chan struct{}
+	now      func() time.Time // clock for lock timestamps; replaced in tests
 }
 
 // NewServer creates a coordinator server bound to a random port on localhost.
 func NewServer() (*Server, error) {
-	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	return NewServerWithAddr("127.0.0.1:0")
+}
+
+// NewServerWithAddr creates a coordinator server bound to addr. If the port
+// is already taken the returned error wraps ErrPortInUse, so callers can
+// tell a collision apart from other bind failures.
+func NewServerWithAddr(addr string) (*Server, error) {
+	ln, err := listen(addr)
+	if err != nil {
+		return nil, err
+	}
+	return newServer(ln), nil
+}
+
+// NewServerPreferAddr binds addr if possible. When that port is in use it
+// tries the next maxPortAttempts-1 ports, then falls back to a random port
+// on the same host. Bind failures other than a port collision are returned
+// immediately. Use Addr to find the port actually chosen.
+func NewServerPreferAddr(addr string) (*Server, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("coordinator: invalid address %q: %w", addr, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port == 0 {
+		return NewServerWithAddr(addr)
+	}
+
+	for i := 0; i < maxPortAttempts && port+i <= 65535; i++ {
+		candidate := net.JoinHostPort(host, strconv.Itoa(port+i))
+		s, err := NewServerWithAddr(candidate)
+		if err == nil {
+			return s, nil
+		}
+		if !errors.Is(err, ErrPortInUse) {
+			return nil, err
+		}
+	}
+
+	return NewServerWithAddr(net.JoinHostPort(host, "0"))
+}
+
+// listen binds a TCP listener, translating EADDRINUSE into ErrPortInUse.
+func listen(addr string) (net.Listener, error) {
+	ln, err := net.Listen("tcp", addr)
 	if err != nil {
-		return nil, fmt.Errorf("coordinator: binding listener: %w", err)
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return nil, fmt.Errorf("%w: %s", ErrPortInUse, addr)
+		}
+		return nil, fmt.Errorf("coordinator: binding listener on %s: %w", addr, err)
 	}
+	return ln, nil
+}
 
+// newServer wires the HTTP handlers onto an already-bound listener.
+func newServer(ln net.Listener) *Server {
 	s := &Server{
 		state:    NewState(),
 		listener: ln,
 		stopCh:   make(chan struct{}),
+		now:      time.Now,
 	}
 
 	mux := http.NewServeMux()
@@ -35,6 +107,7 @@ func NewServer() (*Server, error) {
 	mux.HandleFunc("/acquire_lock", s.handleAcquireLock)
 	mux.HandleFunc("/release_lock", s.handleReleaseLock)
 	mux.HandleFunc("/check_lock", s.handleCheckLock)
+	mux.HandleFunc("/list_locks", s.handleListLocks)
 	mux.HandleFunc("/heartbeat", s.handleHeartbeat)
 	mux.HandleFunc("/write_decision", s.handleWriteDecision)
 	mux.HandleFunc("/read_decisions", s.handleReadDecisions)
@@ -45,7 +118,7 @@ func NewServer() (*Server, error) {
 	mux.HandleFunc("/get_all_status", s.handleGetAllStatus)
 
 	s.server = &http.Server{Handler: mux}
-	return s, nil
+	return s
 }
 
 // Addr returns the address the server is listening on (e.g. "127.0.0.1:12345").
@@ -64,31 +137,60 @@ func (s *Server) Stop() error {
 	return s.server.Close()
 }
 
-// StartLockReaper starts a goroutine that periodically removes stale locks
-// (those with no heartbeat for longer than the given interval).
-func (s *Server) StartLockReaper(interval time.Duration) {
+// StartLockReaper starts a goroutine that checks for stale locks every
+// interval and removes those with no heartbeat for longer than ttl. Nothing
+// is reaped until grace has passed since the reaper started, so beads that
+// took locks at the start of a run have time to send their first
+// heartbeat. Non-positive values fall back to DefaultLockTTL,
+// DefaultLockReapInterval and DefaultLockReapGrace.
+func (s *Server) StartLockReaper(ttl, interval, grace time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultLockTTL
+	}
+	if interval <= 0 {
+		interval = DefaultLockReapInterval
+	}
+	if grace <= 0 {
+		grace = DefaultLockReapGrace
+	}
+	started := s.now()
 	go func() {
-		ticker := time.NewTicker(30 * time.Second)
+		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 		for {
 			select {
 			case <-s.stopCh:
 				return
 			case <-ticker.C:
-				s.reapStaleLocks(interval)
+				if s.now().Sub(started) < grace {
+					continue
+				}
+				s.reapStaleLocks(ttl)
 			}
 		}
 	}()
 }
 
 func (s *Server) reapStaleLocks(maxAge time.Duration) {
-	now := time.Now()
+	now := s.now()
 	s.state.mu.Lock()
 	defer s.state.mu.Unlock()
 
 	for path, lock := range s.state.Locks {
 		if now.Sub(lock.LastHeartbeat) > maxAge {
 			delete(s.state.Locks, path)
+			s.clearWaits(path)
+		}
+	}
+}
+
+// clearWaits drops the waits for path once its lock is gone: a bead still
+// wanting the file records a new wait when its next acquire is refused.
+// Must be called with s.state.mu held.
+func (s *Server) clearWaits(path string) {
+	for beadID, wait := range s.state.Waits {
+		if wait.FilePath == path {
+			delete(s.state.Waits, beadID)
 		}
 	}
 }
@@ -108,13 +210,26 @@ func (s *Server) handleAcquireLock(w http.ResponseWriter, r *http.Request) {
 	s.state.mu.Lock()
 	defer s.state.mu.Unlock()
 
+	now := s.now()
 	existing, held := s.state.Locks[req.FilePath]
 	if held && existing.BeadID != req.BeadID {
+		if wait, ok := s.state.Waits[req.BeadID]; ok && wait.FilePath == req.FilePath {
+			wait.BlockedBy = existing.BeadID
+		} else {
+			s.state.Waits[req.BeadID] = &LockWait{
+				BeadID:    req.BeadID,
+				FilePath:  req.FilePath,
+				BlockedBy: existing.BeadID,
+				Since:     now,
+			}
+		}
 		writeJSON(w, AcquireLockResponse{Acquired: false, BlockedBy: existing.BeadID})
 		return
 	}
 
-	now := time.Now()
+	if wait, ok := s.state.Waits[req.BeadID]; ok && wait.FilePath == req.FilePath {
+		delete(s.state.Waits, req.BeadID)
+	}
 	s.state.Locks[req.FilePath] = &FileLock{
 		BeadID:        req.BeadID,
 		FilePath:      req.FilePath,
@@ -136,6 +251,7 @@ func (s *Server) handleReleaseLock(w http.ResponseWriter, r *http.Request) {
 	existing, held := s.state.Locks[req.FilePath]
 	if held && existing.BeadID == req.BeadID {
 		delete(s.state.Locks, req.FilePath)
+		s.clearWaits(req.FilePath)
 		writeJSON(w, ReleaseLockResponse{Released: true})
 		return
 	}
@@ -159,13 +275,34 @@ func (s *Server) handleCheckLock(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, CheckLockResponse{Locked: false})
 }
 
+func (s *Server) handleListLocks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.ListLocks())
+}
+
+// ListLocks returns a copy of the current file locks and lock waits.
+func (s *Server) ListLocks() ListLocksResponse {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+
+	resp := ListLocksResponse{Locks: make([]FileLock, 0, len(s.state.Locks))}
+	for _, lock := range s.state.Locks {
+		resp.Locks = append(resp.Locks, *lock)
+	}
+	for _, wait := range s.state.Waits {
+		resp.Waiting = append(resp.Waiting, *wait)
+	}
+	sort.Slice(resp.Locks, func(i, j int) bool { return resp.Locks[i].FilePath < resp.Locks[j].FilePath })
+	sort.Slice(resp.Waiting, func(i, j int) bool { return resp.Waiting[i].BeadID < resp.Waiting[j].BeadID })
+	return resp
+}
+
 func (s *Server) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 	var req HeartbeatRequest
 	if !readJSON(w, r, &req) {
 		return
 	}
 
-	now := time.Now()
+	now := s.now()
 	s.state.mu.Lock()
 	defer s.state.mu.Unlock()
 
@@ -0,0 +1,227 @@
+package coordinator
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewServerWithAddrPortInUse(t *testing.T) {
+	first, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	defer first.Stop()
+
+	_, err = NewServerWithAddr(first.Addr())
+	if err == nil {
+		t.Fatal("expected error binding an address already in use")
+	}
+	if !errors.Is(err, ErrPortInUse) {
+		t.Errorf("error = %v, want ErrPortInUse", err)
+	}
+}
+
+func TestNewServerWithAddrOtherBindFailure(t *testing.T) {
+	_, err := NewServerWithAddr("not-an-address")
+	if err == nil {
+		t.Fatal("expected error for invalid address")
+	}
+	if errors.Is(err, ErrPortInUse) {
+		t.Errorf("invalid address should not be reported as port in use: %v", err)
+	}
+}
+
+func TestNewServerPreferAddrFallsBack(t *testing.T) {
+	first, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	defer first.Stop()
+
+	second, err := NewServerPreferAddr(first.Addr())
+	if err != nil {
+		t.Fatalf("NewServerPreferAddr: %v", err)
+	}
+	defer second.Stop()
+
+	if second.Addr() == first.Addr() {
+		t.Errorf("expected an alternate port, got same address %s", second.Addr())
+	}
+	_, port, _ := net.SplitHostPort(second.Addr())
+	if n, _ := strconv.Atoi(port); n == 0 {
+		t.Errorf("expected a concrete port, got %s", second.Addr())
+	}
+}
+
+// fakeClock is a settable clock for Server.now, safe to read from the
+// reaper goroutine.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func (s *Server) lockHeld(path string) bool {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+	_, held := s.state.Locks[path]
+	return held
+}
+
+func TestLockReaperReapsAfterTTL(t *testing.T) {
+	s, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	defer s.Stop()
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.now = clock.now
+
+	body := `{"bead_id": "bt-1", "file_path": "main.go"}`
+	rec := httptest.NewRecorder()
+	s.handleAcquireLock(rec, httptest.NewRequest(http.MethodPost, "/acquire_lock", strings.NewReader(body)))
+	if !strings.Contains(rec.Body.String(), `"acquired":true`) {
+		t.Fatalf("acquire_lock = %s, want acquired", rec.Body.String())
+	}
+
+	const interval = 5 * time.Millisecond
+	s.StartLockReaper(time.Minute, interval, time.Minute)
+
+	// Several ticks within the TTL leave the lock alone.
+	clock.advance(59 * time.Second)
+	time.Sleep(10 * interval)
+	if !s.lockHeld("main.go") {
+		t.Fatal("lock reaped before its TTL expired")
+	}
+
+	clock.advance(2 * time.Second)
+	deadline := time.Now().Add(2 * time.Second)
+	for s.lockHeld("main.go") {
+		if time.Now().After(deadline) {
+			t.Fatal("lock not reaped after its TTL expired")
+		}
+		time.Sleep(interval)
+	}
+}
+
+func TestLockReaperWaitsForGracePeriod(t *testing.T) {
+	s, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	defer s.Stop()
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.now = clock.now
+
+	postJSON(t, s.handleAcquireLock, `{"bead_id": "bt-1", "file_path": "main.go"}`)
+
+	const interval = 5 * time.Millisecond
+	s.StartLockReaper(time.Minute, interval, 10*time.Minute)
+
+	// The lock is past its TTL, but the reaper is still in its grace window.
+	clock.advance(5 * time.Minute)
+	time.Sleep(10 * interval)
+	if !s.lockHeld("main.go") {
+		t.Fatal("lock reaped during the grace period")
+	}
+
+	clock.advance(6 * time.Minute)
+	deadline := time.Now().Add(2 * time.Second)
+	for s.lockHeld("main.go") {
+		if time.Now().After(deadline) {
+			t.Fatal("stale lock not reaped after the grace period")
+		}
+		time.Sleep(interval)
+	}
+}
+
+func postJSON(t *testing.T, handler http.HandlerFunc, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	return rec
+}
+
+func TestListLocksShowsHoldersAndWaits(t *testing.T) {
+	s, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	defer s.Stop()
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.now = clock.now
+
+	postJSON(t, s.handleAcquireLock, `{"bead_id": "bt-2", "file_path": "b.go"}`)
+	postJSON(t, s.handleAcquireLock, `{"bead_id": "bt-1", "file_path": "a.go"}`)
+	clock.advance(time.Minute)
+	postJSON(t, s.handleAcquireLock, `{"bead_id": "bt-2", "file_path": "a.go"}`)
+	clock.advance(time.Minute)
+	postJSON(t, s.handleAcquireLock, `{"bead_id": "bt-2", "file_path": "a.go"}`) // retry keeps Since
+
+	var resp ListLocksResponse
+	if err := json.Unmarshal(postJSON(t, s.handleListLocks, "").Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding list_locks: %v", err)
+	}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if len(resp.Locks) != 2 || resp.Locks[0].FilePath != "a.go" || resp.Locks[0].BeadID != "bt-1" ||
+		resp.Locks[1].FilePath != "b.go" || !resp.Locks[1].AcquiredAt.Equal(start) {
+		t.Errorf("locks = %+v, want a.go held by bt-1 and b.go by bt-2", resp.Locks)
+	}
+	want := []LockWait{{BeadID: "bt-2", FilePath: "a.go", BlockedBy: "bt-1", Since: start.Add(time.Minute)}}
+	if len(resp.Waiting) != 1 || resp.Waiting[0].BeadID != want[0].BeadID || resp.Waiting[0].FilePath != want[0].FilePath ||
+		resp.Waiting[0].BlockedBy != want[0].BlockedBy || !resp.Waiting[0].Since.Equal(want[0].Since) {
+		t.Errorf("waiting = %+v, want %+v", resp.Waiting, want)
+	}
+
+	// Once bt-1 lets go, bt-2 is no longer waiting, and then gets the lock.
+	postJSON(t, s.handleReleaseLock, `{"bead_id": "bt-1", "file_path": "a.go"}`)
+	if got := s.ListLocks(); len(got.Waiting) != 0 {
+		t.Errorf("waiting after release = %+v, want none", got.Waiting)
+	}
+	postJSON(t, s.handleAcquireLock, `{"bead_id": "bt-2", "file_path": "a.go"}`)
+	if got := s.ListLocks(); len(got.Waiting) != 0 || len(got.Locks) != 2 || got.Locks[0].BeadID != "bt-2" {
+		t.Errorf("after release = %+v, want bt-2 holding both files and no waits", got)
+	}
+
+	// A reaped lock drops the waits for it too.
+	postJSON(t, s.handleAcquireLock, `{"bead_id": "bt-3", "file_path": "b.go"}`)
+	clock.advance(2 * time.Minute)
+	s.reapStaleLocks(time.Minute)
+	if got := s.ListLocks(); len(got.Waiting) != 0 || len(got.Locks) != 0 {
+		t.Errorf("after reap = %+v, want no locks and no waits", got)
+	}
+}
+
+func TestBridgeExposesListLocks(t *testing.T) {
+	if toolNameToEndpoint["list_locks"] != "/list_locks" {
+		t.Errorf("list_locks endpoint = %q, want /list_locks", toolNameToEndpoint["list_locks"])
+	}
+	for _, tool := range coordinatorTools {
+		if tool.Name == "list_locks" {
+			return
+		}
+	}
+	t.Error("list_locks missing from coordinatorTools")
+}
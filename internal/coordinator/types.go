@@ -17,6 +17,15 @@ type FileLock struct {
 	LastHeartbeat time.Time `json:"last_heartbeat"`
 }
 
+// LockWait records a bead whose latest acquire_lock was refused because
+// another bead holds the file. It is cleared once the bead acquires it.
+type LockWait struct {
+	BeadID    string    `json:"bead_id"`
+	FilePath  string    `json:"file_path"`
+	BlockedBy string    `json:"blocked_by"`
+	Since     time.Time `json:"since"` // first refused attempt for this file
+}
+
 // Decision records an architectural or structural decision made by an agent.
 type Decision struct {
 	BeadID    string    `json:"bead_id"`
@@ -70,6 +79,7 @@ type State struct {
 	Artifacts  []Artifact
 	Statuses   map[string]*BeadStatus // beadID -> status
 	Heartbeats map[string]time.Time   // beadID -> last heartbeat
+	Waits      map[string]*LockWait   // beadID -> lock it is waiting for
 }
 
 // NewState creates an empty coordination state.
@@ -79,6 +89,7 @@ func NewState() *State {
 		Intents:    make(map[string]*Intent),
 		Statuses:   make(map[string]*BeadStatus),
 		Heartbeats: make(map[string]time.Time),
+		Waits:      make(map[string]*LockWait),
 	}
 }
 
@@ -118,6 +129,13 @@ type CheckLockResponse struct {
 	HeldBy string `json:"held_by,omitempty"`
 }
 
+// ListLocksResponse returns the current file locks, sorted by path, and the
+// beads waiting for one of them, sorted by bead ID.
+type ListLocksResponse struct {
+	Locks   []FileLock `json:"locks"`
+	Waiting []LockWait `json:"waiting,omitempty"`
+}
+
 // HeartbeatRequest is sent periodically by agents to keep locks alive.
 type HeartbeatRequest struct {
 	BeadID string `json:"bead_id"`
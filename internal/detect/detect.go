@@ -1,10 +1,13 @@
 // Package detect handles brownfield/greenfield detection.
-// This file provides HasExistingCode and DetectStack for analyzing project state.
+// This file provides HasExistingCode, DetectStack, and DetectStacks for
+// analyzing project state.
 package detect
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // StackInfo holds the detected project stack information.
@@ -15,6 +18,19 @@ type StackInfo struct {
 	TestCmd        string // "pnpm test", "pytest", "go test ./...", etc.
 	BuildCmd       string // "pnpm build", "go build ./...", etc.
 	LintCmd        string // "pnpm lint", "golangci-lint run", etc.
+	Path           string // directory relative to the scanned root ("." for the root); set by DetectStacks
+}
+
+// Commands returns the stack's build, lint, and test commands, in that
+// order, skipping any that were not detected.
+func (s StackInfo) Commands() []string {
+	var cmds []string
+	for _, c := range []string{s.BuildCmd, s.LintCmd, s.TestCmd} {
+		if c != "" {
+			cmds = append(cmds, c)
+		}
+	}
+	return cmds
 }
 
 // projectIndicators lists files whose presence signals an existing codebase.
@@ -58,6 +74,52 @@ func DetectStack(dir string) StackInfo {
 	return StackInfo{}
 }
 
+// maxStackDepth bounds how deep DetectStacks looks below the root.
+const maxStackDepth = 3
+
+// skipStackDirs are directories DetectStacks never descends into:
+// dependencies and build output rather than the project's own stacks.
+var skipStackDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+	"dist":         true,
+	"build":        true,
+	"venv":         true,
+	"__pycache__":  true,
+}
+
+// DetectStacks walks dir for every project stack, so polyglot repos (a Go
+// backend plus a TS frontend, say) are detected in full. Each StackInfo
+// has Path set to its directory relative to dir, root first, then in walk
+// order. Hidden directories, dependency and build directories, and
+// anything more than maxStackDepth levels down are skipped.
+func DetectStacks(dir string) []StackInfo {
+	var stacks []StackInfo
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil // unreadable entries are ignored, not fatal
+		}
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			return nil
+		}
+		if rel != "." {
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || skipStackDirs[name] ||
+				strings.Count(filepath.ToSlash(rel), "/") >= maxStackDepth {
+				return filepath.SkipDir
+			}
+		}
+		if info := DetectStack(path); info.Language != "" {
+			info.Path = filepath.ToSlash(rel)
+			stacks = append(stacks, info)
+		}
+		return nil
+	})
+	return stacks
+}
+
 // fileExists returns true if path exists and is a regular file.
 func fileExists(path string) bool {
 	info, err := os.Stat(path)
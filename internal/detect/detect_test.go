@@ -208,3 +208,27 @@ func TestDetectStack_JavaScriptNoTS(t *testing.T) {
 		t.Errorf("PackageManager = %q, want %q", info.PackageManager, "npm")
 	}
 }
+
+func TestDetectStacks_Polyglot(t *testing.T) {
+	files := testutil.GoProject()
+	for name, content := range testutil.TypeScriptProject() {
+		files["web/"+name] = content
+	}
+	files["node_modules/left-pad/package.json"] = `{"name": "left-pad"}`
+	files[".cache/tool/go.mod"] = "module cache\n"
+	dir := testutil.TempProject(t, files)
+
+	stacks := DetectStacks(dir)
+	if len(stacks) != 2 {
+		t.Fatalf("DetectStacks found %d stacks, want 2: %+v", len(stacks), stacks)
+	}
+	if stacks[0].Path != "." || stacks[0].Language != "go" {
+		t.Errorf("stacks[0] = %s at %q, want go at the root", stacks[0].Language, stacks[0].Path)
+	}
+	if stacks[1].Path != "web" || stacks[1].Language != "typescript" {
+		t.Errorf("stacks[1] = %s at %q, want typescript at web", stacks[1].Language, stacks[1].Path)
+	}
+	if len(stacks[1].Commands()) == 0 {
+		t.Errorf("web stack has no commands: %+v", stacks[1])
+	}
+}
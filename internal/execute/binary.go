@@ -0,0 +1,52 @@
+// binary.go detects binary files among a bead's declared files so they are
+// left out of source analysis.
+package execute
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// binarySniffLen is how much of a file is read when sniffing for binary
+// content, matching git's heuristic.
+const binarySniffLen = 8000
+
+// isBinaryFile reports whether the file at path looks binary: its first
+// binarySniffLen bytes contain a NUL byte. Missing or unreadable files
+// (e.g. ones the bead will create) are treated as text.
+func isBinaryFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	buf := make([]byte, binarySniffLen)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false
+	}
+	return bytes.IndexByte(buf[:n], 0) >= 0
+}
+
+// textFiles returns the bead's declared files minus any binaries, warning
+// about each binary so the plan author can drop it. Relative paths are
+// resolved against projectRoot.
+func textFiles(projectRoot, beadID string, files []string) []string {
+	var text []string
+	for _, f := range files {
+		path := f
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectRoot, f)
+		}
+		if isBinaryFile(path) {
+			fmt.Fprintf(os.Stderr, "Warning: bead %s lists binary file %s; excluding it from graph analysis\n", beadID, f)
+			continue
+		}
+		text = append(text, f)
+	}
+	return text
+}
@@ -0,0 +1,35 @@
+package execute
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsBinaryFile(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(text, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(binary, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if isBinaryFile(text) {
+		t.Errorf("isBinaryFile(%s) = true, want false", text)
+	}
+	if !isBinaryFile(binary) {
+		t.Errorf("isBinaryFile(%s) = false, want true", binary)
+	}
+	if isBinaryFile(filepath.Join(dir, "new.go")) {
+		t.Error("a file that doesn't exist yet should be treated as text")
+	}
+
+	got := textFiles(dir, "bt-1", []string{"main.go", "logo.png", "new.go"})
+	if want := []string{"main.go", "new.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("textFiles = %v, want %v", got, want)
+	}
+}
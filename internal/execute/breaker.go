@@ -2,15 +2,54 @@
 package execute
 
 import (
+	"context"
+	"errors"
 	"sync"
 )
 
+// FailureCategory classifies why a bead failed, so the circuit breaker can
+// weigh transient problems less than genuine ones.
+type FailureCategory string
+
+const (
+	FailureVerify  FailureCategory = "verify"  // verification failed after all retries
+	FailureInfra   FailureCategory = "infra"   // spawn, diagnostic, or tooling errors
+	FailureTimeout FailureCategory = "timeout" // Claude hit the per-bead timeout
+)
+
+// weight returns how much a failure of this category counts toward the
+// breaker's threshold. Unknown categories count fully.
+func (c FailureCategory) weight() float64 {
+	switch c {
+	case FailureInfra, FailureTimeout:
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+// classifyFailure returns the category of a bead that ended stuck, given the
+// error RetryBead returned. A nil error means every attempt ran and failed
+// verification; any other error means the attempts could not complete.
+func classifyFailure(retryErr error) FailureCategory {
+	switch {
+	case retryErr == nil:
+		return FailureVerify
+	case errors.Is(retryErr, context.DeadlineExceeded):
+		return FailureTimeout
+	default:
+		return FailureInfra
+	}
+}
+
 // CircuitBreaker pauses execution after consecutive failures.
 type CircuitBreaker struct {
 	mu                  sync.Mutex
 	ConsecutiveFailures int
 	Threshold           int
 	Paused              bool
+	Cooldowns           int     // cooldowns taken under the "cooldown" policy; not cleared by Reset
+	score               float64 // weighted sum of the consecutive failures, compared against Threshold
 }
 
 // NewCircuitBreaker creates a circuit breaker with the given threshold.
@@ -23,12 +62,20 @@ func NewCircuitBreaker(threshold int) *CircuitBreaker {
 	}
 }
 
-// RecordFailure increments the failure counter.
+// RecordFailure increments the failure counter with a full-weight failure.
 func (cb *CircuitBreaker) RecordFailure() {
+	cb.RecordCategorizedFailure(FailureVerify)
+}
+
+// RecordCategorizedFailure increments the failure counter, weighting the
+// failure by its category: infra and timeout failures count half as much as
+// verification failures toward the threshold.
+func (cb *CircuitBreaker) RecordCategorizedFailure(cat FailureCategory) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 	cb.ConsecutiveFailures++
-	if cb.ConsecutiveFailures >= cb.Threshold {
+	cb.score += cat.weight()
+	if cb.score >= float64(cb.Threshold) {
 		cb.Paused = true
 	}
 }
@@ -38,6 +85,7 @@ func (cb *CircuitBreaker) RecordSuccess() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 	cb.ConsecutiveFailures = 0
+	cb.score = 0
 	cb.Paused = false
 }
 
@@ -53,6 +101,7 @@ func (cb *CircuitBreaker) Reset() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 	cb.ConsecutiveFailures = 0
+	cb.score = 0
 	cb.Paused = false
 }
 
@@ -64,13 +113,29 @@ func (cb *CircuitBreaker) GetConsecutiveFailures() int {
 }
 
 // SetConsecutiveFailures sets the failure count (used for restoring state).
+// Restored failures count at full weight.
 func (cb *CircuitBreaker) SetConsecutiveFailures(count int) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 	cb.ConsecutiveFailures = count
+	cb.score = float64(count)
 	if cb.ConsecutiveFailures >= cb.Threshold {
 		cb.Paused = true
 	} else {
 		cb.Paused = false
 	}
 }
+
+// RecordCooldown increments the number of cooldowns taken (thread-safe).
+func (cb *CircuitBreaker) RecordCooldown() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.Cooldowns++
+}
+
+// GetCooldowns returns the number of cooldowns taken (thread-safe).
+func (cb *CircuitBreaker) GetCooldowns() int {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.Cooldowns
+}
@@ -1,8 +1,14 @@
 package execute
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/log"
 )
 
 func TestCircuitBreakerTriggered(t *testing.T) {
@@ -138,3 +144,118 @@ func TestCircuitBreakerSuccessResetsPaused(t *testing.T) {
 		t.Errorf("ConsecutiveFailures = %d, want 0 after RecordSuccess", cb.GetConsecutiveFailures())
 	}
 }
+
+func TestCoolDownBreakerContinuesThenAborts(t *testing.T) {
+	logger, err := log.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	cb := NewCircuitBreaker(2)
+
+	// Two trips are ridden out with a cooldown; the breaker resets and
+	// execution continues each time.
+	for i := 1; i <= 2; i++ {
+		cb.RecordFailure()
+		cb.RecordFailure()
+		if !cb.ShouldPause() {
+			t.Fatalf("trip %d: breaker should be paused", i)
+		}
+		if action := coolDownBreaker(context.Background(), cb, time.Millisecond, 2, logger); action != "retry" {
+			t.Fatalf("trip %d: action = %q, want %q", i, action, "retry")
+		}
+		cb.Reset()
+		if cb.ShouldPause() {
+			t.Errorf("trip %d: breaker should resume after cooldown", i)
+		}
+		if cb.GetCooldowns() != i {
+			t.Errorf("trip %d: cooldowns = %d, want %d", i, cb.GetCooldowns(), i)
+		}
+	}
+
+	// The third trip exceeds the cooldown budget.
+	cb.RecordFailure()
+	cb.RecordFailure()
+	if action := coolDownBreaker(context.Background(), cb, time.Millisecond, 2, logger); action != "abort" {
+		t.Errorf("action = %q, want %q after max cooldowns", action, "abort")
+	}
+
+	events, err := logger.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	var cooldowns int
+	for _, e := range events {
+		if e.Event == log.EventCircuitBreakerCooldown {
+			cooldowns++
+		}
+	}
+	if cooldowns != 2 {
+		t.Errorf("logged %d cooldown events, want 2", cooldowns)
+	}
+}
+
+func TestCircuitBreakerCategorizedFailuresWeighted(t *testing.T) {
+	cb := NewCircuitBreaker(3)
+	for i := 0; i < 5; i++ {
+		cb.RecordCategorizedFailure(FailureInfra)
+		if cb.ShouldPause() {
+			t.Fatalf("ShouldPause should be false after %d infra failures (threshold is 3)", i+1)
+		}
+	}
+	cb.RecordCategorizedFailure(FailureTimeout)
+	if !cb.ShouldPause() {
+		t.Error("ShouldPause should be true after six half-weight failures")
+	}
+	if got := cb.GetConsecutiveFailures(); got != 6 {
+		t.Errorf("GetConsecutiveFailures() = %d, want 6", got)
+	}
+
+	cb.RecordSuccess()
+	cb.RecordCategorizedFailure(FailureInfra)
+	cb.RecordFailure()
+	cb.RecordFailure()
+	if cb.ShouldPause() {
+		t.Error("ShouldPause should be false at weighted score 2.5")
+	}
+	cb.RecordCategorizedFailure(FailureVerify)
+	if !cb.ShouldPause() {
+		t.Error("ShouldPause should be true once verification failures reach the threshold")
+	}
+}
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want FailureCategory
+	}{
+		{"verification exhausted", nil, FailureVerify},
+		{"timeout", fmt.Errorf("diagnostic spawn failed for bead bt-1: %w", fmt.Errorf("claude timed out after 10m0s: %w", context.DeadlineExceeded)), FailureTimeout},
+		{"infra", errors.New("diagnostic failed for bead bt-1: exec: \"claude\": executable file not found"), FailureInfra},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyFailure(tt.err); got != tt.want {
+				t.Errorf("classifyFailure() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoolDownBreakerStopsOnCancel(t *testing.T) {
+	cb := NewCircuitBreaker(1)
+	cb.RecordFailure()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if action := coolDownBreaker(ctx, cb, time.Minute, 3, nil); action != "abort" {
+		t.Errorf("action = %q, want %q when cancelled", action, "abort")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cooldown returned after %s, want soon after cancellation", elapsed)
+	}
+	if cb.GetCooldowns() != 0 {
+		t.Errorf("cooldowns = %d, want an interrupted cooldown not counted", cb.GetCooldowns())
+	}
+}
@@ -0,0 +1,26 @@
+// budget.go stops a run once its beads have used more tokens than
+// execution.token_budget allows.
+package execute
+
+import (
+	"fmt"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+// errBudgetExceeded is returned by a run stopped by its token budget. It
+// matches ErrAborted.
+var errBudgetExceeded error = abortedError("token budget exceeded")
+
+// overBudget reports whether used tokens exceed cfg's token budget. A zero
+// budget never does.
+func overBudget(cfg *config.Config, used int) bool {
+	return cfg.Execution.TokenBudget > 0 && used > cfg.Execution.TokenBudget
+}
+
+// budgetError returns the error that ends a run over its token budget. The
+// checkpoint keeps the count, so a resumed run picks up where it stopped.
+func budgetError(cfg *config.Config, used int) error {
+	return fmt.Errorf("%w: beads used %d tokens, budget is %d; raise execution.token_budget and run 'berth resume'",
+		errBudgetExceeded, used, cfg.Execution.TokenBudget)
+}
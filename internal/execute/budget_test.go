@@ -0,0 +1,45 @@
+package execute
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+func TestTokenBudgetCheckpointsUsage(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if overBudget(cfg, 1_000_000) {
+		t.Error("a zero budget should never be exceeded")
+	}
+	cfg.Execution.TokenBudget = 1000
+
+	// Usage restored from a checkpoint counts against the budget.
+	progress := newRunProgress(&ExecuteState{TokensUsed: 900}, NewCircuitBreaker(3))
+	if overBudget(cfg, progress.addTokens(100)) {
+		t.Error("1000 of 1000 tokens should not exceed the budget")
+	}
+	used := progress.addTokens(50)
+	if !overBudget(cfg, used) {
+		t.Fatalf("%d of 1000 tokens should exceed the budget", used)
+	}
+
+	runDir := t.TempDir()
+	progress.save(runDir, "run-1", []string{"bt-1", "bt-2"}, "bt-1", errBudgetExceeded.Error())
+	cp, err := LoadCheckpoint(runDir)
+	if err != nil || cp == nil {
+		t.Fatalf("LoadCheckpoint: %v, %v", cp, err)
+	}
+	if cp.TokensUsed != 1050 {
+		t.Errorf("checkpoint TokensUsed = %d, want 1050", cp.TokensUsed)
+	}
+
+	err = budgetError(cfg, used)
+	if !errors.Is(err, ErrAborted) {
+		t.Errorf("budget error %v should match ErrAborted", err)
+	}
+	if !strings.Contains(err.Error(), "execution.token_budget") {
+		t.Errorf("budget error %q should say how to continue", err)
+	}
+}
@@ -3,32 +3,66 @@ package execute
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
 )
 
 // Checkpoint represents the execution state for resume capability.
 type Checkpoint struct {
 	RunID          string         `json:"run_id"`
+	Beads          []string       `json:"beads,omitempty"` // beads being executed, for reconciling an edited plan on resume
 	CurrentBeadID  string         `json:"current_bead_id"`
 	CompletedBeads []string       `json:"completed_beads"`
 	FailedBeads    []string       `json:"failed_beads"`
-	RetryCount     map[string]int `json:"retry_count"`     // per-bead retry counts
-	ConsecFailures int            `json:"consec_failures"` // for circuit breaker
+	RetryCount     map[string]int `json:"retry_count"`           // per-bead retry counts
+	ConsecFailures int            `json:"consec_failures"`       // for circuit breaker
+	TokensUsed     int            `json:"tokens_used,omitempty"` // counted against execution.token_budget
 	LastError      string         `json:"last_error,omitempty"`
 	Timestamp      time.Time      `json:"timestamp"`
 }
 
-// SaveCheckpoint writes the current state to disk.
+// ErrCheckpointCorrupted is returned by LoadCheckpoint when neither
+// checkpoint.json nor its backup can be parsed, so the run cannot be resumed.
+// It is distinct from a missing checkpoint, which LoadCheckpoint reports as
+// nil, nil.
+var ErrCheckpointCorrupted = errors.New("checkpoint corrupted, cannot resume")
+
+// checkpointFile and checkpointBackup are the checkpoint and the copy of the
+// previous save kept for recovering from a truncated write.
+const (
+	checkpointFile   = "checkpoint.json"
+	checkpointBackup = "checkpoint.json.bak"
+)
+
+// checkpointMu serializes SaveCheckpoint: the interrupt handler saves while
+// the loop may be saving too, and two interleaved backup-then-write
+// sequences could leave both files torn.
+var checkpointMu sync.Mutex
+
+// SaveCheckpoint writes the current state to disk. The previous checkpoint,
+// if it is intact, is kept as checkpoint.json.bak first.
 func SaveCheckpoint(runDir string, cp *Checkpoint) error {
+	checkpointMu.Lock()
+	defer checkpointMu.Unlock()
+
 	cp.Timestamp = time.Now()
 	data, err := json.MarshalIndent(cp, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling checkpoint: %w", err)
 	}
-	path := filepath.Join(runDir, "checkpoint.json")
+	path := filepath.Join(runDir, checkpointFile)
+	if prev, err := os.ReadFile(path); err == nil && json.Valid(prev) {
+		if err := os.Rename(path, filepath.Join(runDir, checkpointBackup)); err != nil {
+			return fmt.Errorf("backing up checkpoint: %w", err)
+		}
+	}
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("writing checkpoint: %w", err)
 	}
@@ -36,9 +70,34 @@ func SaveCheckpoint(runDir string, cp *Checkpoint) error {
 }
 
 // LoadCheckpoint reads the checkpoint from disk.
-// Returns nil, nil if no checkpoint exists (not an error).
+// Returns nil, nil if no checkpoint exists (not an error). A truncated or
+// unparsable checkpoint is recovered from its backup; if that fails too the
+// error wraps ErrCheckpointCorrupted.
 func LoadCheckpoint(runDir string) (*Checkpoint, error) {
-	path := filepath.Join(runDir, "checkpoint.json")
+	cp, err := readCheckpoint(filepath.Join(runDir, checkpointFile))
+	if err == nil && cp != nil {
+		return cp, nil
+	}
+	if err != nil && !errors.Is(err, ErrCheckpointCorrupted) {
+		return nil, err
+	}
+
+	// The primary is missing or corrupted: a save may have been cut off
+	// after backing up the previous checkpoint.
+	backup, bakErr := readCheckpoint(filepath.Join(runDir, checkpointBackup))
+	if bakErr == nil && backup != nil {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; recovered the previous checkpoint from %s\n", err, checkpointBackup)
+		}
+		return backup, nil
+	}
+	return nil, err
+}
+
+// readCheckpoint parses the checkpoint at path. Returns nil, nil if the file
+// does not exist and an error wrapping ErrCheckpointCorrupted if it does not
+// parse.
+func readCheckpoint(path string) (*Checkpoint, error) {
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
 		return nil, nil // No checkpoint is fine
@@ -48,16 +107,87 @@ func LoadCheckpoint(runDir string) (*Checkpoint, error) {
 	}
 	var cp Checkpoint
 	if err := json.Unmarshal(data, &cp); err != nil {
-		return nil, fmt.Errorf("parsing checkpoint: %w", err)
+		return nil, fmt.Errorf("%w: parsing %s: %v", ErrCheckpointCorrupted, filepath.Base(path), err)
 	}
 	return &cp, nil
 }
 
-// ClearCheckpoint removes the checkpoint file.
+// ClearCheckpoint removes the checkpoint file and its backup.
 func ClearCheckpoint(runDir string) error {
-	path := filepath.Join(runDir, "checkpoint.json")
-	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("removing checkpoint: %w", err)
+	for _, name := range []string{checkpointFile, checkpointBackup} {
+		if err := os.Remove(filepath.Join(runDir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing checkpoint: %w", err)
+		}
 	}
 	return nil
 }
+
+// reconcileState aligns restored checkpoint state with the current bead set,
+// for plans edited between an interruption and a resume. Completed and failed
+// beads that no longer exist are dropped along with their retry counts; open
+// beads the checkpoint never saw are reported as added and are scheduled like
+// any other open bead. state is updated in place.
+func reconcileState(state *ExecuteState, current []beads.Bead) (added, removed []string) {
+	if state == nil {
+		return nil, nil
+	}
+
+	exists := make(map[string]bool, len(current))
+	for _, b := range current {
+		exists[b.ID] = true
+	}
+
+	known := make(map[string]bool)
+	for _, group := range [][]string{state.Beads, state.CompletedBeads, state.FailedBeads} {
+		for _, id := range group {
+			if known[id] {
+				continue
+			}
+			known[id] = true
+			if !exists[id] {
+				removed = append(removed, id)
+			}
+		}
+	}
+
+	keep := func(ids []string) []string {
+		out := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if exists[id] {
+				out = append(out, id)
+			}
+		}
+		return out
+	}
+	state.Beads = keep(state.Beads)
+	state.CompletedBeads = keep(state.CompletedBeads)
+	state.FailedBeads = keep(state.FailedBeads)
+	for id := range state.RetryCount {
+		if !exists[id] {
+			delete(state.RetryCount, id)
+		}
+	}
+
+	// Without a recorded bead set (checkpoints from older versions) there is
+	// nothing to tell new beads apart from ones that simply hadn't run yet.
+	if len(state.Beads) == 0 {
+		return nil, removed
+	}
+	for _, b := range current {
+		if known[b.ID] || b.Status == "closed" || b.Status == "done" {
+			continue
+		}
+		added = append(added, b.ID)
+	}
+	return added, removed
+}
+
+// warnReconciled reports how reconcileState changed the bead set.
+func warnReconciled(added, removed []string) {
+	if len(removed) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: plan changed since checkpoint: dropping %d removed bead(s): %s\n", len(removed), strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: plan changed since checkpoint: scheduling %d new bead(s): %s\n", len(added), strings.Join(added, ", "))
+	}
+}
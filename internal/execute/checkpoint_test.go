@@ -1,9 +1,13 @@
 package execute
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
 )
 
 func TestCheckpointRoundTrip(t *testing.T) {
@@ -78,19 +82,55 @@ func TestLoadCheckpointCorrupted(t *testing.T) {
 	}
 
 	cp, err := LoadCheckpoint(tmpDir)
-	if err == nil {
-		t.Error("LoadCheckpoint should return error for corrupted file")
+	if !errors.Is(err, ErrCheckpointCorrupted) {
+		t.Errorf("LoadCheckpoint error = %v, want ErrCheckpointCorrupted", err)
 	}
 	if cp != nil {
 		t.Error("LoadCheckpoint should return nil for corrupted file")
 	}
 }
 
+func TestLoadCheckpointRecoversFromBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, bead := range []string{"bt-1", "bt-2"} {
+		if err := SaveCheckpoint(tmpDir, &Checkpoint{RunID: "run", CurrentBeadID: bead}); err != nil {
+			t.Fatalf("SaveCheckpoint failed: %v", err)
+		}
+	}
+
+	// Simulate a crash mid-write of the second save.
+	path := filepath.Join(tmpDir, "checkpoint.json")
+	if err := os.WriteFile(path, []byte(`{"run_id": "run", "current_be`), 0644); err != nil {
+		t.Fatalf("failed to truncate checkpoint: %v", err)
+	}
+
+	cp, err := LoadCheckpoint(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	if cp == nil || cp.CurrentBeadID != "bt-1" {
+		t.Errorf("LoadCheckpoint = %+v, want the backed-up bt-1 checkpoint", cp)
+	}
+
+	// A save after recovery must not back up the corrupted file.
+	if err := SaveCheckpoint(tmpDir, &Checkpoint{RunID: "run", CurrentBeadID: "bt-3"}); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if cp, err := LoadCheckpoint(tmpDir); err != nil || cp == nil || cp.CurrentBeadID != "bt-1" {
+		t.Errorf("LoadCheckpoint = %+v, %v; want the bt-1 backup kept", cp, err)
+	}
+}
+
 func TestClearCheckpoint(t *testing.T) {
 	tmpDir := t.TempDir()
 	cp := &Checkpoint{RunID: "test"}
-	if err := SaveCheckpoint(tmpDir, cp); err != nil {
-		t.Fatalf("SaveCheckpoint failed: %v", err)
+	for range 2 { // the second save leaves a backup
+		if err := SaveCheckpoint(tmpDir, cp); err != nil {
+			t.Fatalf("SaveCheckpoint failed: %v", err)
+		}
 	}
 
 	err := ClearCheckpoint(tmpDir)
@@ -181,3 +221,102 @@ func TestCheckpointOverwrite(t *testing.T) {
 		t.Errorf("ConsecFailures = %d, want 1", loaded.ConsecFailures)
 	}
 }
+
+func TestParallelCheckpointResumeSkipsCompleted(t *testing.T) {
+	runDir := t.TempDir()
+	allBeads := []beads.Bead{
+		{ID: "bt-1", Title: "Schema"},
+		{ID: "bt-2", Title: "Handlers"},
+		{ID: "bt-3", Title: "Routes", DependsOn: []string{"bt-1"}},
+	}
+
+	// First run: bt-1 merges, bt-2 fails, then the run is interrupted.
+	s := NewScheduler(config.Config{}, "", allBeads, NewExecutionPool(len(allBeads)),
+		nil, nil, nil, nil, nil, "", false)
+	s.EnableCheckpoints(runDir, "berth/feature", nil)
+	s.recordCheckpoint(MergeResult{BeadID: "bt-1", Success: true})
+	s.recordCheckpoint(MergeResult{BeadID: "bt-2", Success: false, Error: errors.New("verify failed")})
+
+	cp, err := LoadCheckpoint(runDir)
+	if err != nil || cp == nil {
+		t.Fatalf("LoadCheckpoint: %v, %v", cp, err)
+	}
+	if len(cp.CompletedBeads) != 1 || cp.CompletedBeads[0] != "bt-1" {
+		t.Errorf("CompletedBeads = %v, want [bt-1]", cp.CompletedBeads)
+	}
+	if len(cp.FailedBeads) != 1 || cp.FailedBeads[0] != "bt-2" {
+		t.Errorf("FailedBeads = %v, want [bt-2]", cp.FailedBeads)
+	}
+	if cp.ConsecFailures != 1 || cp.LastError != "verify failed" {
+		t.Errorf("ConsecFailures = %d, LastError = %q", cp.ConsecFailures, cp.LastError)
+	}
+
+	// Resume: bt-1 is skipped and bt-3, which depended on it, is ready.
+	state := &ExecuteState{
+		RetryCount:     cp.RetryCount,
+		ConsecFailures: cp.ConsecFailures,
+		CompletedBeads: cp.CompletedBeads,
+		FailedBeads:    cp.FailedBeads,
+	}
+	remaining := remainingBeads(allBeads, state)
+	if len(remaining) != 2 || remaining[0].ID != "bt-2" || remaining[1].ID != "bt-3" {
+		t.Fatalf("remaining = %v, want bt-2 and bt-3", remaining)
+	}
+
+	resumed := NewScheduler(config.Config{}, "", remaining, NewExecutionPool(len(remaining)),
+		nil, nil, nil, nil, nil, "", false)
+	resumed.EnableCheckpoints(runDir, "berth/feature", state)
+	if !resumed.depsComplete(resumed.nodes["bt-3"]) {
+		t.Error("bt-3 should be ready once its completed dependency is skipped")
+	}
+
+	resumed.recordCheckpoint(MergeResult{BeadID: "bt-3", Success: true})
+	cp, err = LoadCheckpoint(runDir)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint: %v", err)
+	}
+	if len(cp.CompletedBeads) != 2 || cp.CompletedBeads[1] != "bt-3" || cp.ConsecFailures != 0 {
+		t.Errorf("resumed checkpoint = %+v, want bt-1 and bt-3 completed", cp)
+	}
+}
+
+func TestReconcileStateWithEditedPlan(t *testing.T) {
+	// The checkpoint was saved while bt-1..bt-3 were planned; bt-1 had
+	// completed and bt-2 had failed once. The plan was then edited to drop
+	// bt-2 and add bt-4.
+	state := &ExecuteState{
+		Beads:          []string{"bt-1", "bt-2", "bt-3"},
+		CompletedBeads: []string{"bt-1"},
+		FailedBeads:    []string{"bt-2"},
+		RetryCount:     map[string]int{"bt-2": 1},
+	}
+	current := []beads.Bead{
+		{ID: "bt-1", Status: "closed"},
+		{ID: "bt-3", Status: "open"},
+		{ID: "bt-4", Status: "open"},
+	}
+
+	added, removed := reconcileState(state, current)
+
+	if len(added) != 1 || added[0] != "bt-4" {
+		t.Errorf("added = %v, want [bt-4]", added)
+	}
+	if len(removed) != 1 || removed[0] != "bt-2" {
+		t.Errorf("removed = %v, want [bt-2]", removed)
+	}
+	if len(state.CompletedBeads) != 1 || state.CompletedBeads[0] != "bt-1" {
+		t.Errorf("CompletedBeads = %v, want [bt-1]", state.CompletedBeads)
+	}
+	if len(state.FailedBeads) != 0 {
+		t.Errorf("FailedBeads = %v, want removed bead dropped", state.FailedBeads)
+	}
+	if _, ok := state.RetryCount["bt-2"]; ok {
+		t.Error("retry count for removed bead bt-2 was kept")
+	}
+
+	// The completed bead is still skipped; the surviving and new beads run.
+	remaining := remainingBeads(current, state)
+	if len(remaining) != 2 || remaining[0].ID != "bt-3" || remaining[1].ID != "bt-4" {
+		t.Errorf("remaining = %v, want bt-3 and bt-4", remaining)
+	}
+}
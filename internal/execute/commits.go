@@ -0,0 +1,93 @@
+// commits.go links a bead to the git commits it produced.
+package execute
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/git"
+	"github.com/berth-dev/berth/internal/graph"
+)
+
+// recordBeadCommits sets task.Commits to the commits made since baseRef,
+// i.e. those Claude created while executing the bead. It must run before
+// onBeadSuccess so berth's own metadata commit is not included. A failed
+// lookup only warns; the bead still closes without a commit record.
+func recordBeadCommits(task *beads.Bead, baseRef string) {
+	if baseRef == "" {
+		return
+	}
+	commits, err := git.CommitsSince(baseRef)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to list commits for bead %s: %v\n", task.ID, err)
+		return
+	}
+	task.Commits = commits
+}
+
+// reindexBead brings the KG up to date with a bead's changes. Files the bead
+// deleted or renamed away are dropped from the index, and everything else it
+// touched (declared or discovered from the diff) is reindexed. When the diff
+// cannot be read it falls back to reindexing the declared files.
+func reindexBead(task *beads.Bead, kgClient *graph.Client) {
+	base := "HEAD"
+	if len(task.Commits) > 0 {
+		base = task.Commits[0] + "^"
+	}
+
+	reindex, removed := task.Files, []string(nil)
+	if changes, err := git.DiffStatusSince(base); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to diff bead %s: %v\n", task.ID, err)
+	} else {
+		reindex, removed = splitIndexChanges(task.Files, changes)
+	}
+
+	if err := graph.RemoveFromIndex(kgClient, removed); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove deleted files from KG after bead %s: %v\n", task.ID, err)
+	}
+	if err := graph.ReindexChanged(kgClient, reindex); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to reindex after bead %s: %v\n", task.ID, err)
+	}
+}
+
+// splitIndexChanges partitions a bead's changes into files to reindex and
+// files to remove from the KG. Deleted paths and the old side of renames are
+// removed; declared files plus any added, modified, or renamed-to source
+// files are reindexed, minus anything that no longer exists.
+func splitIndexChanges(declared []string, changes []git.FileChange) (reindex, removed []string) {
+	gone := make(map[string]bool)
+	var touched []string
+	for _, c := range changes {
+		switch c.Status {
+		case 'D':
+			gone[c.Path] = true
+		case 'R':
+			gone[c.OldPath] = true
+			touched = append(touched, c.Path)
+		default:
+			touched = append(touched, c.Path)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range append(append([]string{}, declared...), graph.SourceFiles(touched)...) {
+		if gone[f] || seen[f] {
+			continue
+		}
+		seen[f] = true
+		reindex = append(reindex, f)
+	}
+	return reindex, graph.SourceFiles(sortedKeys(gone))
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,125 @@
+package execute
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/git"
+)
+
+func TestRecordBeadCommitsCapturesCommitsSinceStart(t *testing.T) {
+	dir := initTestRepo(t)
+
+	base, err := git.HeadSHA()
+	if err != nil {
+		t.Fatalf("HeadSHA: %v", err)
+	}
+
+	// Simulate Claude committing twice during the bead.
+	var want []string
+	for _, name := range []string{"a.go", "b.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := git.CommitFiles([]string{name}, "add "+name); err != nil {
+			t.Fatalf("commit %s: %v", name, err)
+		}
+		sha, err := git.HeadSHA()
+		if err != nil {
+			t.Fatalf("HeadSHA: %v", err)
+		}
+		want = append(want, sha)
+	}
+
+	task := &beads.Bead{ID: "bt-1", Title: "Add files", Files: []string{"a.go", "b.go"}}
+	recordBeadCommits(task, base)
+
+	if len(task.Commits) != len(want) {
+		t.Fatalf("Commits = %v, want %v", task.Commits, want)
+	}
+	for i := range want {
+		if task.Commits[i] != want[i] {
+			t.Errorf("Commits[%d] = %s, want %s", i, task.Commits[i], want[i])
+		}
+	}
+
+	// The base commit itself must not be attributed to the bead.
+	out, err := exec.Command("git", "rev-parse", want[0]+"^").Output()
+	if err != nil {
+		t.Fatalf("git rev-parse: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != base {
+		t.Errorf("first recorded commit's parent = %s, want base %s", got, base)
+	}
+
+	// The SHAs are persisted in the bead sidecar on close.
+	if err := beads.RecordApplied(dir, task); err != nil {
+		t.Fatalf("RecordApplied: %v", err)
+	}
+	meta, err := beads.ReadBeadMeta(dir, task.ID)
+	if err != nil {
+		t.Fatalf("ReadBeadMeta: %v", err)
+	}
+	if len(meta.Commits) != len(want) || meta.Commits[0] != want[0] || meta.Commits[1] != want[1] {
+		t.Errorf("sidecar commits = %v, want %v", meta.Commits, want)
+	}
+}
+
+func TestRecordBeadCommitsNoNewCommits(t *testing.T) {
+	initTestRepo(t)
+
+	base, err := git.HeadSHA()
+	if err != nil {
+		t.Fatalf("HeadSHA: %v", err)
+	}
+
+	task := &beads.Bead{ID: "bt-1"}
+	recordBeadCommits(task, base)
+	if len(task.Commits) != 0 {
+		t.Errorf("Commits = %v, want none", task.Commits)
+	}
+}
+
+func TestSplitIndexChangesDropsDeletedAndRenamedFiles(t *testing.T) {
+	dir := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "util.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.CommitFiles([]string{"util.go"}, "add util.go"); err != nil {
+		t.Fatalf("commit util.go: %v", err)
+	}
+
+	// Simulate a bead that deletes main.go and renames util.go.
+	for _, args := range [][]string{
+		{"rm", "-q", "main.go"},
+		{"mv", "util.go", "helpers.go"},
+		{"commit", "-q", "-m", "remove main.go, rename util.go"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	sha, err := git.HeadSHA()
+	if err != nil {
+		t.Fatalf("HeadSHA: %v", err)
+	}
+
+	changes, err := git.DiffStatusSince(sha + "^")
+	if err != nil {
+		t.Fatalf("DiffStatusSince: %v", err)
+	}
+
+	reindex, removed := splitIndexChanges([]string{"main.go", "util.go"}, changes)
+
+	if strings.Join(removed, ",") != "main.go,util.go" {
+		t.Errorf("removed = %v, want [main.go util.go]", removed)
+	}
+	if strings.Join(reindex, ",") != "helpers.go" {
+		t.Errorf("reindex = %v, want [helpers.go]", reindex)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"text/template"
 	"time"
 
@@ -28,14 +27,14 @@ type diagnosticData struct {
 // RunDiagnostic spawns a non-interactive Claude session to analyze why a
 // bead has failed 3 consecutive times. It renders the diagnostic template
 // with the bead metadata and error outputs, then parses Claude's JSON
-// response to extract the diagnosis.
-func RunDiagnostic(cfg config.Config, bead *beads.Bead, errors []string, projectRoot string) (string, error) {
+// response to extract the diagnosis. Canceling ctx stops the session.
+func RunDiagnostic(ctx context.Context, cfg config.Config, bead *beads.Bead, errors []string, projectRoot string) (string, error) {
 	prompt, err := buildDiagnosticPrompt(bead, errors)
 	if err != nil {
 		return "", fmt.Errorf("building diagnostic prompt: %w", err)
 	}
 
-	raw, err := spawnDiagnosticClaude(cfg, prompt, projectRoot)
+	raw, err := spawnDiagnosticClaude(ctx, cfg, prompt, projectRoot)
 	if err != nil {
 		return "", fmt.Errorf("spawning diagnostic claude: %w", err)
 	}
@@ -91,17 +90,18 @@ func buildDiagnosticPrompt(bead *beads.Bead, errors []string) (string, error) {
 
 // spawnDiagnosticClaude runs `claude -p` with the diagnostic prompt and
 // returns the raw output bytes. It enforces a timeout derived from
-// cfg.Execution.TimeoutPerBead, matching the pattern in spawner.go.
-func spawnDiagnosticClaude(cfg config.Config, prompt string, projectRoot string) ([]byte, error) {
+// cfg.Execution.TimeoutPerBead, matching the pattern in spawner.go, within
+// any deadline ctx already carries (e.g. bead_timeout).
+func spawnDiagnosticClaude(parent context.Context, cfg config.Config, prompt string, projectRoot string) ([]byte, error) {
 	timeout := time.Duration(cfg.Execution.TimeoutPerBead) * time.Second
 	if timeout <= 0 {
 		timeout = 10 * time.Minute
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "claude", "-p", prompt, "--output-format", "json", "--dangerously-skip-permissions")
+	cmd := cfg.Agent.Cmd(ctx, "-p", prompt, "--output-format", "json", "--dangerously-skip-permissions")
 	cmd.Dir = projectRoot
 
 	var stdout bytes.Buffer
@@ -110,6 +110,9 @@ func spawnDiagnosticClaude(cfg config.Config, prompt string, projectRoot string)
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
+		if parent.Err() != nil {
+			return nil, fmt.Errorf("claude diagnostic stopped: %w", parent.Err())
+		}
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("claude diagnostic timed out after %s: %w", timeout, ctx.Err())
 		}
@@ -0,0 +1,70 @@
+// dryrun.go renders how a plan would execute without running it, for
+// "berth run --dry-run".
+package execute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+)
+
+// FormatDryRun describes how RunExecute would schedule allBeads under cfg:
+// whether the parallel scheduler is used, and otherwise each execution group
+// in order, marked parallel or sequential, with the batches a parallel group
+// larger than max_parallel runs in. Every bead is listed with its files and
+// dependencies.
+func FormatDryRun(cfg config.Config, allBeads []beads.Bead) string {
+	var b strings.Builder
+
+	mode := cfg.Execution.ParallelMode
+	if mode == "" {
+		mode = "auto"
+	}
+	groups := ComputeGroups(allBeads)
+	fmt.Fprintf(&b, "%d beads in %d groups (parallel_mode: %s)\n", len(allBeads), len(groups), mode)
+
+	maxParallel := cfg.Execution.MaxParallel
+	if maxParallel <= 0 {
+		maxParallel = 5
+	}
+	scheduler := ShouldRunParallel(cfg, allBeads)
+	if scheduler {
+		fmt.Fprintf(&b, "Execution: parallel scheduler, up to %d beads at once as dependencies allow\n", maxParallel)
+	} else {
+		b.WriteString("Execution: group by group on one branch\n")
+	}
+
+	byID := make(map[string]beads.Bead, len(allBeads))
+	for _, bead := range allBeads {
+		byID[bead.ID] = bead
+	}
+
+	for _, group := range groups {
+		parallel := shouldRunParallel(group, &cfg)
+		if scheduler {
+			parallel = len(group.BeadIDs) > 1
+		}
+		kind := "sequential"
+		if parallel {
+			kind = "parallel"
+			if !scheduler && len(group.BeadIDs) > maxParallel {
+				kind = fmt.Sprintf("parallel, in batches of %d", maxParallel)
+			}
+		}
+		fmt.Fprintf(&b, "\nGroup %d (%s)\n", group.Index+1, kind)
+		for _, id := range group.BeadIDs {
+			bead := byID[id]
+			fmt.Fprintf(&b, "  %s: %s\n", bead.ID, bead.Title)
+			if len(bead.Files) > 0 {
+				fmt.Fprintf(&b, "    files: %s\n", strings.Join(bead.Files, ", "))
+			}
+			if len(bead.DependsOn) > 0 {
+				fmt.Fprintf(&b, "    depends on: %s\n", strings.Join(bead.DependsOn, ", "))
+			}
+		}
+	}
+
+	return b.String()
+}
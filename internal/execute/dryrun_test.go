@@ -0,0 +1,44 @@
+package execute
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+)
+
+func TestFormatDryRunGroups(t *testing.T) {
+	cfg := config.DefaultConfig()
+	allBeads := []beads.Bead{
+		{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}},
+		{ID: "bt-2", Title: "Add store", Files: []string{"store.go"}},
+		{ID: "bt-3", Title: "Wire handler", Files: []string{"handler.go"}, DependsOn: []string{"bt-1", "bt-2"}},
+	}
+
+	cfg.Execution.ParallelMode = "always"
+	out := FormatDryRun(*cfg, allBeads)
+	for _, want := range []string{
+		"3 beads in 2 groups (parallel_mode: always)",
+		"Execution: parallel scheduler, up to 5 beads at once",
+		"Group 1 (parallel)",
+		"  bt-1: Add model\n    files: model.go\n",
+		"Group 2 (sequential)",
+		"    depends on: bt-1, bt-2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("always: output missing %q:\n%s", want, out)
+		}
+	}
+
+	cfg.Execution.ParallelMode = "never"
+	out = FormatDryRun(*cfg, allBeads)
+	for _, want := range []string{
+		"Execution: group by group on one branch",
+		"Group 1 (sequential)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("never: output missing %q:\n%s", want, out)
+		}
+	}
+}
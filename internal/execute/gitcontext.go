@@ -0,0 +1,84 @@
+// gitcontext.go lists the recent commits touching a bead's files, giving the
+// executor recency context the Knowledge Graph lacks.
+package execute
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/git"
+	"github.com/berth-dev/berth/internal/graph"
+)
+
+// Bounds on the git history context, so a bead touching many files with
+// long histories cannot crowd out the rest of the prompt.
+const (
+	gitContextCommitsPerFile = 5
+	gitContextMaxFiles       = 10
+	gitContextMaxSubject     = 100 // characters
+)
+
+// embedBeadContext returns the context embedded in a bead's executor
+// prompt: graph data for its text files, followed by their recent git
+// history when execution.include_git_context is set.
+func embedBeadContext(cfg config.Config, kgClient *graph.Client, projectRoot string, bead *beads.Bead) string {
+	files := textFiles(projectRoot, bead.ID, bead.Files)
+	graphData := preEmbedGraphData(kgClient, cfg.Project.Language, files)
+	if !cfg.Execution.IncludeGitContext {
+		return graphData
+	}
+	history := buildGitContext(projectRoot, files)
+	if graphData == "" || history == "" {
+		return graphData + history
+	}
+	return graphData + "\n" + history
+}
+
+// buildGitContext renders the last few commit subjects for each of files
+// that exists, or "" when none has history. Files beyond
+// gitContextMaxFiles are left out.
+func buildGitContext(projectRoot string, files []string) string {
+	var b strings.Builder
+	listed := 0
+	for _, f := range files {
+		if listed == gitContextMaxFiles {
+			break
+		}
+		path := f
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectRoot, f)
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue // new file: no history yet
+		}
+		commits, err := git.RecentCommitsForFile(path, gitContextCommitsPerFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read git history of %s: %v\n", f, err)
+			continue
+		}
+		if len(commits) == 0 {
+			continue
+		}
+		if listed == 0 {
+			b.WriteString("## Recent Git History\n")
+		}
+		listed++
+
+		b.WriteString("### ")
+		b.WriteString(f)
+		b.WriteString("\n")
+		for _, c := range commits {
+			if r := []rune(c); len(r) > gitContextMaxSubject {
+				c = string(r[:gitContextMaxSubject]) + "..."
+			}
+			b.WriteString("- ")
+			b.WriteString(c)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
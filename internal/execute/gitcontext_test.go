@@ -0,0 +1,43 @@
+package execute
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/git"
+)
+
+func TestEmbedBeadContextIncludesGitHistory(t *testing.T) {
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.CommitFiles([]string{"main.go"}, "add empty main"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	bead := &beads.Bead{ID: "bt-1", Files: []string{"main.go", "new.go"}}
+	cfg := config.DefaultConfig()
+
+	if got := embedBeadContext(*cfg, nil, dir, bead); strings.Contains(got, "Recent Git History") {
+		t.Errorf("git history embedded while include_git_context is off:\n%s", got)
+	}
+
+	cfg.Execution.IncludeGitContext = true
+	got := embedBeadContext(*cfg, nil, dir, bead)
+	for _, want := range []string{"## Recent Git History", "### main.go", "add empty main", "initial"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("context missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "add empty main") > strings.Index(got, "initial") {
+		t.Errorf("commits not listed newest first:\n%s", got)
+	}
+	if strings.Contains(got, "new.go") {
+		t.Errorf("context lists history for a file that does not exist yet:\n%s", got)
+	}
+}
@@ -1,11 +1,7 @@
 // groups.go computes parallel execution groups from bead dependencies.
 package execute
 
-import (
-	"sort"
-
-	"github.com/berth-dev/berth/internal/beads"
-)
+import "github.com/berth-dev/berth/internal/beads"
 
 // ExecutionGroup represents a set of beads that can be executed together.
 // All beads in a group have no dependencies on each other.
@@ -15,94 +11,45 @@ type ExecutionGroup struct {
 	Parallel bool     // true if len(BeadIDs) > 1
 }
 
-// ComputeGroups computes parallel execution groups from bead dependencies.
-// It uses topological sort with level grouping (Kahn's algorithm) to group
-// beads by "level" - all beads at the same level have no dependencies on
-// each other and can be executed in parallel.
-// Returns groups in execution order (level 0 first, then level 1, etc.).
+// ComputeGroups computes parallel execution groups from bead dependencies,
+// one group per level of beads.Levels, in execution order (level 0 first,
+// then level 1, etc.). All beads in a group have no dependencies on each
+// other and can be executed in parallel.
 func ComputeGroups(allBeads []beads.Bead) []ExecutionGroup {
-	if len(allBeads) == 0 {
-		return nil
-	}
-
-	// Build bead ID set for filtering valid dependencies.
-	beadSet := make(map[string]bool, len(allBeads))
-	for _, b := range allBeads {
-		beadSet[b.ID] = true
-	}
-
-	// Build dependency graph: inDegree tracks how many unresolved dependencies each bead has.
-	inDegree := make(map[string]int, len(allBeads))
-	// rdeps maps bead ID to the list of beads that depend on it.
-	rdeps := make(map[string][]string, len(allBeads))
-
-	for _, b := range allBeads {
-		inDegree[b.ID] = 0
-	}
-
-	for _, b := range allBeads {
-		for _, dep := range b.DependsOn {
-			// Only count dependencies that exist in our bead set.
-			if beadSet[dep] {
-				inDegree[b.ID]++
-				rdeps[dep] = append(rdeps[dep], b.ID)
-			}
-		}
+	var groups []ExecutionGroup
+	for level, ids := range beads.Levels(allBeads) {
+		groups = append(groups, ExecutionGroup{
+			Index:    level,
+			BeadIDs:  ids,
+			Parallel: len(ids) > 1,
+		})
 	}
+	return groups
+}
 
-	// Track remaining beads to process.
-	remaining := make(map[string]bool, len(allBeads))
-	for _, b := range allBeads {
-		remaining[b.ID] = true
+// SplitGroup splits a group into batches of at most maxSize beads, in
+// order, so a parallel group never runs more than maxSize beads (and
+// worktrees) at once. Each batch keeps the group's Index. A maxSize of zero
+// or less returns the group unsplit.
+func SplitGroup(group ExecutionGroup, maxSize int) []ExecutionGroup {
+	if maxSize <= 0 || len(group.BeadIDs) <= maxSize {
+		return []ExecutionGroup{group}
 	}
 
-	var groups []ExecutionGroup
-	level := 0
-
-	// Process beads level by level using Kahn's algorithm.
-	for len(remaining) > 0 {
-		// Find all beads with inDegree == 0 (no unresolved dependencies).
-		var ready []string
-		for id := range remaining {
-			if inDegree[id] == 0 {
-				ready = append(ready, id)
-			}
-		}
-
-		// If no beads are ready but some remain, there's a cycle.
-		// Include all remaining beads in final group to avoid infinite loop.
-		if len(ready) == 0 {
-			for id := range remaining {
-				ready = append(ready, id)
-			}
+	var batches []ExecutionGroup
+	for start := 0; start < len(group.BeadIDs); start += maxSize {
+		end := start + maxSize
+		if end > len(group.BeadIDs) {
+			end = len(group.BeadIDs)
 		}
-
-		// Sort for deterministic ordering.
-		sort.Strings(ready)
-
-		// Create execution group for this level.
-		group := ExecutionGroup{
-			Index:    level,
-			BeadIDs:  ready,
-			Parallel: len(ready) > 1,
-		}
-		groups = append(groups, group)
-
-		// Remove processed beads from remaining and update inDegree for dependents.
-		for _, id := range ready {
-			delete(remaining, id)
-			// Decrement inDegree for all beads that depend on this one.
-			for _, dependent := range rdeps[id] {
-				if remaining[dependent] {
-					inDegree[dependent]--
-				}
-			}
-		}
-
-		level++
+		ids := group.BeadIDs[start:end]
+		batches = append(batches, ExecutionGroup{
+			Index:    group.Index,
+			BeadIDs:  ids,
+			Parallel: len(ids) > 1,
+		})
 	}
-
-	return groups
+	return batches
 }
 
 // GetBeadByID finds a bead by ID in the given slice.
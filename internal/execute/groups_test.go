@@ -0,0 +1,95 @@
+package execute
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+)
+
+func TestSplitGroupCapsBatchSize(t *testing.T) {
+	group := ExecutionGroup{Index: 2, Parallel: true}
+	for i := 1; i <= 10; i++ {
+		group.BeadIDs = append(group.BeadIDs, fmt.Sprintf("bt-%02d", i))
+	}
+
+	batches := SplitGroup(group, 3)
+	want := [][]string{
+		{"bt-01", "bt-02", "bt-03"},
+		{"bt-04", "bt-05", "bt-06"},
+		{"bt-07", "bt-08", "bt-09"},
+		{"bt-10"},
+	}
+	if len(batches) != len(want) {
+		t.Fatalf("got %d batches, want %d: %+v", len(batches), len(want), batches)
+	}
+	for i, batch := range batches {
+		if !reflect.DeepEqual(batch.BeadIDs, want[i]) {
+			t.Errorf("batch %d = %v, want %v", i, batch.BeadIDs, want[i])
+		}
+		if batch.Index != 2 {
+			t.Errorf("batch %d Index = %d, want the group's 2", i, batch.Index)
+		}
+		if batch.Parallel != (len(want[i]) > 1) {
+			t.Errorf("batch %d Parallel = %v with %d beads", i, batch.Parallel, len(want[i]))
+		}
+	}
+}
+
+func TestSplitGroupLeavesSmallGroupsWhole(t *testing.T) {
+	group := ExecutionGroup{BeadIDs: []string{"bt-1", "bt-2", "bt-3"}, Parallel: true}
+	for _, maxSize := range []int{3, 5, 0} {
+		if got := SplitGroup(group, maxSize); len(got) != 1 || !reflect.DeepEqual(got[0], group) {
+			t.Errorf("SplitGroup(max %d) = %+v, want the group unsplit", maxSize, got)
+		}
+	}
+}
+
+func TestFormatDryRunShowsBatches(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Execution.MaxParallel = 3
+	// One root keeps auto mode off the scheduler; its 10 dependents form
+	// one parallel group.
+	allBeads := []beads.Bead{{ID: "bt-00", Title: "Root"}}
+	for i := 1; i <= 10; i++ {
+		allBeads = append(allBeads, beads.Bead{ID: fmt.Sprintf("bt-%02d", i), DependsOn: []string{"bt-00"}})
+	}
+
+	out := FormatDryRun(*cfg, allBeads)
+	if !strings.Contains(out, "Group 2 (parallel, in batches of 3)") {
+		t.Errorf("output missing the batched group:\n%s", out)
+	}
+}
+
+func TestComputeGroupsReopenedBeadSkipsClosedDeps(t *testing.T) {
+	groups := ComputeGroups([]beads.Bead{
+		{ID: "bt-1", Status: "closed"},
+		{ID: "bt-2", Status: "open", DependsOn: []string{"bt-1"}},
+		{ID: "bt-3", Status: "open", DependsOn: []string{"bt-2"}},
+	})
+
+	var level0 []string
+	for _, g := range groups {
+		if g.Index == 0 {
+			level0 = g.BeadIDs
+		}
+	}
+	if !containsID(level0, "bt-2") {
+		t.Errorf("groups = %+v, want reopened bt-2 in the first group despite its closed dependency", groups)
+	}
+	if containsID(level0, "bt-3") {
+		t.Errorf("groups = %+v, want bt-3 to still wait for open bt-2", groups)
+	}
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
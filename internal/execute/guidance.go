@@ -0,0 +1,113 @@
+// guidance.go lets the user steer a bead while it runs: instructions
+// submitted for a bead (e.g. from the TUI's bead chat) are added to its next
+// attempt.
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// guidance holds per-bead user instructions and holds, keyed by bead ID.
+var guidance = struct {
+	mu      sync.Mutex
+	active  map[string]bool          // beads inside RetryBead
+	pending map[string][]string      // instructions not yet used by an attempt
+	held    map[string]chan struct{} // closed when a hold is lifted
+}{
+	active:  make(map[string]bool),
+	pending: make(map[string][]string),
+	held:    make(map[string]chan struct{}),
+}
+
+// HoldBead pauses a bead before its next attempt until SubmitGuidance or
+// ReleaseBead is called for it. An attempt already running is not
+// interrupted.
+func HoldBead(beadID string) {
+	guidance.mu.Lock()
+	defer guidance.mu.Unlock()
+	if _, ok := guidance.held[beadID]; !ok {
+		guidance.held[beadID] = make(chan struct{})
+	}
+}
+
+// ReleaseBead lifts a hold placed by HoldBead without adding guidance.
+func ReleaseBead(beadID string) {
+	guidance.mu.Lock()
+	defer guidance.mu.Unlock()
+	releaseLocked(beadID)
+}
+
+// SubmitGuidance queues instructions for the bead's next attempt and lifts
+// any hold on it. It reports false, dropping the text, when the bead is
+// neither running nor held (e.g. it already finished).
+func SubmitGuidance(beadID, text string) bool {
+	guidance.mu.Lock()
+	defer guidance.mu.Unlock()
+	_, held := guidance.held[beadID]
+	releaseLocked(beadID)
+	if !guidance.active[beadID] && !held {
+		return false
+	}
+	guidance.pending[beadID] = append(guidance.pending[beadID], text)
+	return true
+}
+
+// releaseLocked lifts a hold; guidance.mu must be held.
+func releaseLocked(beadID string) {
+	if ch, ok := guidance.held[beadID]; ok {
+		close(ch)
+		delete(guidance.held, beadID)
+	}
+}
+
+// beginGuidance marks a bead as running so guidance can be submitted for it.
+// The returned func ends that window and drops unused guidance.
+func beginGuidance(beadID string) func() {
+	guidance.mu.Lock()
+	guidance.active[beadID] = true
+	guidance.mu.Unlock()
+
+	return func() {
+		guidance.mu.Lock()
+		defer guidance.mu.Unlock()
+		delete(guidance.active, beadID)
+		delete(guidance.pending, beadID)
+		releaseLocked(beadID)
+	}
+}
+
+// awaitGuidance waits while the bead is held (or until ctx is done), then
+// returns and clears its queued guidance. It returns "" at once when the
+// bead is neither held nor has guidance queued.
+func awaitGuidance(ctx context.Context, beadID string) string {
+	guidance.mu.Lock()
+	ch, held := guidance.held[beadID]
+	guidance.mu.Unlock()
+
+	if held {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+		}
+	}
+
+	guidance.mu.Lock()
+	defer guidance.mu.Unlock()
+	text := strings.Join(guidance.pending[beadID], "\n")
+	delete(guidance.pending, beadID)
+	return text
+}
+
+// appendGuidance adds the user's instructions to an executor task prompt.
+func appendGuidance(taskPrompt, userGuidance string) string {
+	if userGuidance == "" {
+		return taskPrompt
+	}
+	return taskPrompt + fmt.Sprintf(
+		"\n## User Guidance\nThe user reviewed this bead's progress and gave these additional instructions. Follow them:\n%s\n",
+		userGuidance,
+	)
+}
@@ -0,0 +1,61 @@
+package execute
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+func TestRetryBeadInjectsSubmittedGuidance(t *testing.T) {
+	// Record each task prompt (the -p argument) so the test can inspect it.
+	testutil.FakeCommand(t, "claude", "printf '%s\\n---\\n' \"$2\" >> prompts.txt\necho '{\"type\":\"result\",\"result\":\"feat: done\",\"is_error\":false}'\n")
+	workDir := t.TempDir()
+
+	cfg := config.Config{VerifyPipeline: []string{"true"}}
+	bead := &beads.Bead{ID: "bt-guided", Title: "Guided bead"}
+
+	// Hold the bead so its first attempt waits for the chat to finish.
+	HoldBead(bead.ID)
+	done := make(chan *BeadResult)
+	go func() {
+		result, err := RetryBead(context.Background(), cfg, bead, "", workDir, nil, nil, &SpawnClaudeOpts{WorkDir: workDir})
+		if err != nil {
+			t.Errorf("RetryBead: %v", err)
+		}
+		done <- result
+	}()
+
+	if !SubmitGuidance(bead.ID, "Use the existing session helper instead of a new one.") {
+		t.Fatal("SubmitGuidance refused guidance for a held bead")
+	}
+
+	select {
+	case result := <-done:
+		if result == nil || !result.Passed {
+			t.Fatalf("result = %+v, want passed", result)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("RetryBead did not finish after guidance was submitted")
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, "prompts.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prompt := string(data)
+	if !strings.Contains(prompt, "## User Guidance") || !strings.Contains(prompt, "Use the existing session helper") {
+		t.Errorf("attempt prompt missing guidance:\n%s", prompt)
+	}
+
+	// Once the bead has finished, guidance for it is refused.
+	if SubmitGuidance(bead.ID, "too late") {
+		t.Error("SubmitGuidance accepted guidance for a finished bead")
+	}
+}
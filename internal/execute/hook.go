@@ -0,0 +1,80 @@
+// hook.go runs the user-configured post-run hook after execution finishes.
+package execute
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+// postRunHookTimeout bounds how long the post-run hook may run.
+const postRunHookTimeout = 5 * time.Minute
+
+// runPostRunHook runs cfg.Execution.PostRunHook via `sh -c` in projectRoot,
+// passing the run outcome through BERTH_* environment variables. By default
+// the hook runs only when the run finished without error and with no stuck
+// beads; set post_run_hook_always to run it regardless. Hook failures are
+// logged and reported as warnings but never fail the run.
+func runPostRunHook(cfg config.Config, projectRoot, runDir, branchName string, pool *ExecutionPool, runErr error, logger *log.Logger) {
+	hook := strings.TrimSpace(cfg.Execution.PostRunHook)
+	if hook == "" {
+		return
+	}
+
+	success := runErr == nil && pool.GetStuck() == 0
+	if !success && !cfg.Execution.PostRunHookAlways {
+		return
+	}
+
+	status := "success"
+	if !success {
+		status = "failure"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), postRunHookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook)
+	cmd.Dir = projectRoot
+	cmd.Env = append(os.Environ(),
+		"BERTH_BRANCH="+branchName,
+		"BERTH_COMPLETED="+strconv.Itoa(pool.GetCompleted()),
+		"BERTH_STUCK="+strconv.Itoa(pool.GetStuck()),
+		"BERTH_RUN_DIR="+runDir,
+		"BERTH_STATUS="+status,
+	)
+
+	fmt.Println("Running post-run hook...")
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if output != "" {
+		fmt.Println(output)
+	}
+
+	event := log.LogEvent{
+		Event:  log.EventPostRunHook,
+		Branch: branchName,
+		Reason: status,
+		Data:   map[string]interface{}{"command": hook, "output": output},
+	}
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", postRunHookTimeout)
+		}
+		event.Error = err.Error()
+		fmt.Fprintf(os.Stderr, "Warning: post-run hook failed: %v\n", err)
+	}
+
+	if logger != nil {
+		if logErr := logger.Append(event); logErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to log post_run_hook: %v\n", logErr)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package execute
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+func TestPostRunHookReceivesOutcomeEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post-run hook requires a POSIX shell")
+	}
+	projectRoot := t.TempDir()
+	logger, err := log.NewLogger(projectRoot)
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+
+	cfg := config.Config{}
+	cfg.Execution.PostRunHook = `printf '%s|%s|%s|%s|%s' "$BERTH_BRANCH" "$BERTH_COMPLETED" "$BERTH_STUCK" "$BERTH_RUN_DIR" "$BERTH_STATUS" > hook.out`
+
+	pool := NewExecutionPool(3)
+	pool.Completed = 3
+
+	runPostRunHook(cfg, projectRoot, "/runs/42", "berth/feature", pool, nil, logger)
+
+	data, err := os.ReadFile(filepath.Join(projectRoot, "hook.out"))
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	want := "berth/feature|3|0|/runs/42|success"
+	if string(data) != want {
+		t.Errorf("hook env = %q, want %q", data, want)
+	}
+
+	events, err := logger.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(events) != 1 || events[0].Event != log.EventPostRunHook || events[0].Error != "" {
+		t.Errorf("expected one successful post_run_hook event, got %+v", events)
+	}
+}
+
+func TestPostRunHookSkippedOnFailureUnlessAlways(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post-run hook requires a POSIX shell")
+	}
+	projectRoot := t.TempDir()
+	marker := filepath.Join(projectRoot, "ran")
+
+	cfg := config.Config{}
+	cfg.Execution.PostRunHook = `echo "$BERTH_STATUS" > ran`
+
+	pool := NewExecutionPool(2)
+	pool.Completed = 1
+	pool.Stuck = 1
+
+	runPostRunHook(cfg, projectRoot, "", "b", pool, nil, nil)
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("hook should not run when beads are stuck")
+	}
+
+	cfg.Execution.PostRunHookAlways = true
+	runPostRunHook(cfg, projectRoot, "", "b", pool, errors.New("aborted"), nil)
+	data, err := os.ReadFile(marker)
+	if err != nil {
+		t.Fatalf("hook should run with post_run_hook_always: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != "failure" {
+		t.Errorf("BERTH_STATUS = %q, want failure", data)
+	}
+}
+
+func TestPostRunHookFailureIsLogged(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post-run hook requires a POSIX shell")
+	}
+	projectRoot := t.TempDir()
+	logger, err := log.NewLogger(projectRoot)
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+
+	cfg := config.Config{}
+	cfg.Execution.PostRunHook = "echo boom; exit 2"
+
+	runPostRunHook(cfg, projectRoot, "", "b", NewExecutionPool(0), nil, logger)
+
+	events, err := logger.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(events) != 1 || events[0].Error == "" {
+		t.Fatalf("expected logged hook failure, got %+v", events)
+	}
+	if out, _ := events[0].Data["output"].(string); out != "boom" {
+		t.Errorf("logged output = %q, want boom", out)
+	}
+}
@@ -0,0 +1,168 @@
+// interrupt.go stops a headless run cleanly on Ctrl+C: the first SIGINT or
+// SIGTERM saves a checkpoint and cancels the running bead, a second one
+// soon after exits at once.
+package execute
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// interruptGrace is how soon a second signal must follow the first to exit
+// immediately instead of waiting for the running bead to stop.
+const interruptGrace = 2 * time.Second
+
+// errRunInterrupted is returned by the execution loop once a signal has
+// cancelled the run. It matches ErrAborted.
+var errRunInterrupted error = abortedError("run interrupted")
+
+// exitHard ends the process on a second signal; tests replace it.
+var exitHard = os.Exit
+
+// runProgress is the execution loop's checkpointed progress. The signal
+// handler reads it from its own goroutine, so every field is guarded by mu;
+// breaker is thread-safe on its own.
+type runProgress struct {
+	mu             sync.Mutex
+	completedBeads []string
+	failedBeads    []string
+	retryCount     map[string]int
+	currentBeadID  string
+	tokensUsed     int
+	breaker        *CircuitBreaker
+}
+
+// newRunProgress returns progress restored from state, or fresh progress
+// when state is nil.
+func newRunProgress(state *ExecuteState, breaker *CircuitBreaker) *runProgress {
+	p := &runProgress{
+		completedBeads: []string{},
+		failedBeads:    []string{},
+		retryCount:     make(map[string]int),
+		breaker:        breaker,
+	}
+	if state != nil {
+		p.completedBeads = append(p.completedBeads, state.CompletedBeads...)
+		p.failedBeads = append(p.failedBeads, state.FailedBeads...)
+		if state.RetryCount != nil {
+			p.retryCount = state.RetryCount
+		}
+		p.tokensUsed = state.TokensUsed
+	}
+	return p
+}
+
+// begin records beadID as the bead being executed.
+func (p *runProgress) begin(beadID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.currentBeadID = beadID
+}
+
+// current returns the bead being executed, or the last one begun.
+func (p *runProgress) current() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.currentBeadID
+}
+
+// complete records beadID as completed.
+func (p *runProgress) complete(beadID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completedBeads = append(p.completedBeads, beadID)
+}
+
+// fail records beadID as failed.
+func (p *runProgress) fail(beadID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failedBeads = append(p.failedBeads, beadID)
+}
+
+// setAttempts records how many attempts beadID has used.
+func (p *runProgress) setAttempts(beadID string, attempts int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retryCount[beadID] = attempts
+}
+
+// addTokens adds n to the tokens the run has used and returns the new total.
+func (p *runProgress) addTokens(n int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tokensUsed += n
+	return p.tokensUsed
+}
+
+// tokens returns the tokens the run has used.
+func (p *runProgress) tokens() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.tokensUsed
+}
+
+// save writes the progress to runDir's checkpoint. It copies the progress
+// under mu, so it is safe to call while the loop keeps running.
+func (p *runProgress) save(runDir, runID string, plannedBeads []string, currentBeadID, lastError string) {
+	p.mu.Lock()
+	completed := append([]string(nil), p.completedBeads...)
+	failed := append([]string(nil), p.failedBeads...)
+	retryCount := make(map[string]int, len(p.retryCount))
+	for id, n := range p.retryCount {
+		retryCount[id] = n
+	}
+	tokensUsed := p.tokensUsed
+	p.mu.Unlock()
+	saveCheckpointState(runDir, runID, plannedBeads, currentBeadID, completed, failed, retryCount, p.breaker.GetConsecutiveFailures(), tokensUsed, lastError)
+}
+
+// interrupted saves the checkpoint of a run interrupted at currentBeadID
+// and returns the error that ends it.
+func (p *runProgress) interrupted(runDir, runID string, plannedBeads []string, currentBeadID string) error {
+	p.save(runDir, runID, plannedBeads, currentBeadID, errRunInterrupted.Error())
+	if currentBeadID == "" {
+		return fmt.Errorf("%w; resume with: berth resume", errRunInterrupted)
+	}
+	return fmt.Errorf("%w at bead %s; resume with: berth resume", errRunInterrupted, currentBeadID)
+}
+
+// handleInterrupts calls save and then cancel on the first SIGINT or
+// SIGTERM, and exits with status 130 on another signal within
+// interruptGrace. Later signals start over. The returned stop function
+// uninstalls the handler.
+func handleInterrupts(cancel context.CancelFunc, save func()) (stop func()) {
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		var last time.Time
+		for {
+			select {
+			case <-sigCh:
+				if !last.IsZero() && time.Since(last) <= interruptGrace {
+					fmt.Fprintln(os.Stderr, "\nInterrupted again, exiting without waiting for the current bead.")
+					exitHard(130)
+					return
+				}
+				last = time.Now()
+				fmt.Fprintln(os.Stderr, "\nInterrupted: saving checkpoint and stopping the current bead (interrupt again to exit now)...")
+				save()
+				cancel()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
@@ -0,0 +1,87 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// interruptSelf sends SIGINT to the test process, whose handler is
+// installed by handleInterrupts.
+func interruptSelf(t *testing.T) {
+	t.Helper()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatalf("sending SIGINT: %v", err)
+	}
+}
+
+func TestHandleInterruptsSavesCheckpointAndCancels(t *testing.T) {
+	runDir := t.TempDir()
+	progress := newRunProgress(&ExecuteState{CompletedBeads: []string{"bt-1"}}, NewCircuitBreaker(3))
+	progress.begin("bt-2")
+	progress.setAttempts("bt-2", 2)
+	progress.breaker.RecordFailure()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stop := handleInterrupts(cancel, func() {
+		progress.save(runDir, "berth/auth", []string{"bt-1", "bt-2", "bt-3"}, progress.current(), errRunInterrupted.Error())
+	})
+	defer stop()
+
+	interruptSelf(t)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("SIGINT did not cancel the run")
+	}
+
+	cp, err := LoadCheckpoint(runDir)
+	if err != nil || cp == nil {
+		t.Fatalf("LoadCheckpoint = %v, %v; want the checkpoint saved on SIGINT", cp, err)
+	}
+	if cp.CurrentBeadID != "bt-2" || cp.RetryCount["bt-2"] != 2 {
+		t.Errorf("checkpoint current = %q with retries %v, want bt-2 at 2 attempts", cp.CurrentBeadID, cp.RetryCount)
+	}
+	if len(cp.CompletedBeads) != 1 || cp.CompletedBeads[0] != "bt-1" || cp.ConsecFailures != 1 {
+		t.Errorf("checkpoint = %+v, want bt-1 completed and 1 consecutive failure", cp)
+	}
+	if cp.LastError != "run interrupted" {
+		t.Errorf("LastError = %q, want %q", cp.LastError, "run interrupted")
+	}
+
+	if err := progress.interrupted(runDir, "berth/auth", []string{"bt-1", "bt-2", "bt-3"}, "bt-2"); !errors.Is(err, ErrAborted) {
+		t.Errorf("interrupted() = %v, want an error matching ErrAborted", err)
+	}
+}
+
+func TestHandleInterruptsSecondSignalExits(t *testing.T) {
+	exited := make(chan int, 1)
+	exitHard = func(code int) { exited <- code }
+	defer func() { exitHard = os.Exit }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	saves := 0
+	stop := handleInterrupts(cancel, func() { saves++ })
+	defer stop()
+
+	interruptSelf(t)
+	<-ctx.Done()
+	interruptSelf(t)
+
+	select {
+	case code := <-exited:
+		if code != 130 {
+			t.Errorf("exit code = %d, want 130", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second SIGINT within the grace period did not exit")
+	}
+	if saves != 1 {
+		t.Errorf("checkpoint saved %d times, want once", saves)
+	}
+}
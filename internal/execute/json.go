@@ -0,0 +1,147 @@
+// json.go implements the machine-readable execution mode used in CI.
+package execute
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+// JSONSummary is the single document RunExecuteJSON writes when a run ends.
+type JSONSummary struct {
+	Branch     string           `json:"branch"`
+	Completed  int              `json:"completed"`
+	Stuck      int              `json:"stuck"`
+	Skipped    int              `json:"skipped"`
+	Total      int              `json:"total"`
+	DurationMs int64            `json:"duration_ms"`
+	Tokens     int              `json:"tokens"`
+	Beads      []JSONBeadStatus `json:"beads"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// JSONBeadStatus is one bead's final state within a JSONSummary.
+type JSONBeadStatus struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"` // "completed" | "stuck" | the bead's bd status if it never finished
+	Tokens int    `json:"tokens,omitempty"`
+}
+
+// RunExecuteJSON runs RunExecute without ever prompting and writes a
+// JSONSummary to w when it finishes. Human-readable progress goes to stderr
+// so w (normally stdout) carries only the JSON. A tripped circuit breaker
+// aborts the run (unless the cooldown policy is set) and stuck beads are
+// skipped. The run's error, if any, is returned and, unless it only reports
+// stuck or skipped beads, recorded in the summary.
+func RunExecuteJSON(cfg config.Config, projectRoot string, runDir string, branchName string, verbose bool, w io.Writer) error {
+	cfg = nonInteractive(cfg)
+
+	planned, err := beads.List()
+	if err != nil {
+		return fmt.Errorf("listing beads: %w", err)
+	}
+
+	start := time.Now()
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	runErr := RunExecuteWithState(cfg, projectRoot, runDir, branchName, verbose, nil, nil, nil)
+	os.Stdout = stdout
+
+	summary := buildJSONSummary(projectRoot, branchName, planned, start, runErr)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(summary); err != nil {
+		return fmt.Errorf("writing JSON summary: %w", err)
+	}
+	return runErr
+}
+
+// nonInteractive returns cfg with every execution prompt replaced by an
+// unattended decision.
+func nonInteractive(cfg config.Config) config.Config {
+	if cfg.Execution.CircuitBreakerPolicy != "cooldown" {
+		cfg.Execution.CircuitBreakerPolicy = "abort"
+	}
+	cfg.Execution.StuckPolicy = "skip"
+	return cfg
+}
+
+// buildJSONSummary assembles a JSONSummary for the beads that were open when
+// the run started. Counts come from the run's run_complete event, tokens from
+// its claude_usage events, and bead statuses from bd.
+func buildJSONSummary(projectRoot, branch string, planned []beads.Bead, start time.Time, runErr error) *JSONSummary {
+	s := &JSONSummary{
+		Branch:     branch,
+		Total:      len(planned),
+		DurationMs: time.Since(start).Milliseconds(),
+		Beads:      []JSONBeadStatus{},
+	}
+	var incomplete *IncompleteError
+	if runErr != nil && !errors.As(runErr, &incomplete) {
+		// Stuck and skipped beads are already counted below.
+		s.Error = runErr.Error()
+	}
+
+	beadTokens := make(map[string]int)
+	if logger, err := log.NewLogger(projectRoot); err == nil {
+		events, readErr := logger.ReadAll()
+		if readErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: reading log for JSON summary: %v\n", readErr)
+		}
+		for _, e := range events {
+			if e.Time.Before(start) {
+				continue
+			}
+			switch e.Event {
+			case log.EventClaudeUsage:
+				s.Tokens += e.Tokens
+				beadTokens[e.BeadID] += e.Tokens
+			case log.EventRunComplete:
+				s.Completed, s.Stuck, s.Skipped = e.Completed, e.Stuck, e.Skipped
+				if e.Total > 0 {
+					s.Total = e.Total
+				}
+			}
+		}
+	}
+
+	status := make(map[string]string)
+	if current, err := beads.ListAll(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: listing beads for JSON summary: %v\n", err)
+	} else {
+		for _, b := range current {
+			status[b.ID] = b.Status
+		}
+	}
+	for _, b := range planned {
+		st, ok := status[b.ID]
+		if !ok {
+			st = b.Status
+		}
+		s.Beads = append(s.Beads, JSONBeadStatus{
+			ID:     b.ID,
+			Title:  b.Title,
+			Status: jsonBeadStatus(st),
+			Tokens: beadTokens[b.ID],
+		})
+	}
+	return s
+}
+
+// jsonBeadStatus maps a bd status onto the summary's vocabulary.
+func jsonBeadStatus(status string) string {
+	switch status {
+	case "closed", "done":
+		return "completed"
+	default:
+		return status
+	}
+}
@@ -0,0 +1,126 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+// fakeBD puts a bd script on PATH that prints listing for "list" and appends
+// every other invocation to the returned calls file.
+func fakeBD(t *testing.T, listing string) string {
+	t.Helper()
+	calls := filepath.Join(t.TempDir(), "calls")
+	testutil.FakeCommand(t, "bd", "if [ \"$1\" = list ]; then\ncat <<'EOF'\n"+listing+"\nEOF\nelse\necho \"$@\" >> "+calls+"\nfi\n")
+	return calls
+}
+
+func TestRunExecuteJSONWritesOnlyJSON(t *testing.T) {
+	dir := initTestRepo(t)
+	fakeBD(t, "[]")
+
+	var out bytes.Buffer
+	stdout := os.Stdout
+	if err := RunExecuteJSON(*config.DefaultConfig(), dir, filepath.Join(dir, ".berth", "runs", "test"), "berth/empty", false, &out); err != nil {
+		t.Fatalf("RunExecuteJSON: %v", err)
+	}
+	if os.Stdout != stdout {
+		t.Error("os.Stdout was not restored")
+	}
+
+	var summary JSONSummary
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+		t.Fatalf("output is not a JSON summary: %v\n%s", err, out.String())
+	}
+	if summary.Branch != "berth/empty" || summary.Total != 0 || summary.Beads == nil {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestNonInteractivePolicies(t *testing.T) {
+	cfg := *config.DefaultConfig()
+	got := nonInteractive(cfg)
+	if got.Execution.CircuitBreakerPolicy != "abort" || got.Execution.StuckPolicy != "skip" {
+		t.Errorf("policies = %q/%q, want abort/skip", got.Execution.CircuitBreakerPolicy, got.Execution.StuckPolicy)
+	}
+
+	// An unattended cooldown is kept: it never prompts either.
+	cfg.Execution.CircuitBreakerPolicy = "cooldown"
+	if got := nonInteractive(cfg); got.Execution.CircuitBreakerPolicy != "cooldown" {
+		t.Errorf("cooldown policy replaced by %q", got.Execution.CircuitBreakerPolicy)
+	}
+}
+
+func TestCircuitBreakerAbortPolicyDoesNotPrompt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Execution.CircuitBreakerPolicy = "abort"
+	breaker := NewCircuitBreaker(1)
+	breaker.RecordFailure()
+
+	action, err := resolveCircuitBreaker(context.Background(), cfg, breaker, NewExecutionPool(2), nil)
+	if err != nil || action != "abort" {
+		t.Errorf("resolveCircuitBreaker = %q, %v; want abort", action, err)
+	}
+}
+
+func TestHandleStuckSkipPolicy(t *testing.T) {
+	calls := fakeBD(t, "[]")
+	cfg := *config.DefaultConfig()
+	cfg.Execution.StuckPolicy = "skip"
+
+	action, err := HandleStuck(cfg, &beads.Bead{ID: "bt-1", Title: "Add search"}, nil, "", "", t.TempDir())
+	if err != nil {
+		t.Fatalf("HandleStuck: %v", err)
+	}
+	if action.Action != stuckActionSkip {
+		t.Errorf("action = %q, want skip", action.Action)
+	}
+	data, _ := os.ReadFile(calls)
+	if !strings.Contains(string(data), "update bt-1 --status stuck") {
+		t.Errorf("bd calls = %q, want the bead marked stuck", data)
+	}
+}
+
+func TestHandleStuckAbortPolicy(t *testing.T) {
+	calls := fakeBD(t, "[]")
+	cfg := *config.DefaultConfig()
+	cfg.Execution.StuckPolicy = "abort"
+
+	action, err := HandleStuck(cfg, &beads.Bead{ID: "bt-1", Title: "Add search"}, nil, "", "", t.TempDir())
+	if err != nil {
+		t.Fatalf("HandleStuck: %v", err)
+	}
+	if action.Action != stuckActionAbort {
+		t.Errorf("action = %q, want abort", action.Action)
+	}
+	if data, _ := os.ReadFile(calls); strings.Contains(string(data), "--status stuck") {
+		t.Errorf("bd calls = %q, want the bead left as is on abort", data)
+	}
+}
+
+func TestBuildJSONSummaryStatuses(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fakeBD(t, `[{"id":"bt-1","title":"Add search","status":"closed"},{"id":"bt-2","title":"Add export","status":"stuck"}]`)
+
+	planned := []beads.Bead{{ID: "bt-1", Title: "Add search", Status: "open"}, {ID: "bt-2", Title: "Add export", Status: "open"}, {ID: "bt-3", Title: "Docs", Status: "open"}}
+	s := buildJSONSummary(t.TempDir(), "berth/x", planned, time.Now(), nil)
+
+	want := []string{"completed", "stuck", "open"}
+	for i, b := range s.Beads {
+		if b.Status != want[i] {
+			t.Errorf("bead %s status = %q, want %q", b.ID, b.Status, want[i])
+		}
+	}
+	if s.Total != 3 || s.Error != "" {
+		t.Errorf("summary = %+v", s)
+	}
+}
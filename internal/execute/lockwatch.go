@@ -0,0 +1,110 @@
+// lockwatch.go reports parallel beads that have waited too long for another
+// bead's file lock, with the coordinator's full lock map, so lock cycles
+// between agents show up in the output and the log.
+package execute
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/berth-dev/berth/internal/coordinator"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+const (
+	lockWatchInterval = 30 * time.Second
+	lockWaitWarnAfter = 2 * time.Minute
+)
+
+// watchLockWaits checks the coordinator for long lock waits every
+// lockWatchInterval until the returned stop is called.
+func (s *Scheduler) watchLockWaits() (stop func()) {
+	if s.coordServer == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(lockWatchInterval)
+		defer ticker.Stop()
+		warned := make(map[coordinator.LockWait]bool)
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				s.reportLockWaits(s.coordServer.ListLocks(), now, warned)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// reportLockWaits warns, once per wait, about each running bead that has
+// waited longer than lockWaitWarnAfter for a lock, printing and logging the
+// lock map alongside.
+func (s *Scheduler) reportLockWaits(locks coordinator.ListLocksResponse, now time.Time, warned map[coordinator.LockWait]bool) {
+	for _, wait := range locks.Waiting {
+		key := coordinator.LockWait{BeadID: wait.BeadID, FilePath: wait.FilePath, Since: wait.Since}
+		if warned[key] || now.Sub(wait.Since) < lockWaitWarnAfter || !s.isRunning(wait.BeadID) {
+			continue
+		}
+		warned[key] = true
+
+		waited := now.Sub(wait.Since).Round(time.Second)
+		fmt.Fprintf(os.Stderr, "Warning: %s has waited %s for the lock on %s held by %s\n%s",
+			wait.BeadID, waited, wait.FilePath, wait.BlockedBy, formatLockMap(locks))
+		if s.logger == nil {
+			continue
+		}
+		if logErr := s.logger.Append(log.LogEvent{
+			Event:  log.EventLockWait,
+			BeadID: wait.BeadID,
+			Data: map[string]interface{}{
+				"file":       wait.FilePath,
+				"blocked_by": wait.BlockedBy,
+				"waited_ms":  waited.Milliseconds(),
+				"locks":      lockHolders(locks),
+			},
+		}); logErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to log lock_wait: %v\n", logErr)
+		}
+	}
+}
+
+// isRunning reports whether the bead's worker is still running; waits left
+// behind by finished beads are not worth reporting.
+func (s *Scheduler) isRunning(beadID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	node, ok := s.nodes[beadID]
+	return ok && node.Status == "running"
+}
+
+// formatLockMap renders the locks and waits as indented lines, e.g.
+// "  api.go: held by bt-2 since 14:03:10" and
+// "  bt-3 waiting for api.go (held by bt-2)".
+func formatLockMap(locks coordinator.ListLocksResponse) string {
+	var b strings.Builder
+	b.WriteString("Current locks:\n")
+	if len(locks.Locks) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, l := range locks.Locks {
+		fmt.Fprintf(&b, "  %s: held by %s since %s\n", l.FilePath, l.BeadID, l.AcquiredAt.Format("15:04:05"))
+	}
+	for _, w := range locks.Waiting {
+		fmt.Fprintf(&b, "  %s waiting for %s (held by %s)\n", w.BeadID, w.FilePath, w.BlockedBy)
+	}
+	return b.String()
+}
+
+// lockHolders maps each locked file to the bead holding it.
+func lockHolders(locks coordinator.ListLocksResponse) map[string]string {
+	holders := make(map[string]string, len(locks.Locks))
+	for _, l := range locks.Locks {
+		holders[l.FilePath] = l.BeadID
+	}
+	return holders
+}
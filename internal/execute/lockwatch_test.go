@@ -0,0 +1,49 @@
+package execute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/coordinator"
+)
+
+func TestReportLockWaitsWarnsOnceForRunningBeads(t *testing.T) {
+	s := &Scheduler{nodes: map[string]*BeadNode{
+		"bt-2": {Status: "running"},
+		"bt-3": {Status: "running"},
+		"bt-4": {Status: "completed"},
+	}}
+	now := time.Date(2026, 1, 1, 12, 10, 0, 0, time.UTC)
+	long := now.Add(-5 * time.Minute)
+	locks := coordinator.ListLocksResponse{
+		Locks: []coordinator.FileLock{{BeadID: "bt-1", FilePath: "api.go", AcquiredAt: long}},
+		Waiting: []coordinator.LockWait{
+			{BeadID: "bt-2", FilePath: "api.go", BlockedBy: "bt-1", Since: long},
+			{BeadID: "bt-3", FilePath: "api.go", BlockedBy: "bt-1", Since: now.Add(-time.Minute)},
+			{BeadID: "bt-4", FilePath: "api.go", BlockedBy: "bt-1", Since: long},
+		},
+	}
+
+	warned := make(map[coordinator.LockWait]bool)
+	s.reportLockWaits(locks, now, warned)
+	s.reportLockWaits(locks, now.Add(lockWatchInterval), warned)
+
+	want := coordinator.LockWait{BeadID: "bt-2", FilePath: "api.go", Since: long}
+	if len(warned) != 1 || !warned[want] {
+		t.Errorf("warned = %v, want only bt-2's wait (bt-3 is recent, bt-4 finished)", warned)
+	}
+}
+
+func TestFormatLockMap(t *testing.T) {
+	locks := coordinator.ListLocksResponse{
+		Locks:   []coordinator.FileLock{{BeadID: "bt-1", FilePath: "api.go", AcquiredAt: time.Date(2026, 1, 1, 14, 3, 10, 0, time.UTC)}},
+		Waiting: []coordinator.LockWait{{BeadID: "bt-2", FilePath: "api.go", BlockedBy: "bt-1"}},
+	}
+	want := "Current locks:\n  api.go: held by bt-1 since 14:03:10\n  bt-2 waiting for api.go (held by bt-1)\n"
+	if got := formatLockMap(locks); got != want {
+		t.Errorf("formatLockMap =\n%q\nwant\n%q", got, want)
+	}
+	if got := formatLockMap(coordinator.ListLocksResponse{}); got != "Current locks:\n  (none)\n" {
+		t.Errorf("formatLockMap(empty) = %q", got)
+	}
+}
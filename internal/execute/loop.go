@@ -5,10 +5,13 @@ package execute
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
@@ -16,6 +19,7 @@ import (
 	"github.com/berth-dev/berth/internal/git"
 	"github.com/berth-dev/berth/internal/graph"
 	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/session"
 	"github.com/berth-dev/berth/prompts"
 )
 
@@ -33,28 +37,53 @@ const (
 type ExecuteState struct {
 	RetryCount     map[string]int // per-bead retry counts
 	ConsecFailures int            // consecutive failures for circuit breaker
+	CompletedBeads []string       // beads already merged (skipped by parallel resume)
+	FailedBeads    []string       // beads that failed before the interruption
+	TokensUsed     int            // tokens the run's beads used before the interruption
+	Beads          []string       // beads being executed when the checkpoint was saved
 }
 
 // RunExecute is the main execution entry point. It creates a feature branch,
 // starts the KG MCP server, and processes beads one at a time through the
 // retry loop until all beads are completed, stuck, or skipped.
 // If parallel mode is active, delegates to RunExecuteParallel.
+// A run that leaves beads stuck or skipped returns an *IncompleteError, and
+// one stopped early returns an error matching ErrAborted.
 func RunExecute(cfg config.Config, projectRoot string, runDir string, branchName string, verbose bool) error {
-	return RunExecuteWithState(cfg, projectRoot, runDir, branchName, verbose, nil, nil)
+	return RunExecuteWithState(cfg, projectRoot, runDir, branchName, verbose, nil, nil, nil)
 }
 
 // RunExecuteWithState is the main execution entry point that accepts optional
 // restored state from a checkpoint. Used by resume to restore execution state.
-// The outputChan parameter is optional and receives StreamEvents during execution for TUI integration.
-func RunExecuteWithState(cfg config.Config, projectRoot string, runDir string, branchName string, verbose bool, state *ExecuteState, outputChan chan<- StreamEvent) error {
+// The outputChan parameter is optional and receives StreamEvents during execution for TUI integration:
+// bead_init, output, bead_complete and error, in both sequential and parallel groups.
+// The control parameter is optional and carries pause and resume signals:
+// while paused no new bead starts, but running beads are never stopped.
+func RunExecuteWithState(cfg config.Config, projectRoot string, runDir string, branchName string, verbose bool, state *ExecuteState, outputChan chan<- StreamEvent, control <-chan ControlSignal) error {
 	// Check if parallel execution is appropriate (full parallel mode).
 	allBeadsList, err := beads.List()
 	if err != nil {
 		return fmt.Errorf("listing beads for mode check: %w", err)
 	}
-	if ShouldRunParallel(cfg, allBeadsList) {
+	if state != nil {
+		if current, listErr := beads.ListAll(); listErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not check checkpoint against current beads: %v\n", listErr)
+		} else {
+			warnReconciled(reconcileState(state, current))
+		}
+	}
+	if len(allBeadsList) == 0 {
+		fmt.Println("Nothing to do: no open beads to execute.")
+		return nil
+	}
+	if err := beads.ValidateDependencies(allBeadsList); err != nil {
+		return fmt.Errorf("invalid bead dependencies: %w", err)
+	}
+	parallel := ShouldRunParallel(cfg, allBeadsList)
+	reportRunManifest(cfg, projectRoot, allBeadsList, state, !parallel)
+	if parallel {
 		fmt.Println("Parallel mode enabled")
-		return RunExecuteParallel(cfg, projectRoot, runDir, branchName, allBeadsList, verbose)
+		return RunExecuteParallel(cfg, projectRoot, runDir, branchName, allBeadsList, verbose, state, control)
 	}
 
 	// 1. Create a git branch for this execution run.
@@ -103,27 +132,33 @@ func RunExecuteWithState(cfg config.Config, projectRoot string, runDir string, b
 	}
 	pool := NewExecutionPool(len(allBeads))
 
-	// 4a. Initialize checkpoint tracking state.
-	// If we have restored state from a checkpoint, use it; otherwise start fresh.
-	retryCount := make(map[string]int)
-	completedBeads := []string{}
-	failedBeads := []string{}
-
-	// 4b. Initialize circuit breaker with threshold from config.
+	// 4a. Initialize circuit breaker with threshold from config.
 	breaker := NewCircuitBreaker(cfg.Execution.CircuitBreakerThreshold)
 	if state != nil {
-		if state.RetryCount != nil {
-			retryCount = state.RetryCount
-		}
 		// Restore circuit breaker state from checkpoint.
 		breaker.SetConsecutiveFailures(state.ConsecFailures)
 	}
 
+	// 4b. Initialize checkpoint tracking state.
+	// If we have restored state from a checkpoint, use it; otherwise start fresh.
+	progress := newRunProgress(state, breaker)
+
+	// 4c. Without the TUI, Ctrl+C saves a checkpoint and stops the running
+	// bead instead of killing the process mid-bead.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if outputChan == nil {
+		stop := handleInterrupts(cancel, func() {
+			progress.save(runDir, branchName, beadIDs(allBeads), progress.current(), errRunInterrupted.Error())
+		})
+		defer stop()
+	}
+
 	// 5. Print header.
 	fmt.Printf("Executing %d beads on branch %s\n", pool.Total, branchName)
 
 	// 6. Create logger.
-	logger, err := log.NewLogger(projectRoot)
+	logger, err := log.NewLoggerWithPrivacy(projectRoot, cfg.Log.Privacy())
 	if err != nil {
 		return fmt.Errorf("creating logger: %w", err)
 	}
@@ -137,8 +172,16 @@ func RunExecuteWithState(cfg config.Config, projectRoot string, runDir string, b
 		fmt.Fprintf(os.Stderr, "Warning: failed to log run_started: %v\n", logErr)
 	}
 
+	// 7a. Tag the branch periodically for git-level recovery.
+	snapshots := newSnapshotter(cfg.Execution, runDir, branchName)
+
+	// 7b. Mirror bead transitions into the session store.
+	states := openBeadStateRecorder(projectRoot, cfg.Project.Name, branchName)
+	defer states.close()
+
 	// 8. Compute execution groups for group-based execution.
 	groups := ComputeGroups(allBeads)
+	pause := newPauseGate(control)
 
 	// 9. Main loop: process beads group by group.
 	for _, group := range groups {
@@ -151,19 +194,21 @@ func RunExecuteWithState(cfg config.Config, projectRoot string, runDir string, b
 		if shouldRunParallel(group, &cfg) {
 			// Parallel execution for this group.
 			if err := executeGroupParallel(
-				&cfg, group, allBeads, pool, projectRoot, branchName, runDir,
+				ctx, &cfg, group, allBeads, pool, projectRoot, branchName, runDir,
 				kgClient, logger, systemPrompt, verbose,
-				&completedBeads, &failedBeads, retryCount, breaker, outputChan,
+				progress, snapshots, states, outputChan, pause,
 			); err != nil {
+				runPostRunHook(cfg, projectRoot, runDir, branchName, pool, err, logger)
 				return err
 			}
 		} else {
 			// Sequential execution for this group.
 			if err := executeGroupSequential(
-				&cfg, group, allBeads, pool, projectRoot, branchName, runDir,
+				ctx, &cfg, group, allBeads, pool, projectRoot, branchName, runDir,
 				kgClient, logger, systemPrompt, verbose,
-				&completedBeads, &failedBeads, retryCount, breaker, outputChan,
+				progress, snapshots, states, outputChan, pause,
 			); err != nil {
+				runPostRunHook(cfg, projectRoot, runDir, branchName, pool, err, logger)
 				return err
 			}
 		}
@@ -176,44 +221,51 @@ func RunExecuteWithState(cfg config.Config, projectRoot string, runDir string, b
 	// 9. Log run_complete.
 	if logErr := logger.Append(log.LogEvent{
 		Event:     log.EventRunComplete,
-		Completed: pool.Completed,
-		Stuck:     pool.Stuck,
+		Completed: pool.GetCompleted(),
+		Stuck:     pool.GetStuck(),
+		Skipped:   pool.GetSkipped(),
 		Total:     pool.Total,
 	}); logErr != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to log run_complete: %v\n", logErr)
 	}
+	states.complete()
 
-	// 10. Clear checkpoint on successful completion.
-	if pool.Stuck == 0 && pool.Skipped == 0 {
+	// 10. Clear checkpoint and snapshot tags on successful completion.
+	if pool.GetStuck() == 0 && pool.GetSkipped() == 0 {
 		if err := ClearCheckpoint(runDir); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to clear checkpoint: %v\n", err)
 		}
+		snapshots.cleanup()
 	}
 
 	fmt.Printf("Execution complete: %d completed, %d stuck, %d skipped out of %d total\n",
-		pool.Completed, pool.Stuck, pool.Skipped, pool.Total)
+		pool.GetCompleted(), pool.GetStuck(), pool.GetSkipped(), pool.Total)
+
+	runPostRunHook(cfg, projectRoot, runDir, branchName, pool, nil, logger)
 
 	// Send execution_complete event to TUI.
 	if outputChan != nil {
 		outputChan <- StreamEvent{
 			Type:    "execution_complete",
-			Content: fmt.Sprintf("%d completed, %d stuck, %d skipped", pool.Completed, pool.Stuck, pool.Skipped),
+			Content: fmt.Sprintf("%d completed, %d stuck, %d skipped", pool.GetCompleted(), pool.GetStuck(), pool.GetSkipped()),
 		}
 	}
 
-	return nil
+	return pool.Outcome()
 }
 
 // saveCheckpointState is a helper function that saves checkpoint state.
 // Errors are logged but not returned since checkpoint is best-effort.
-func saveCheckpointState(runDir, runID, currentBeadID string, completedBeads, failedBeads []string, retryCount map[string]int, consecFailures int, lastError string) {
+func saveCheckpointState(runDir, runID string, plannedBeads []string, currentBeadID string, completedBeads, failedBeads []string, retryCount map[string]int, consecFailures, tokensUsed int, lastError string) {
 	cp := &Checkpoint{
 		RunID:          runID,
+		Beads:          plannedBeads,
 		CurrentBeadID:  currentBeadID,
 		CompletedBeads: completedBeads,
 		FailedBeads:    failedBeads,
 		RetryCount:     retryCount,
 		ConsecFailures: consecFailures,
+		TokensUsed:     tokensUsed,
 		LastError:      lastError,
 	}
 	if err := SaveCheckpoint(runDir, cp); err != nil {
@@ -221,6 +273,15 @@ func saveCheckpointState(runDir, runID, currentBeadID string, completedBeads, fa
 	}
 }
 
+// beadIDs returns the IDs of bs in order.
+func beadIDs(bs []beads.Bead) []string {
+	ids := make([]string, len(bs))
+	for i, b := range bs {
+		ids[i] = b.ID
+	}
+	return ids
+}
+
 // shouldRunParallel determines whether a group should run in parallel based on
 // config settings and group characteristics.
 func shouldRunParallel(group ExecutionGroup, cfg *config.Config) bool {
@@ -237,6 +298,7 @@ func shouldRunParallel(group ExecutionGroup, cfg *config.Config) bool {
 // executeGroupParallel runs all beads in a group concurrently, merges results,
 // and handles any conflicts.
 func executeGroupParallel(
+	ctx context.Context,
 	cfg *config.Config,
 	group ExecutionGroup,
 	allBeads []beads.Bead,
@@ -248,28 +310,53 @@ func executeGroupParallel(
 	logger *log.Logger,
 	systemPrompt string,
 	verbose bool,
-	completedBeads *[]string,
-	failedBeads *[]string,
-	retryCount map[string]int,
-	breaker *CircuitBreaker,
+	progress *runProgress,
+	snapshots *snapshotter,
+	states *beadStateRecorder,
 	outputChan chan<- StreamEvent,
+	pause *pauseGate,
 ) error {
 	fmt.Printf("Executing group %d with %d beads in parallel\n", group.Index, len(group.BeadIDs))
 
-	// Log task_started for all beads in the group.
+	// Log task_started for all beads in the group, skipping those whose work
+	// is already present in the tree.
+	var pending []string
 	for _, beadID := range group.BeadIDs {
 		bead := GetBeadByID(allBeads, beadID)
 		if bead == nil {
 			continue
 		}
+		// Load sidecar meta so it is carried through to task_completed.
+		if meta, metaErr := beads.ReadBeadMeta(projectRoot, beadID); metaErr == nil {
+			if len(bead.Files) == 0 && len(meta.Files) > 0 {
+				bead.Files = meta.Files
+			}
+			bead.Meta = meta.Meta
+			bead.NoFiles = meta.NoFiles
+		}
+		if beads.IsApplied(projectRoot, bead) {
+			fmt.Printf("%s %s: %s (already applied, skipping)\n", pool.Progress(), beadID, bead.Title)
+			onBeadAlreadyApplied(bead, logger)
+			states.end(beadID, session.BeadCompleted, 0)
+			pool.RecordCompletion()
+			progress.complete(beadID)
+			if outputChan != nil {
+				outputChan <- StreamEvent{Type: "bead_complete", BeadID: beadID}
+			}
+			progress.save(runDir, branchName, beadIDs(allBeads), beadID, "")
+			continue
+		}
+		pending = append(pending, beadID)
 		// Mark bead as in_progress.
 		if err := beads.UpdateStatus(beadID, "in_progress"); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to update bead %s status: %v\n", beadID, err)
 		}
+		states.begin(beadID)
 		if logErr := logger.Append(log.LogEvent{
 			Event:  log.EventTaskStarted,
 			BeadID: beadID,
 			Title:  bead.Title,
+			Meta:   bead.Meta,
 		}); logErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to log task_started: %v\n", logErr)
 		}
@@ -280,18 +367,47 @@ func executeGroupParallel(
 			outputChan <- StreamEvent{Type: "bead_init", BeadID: beadID}
 		}
 	}
+	if len(pending) == 0 {
+		return nil
+	}
+	group.BeadIDs = pending
+
+	// Run the group in batches of at most MaxParallel beads, merging each
+	// batch before the next starts so no more than MaxParallel worktrees
+	// exist at once.
+	// RunParallel streams OutputEvents; their output is relayed to outputChan.
+	maxParallel := cfg.Execution.MaxParallel
+	if maxParallel <= 0 {
+		maxParallel = 5
+	}
+	batches := SplitGroup(group, maxParallel)
+	var results []ParallelResult
+	var conflicts []git.MergeConflict
+	for i, batch := range batches {
+		pause.wait(ctx)
+		if len(batches) > 1 {
+			fmt.Printf("  Batch %d/%d: %s\n", i+1, len(batches), strings.Join(batch.BeadIDs, ", "))
+		}
+		progress.begin(batch.BeadIDs[0])
+		var events chan<- OutputEvent
+		stopEvents := func() {}
+		if outputChan != nil {
+			events, stopEvents = forwardParallelEvents(outputChan)
+		}
+		batchResults := RunParallel(ctx, batch, projectRoot, cfg, kgClient, systemPrompt, events)
+		stopEvents()
+		if ctx.Err() != nil {
+			// Interrupted: the batch's beads rerun on resume.
+			return progress.interrupted(runDir, branchName, beadIDs(allBeads), batch.BeadIDs[0])
+		}
 
-	// Create context for parallel execution.
-	ctx := context.Background()
-
-	// Run all beads in the group in parallel.
-	// Note: RunParallel uses OutputEvent for streaming; the outputChan here is for higher-level events.
-	results := RunParallel(ctx, group, projectRoot, cfg, kgClient, systemPrompt, nil)
-
-	// Merge results into the target branch.
-	conflicts, mergeErr := MergeParallelResults(projectRoot, branchName, results)
-	if mergeErr != nil {
-		return fmt.Errorf("merging parallel results: %w", mergeErr)
+		// Merge results into the target branch.
+		batchConflicts, mergeErr := MergeParallelResults(cfg, projectRoot, branchName, batchResults)
+		if mergeErr != nil {
+			return fmt.Errorf("merging parallel results: %w", mergeErr)
+		}
+		results = append(results, batchResults...)
+		conflicts = append(conflicts, batchConflicts...)
 	}
 
 	// Handle conflicts if any.
@@ -310,12 +426,14 @@ func executeGroupParallel(
 					fmt.Fprintf(os.Stderr, "Error handling stuck bead %s: %v\n", conflict.BeadID, stuckErr)
 				}
 				if action.Action == stuckActionAbort {
-					saveCheckpointState(runDir, branchName, conflict.BeadID, *completedBeads, *failedBeads, retryCount, breaker.GetConsecutiveFailures(), "merge conflict")
-					return fmt.Errorf("run aborted at bead %s due to unresolved merge conflict", conflict.BeadID)
+					states.end(conflict.BeadID, session.BeadFailed, 0)
+					progress.save(runDir, branchName, beadIDs(allBeads), conflict.BeadID, "merge conflict")
+					return fmt.Errorf("%w at bead %s due to unresolved merge conflict", ErrAborted, conflict.BeadID)
 				}
+				states.end(conflict.BeadID, session.BeadFailed, 0)
 				pool.RecordStuck()
-				*failedBeads = append(*failedBeads, conflict.BeadID)
-				breaker.RecordFailure()
+				progress.fail(conflict.BeadID)
+				progress.breaker.RecordFailure()
 			}
 		}
 	}
@@ -326,22 +444,37 @@ func executeGroupParallel(
 
 	// Process results and update progress.
 	for _, result := range results {
+		progress.addTokens(result.Tokens)
 		bead := GetBeadByID(allBeads, result.BeadID)
 		if bead == nil {
 			continue
 		}
-
-		if result.Passed {
+		bead.AttemptsUsed = result.AttemptsUsed
+		progress.setAttempts(result.BeadID, result.AttemptsUsed)
+
+		if len(result.Protected) > 0 {
+			// Its merge was undone; leave it stuck for a human to review.
+			markProtectedStuck(bead, result.Protected, logger)
+			states.end(result.BeadID, session.BeadFailed, result.Tokens)
+			pool.RecordStuck()
+			progress.fail(result.BeadID)
+			progress.breaker.RecordFailure()
+			if outputChan != nil {
+				outputChan <- StreamEvent{Type: "error", BeadID: result.BeadID, Content: protectedFileReason}
+			}
+		} else if result.Passed {
 			// Determine close reason from output.
 			closeReason := beads.ExtractSummary(result.ClaudeOutput, bead.Title)
 
 			// Handle success (commit metadata, close bead, log).
+			bead.Commits = result.Commits
 			if err := onBeadSuccess(bead, kgClient, projectRoot, logger, systemPrompt, closeReason); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: post-success steps failed for bead %s: %v\n", result.BeadID, err)
 			}
+			states.end(result.BeadID, session.BeadCompleted, result.Tokens)
 			pool.RecordCompletion()
-			*completedBeads = append(*completedBeads, result.BeadID)
-			breaker.RecordSuccess()
+			progress.complete(result.BeadID)
+			progress.breaker.RecordSuccess()
 
 			// Send bead_complete event to TUI.
 			if outputChan != nil {
@@ -376,6 +509,11 @@ func executeGroupParallel(
 					outputChan <- StreamEvent{Type: "error", BeadID: result.BeadID, Content: errMsg}
 				}
 
+				// A hint or rescue commits on the target branch itself.
+				stuckBase, err := git.HeadSHA()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to read HEAD before handling stuck bead %s: %v\n", result.BeadID, err)
+				}
 				action, stuckErr := HandleStuck(*cfg, bead, nil, errMsg, "", projectRoot)
 				if stuckErr != nil {
 					fmt.Fprintf(os.Stderr, "Error handling stuck bead %s: %v\n", result.BeadID, stuckErr)
@@ -383,28 +521,33 @@ func executeGroupParallel(
 
 				switch action.Action {
 				case stuckActionSkip:
+					states.end(result.BeadID, session.BeadSkipped, result.Tokens)
 					pool.RecordSkip()
-					*failedBeads = append(*failedBeads, result.BeadID)
-					breaker.RecordFailure()
+					progress.fail(result.BeadID)
+					progress.breaker.RecordFailure()
 				case stuckActionAbort:
-					saveCheckpointState(runDir, branchName, result.BeadID, *completedBeads, *failedBeads, retryCount, breaker.GetConsecutiveFailures(), errMsg)
-					return fmt.Errorf("run aborted at bead %s", result.BeadID)
+					states.end(result.BeadID, session.BeadFailed, result.Tokens)
+					progress.save(runDir, branchName, beadIDs(allBeads), result.BeadID, errMsg)
+					return fmt.Errorf("%w at bead %s", ErrAborted, result.BeadID)
 				case stuckActionRescue, stuckActionHint:
+					recordBeadCommits(bead, stuckBase)
 					if err := onBeadSuccess(bead, kgClient, projectRoot, logger, systemPrompt); err != nil {
 						fmt.Fprintf(os.Stderr, "Warning: post-rescue steps failed for bead %s: %v\n", result.BeadID, err)
 					}
+					states.end(result.BeadID, session.BeadCompleted, result.Tokens)
 					pool.RecordCompletion()
-					*completedBeads = append(*completedBeads, result.BeadID)
-					breaker.RecordSuccess()
+					progress.complete(result.BeadID)
+					progress.breaker.RecordSuccess()
 
 					// Send bead_complete event for rescued beads.
 					if outputChan != nil {
 						outputChan <- StreamEvent{Type: "bead_complete", BeadID: result.BeadID}
 					}
 				default:
+					states.end(result.BeadID, session.BeadFailed, result.Tokens)
 					pool.RecordStuck()
-					*failedBeads = append(*failedBeads, result.BeadID)
-					breaker.RecordFailure()
+					progress.fail(result.BeadID)
+					progress.breaker.RecordFailure()
 				}
 			}
 		}
@@ -417,24 +560,30 @@ func executeGroupParallel(
 		}
 	}
 
+	snapshots.maybeSnapshot(pool.GetCompleted())
+
 	// Save checkpoint after group completion.
 	var lastBeadID string
 	if len(group.BeadIDs) > 0 {
 		lastBeadID = group.BeadIDs[len(group.BeadIDs)-1]
 	}
-	saveCheckpointState(runDir, branchName, lastBeadID, *completedBeads, *failedBeads, retryCount, breaker.GetConsecutiveFailures(), "")
+	if used := progress.tokens(); overBudget(cfg, used) {
+		progress.save(runDir, branchName, beadIDs(allBeads), lastBeadID, errBudgetExceeded.Error())
+		return budgetError(cfg, used)
+	}
+	progress.save(runDir, branchName, beadIDs(allBeads), lastBeadID, "")
 
 	// Check circuit breaker.
-	if breaker.ShouldPause() {
-		action, err := handleCircuitBreakerPause(breaker, pool)
+	if progress.breaker.ShouldPause() {
+		action, err := resolveCircuitBreaker(ctx, cfg, progress.breaker, pool, logger)
 		if err != nil {
 			return fmt.Errorf("circuit breaker pause error: %w", err)
 		}
 		switch action {
 		case "abort":
-			return fmt.Errorf("run aborted by circuit breaker")
+			return fmt.Errorf("%w by circuit breaker", ErrAborted)
 		case "skip", "retry":
-			breaker.Reset()
+			progress.breaker.Reset()
 		}
 	}
 
@@ -443,6 +592,7 @@ func executeGroupParallel(
 
 // executeGroupSequential runs beads in a group one at a time (original behavior).
 func executeGroupSequential(
+	ctx context.Context,
 	cfg *config.Config,
 	group ExecutionGroup,
 	allBeads []beads.Bead,
@@ -454,24 +604,44 @@ func executeGroupSequential(
 	logger *log.Logger,
 	systemPrompt string,
 	verbose bool,
-	completedBeads *[]string,
-	failedBeads *[]string,
-	retryCount map[string]int,
-	breaker *CircuitBreaker,
+	progress *runProgress,
+	snapshots *snapshotter,
+	states *beadStateRecorder,
 	outputChan chan<- StreamEvent,
+	pause *pauseGate,
 ) error {
 	for _, beadID := range group.BeadIDs {
+		pause.wait(ctx)
+		if ctx.Err() != nil {
+			return progress.interrupted(runDir, branchName, beadIDs(allBeads), progress.current())
+		}
 		task := GetBeadByID(allBeads, beadID)
 		if task == nil {
 			continue
 		}
 
-		// Load sidecar metadata (files, verify_extra) from the plan phase.
+		// Load sidecar metadata (files, verify_extra, meta) from the plan phase.
 		if meta, metaErr := beads.ReadBeadMeta(projectRoot, task.ID); metaErr == nil {
 			if len(task.Files) == 0 && len(meta.Files) > 0 {
 				task.Files = meta.Files
 			}
 			task.VerifyExtra = meta.VerifyExtra
+			task.Meta = meta.Meta
+			task.NoFiles = meta.NoFiles
+		}
+
+		// Skip beads whose work is already present in the tree.
+		if beads.IsApplied(projectRoot, task) {
+			fmt.Printf("%s %s: %s (already applied, skipping)\n", pool.Progress(), task.ID, task.Title)
+			onBeadAlreadyApplied(task, logger)
+			states.end(task.ID, session.BeadCompleted, 0)
+			pool.RecordCompletion()
+			progress.complete(task.ID)
+			if outputChan != nil {
+				outputChan <- StreamEvent{Type: "bead_complete", BeadID: task.ID}
+			}
+			progress.save(runDir, branchName, beadIDs(allBeads), task.ID, "")
+			continue
 		}
 
 		// Ensure KG MCP is alive for this bead.
@@ -488,12 +658,14 @@ func executeGroupSequential(
 		if err := beads.UpdateStatus(task.ID, "in_progress"); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to update bead %s status: %v\n", task.ID, err)
 		}
+		states.begin(task.ID)
 
 		// Log task_started.
 		if logErr := logger.Append(log.LogEvent{
 			Event:  log.EventTaskStarted,
 			BeadID: task.ID,
 			Title:  task.Title,
+			Meta:   task.Meta,
 		}); logErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to log task_started: %v\n", logErr)
 		}
@@ -506,36 +678,95 @@ func executeGroupSequential(
 		// Print progress.
 		fmt.Printf("%s %s: %s (attempt 1)...\n", pool.Progress(), task.ID, task.Title)
 
-		// Pre-embed graph data for this bead's files.
-		graphData := preEmbedGraphData(kgClient, task.Files)
+		// Pre-embed graph data (and git history, when enabled) for this
+		// bead's files, skipping binaries. A bead without files only gets
+		// a warning when it didn't declare "files: none".
+		if len(task.Files) == 0 && !task.NoFiles {
+			fmt.Fprintf(os.Stderr, "Warning: bead %s declares no files; running without graph context\n", task.ID)
+		}
+		graphData := embedBeadContext(*cfg, kgClient, projectRoot, task)
+
+		// Remember HEAD so the bead's commits and changed files can be
+		// identified once it finishes.
+		baseRef, err := git.HeadSHA()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read HEAD before bead %s: %v\n", task.ID, err)
+		}
 
-		// Execute with retry logic.
+		// Execute with retry logic, in the bead's own worktree when
+		// sequential_worktrees is set.
+		worktreePath := startSequentialWorktree(cfg, projectRoot, task.ID)
 		opts := &SpawnClaudeOpts{
 			Verbose:    verbose,
 			OutputChan: outputChan,
 			BeadID:     task.ID,
+			WorkDir:    worktreePath,
+		}
+		progress.begin(task.ID)
+		beadResult, retryErr := RetryBead(ctx, *cfg, task, graphData, projectRoot, logger, kgClient, opts)
+		if beadResult != nil {
+			progress.setAttempts(task.ID, beadResult.AttemptsUsed)
+		}
+		if worktreePath != "" {
+			// Merge before the checks below so they see the bead's commits
+			// on the run branch; a bead that failed is discarded.
+			passed := beadResult != nil && beadResult.Passed && ctx.Err() == nil
+			if err := finishSequentialWorktree(projectRoot, task.ID, branchName, passed); err != nil {
+				beadResult.Passed = false
+				retryErr = fmt.Errorf("merging worktree for bead %s: %w", task.ID, err)
+			}
+		}
+		if ctx.Err() != nil {
+			// Interrupted: leave the bead in progress so resume reruns it.
+			return progress.interrupted(runDir, branchName, beadIDs(allBeads), task.ID)
 		}
-		beadResult, retryErr := RetryBead(*cfg, task, graphData, projectRoot, logger, kgClient, opts)
 		if retryErr != nil {
 			fmt.Fprintf(os.Stderr, "Error during bead %s execution: %v\n", task.ID, retryErr)
 		}
 
 		// Extract summary from Claude's output for close reason.
 		var claudeOutput string
+		var tokens int
 		if beadResult != nil {
 			claudeOutput = beadResult.ClaudeOutput
+			task.AttemptsUsed = beadResult.AttemptsUsed
+			tokens = beadResult.Tokens
 		}
 		closeReason := beads.ExtractSummary(claudeOutput, task.Title)
 
 		var lastError string
+		var touchedProtected []string
 		if beadResult != nil && beadResult.Passed {
+			touched, err := rejectProtectedChanges(cfg, task.ID, baseRef)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: protected file check failed for bead %s: %v\n", task.ID, err)
+			}
+			touchedProtected = touched
+		}
+
+		if len(touchedProtected) > 0 {
+			// Bead passed verification but modified a protected file: its
+			// changes are discarded and it is left stuck for a human to review.
+			markProtectedStuck(task, touchedProtected, logger)
+			states.end(task.ID, session.BeadFailed, tokens)
+			pool.RecordStuck()
+			progress.fail(task.ID)
+			progress.breaker.RecordFailure()
+			lastError = protectedFileReason
+
+			if outputChan != nil {
+				outputChan <- StreamEvent{Type: "error", BeadID: task.ID, Content: protectedFileReason}
+			}
+		} else if beadResult != nil && beadResult.Passed {
 			// Bead succeeded: commit, close, record learning, reindex.
+			recordBeadCommits(task, baseRef)
 			if err := onBeadSuccess(task, kgClient, projectRoot, logger, systemPrompt, closeReason); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: post-success steps failed for bead %s: %v\n", task.ID, err)
 			}
+			states.end(task.ID, session.BeadCompleted, tokens)
 			pool.RecordCompletion()
-			*completedBeads = append(*completedBeads, task.ID)
-			breaker.RecordSuccess()
+			progress.complete(task.ID)
+			progress.breaker.RecordSuccess()
 
 			// Send bead_complete event to TUI.
 			if outputChan != nil {
@@ -553,7 +784,13 @@ func executeGroupSequential(
 				outputChan <- StreamEvent{Type: "error", BeadID: task.ID, Content: errMsg}
 			}
 
-			action, stuckErr := HandleStuck(*cfg, task, nil, "", graphData, projectRoot)
+			// A timed-out bead shows why it stopped in the stuck menu.
+			var stuckReason string
+			if errors.Is(retryErr, context.DeadlineExceeded) {
+				stuckReason = errMsg
+			}
+
+			action, stuckErr := HandleStuck(*cfg, task, nil, stuckReason, graphData, projectRoot)
 			if stuckErr != nil {
 				fmt.Fprintf(os.Stderr, "Error handling stuck bead %s: %v\n", task.ID, stuckErr)
 				lastError = stuckErr.Error()
@@ -561,55 +798,82 @@ func executeGroupSequential(
 
 			switch action.Action {
 			case stuckActionSkip:
+				states.end(task.ID, session.BeadSkipped, tokens)
 				pool.RecordSkip()
-				*failedBeads = append(*failedBeads, task.ID)
-				breaker.RecordFailure()
+				progress.fail(task.ID)
+				progress.breaker.RecordCategorizedFailure(classifyFailure(retryErr))
 			case stuckActionAbort:
-				saveCheckpointState(runDir, branchName, task.ID, *completedBeads, *failedBeads, retryCount, breaker.GetConsecutiveFailures(), "aborted by user")
+				states.end(task.ID, session.BeadFailed, tokens)
+				progress.save(runDir, branchName, beadIDs(allBeads), task.ID, "aborted by user")
 				if logErr := logger.Append(log.LogEvent{
-					Event:  log.EventRunComplete,
-					Reason: "aborted",
-					Total:  pool.Total,
+					Event:     log.EventRunComplete,
+					Reason:    "aborted",
+					Completed: pool.GetCompleted(),
+					Stuck:     pool.GetStuck(),
+					Skipped:   pool.GetSkipped(),
+					Total:     pool.Total,
 				}); logErr != nil {
 					fmt.Fprintf(os.Stderr, "Warning: failed to log run_complete: %v\n", logErr)
 				}
-				return fmt.Errorf("run aborted at bead %s", task.ID)
+				return fmt.Errorf("%w at bead %s", ErrAborted, task.ID)
 			case stuckActionRescue:
+				recordBeadCommits(task, baseRef)
 				if err := onBeadSuccess(task, kgClient, projectRoot, logger, systemPrompt); err != nil {
 					fmt.Fprintf(os.Stderr, "Warning: post-rescue steps failed for bead %s: %v\n", task.ID, err)
 				}
+				states.end(task.ID, session.BeadCompleted, tokens)
 				pool.RecordCompletion()
-				*completedBeads = append(*completedBeads, task.ID)
-				breaker.RecordSuccess()
+				progress.complete(task.ID)
+				progress.breaker.RecordSuccess()
 
 				// Send bead_complete event for rescued beads.
 				if outputChan != nil {
 					outputChan <- StreamEvent{Type: "bead_complete", BeadID: task.ID}
 				}
 			case stuckActionHint:
+				recordBeadCommits(task, baseRef)
 				if err := onBeadSuccess(task, kgClient, projectRoot, logger, systemPrompt); err != nil {
 					fmt.Fprintf(os.Stderr, "Warning: post-hint steps failed for bead %s: %v\n", task.ID, err)
 				}
+				states.end(task.ID, session.BeadCompleted, tokens)
 				pool.RecordCompletion()
-				*completedBeads = append(*completedBeads, task.ID)
-				breaker.RecordSuccess()
+				progress.complete(task.ID)
+				progress.breaker.RecordSuccess()
 
 				// Send bead_complete event for hint-resolved beads.
 				if outputChan != nil {
 					outputChan <- StreamEvent{Type: "bead_complete", BeadID: task.ID}
 				}
 			default:
+				states.end(task.ID, session.BeadFailed, tokens)
 				pool.RecordStuck()
-				*failedBeads = append(*failedBeads, task.ID)
-				breaker.RecordFailure()
+				progress.fail(task.ID)
+				progress.breaker.RecordCategorizedFailure(classifyFailure(retryErr))
+			}
+		}
+
+		// Stop once the run has used up its token budget.
+		if used := progress.addTokens(tokens); overBudget(cfg, used) {
+			progress.save(runDir, branchName, beadIDs(allBeads), task.ID, errBudgetExceeded.Error())
+			if logErr := logger.Append(log.LogEvent{
+				Event:     log.EventRunComplete,
+				Reason:    errBudgetExceeded.Error(),
+				Completed: pool.GetCompleted(),
+				Stuck:     pool.GetStuck(),
+				Skipped:   pool.GetSkipped(),
+				Total:     pool.Total,
+				Tokens:    used,
+			}); logErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to log run_complete: %v\n", logErr)
 			}
+			return budgetError(cfg, used)
 		}
 
 		// Check if circuit breaker should pause execution.
-		if breaker.ShouldPause() {
-			saveCheckpointState(runDir, branchName, task.ID, *completedBeads, *failedBeads, retryCount, breaker.GetConsecutiveFailures(), lastError)
+		if progress.breaker.ShouldPause() {
+			progress.save(runDir, branchName, beadIDs(allBeads), task.ID, lastError)
 
-			action, err := handleCircuitBreakerPause(breaker, pool)
+			action, err := resolveCircuitBreaker(ctx, cfg, progress.breaker, pool, logger)
 			if err != nil {
 				return fmt.Errorf("circuit breaker pause error: %w", err)
 			}
@@ -617,24 +881,29 @@ func executeGroupSequential(
 			switch action {
 			case "abort":
 				if logErr := logger.Append(log.LogEvent{
-					Event:  log.EventRunComplete,
-					Reason: "aborted by circuit breaker",
-					Total:  pool.Total,
+					Event:     log.EventRunComplete,
+					Reason:    "aborted by circuit breaker",
+					Completed: pool.GetCompleted(),
+					Stuck:     pool.GetStuck(),
+					Skipped:   pool.GetSkipped(),
+					Total:     pool.Total,
 				}); logErr != nil {
 					fmt.Fprintf(os.Stderr, "Warning: failed to log run_complete: %v\n", logErr)
 				}
-				return fmt.Errorf("run aborted by circuit breaker after %d consecutive failures", cfg.Execution.CircuitBreakerThreshold)
+				return fmt.Errorf("%w by circuit breaker after %d consecutive failures", ErrAborted, cfg.Execution.CircuitBreakerThreshold)
 			case "skip":
-				breaker.Reset()
+				progress.breaker.Reset()
 				fmt.Println("Circuit breaker reset. Continuing with remaining beads...")
 			case "retry":
-				breaker.Reset()
+				progress.breaker.Reset()
 				fmt.Println("Circuit breaker reset. Retrying...")
 			}
 		}
 
+		snapshots.maybeSnapshot(pool.GetCompleted())
+
 		// Save checkpoint after each bead completion/failure.
-		saveCheckpointState(runDir, branchName, task.ID, *completedBeads, *failedBeads, retryCount, breaker.GetConsecutiveFailures(), lastError)
+		progress.save(runDir, branchName, beadIDs(allBeads), task.ID, lastError)
 
 		if pool.IsComplete() {
 			break
@@ -678,28 +947,55 @@ func onBeadSuccess(task *beads.Bead, kgClient *graph.Client, projectRoot string,
 		return fmt.Errorf("closing bead %s: %w", task.ID, err)
 	}
 
+	// Record the idempotency marker so a resume or replay doesn't re-apply it.
+	if err := beads.RecordApplied(projectRoot, task); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record idempotency marker for bead %s: %v\n", task.ID, err)
+	}
+
 	// Append learning.
 	if err := berthcontext.AppendLearning(projectRoot, "Completed: "+task.Title); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to append learning for bead %s: %v\n", task.ID, err)
 	}
 
-	// Reindex changed files in the KG.
-	if kgClient != nil && len(task.Files) > 0 {
-		if err := graph.ReindexChanged(kgClient, task.Files); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to reindex after bead %s: %v\n", task.ID, err)
-		}
+	// Reindex changed files in the KG, dropping deleted and renamed ones.
+	if kgClient != nil {
+		reindexBead(task, kgClient)
 	}
 
 	// Log completion.
+	if logErr := logger.Append(log.LogEvent{
+		Event:   log.EventTaskCompleted,
+		BeadID:  task.ID,
+		Title:   task.Title,
+		Attempt: task.AttemptsUsed,
+		Commits: task.Commits,
+		Meta:    task.Meta,
+	}); logErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to log task_completed: %v\n", logErr)
+	}
+
+	return nil
+}
+
+// onBeadAlreadyApplied closes a bead whose idempotency marker shows its work
+// is already applied, without spawning Claude. Closing is best-effort since
+// the bead may already be closed from the earlier run.
+func onBeadAlreadyApplied(task *beads.Bead, logger *log.Logger) {
+	if err := beads.Close(task.ID, "already applied"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to close already-applied bead %s: %v\n", task.ID, err)
+	}
+	if logger == nil {
+		return
+	}
 	if logErr := logger.Append(log.LogEvent{
 		Event:  log.EventTaskCompleted,
 		BeadID: task.ID,
 		Title:  task.Title,
+		Reason: "already applied",
+		Meta:   task.Meta,
 	}); logErr != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to log task_completed: %v\n", logErr)
 	}
-
-	return nil
 }
 
 // readSystemPrompt reads system prompts and combines them.
@@ -726,9 +1022,10 @@ func readSystemPrompt(projectRoot string) (string, error) {
 }
 
 // preEmbedGraphData queries the KG client for data about the bead's files
-// and formats it as a markdown section. Returns an empty string if KG is
-// unavailable or has no data.
-func preEmbedGraphData(kgClient *graph.Client, files []string) string {
+// and formats it as a markdown section, tracing call paths from the entry
+// points of lang. Returns an empty string if KG is unavailable or has no
+// data.
+func preEmbedGraphData(kgClient *graph.Client, lang string, files []string) string {
 	if kgClient == nil || len(files) == 0 {
 		return ""
 	}
@@ -749,6 +1046,7 @@ func preEmbedGraphData(kgClient *graph.Client, files []string) string {
 			Importers:  understanding.Importers,
 			Callers:    make(map[string][]graph.CallerResult),
 			TypeUsages: make(map[string][]graph.TypeUsageResult),
+			CallPaths:  make(map[string][]graph.CallPathStep),
 		}
 
 		// Query callers for each exported function.
@@ -779,6 +1077,28 @@ func preEmbedGraphData(kgClient *graph.Client, files []string) string {
 		return ""
 	}
 
+	// Trace how the exported functions are reached from the entry points,
+	// in one search for all files.
+	if entries := graph.EntryPoints(lang); len(entries) > 0 {
+		var targets []string
+		for _, fgd := range graphFiles {
+			for _, exp := range fgd.Exports {
+				if exp.Kind == "function" && !slices.Contains(entries, exp.Name) {
+					targets = append(targets, exp.Name)
+				}
+			}
+		}
+		if paths, err := kgClient.TraceCallPaths(entries, targets); err == nil {
+			for i := range graphFiles {
+				for _, exp := range graphFiles[i].Exports {
+					if path, ok := paths[exp.Name]; ok && exp.Kind == "function" {
+						graphFiles[i].CallPaths[exp.Name] = path
+					}
+				}
+			}
+		}
+	}
+
 	// Impact analysis for the bead's file set.
 	// AnalyzeImpact takes a single file path, so call per-file and merge
 	// with deduplication (multiple files may share dependents).
@@ -826,6 +1146,68 @@ func preEmbedGraphData(kgClient *graph.Client, files []string) string {
 	return graph.FormatGraphData(data)
 }
 
+// resolveCircuitBreaker decides how to proceed once the circuit breaker has
+// tripped: the "cooldown" policy waits and resumes unattended, "abort" stops
+// the run, otherwise the user is prompted. Returns "retry", "skip", or "abort".
+func resolveCircuitBreaker(ctx context.Context, cfg *config.Config, breaker *CircuitBreaker, pool *ExecutionPool, logger *log.Logger) (string, error) {
+	switch cfg.Execution.CircuitBreakerPolicy {
+	case "cooldown":
+	case "abort":
+		fmt.Printf("Circuit breaker triggered: %d consecutive failures. Aborting.\n", breaker.GetConsecutiveFailures())
+		return "abort", nil
+	default:
+		return handleCircuitBreakerPause(breaker, pool)
+	}
+
+	wait := time.Duration(cfg.Execution.CircuitBreakerCooldown) * time.Second
+	if wait <= 0 {
+		wait = 60 * time.Second
+	}
+	maxCooldowns := cfg.Execution.CircuitBreakerMaxCooldowns
+	if maxCooldowns <= 0 {
+		maxCooldowns = 3
+	}
+	return coolDownBreaker(ctx, breaker, wait, maxCooldowns, logger), nil
+}
+
+// coolDownBreaker waits for wait and returns "retry" so execution resumes,
+// letting a run ride out a transient outage. Once maxCooldowns have been
+// taken, or when ctx is cancelled during the wait, it returns "abort"
+// instead, bounding the total time spent waiting.
+func coolDownBreaker(ctx context.Context, breaker *CircuitBreaker, wait time.Duration, maxCooldowns int, logger *log.Logger) string {
+	used := breaker.GetCooldowns()
+	if used >= maxCooldowns {
+		fmt.Printf("Circuit breaker triggered again after %d cooldowns. Aborting.\n", used)
+		return "abort"
+	}
+
+	fmt.Printf("Circuit breaker triggered: %d consecutive failures. Cooling down for %s (%d/%d)...\n",
+		breaker.GetConsecutiveFailures(), wait, used+1, maxCooldowns)
+	if logger != nil {
+		if logErr := logger.Append(log.LogEvent{
+			Event: log.EventCircuitBreakerCooldown,
+			Data: map[string]interface{}{
+				"cooldown":      used + 1,
+				"max_cooldowns": maxCooldowns,
+				"seconds":       wait.Seconds(),
+			},
+		}); logErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to log circuit_breaker_cooldown: %v\n", logErr)
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		fmt.Println("Cooldown interrupted. Aborting.")
+		return "abort"
+	}
+	breaker.RecordCooldown()
+	return "retry"
+}
+
 // handleCircuitBreakerPause presents the user with options when the circuit
 // breaker has triggered due to consecutive failures. Returns the user's
 // chosen action: "retry", "skip", or "abort".
@@ -835,7 +1217,7 @@ func handleCircuitBreakerPause(breaker *CircuitBreaker, pool *ExecutionPool) (st
 	fmt.Println()
 	fmt.Printf("Circuit breaker triggered: %d consecutive failures reached threshold.\n", breaker.ConsecutiveFailures)
 	fmt.Printf("Progress: %d completed, %d stuck, %d skipped out of %d total\n",
-		pool.Completed, pool.Stuck, pool.Skipped, pool.Total)
+		pool.GetCompleted(), pool.GetStuck(), pool.GetSkipped(), pool.Total)
 	fmt.Println()
 	fmt.Println("What do you want to do?")
 	fmt.Println()
@@ -1,12 +1,20 @@
 package execute
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	berthcontext "github.com/berth-dev/berth/internal/context"
+	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/testutil"
 )
 
 // Integration tests for the execution loop components working together.
@@ -411,6 +419,63 @@ func TestIntegrationCheckpointClearedOnSuccess(t *testing.T) {
 	}
 }
 
+// TestIntegrationLearningsAccumulateBetweenBeads tests that completing one
+// bead records a learning that reaches the prompt Claude gets for the next.
+func TestIntegrationLearningsAccumulateBetweenBeads(t *testing.T) {
+	root := initTestRepo(t)
+	fakeBD(t, "[]")
+	testutil.FakeCommand(t, "claude", "printf '%s\\n---\\n' \"$2\" >> prompts.txt\necho '{\"type\":\"result\",\"result\":\"feat: done\",\"is_error\":false}'\n")
+	logger, err := log.NewLogger(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bead1 := &beads.Bead{ID: "bt-1", Title: "Add user model"}
+	bead2 := &beads.Bead{ID: "bt-2", Title: "Add user handler"}
+	if err := onBeadSuccess(bead1, nil, root, logger, ""); err != nil {
+		t.Fatalf("onBeadSuccess: %v", err)
+	}
+
+	workDir := t.TempDir()
+	cfg := config.Config{VerifyPipeline: []string{"true"}}
+	result, err := RetryBead(context.Background(), cfg, bead2, "", root, logger, nil, &SpawnClaudeOpts{WorkDir: workDir})
+	if err != nil || !result.Passed {
+		t.Fatalf("RetryBead = %+v, %v; want passed", result, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, "prompts.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt := string(data); !strings.Contains(prompt, "Accumulated Learnings") || !strings.Contains(prompt, "Completed: Add user model") {
+		t.Errorf("second bead prompt should include the learning from the first bead:\n%s", prompt)
+	}
+}
+
+// TestIntegrationPromptLearningsAreBounded tests that only the most recent
+// learnings are embedded in the executor prompt.
+func TestIntegrationPromptLearningsAreBounded(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	total := maxPromptLearnings + 5
+	for i := 1; i <= total; i++ {
+		if err := berthcontext.AppendLearning(tmpDir, fmt.Sprintf("learning %d", i)); err != nil {
+			t.Fatalf("AppendLearning failed: %v", err)
+		}
+	}
+
+	learnings := promptLearnings(tmpDir)
+	if len(learnings) != maxPromptLearnings {
+		t.Fatalf("promptLearnings returned %d entries, want %d", len(learnings), maxPromptLearnings)
+	}
+	if learnings[0] != "learning 6" {
+		t.Errorf("oldest included learning = %q, want %q", learnings[0], "learning 6")
+	}
+	if learnings[len(learnings)-1] != fmt.Sprintf("learning %d", total) {
+		t.Errorf("newest included learning = %q, want %q", learnings[len(learnings)-1], fmt.Sprintf("learning %d", total))
+	}
+}
+
 // contains checks if s contains substr.
 func contains(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
@@ -420,3 +485,89 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+// TestIntegrationNoBeadsIsNoOp verifies that an empty bead list returns
+// cleanly without creating the run branch or writing a log.
+func TestIntegrationNoBeadsIsNoOp(t *testing.T) {
+	dir := initTestRepo(t)
+	testutil.FakeCommand(t, "bd", "echo '[]'\n")
+
+	cfg := *config.DefaultConfig()
+	for _, mode := range []string{"never", "always"} {
+		cfg.Execution.ParallelMode = mode
+		if err := RunExecute(cfg, dir, filepath.Join(dir, ".berth", "runs", "test"), "berth/empty", false); err != nil {
+			t.Fatalf("RunExecute (parallel_mode=%s): %v", mode, err)
+		}
+	}
+
+	out, err := exec.Command("git", "branch", "--list", "berth/empty").Output()
+	if err != nil {
+		t.Fatalf("git branch: %v", err)
+	}
+	if strings.TrimSpace(string(out)) != "" {
+		t.Errorf("expected no branch to be created, got %q", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".berth", "log.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("expected no run log, stat err = %v", err)
+	}
+}
+
+// appliedBead writes a.go in root and records bt-1 as already applied.
+func appliedBead(t *testing.T, root string) beads.Bead {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, "a.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bead := beads.Bead{ID: "bt-1", Title: "Add a", Files: []string{"a.go"}}
+	if err := beads.RecordApplied(root, &bead); err != nil {
+		t.Fatalf("RecordApplied: %v", err)
+	}
+	return bead
+}
+
+func TestGroupParallelSkipsAppliedBeads(t *testing.T) {
+	root := t.TempDir()
+	calls := fakeBD(t, "[]")
+	allBeads := []beads.Bead{appliedBead(t, root)}
+	pool := NewExecutionPool(1)
+	progress := newRunProgress(nil, NewCircuitBreaker(3))
+	cfg := config.DefaultConfig()
+
+	err := executeGroupParallel(context.Background(), cfg, ExecutionGroup{BeadIDs: []string{"bt-1"}}, allBeads,
+		pool, root, "berth/test", t.TempDir(), nil, nil, "", false, progress, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("executeGroupParallel: %v", err)
+	}
+	if pool.GetCompleted() != 1 {
+		t.Errorf("completed = %d, want the applied bead counted as completed", pool.GetCompleted())
+	}
+	data, _ := os.ReadFile(calls)
+	if !strings.Contains(string(data), "close bt-1") || strings.Contains(string(data), "in_progress") {
+		t.Errorf("bd calls = %q, want bt-1 closed without being started", data)
+	}
+}
+
+func TestSchedulerSkipsAppliedBeads(t *testing.T) {
+	root := t.TempDir()
+	calls := fakeBD(t, "[]")
+	allBeads := []beads.Bead{appliedBead(t, root)}
+	cfg := *config.DefaultConfig()
+
+	mq := NewMergeQueue(cfg, root, "main", allBeads, nil, nil, nil, "")
+	go mq.Start()
+	s := NewScheduler(cfg, root, allBeads, NewExecutionPool(1), nil, mq, nil, nil, nil, "", false)
+
+	s.wg.Add(1)
+	s.executeWorker(s.nodes["bt-1"])
+	result := <-mq.Results()
+	mq.Close()
+	mq.Wait()
+
+	if result.BeadID != "bt-1" || !result.Success {
+		t.Errorf("merge result = %+v, want bt-1 completed", result)
+	}
+	data, _ := os.ReadFile(calls)
+	if !strings.Contains(string(data), "close bt-1") || strings.Contains(string(data), "in_progress") {
+		t.Errorf("bd calls = %q, want bt-1 closed without being started", data)
+	}
+}
@@ -0,0 +1,152 @@
+// manifest.go builds the run manifest shown before execution starts: every
+// bead bd knows about and whether this run will execute it.
+package execute
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+// Dispositions of a bead in the run manifest.
+const (
+	DispositionWillRun   = "will-run"
+	DispositionClosed    = "skipped-already-closed"
+	DispositionCompleted = "skipped-completed-before-resume"
+	DispositionApplied   = "skipped-already-applied"
+	DispositionBlocked   = "blocked-by-dependency"
+)
+
+// ManifestEntry is one bead in the run manifest.
+type ManifestEntry struct {
+	BeadID      string
+	Title       string
+	Disposition string
+}
+
+// BuildManifest gives every bead in all, open and closed alike, its
+// disposition. Filters apply in the order the execution loop applies them:
+// closed beads, beads a restored checkpoint records as completed, then
+// beads whose work applied reports as already in the tree. Any other bead
+// that depends, directly or transitively, on a stuck or failed bead is
+// blocked: the run skips it once that dependency fails again. A nil
+// applied treats no bead as applied.
+func BuildManifest(all []beads.Bead, state *ExecuteState, applied func(*beads.Bead) bool) []ManifestEntry {
+	completed := make(map[string]bool)
+	if state != nil {
+		for _, id := range state.CompletedBeads {
+			completed[id] = true
+		}
+	}
+
+	byID := make(map[string]*beads.Bead, len(all))
+	for i := range all {
+		byID[all[i].ID] = &all[i]
+	}
+	blocked := make(map[string]bool)
+	visited := make(map[string]bool)
+	var isBlocked func(b *beads.Bead) bool
+	isBlocked = func(b *beads.Bead) bool {
+		if visited[b.ID] {
+			return blocked[b.ID]
+		}
+		visited[b.ID] = true
+		for _, depID := range b.DependsOn {
+			dep, ok := byID[depID]
+			if !ok || dep.Status == "closed" || dep.Status == "done" {
+				continue
+			}
+			if dep.Status == "stuck" || dep.Status == "failed" || isBlocked(dep) {
+				blocked[b.ID] = true
+				break
+			}
+		}
+		return blocked[b.ID]
+	}
+
+	entries := make([]ManifestEntry, 0, len(all))
+	for i := range all {
+		b := &all[i]
+		disposition := DispositionWillRun
+		switch {
+		case b.Status == "closed" || b.Status == "done":
+			disposition = DispositionClosed
+		case completed[b.ID]:
+			disposition = DispositionCompleted
+		case applied != nil && applied(b):
+			disposition = DispositionApplied
+		case isBlocked(b):
+			disposition = DispositionBlocked
+		}
+		entries = append(entries, ManifestEntry{BeadID: b.ID, Title: b.Title, Disposition: disposition})
+	}
+	return entries
+}
+
+// formatManifest renders the manifest as a header line and one line per bead.
+func formatManifest(entries []ManifestEntry) string {
+	willRun := 0
+	idWidth, dispWidth := 0, 0
+	for _, e := range entries {
+		if e.Disposition == DispositionWillRun {
+			willRun++
+		}
+		idWidth = max(idWidth, len(e.BeadID))
+		dispWidth = max(dispWidth, len(e.Disposition))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Run manifest: %d of %d beads will run\n", willRun, len(entries))
+	for _, e := range entries {
+		fmt.Fprintf(&b, "  %-*s  %-*s  %s\n", idWidth, e.BeadID, dispWidth, e.Disposition, e.Title)
+	}
+	return b.String()
+}
+
+// reportRunManifest prints the manifest for the beads about to be executed
+// and logs it as a run_manifest event. Closed beads come from bd list --all;
+// if that fails only the open beads are listed. Already-applied beads are
+// only detected in sequential mode, the only mode that skips them.
+func reportRunManifest(cfg config.Config, projectRoot string, open []beads.Bead, state *ExecuteState, sequential bool) {
+	all, err := beads.ListAll()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: listing closed beads for the run manifest: %v\n", err)
+		all = open
+	}
+
+	var applied func(*beads.Bead) bool
+	if sequential {
+		applied = func(b *beads.Bead) bool {
+			// Match the execution loop, which fills in files from the sidecar.
+			c := *b
+			if meta, metaErr := beads.ReadBeadMeta(projectRoot, c.ID); metaErr == nil && len(c.Files) == 0 {
+				c.Files = meta.Files
+			}
+			return beads.IsApplied(projectRoot, &c)
+		}
+	}
+
+	entries := BuildManifest(all, state, applied)
+	fmt.Print(formatManifest(entries))
+
+	logger, err := log.NewLoggerWithPrivacy(projectRoot, cfg.Log.Privacy())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to log run_manifest: %v\n", err)
+		return
+	}
+	dispositions := make(map[string]interface{}, len(entries))
+	for _, e := range entries {
+		dispositions[e.BeadID] = e.Disposition
+	}
+	if logErr := logger.Append(log.LogEvent{
+		Event: log.EventRunManifest,
+		Beads: len(entries),
+		Data:  map[string]interface{}{"beads": dispositions},
+	}); logErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to log run_manifest: %v\n", logErr)
+	}
+}
@@ -0,0 +1,58 @@
+package execute
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+)
+
+func TestBuildManifestDispositions(t *testing.T) {
+	all := []beads.Bead{
+		{ID: "bt-1", Title: "Add model", Status: "closed"},
+		{ID: "bt-2", Title: "Add handler", Status: "open"},
+		{ID: "bt-3", Title: "Add route", Status: "open"},
+		{ID: "bt-4", Title: "Add tests", Status: "stuck"},
+		{ID: "bt-5", Title: "Closed and applied", Status: "done"},
+		{ID: "bt-6", Title: "Add docs", Status: "open", DependsOn: []string{"bt-4"}},
+		{ID: "bt-7", Title: "Add changelog", Status: "open", DependsOn: []string{"bt-1", "bt-6"}},
+		{ID: "bt-8", Title: "Add example", Status: "open", DependsOn: []string{"bt-1", "bt-5"}},
+	}
+	state := &ExecuteState{CompletedBeads: []string{"bt-2"}}
+	applied := func(b *beads.Bead) bool { return b.ID == "bt-3" || b.ID == "bt-5" }
+
+	entries := BuildManifest(all, state, applied)
+	want := map[string]string{
+		"bt-1": DispositionClosed,
+		"bt-2": DispositionCompleted,
+		"bt-3": DispositionApplied,
+		"bt-4": DispositionWillRun,
+		"bt-5": DispositionClosed,
+		"bt-6": DispositionBlocked,
+		"bt-7": DispositionBlocked,
+		"bt-8": DispositionWillRun,
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for _, e := range entries {
+		if e.Disposition != want[e.BeadID] {
+			t.Errorf("%s: disposition %q, want %q", e.BeadID, e.Disposition, want[e.BeadID])
+		}
+	}
+
+	out := formatManifest(entries)
+	if !strings.HasPrefix(out, "Run manifest: 2 of 8 beads will run\n") {
+		t.Errorf("unexpected header:\n%s", out)
+	}
+	if !strings.Contains(out, "bt-4  will-run") || !strings.Contains(out, "Add tests") {
+		t.Errorf("manifest missing bt-4 line:\n%s", out)
+	}
+
+	// Without a checkpoint or applied check, every open bead runs.
+	for _, e := range BuildManifest(all, nil, nil) {
+		if e.BeadID == "bt-2" && e.Disposition != DispositionWillRun {
+			t.Errorf("bt-2 without checkpoint: %q, want will-run", e.Disposition)
+		}
+	}
+}
@@ -1,17 +1,23 @@
-// merge.go implements the serial merge queue for parallel bead execution.
-// Only the merge queue goroutine mutates the trunk branch, ensuring no
-// concurrent git operations on the main integration branch.
+// merge.go implements the merge queue for parallel bead execution.
+// Only the merge queue mutates the trunk branch. Merges of beads with
+// disjoint files may be processed by several workers at once: each is
+// merged and verified in a checkout of its own, and only landing it on
+// trunk is serialized.
 package execute
 
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/git"
 	"github.com/berth-dev/berth/internal/graph"
 	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/trace"
 )
 
 // MergeRequest is submitted by a worker goroutine after bead execution.
@@ -21,6 +27,7 @@ type MergeRequest struct {
 	BranchName   string
 	GraphData    string
 	Success      bool
+	Applied      bool // work already present in the tree; nothing to merge
 	Error        error
 }
 
@@ -31,8 +38,11 @@ type MergeResult struct {
 	Error   error
 }
 
-// MergeQueue serializes merges into the trunk branch. Exactly one goroutine
-// reads from the requests channel and performs git merge + verification.
+// MergeQueue integrates worker branches into the trunk branch. A single
+// dispatcher goroutine reads the requests channel and hands each merge to one
+// of up to workers goroutines; merges whose files overlap an in-flight or
+// earlier pending merge wait their turn, and a bead is held back until every
+// bead of the run it depends on has been merged.
 type MergeQueue struct {
 	cfg         config.Config
 	projectRoot string
@@ -44,20 +54,32 @@ type MergeQueue struct {
 	requests    chan MergeRequest
 	results     chan MergeResult
 	done        chan struct{}
+	deps        map[string][]string // bead ID -> unmerged dependencies within the run
+
+	workers int                            // concurrent merges (Execution.MergeWorkers, min 1)
+	trunkMu sync.Mutex                     // held while reading or landing on trunk; see processMerge
+	process func(MergeRequest) MergeResult // processMerge, replaceable in tests
 }
 
-// NewMergeQueue creates a MergeQueue. Call Start() in a goroutine before
-// submitting requests.
+// NewMergeQueue creates a MergeQueue for the beads of a run. allBeads gives
+// the dependency graph merges are ordered by; dependencies that are not in
+// allBeads or are already closed do not hold a merge back. Call Start() in a
+// goroutine before submitting requests.
 func NewMergeQueue(
 	cfg config.Config,
 	projectRoot string,
 	trunkBranch string,
+	allBeads []beads.Bead,
 	kgClient *graph.Client,
 	logger *log.Logger,
 	worktrees *WorktreeManager,
 	systemPrompt string,
 ) *MergeQueue {
-	return &MergeQueue{
+	workers := cfg.Execution.MergeWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	mq := &MergeQueue{
 		cfg:          cfg,
 		projectRoot:  projectRoot,
 		trunkBranch:  trunkBranch,
@@ -68,19 +90,177 @@ func NewMergeQueue(
 		requests:     make(chan MergeRequest, 32),
 		results:      make(chan MergeResult, 32),
 		done:         make(chan struct{}),
+		deps:         mergeDeps(allBeads),
+		workers:      workers,
+	}
+	mq.process = mq.processMerge
+	return mq
+}
+
+// mergeDeps maps each bead to the dependencies whose merges it must wait
+// for: those that are part of the run and not yet closed.
+func mergeDeps(allBeads []beads.Bead) map[string][]string {
+	open := make(map[string]bool, len(allBeads))
+	for _, b := range allBeads {
+		if b.Status != "closed" && b.Status != "done" {
+			open[b.ID] = true
+		}
+	}
+	deps := make(map[string][]string)
+	for _, b := range allBeads {
+		for _, dep := range b.DependsOn {
+			if open[dep] {
+				deps[b.ID] = append(deps[b.ID], dep)
+			}
+		}
 	}
+	return deps
 }
 
 // Start begins the merge processor loop. Run in a goroutine.
 func (mq *MergeQueue) Start() {
 	defer close(mq.done)
-	for req := range mq.requests {
-		result := mq.processMerge(req)
-		mq.results <- result
+
+	type finishedMerge struct {
+		files  []string
+		result MergeResult
+	}
+	finished := make(chan finishedMerge)
+	busy := make(map[string]bool)    // files held by in-flight merges
+	settled := make(map[string]bool) // bead ID -> merged, once its merge is done
+	var pending []MergeRequest
+	inFlight := 0
+	requests := mq.requests
+
+	for requests != nil || len(pending) > 0 || inFlight > 0 {
+		// Once nothing more can arrive or finish, dependencies still
+		// unmerged never will be; fail the beads waiting on them.
+		if requests == nil && inFlight == 0 {
+			for i := range pending {
+				if dep, ok := mq.waitingOn(pending[i], settled); !ok {
+					pending[i] = failedDependency(pending[i], fmt.Errorf("dependency %s was never merged", dep))
+				}
+			}
+		}
+
+		// Launch every pending merge that doesn't overlap an in-flight merge
+		// or an earlier pending one, so overlapping merges keep their order.
+		// Merges waiting on a dependency are skipped without blocking others.
+		blocked := make(map[string]bool)
+		for i := 0; i < len(pending) && inFlight < mq.workers; {
+			req := pending[i]
+			if dep, ok := mq.waitingOn(req, settled); !ok {
+				if merged, done := settled[dep]; done && !merged {
+					pending[i] = failedDependency(req, fmt.Errorf("dependency %s failed to merge", dep))
+					continue
+				}
+				i++
+				continue
+			}
+			files := mergeFootprint(req)
+			if overlaps(busy, files) || overlaps(blocked, files) {
+				for _, f := range files {
+					blocked[f] = true
+				}
+				i++
+				continue
+			}
+			pending = append(pending[:i], pending[i+1:]...)
+			for _, f := range files {
+				busy[f] = true
+			}
+			inFlight++
+			go func() {
+				finished <- finishedMerge{files: files, result: mq.handle(req)}
+			}()
+		}
+
+		select {
+		case req, ok := <-requests:
+			if !ok {
+				requests = nil
+				continue
+			}
+			pending = append(pending, req)
+		case f := <-finished:
+			inFlight--
+			for _, file := range f.files {
+				delete(busy, file)
+			}
+			settled[f.result.BeadID] = f.result.Success
+			mq.results <- f.result
+		}
 	}
 	close(mq.results)
 }
 
+// waitingOn returns the first dependency of req that has not been merged,
+// reporting false, or true when req is free to merge. Failed requests never
+// wait, since nothing of theirs reaches trunk.
+func (mq *MergeQueue) waitingOn(req MergeRequest, settled map[string]bool) (string, bool) {
+	if !req.Success {
+		return "", true
+	}
+	for _, dep := range mq.deps[req.Bead.ID] {
+		if !settled[dep] {
+			return dep, false
+		}
+	}
+	return "", true
+}
+
+// failedDependency turns req into a failed request, so the bead is reported
+// as failed without being merged.
+func failedDependency(req MergeRequest, err error) MergeRequest {
+	req.Success = false
+	req.Error = err
+	return req
+}
+
+// handle processes one merge request inside a trace span.
+func (mq *MergeQueue) handle(req MergeRequest) MergeResult {
+	span := trace.Start("merge", trace.String("bead.id", req.Bead.ID))
+	result := mq.process(req)
+	span.Set(trace.Bool("passed", result.Success))
+	span.SetError(result.Error)
+	span.End()
+	return result
+}
+
+// allFiles is the footprint of a merge whose files are unknown; it overlaps
+// every other merge.
+const allFiles = "*"
+
+// mergeFootprint returns the files a merge may touch on trunk. Failed beads
+// are never merged and touch nothing; beads without declared files are
+// treated as touching everything. Already-applied beads touch nothing.
+func mergeFootprint(req MergeRequest) []string {
+	if !req.Success || req.Applied {
+		return nil
+	}
+	if len(req.Bead.Files) == 0 {
+		return []string{allFiles}
+	}
+	return req.Bead.Files
+}
+
+// overlaps reports whether any of files is in set, treating allFiles on
+// either side as a match.
+func overlaps(set map[string]bool, files []string) bool {
+	if len(files) == 0 || len(set) == 0 {
+		return false
+	}
+	if set[allFiles] {
+		return true
+	}
+	for _, f := range files {
+		if f == allFiles || set[f] {
+			return true
+		}
+	}
+	return false
+}
+
 // Submit enqueues a merge request from a worker goroutine.
 func (mq *MergeQueue) Submit(req MergeRequest) {
 	mq.requests <- req
@@ -102,12 +282,18 @@ func (mq *MergeQueue) Wait() {
 }
 
 // processMerge handles a single merge request:
-// 1. If bead failed execution, return failure
-// 2. Switch to trunk, merge worker branch
-// 3. On merge conflict, try reconciliation
-// 4. Run verification on trunk
-// 5. On verify fail, try reconciliation
-// 6. On success, run onBeadSuccess, clean up worktree
+// 1. If bead failed execution, return failure; if it was already applied,
+//    return success
+// 2. Merge the worker branch onto trunk in a merge checkout of its own
+// 3. On merge conflict, fail
+// 4. Run verification in the merge checkout
+// 5. On verify fail, try reconciliation there
+// 6. Fast-forward trunk to the verified merge, run onBeadSuccess, clean up
+//
+// Steps 2 to 5 run concurrently with other merges; only step 6 takes
+// trunkMu. If trunk moved while the merge was being verified, steps 2 to 5
+// are repeated on top of the new trunk, so what lands is always what was
+// verified.
 func (mq *MergeQueue) processMerge(req MergeRequest) MergeResult {
 	beadID := req.Bead.ID
 
@@ -119,6 +305,9 @@ func (mq *MergeQueue) processMerge(req MergeRequest) MergeResult {
 			Error:   fmt.Errorf("bead %s failed execution: %w", beadID, req.Error),
 		}
 	}
+	if req.Applied {
+		return MergeResult{BeadID: beadID, Success: true}
+	}
 
 	// Log merge start.
 	if mq.logger != nil {
@@ -130,22 +319,97 @@ func (mq *MergeQueue) processMerge(req MergeRequest) MergeResult {
 		})
 	}
 
-	// Switch to trunk branch.
-	if err := git.SwitchBranch(mq.trunkBranch); err != nil {
-		return MergeResult{
-			BeadID:  beadID,
-			Success: false,
-			Error:   fmt.Errorf("switching to trunk branch: %w", err),
+	result := mq.integrate(req)
+	if !result.Success {
+		return result
+	}
+
+	// Clean up worktree.
+	if err := mq.worktrees.Remove(beadID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree for bead %s: %v\n", beadID, err)
+	}
+
+	// Log merge success.
+	if mq.logger != nil {
+		_ = mq.logger.Append(log.LogEvent{
+			Event:     log.EventMergeCompleted,
+			BeadID:    beadID,
+			MergeFrom: req.BranchName,
+			MergeTo:   mq.trunkBranch,
+		})
+	}
+
+	return result
+}
+
+// integrate prepares and verifies req's merge against the current trunk and
+// lands it, starting over whenever another merge lands on trunk first.
+func (mq *MergeQueue) integrate(req MergeRequest) MergeResult {
+	beadID := req.Bead.ID
+	mergeDir := filepath.Join(mq.projectRoot, ".berth", "worktrees", "merge-"+beadID)
+
+	for {
+		mq.trunkMu.Lock()
+		baseRef, err := mq.trunkHead()
+		mq.trunkMu.Unlock()
+		if err != nil {
+			return MergeResult{BeadID: beadID, Success: false, Error: err}
 		}
+
+		candidate, failed := mq.prepare(req, mergeDir, baseRef)
+		if failed != nil {
+			return *failed
+		}
+
+		mq.trunkMu.Lock()
+		result, landed := mq.land(req, baseRef, candidate)
+		mq.trunkMu.Unlock()
+		if landed {
+			return result
+		}
+		fmt.Printf("Trunk moved while merging bead %s; merging it again\n", beadID)
 	}
+}
 
-	// Merge worker branch into trunk.
-	commitMsg := fmt.Sprintf("merge(berth): integrate bead %s - %s", beadID, req.Bead.Title)
-	if mergeErr := git.MergeWorktreeBranch(req.BranchName, commitMsg); mergeErr != nil {
-		// Merge conflict — abort. Reconciliation cannot resolve git conflicts
-		// (trunk is clean after abort), so skip it and fail directly.
-		_ = git.AbortMerge()
+// trunkHead checks out trunk in the project root and returns its HEAD. The
+// checkout is skipped when trunk is already checked out, since it would scan
+// the merge checkouts other workers are creating and removing meanwhile. The
+// caller holds trunkMu.
+func (mq *MergeQueue) trunkHead() (string, error) {
+	if branch, err := git.CurrentBranch(); err != nil || branch != mq.trunkBranch {
+		if err := git.SwitchBranch(mq.trunkBranch); err != nil {
+			return "", fmt.Errorf("switching to trunk branch: %w", err)
+		}
+	}
+	head, err := git.HeadSHA()
+	if err != nil {
+		return "", fmt.Errorf("reading trunk HEAD: %w", err)
+	}
+	return head, nil
+}
 
+// prepare merges req's branch onto baseRef in mergeDir, a detached checkout
+// that no other merge uses, and verifies it there, reconciling if
+// verification fails. It returns the commit to land, or the failed result.
+func (mq *MergeQueue) prepare(req MergeRequest, mergeDir, baseRef string) (string, *MergeResult) {
+	beadID := req.Bead.ID
+	fail := func(err error) (string, *MergeResult) {
+		return "", &MergeResult{BeadID: beadID, Success: false, Error: err}
+	}
+
+	_ = git.RemoveWorktree(mergeDir) // left over from an interrupted run
+	if err := git.AddDetachedWorktree(mergeDir, baseRef); err != nil {
+		return fail(fmt.Errorf("creating merge checkout for bead %s: %w", beadID, err))
+	}
+	defer func() {
+		if err := git.RemoveWorktree(mergeDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove merge checkout of bead %s: %v\n", beadID, err)
+		}
+	}()
+
+	commitMsg := fmt.Sprintf("merge(berth): integrate bead %s - %s", beadID, req.Bead.Title)
+	if mergeErr := git.MergeBranchIn(mergeDir, req.BranchName, commitMsg); mergeErr != nil {
+		// Reconciliation cannot resolve git conflicts, so fail directly.
 		if mq.logger != nil {
 			_ = mq.logger.Append(log.LogEvent{
 				Event:  log.EventMergeFailed,
@@ -153,22 +417,13 @@ func (mq *MergeQueue) processMerge(req MergeRequest) MergeResult {
 				Error:  mergeErr.Error(),
 			})
 		}
-
-		return MergeResult{
-			BeadID:  beadID,
-			Success: false,
-			Error:   fmt.Errorf("merge conflict for bead %s: %w", beadID, mergeErr),
-		}
+		return fail(fmt.Errorf("merge conflict for bead %s: %w", beadID, mergeErr))
 	}
 
-	// Run verification on trunk.
-	verifyResult, err := RunVerification(mq.cfg, req.Bead, mq.projectRoot)
+	// Run verification on the merge.
+	verifyResult, err := RunVerification(mq.cfg, req.Bead, mergeDir)
 	if err != nil {
-		return MergeResult{
-			BeadID:  beadID,
-			Success: false,
-			Error:   fmt.Errorf("post-merge verification error for bead %s: %w", beadID, err),
-		}
+		return fail(fmt.Errorf("post-merge verification error for bead %s: %w", beadID, err))
 	}
 
 	if !verifyResult.Passed {
@@ -183,60 +438,75 @@ func (mq *MergeQueue) processMerge(req MergeRequest) MergeResult {
 		}
 
 		reconciled, reconcileErr := Reconcile(
-			mq.cfg, req.Bead, req.WorktreePath, mq.projectRoot,
+			mq.cfg, req.Bead, req.WorktreePath, mergeDir,
 			mq.kgClient, mq.logger,
 		)
-		if reconcileErr != nil || !reconciled {
-			mergeResult := MergeResult{
-				BeadID:  beadID,
-				Success: false,
-			}
-			if reconcileErr != nil {
-				mergeResult.Error = fmt.Errorf("post-merge verify failed for bead %s, reconciliation failed: %w", beadID, reconcileErr)
-			} else {
-				mergeResult.Error = fmt.Errorf("post-merge verify failed for bead %s at %q, reconciliation did not fix", beadID, verifyResult.FailedStep)
-			}
-			return mergeResult
+		if reconcileErr != nil {
+			return fail(fmt.Errorf("post-merge verify failed for bead %s, reconciliation failed: %w", beadID, reconcileErr))
+		}
+		if !reconciled {
+			return fail(fmt.Errorf("post-merge verify failed for bead %s at %q, reconciliation did not fix", beadID, verifyResult.FailedStep))
 		}
 
 		// Re-verify after reconciliation.
-		reVerify, reErr := RunVerification(mq.cfg, req.Bead, mq.projectRoot)
-		if reErr != nil || !reVerify.Passed {
-			mergeResult := MergeResult{
-				BeadID:  beadID,
-				Success: false,
-			}
-			if reErr != nil {
-				mergeResult.Error = fmt.Errorf("post-reconcile verify error for bead %s: %w", beadID, reErr)
-			} else {
-				mergeResult.Error = fmt.Errorf("post-reconcile verify still failing for bead %s at %q", beadID, reVerify.FailedStep)
-			}
-			return mergeResult
+		reVerify, reErr := RunVerification(mq.cfg, req.Bead, mergeDir)
+		if reErr != nil {
+			return fail(fmt.Errorf("post-reconcile verify error for bead %s: %w", beadID, reErr))
+		}
+		if !reVerify.Passed {
+			return fail(fmt.Errorf("post-reconcile verify still failing for bead %s at %q", beadID, reVerify.FailedStep))
 		}
 	}
 
-	// Success: run post-success steps.
-	if err := onBeadSuccess(req.Bead, mq.kgClient, mq.projectRoot, mq.logger, mq.systemPrompt); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: post-merge success steps failed for bead %s: %v\n", beadID, err)
+	candidate, err := git.HeadSHAIn(mergeDir)
+	if err != nil {
+		return fail(fmt.Errorf("reading merge of bead %s: %w", beadID, err))
 	}
 
-	// Clean up worktree.
-	if err := mq.worktrees.Remove(beadID); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree for bead %s: %v\n", beadID, err)
+	// A bead that touched a protected file never lands.
+	touched, err := checkProtectedCommits(&mq.cfg, baseRef, candidate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: protected file check failed for bead %s: %v\n", beadID, err)
+	}
+	if len(touched) > 0 {
+		markProtectedStuck(req.Bead, touched, mq.logger)
+		return fail(fmt.Errorf("bead %s %s: %s", beadID, protectedFileReason, strings.Join(touched, ", ")))
 	}
 
-	// Log merge success.
-	if mq.logger != nil {
-		_ = mq.logger.Append(log.LogEvent{
-			Event:     log.EventMergeCompleted,
-			BeadID:    beadID,
-			MergeFrom: req.BranchName,
-			MergeTo:   mq.trunkBranch,
-		})
+	return candidate, nil
+}
+
+// land fast-forwards trunk to candidate and runs onBeadSuccess, reporting
+// false without touching trunk when trunk is no longer at baseRef. The
+// caller holds trunkMu.
+func (mq *MergeQueue) land(req MergeRequest, baseRef, candidate string) (MergeResult, bool) {
+	beadID := req.Bead.ID
+
+	head, err := mq.trunkHead()
+	if err != nil {
+		return MergeResult{BeadID: beadID, Success: false, Error: err}, true
+	}
+	if head != baseRef {
+		return MergeResult{}, false
+	}
+	if err := git.FastForward(candidate); err != nil {
+		return MergeResult{
+			BeadID:  beadID,
+			Success: false,
+			Error:   fmt.Errorf("landing merge of bead %s on trunk: %w", beadID, err),
+		}, true
+	}
+
+	// Success: everything reachable from trunk now but not from baseRef is
+	// the bead's: its worker branch commits, the merge and any
+	// reconciliation.
+	recordBeadCommits(req.Bead, baseRef)
+	if err := onBeadSuccess(req.Bead, mq.kgClient, mq.projectRoot, mq.logger, mq.systemPrompt); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: post-merge success steps failed for bead %s: %v\n", beadID, err)
 	}
 
 	return MergeResult{
 		BeadID:  beadID,
 		Success: true,
-	}
+	}, true
 }
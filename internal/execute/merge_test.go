@@ -0,0 +1,335 @@
+package execute
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+// runMerges submits reqs to a MergeQueue with the given worker count and a
+// fake processor, returning the results in completion order.
+func runMerges(t *testing.T, workers int, process func(MergeRequest) MergeResult, reqs ...MergeRequest) []MergeResult {
+	t.Helper()
+	return runMergesWithBeads(t, workers, nil, process, reqs...)
+}
+
+// runMergesWithBeads is runMerges for a queue that knows the run's beads.
+func runMergesWithBeads(t *testing.T, workers int, allBeads []beads.Bead, process func(MergeRequest) MergeResult, reqs ...MergeRequest) []MergeResult {
+	t.Helper()
+	cfg := *config.DefaultConfig()
+	cfg.Execution.MergeWorkers = workers
+	mq := NewMergeQueue(cfg, t.TempDir(), "trunk", allBeads, nil, nil, nil, "")
+	mq.process = process
+	go mq.Start()
+
+	for _, req := range reqs {
+		mq.Submit(req)
+	}
+	mq.Close()
+
+	var results []MergeResult
+	timeout := time.After(5 * time.Second)
+	for len(results) < len(reqs) {
+		select {
+		case r := <-mq.Results():
+			results = append(results, r)
+		case <-timeout:
+			t.Fatalf("timed out with %d of %d merges done", len(results), len(reqs))
+		}
+	}
+	mq.Wait()
+	return results
+}
+
+func mergeReq(id string, files ...string) MergeRequest {
+	return MergeRequest{Bead: &beads.Bead{ID: id, Files: files}, Success: true}
+}
+
+func TestMergeQueueRunsDisjointMergesConcurrently(t *testing.T) {
+	var started sync.WaitGroup
+	started.Add(2)
+	bothStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(bothStarted)
+	}()
+
+	process := func(req MergeRequest) MergeResult {
+		started.Done()
+		select {
+		case <-bothStarted:
+			return MergeResult{BeadID: req.Bead.ID, Success: true}
+		case <-time.After(2 * time.Second):
+			return MergeResult{BeadID: req.Bead.ID}
+		}
+	}
+
+	results := runMerges(t, 2, process, mergeReq("bt-1", "a.go"), mergeReq("bt-2", "b.go"))
+	for _, r := range results {
+		if !r.Success {
+			t.Errorf("merge %s did not overlap with the other; disjoint merges ran serially", r.BeadID)
+		}
+	}
+}
+
+func TestMergeQueueSerializesOverlappingMerges(t *testing.T) {
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	process := func(req MergeRequest) MergeResult {
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return MergeResult{BeadID: req.Bead.ID, Success: true}
+	}
+
+	results := runMerges(t, 4, process,
+		mergeReq("bt-1", "shared.go", "a.go"),
+		mergeReq("bt-2", "shared.go"),
+		mergeReq("bt-3", "b.go", "shared.go"),
+	)
+
+	if maxActive != 1 {
+		t.Errorf("max concurrent merges = %d, want 1 for overlapping files", maxActive)
+	}
+	for i, want := range []string{"bt-1", "bt-2", "bt-3"} {
+		if results[i].BeadID != want {
+			t.Errorf("result %d = %s, want %s (submission order)", i, results[i].BeadID, want)
+		}
+	}
+}
+
+func TestMergeQueueMergesDependencyFirst(t *testing.T) {
+	allBeads := []beads.Bead{
+		{ID: "bt-1", Status: "open", Files: []string{"a.go"}},
+		{ID: "bt-2", Status: "open", Files: []string{"b.go"}, DependsOn: []string{"bt-1"}},
+	}
+	var mu sync.Mutex
+	var order []string
+	process := func(req MergeRequest) MergeResult {
+		mu.Lock()
+		order = append(order, req.Bead.ID)
+		mu.Unlock()
+		return MergeResult{BeadID: req.Bead.ID, Success: req.Success}
+	}
+
+	// bt-2 finishes first but must wait for bt-1 to merge.
+	results := runMergesWithBeads(t, 2, allBeads, process,
+		MergeRequest{Bead: &allBeads[1], Success: true},
+		MergeRequest{Bead: &allBeads[0], Success: true},
+	)
+
+	if len(order) != 2 || order[0] != "bt-1" || order[1] != "bt-2" {
+		t.Errorf("merge order = %v, want [bt-1 bt-2]", order)
+	}
+	for _, r := range results {
+		if !r.Success {
+			t.Errorf("merge %s failed", r.BeadID)
+		}
+	}
+}
+
+func TestMergeQueueFailsBeadWhoseDependencyFailed(t *testing.T) {
+	allBeads := []beads.Bead{
+		{ID: "bt-1", Status: "open", Files: []string{"a.go"}},
+		{ID: "bt-2", Status: "open", Files: []string{"b.go"}, DependsOn: []string{"bt-1"}},
+		{ID: "bt-3", Status: "open", Files: []string{"c.go"}, DependsOn: []string{"bt-9"}},
+	}
+	process := func(req MergeRequest) MergeResult {
+		return MergeResult{BeadID: req.Bead.ID, Success: req.Success}
+	}
+
+	// bt-3's dependency is not part of the run and does not hold it back.
+	results := runMergesWithBeads(t, 2, allBeads, process,
+		MergeRequest{Bead: &allBeads[1], Success: true},
+		MergeRequest{Bead: &allBeads[0], Success: false},
+		MergeRequest{Bead: &allBeads[2], Success: true},
+	)
+
+	got := make(map[string]bool)
+	for _, r := range results {
+		got[r.BeadID] = r.Success
+	}
+	if got["bt-1"] || got["bt-2"] {
+		t.Errorf("results = %v, want bt-1 and bt-2 failed", got)
+	}
+	if !got["bt-3"] {
+		t.Errorf("results = %v, want bt-3 merged", got)
+	}
+}
+
+func TestMergeQueueFailsBeadWhoseDependencyNeverArrives(t *testing.T) {
+	allBeads := []beads.Bead{
+		{ID: "bt-1", Status: "open"},
+		{ID: "bt-2", Status: "open", DependsOn: []string{"bt-1"}},
+	}
+	process := func(req MergeRequest) MergeResult {
+		return MergeResult{BeadID: req.Bead.ID, Success: req.Success}
+	}
+
+	results := runMergesWithBeads(t, 1, allBeads, process, MergeRequest{Bead: &allBeads[1], Success: true})
+	if results[0].Success {
+		t.Error("bt-2 merged although bt-1 was never merged")
+	}
+}
+
+// gitOut runs git in the current directory and returns its trimmed output.
+func gitOut(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// runRealMerges creates a worker branch with one commit for each of ids,
+// merges them through a MergeQueue with three workers and returns the
+// requests. Each bead's verification checks that the last commit it sees is
+// its own merge and that nothing lands there while it runs.
+func runRealMerges(t *testing.T, ids ...string) []MergeRequest {
+	t.Helper()
+	return runRealMergesVerifying(t, func(id string) string {
+		return fmt.Sprintf(`s=$(git log -1 --format=%%s); case "$s" in *"bead %s "*) ;; *) echo "HEAD is $s"; exit 1;; esac; sleep 0.2; [ "$(git log -1 --format=%%s)" = "$s" ] || { echo "HEAD moved"; exit 1; }`, id)
+	}, ids...)
+}
+
+// runRealMergesVerifying is runRealMerges with verify(id) as each bead's
+// verification command.
+func runRealMergesVerifying(t *testing.T, verify func(id string) string, ids ...string) []MergeRequest {
+	t.Helper()
+	dir := initTestRepo(t)
+	fakeBD(t, "[]")
+	trunk := gitOut(t, "rev-parse", "--abbrev-ref", "HEAD")
+
+	var reqs []MergeRequest
+	for _, id := range ids {
+		gitOut(t, "checkout", "-q", "-b", "berth/"+id, trunk)
+		if err := os.WriteFile(id+".txt", []byte(id+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gitOut(t, "add", id+".txt")
+		gitOut(t, "commit", "-q", "-m", "feat: "+id)
+		gitOut(t, "checkout", "-q", trunk)
+
+		reqs = append(reqs, MergeRequest{
+			Bead:       &beads.Bead{ID: id, Title: id, Files: []string{id + ".txt"}, VerifyExtra: []string{verify(id)}},
+			BranchName: "berth/" + id,
+			Success:    true,
+		})
+	}
+
+	cfg := *config.DefaultConfig()
+	cfg.Execution.MergeWorkers = 3
+	cfg.Agent.Command = "false" // reconciliation fails straight away
+	logger, err := log.NewLogger(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mq := NewMergeQueue(cfg, dir, trunk, nil, nil, logger, NewWorktreeManager(dir, trunk), "")
+	go mq.Start()
+	for _, req := range reqs {
+		mq.Submit(req)
+	}
+	mq.Close()
+
+	for range reqs {
+		if r := <-mq.Results(); !r.Success {
+			t.Errorf("merge of %s failed: %v", r.BeadID, r.Error)
+		}
+	}
+	mq.Wait()
+
+	if got := gitOut(t, "rev-parse", "--abbrev-ref", "HEAD"); got != trunk {
+		t.Errorf("project root is on %s, want trunk %s", got, trunk)
+	}
+	for _, id := range ids {
+		if _, err := os.Stat(id + ".txt"); err != nil {
+			t.Errorf("%s did not land on trunk: %v", id, err)
+		}
+	}
+	return reqs
+}
+
+func TestProcessMergeVerifiesItsOwnMerge(t *testing.T) {
+	runRealMerges(t, "bt-1", "bt-2", "bt-3")
+}
+
+func TestProcessMergeVerifiesDisjointMergesConcurrently(t *testing.T) {
+	started := t.TempDir()
+	// Each verification waits until all three have started; run one at a
+	// time, the first would give up.
+	runRealMergesVerifying(t, func(id string) string {
+		return fmt.Sprintf(`touch %[1]s/%[2]s; i=0; while [ "$(ls %[1]s | wc -l)" -lt 3 ]; do i=$((i+1)); [ $i -gt 50 ] && { echo "verified alone"; exit 1; }; sleep 0.1; done`, started, id)
+	}, "bt-1", "bt-2", "bt-3")
+}
+
+func TestProcessMergeRecordsBeadCommits(t *testing.T) {
+	for _, req := range runRealMerges(t, "bt-1", "bt-2") {
+		var subjects []string
+		for _, sha := range req.Bead.Commits {
+			subjects = append(subjects, gitOut(t, "log", "-1", "--format=%s", sha))
+		}
+		want := []string{"feat: " + req.Bead.ID, "merge(berth): integrate bead " + req.Bead.ID + " - " + req.Bead.ID}
+		if strings.Join(subjects, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s commits = %q, want %q", req.Bead.ID, subjects, want)
+		}
+	}
+}
+
+func TestMergeParallelResultsRecordsBeadCommits(t *testing.T) {
+	dir := initTestRepo(t)
+	trunk := gitOut(t, "rev-parse", "--abbrev-ref", "HEAD")
+	gitOut(t, "checkout", "-q", "-b", "berth/worker/bt-1")
+	if err := os.WriteFile("bt-1.txt", []byte("bt-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitOut(t, "add", "bt-1.txt")
+	gitOut(t, "commit", "-q", "-m", "feat: bt-1")
+	gitOut(t, "checkout", "-q", trunk)
+
+	results := []ParallelResult{{BeadID: "bt-1", Passed: true}, {BeadID: "bt-2"}}
+	if _, err := MergeParallelResults(config.DefaultConfig(), dir, trunk, results); err != nil {
+		t.Fatalf("MergeParallelResults: %v", err)
+	}
+
+	var subjects []string
+	for _, sha := range results[0].Commits {
+		subjects = append(subjects, gitOut(t, "log", "-1", "--format=%s", sha))
+	}
+	if want := "feat: bt-1\nMerge bead bt-1"; strings.Join(subjects, "\n") != want {
+		t.Errorf("bt-1 commits = %q, want the bead's commit and its merge", subjects)
+	}
+	if results[1].Commits != nil {
+		t.Errorf("failed bead has commits %v", results[1].Commits)
+	}
+}
+
+func TestMergeFootprint(t *testing.T) {
+	if got := mergeFootprint(MergeRequest{Bead: &beads.Bead{Files: []string{"a.go"}}}); got != nil {
+		t.Errorf("failed bead footprint = %v, want none", got)
+	}
+	undeclared := mergeFootprint(mergeReq("bt-1"))
+	if !overlaps(map[string]bool{"x.go": true}, undeclared) {
+		t.Error("a bead without declared files should overlap every merge")
+	}
+	if overlaps(map[string]bool{"a.go": true}, mergeFootprint(mergeReq("bt-2", "b.go"))) {
+		t.Error("disjoint files reported as overlapping")
+	}
+}
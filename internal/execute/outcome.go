@@ -0,0 +1,44 @@
+// outcome.go defines how a finished run reports beads that did not complete,
+// so callers such as "berth run" can tell a clean run from a partial or
+// aborted one.
+package execute
+
+import "fmt"
+
+// ErrAborted is wrapped by the error of a run stopped before every bead was
+// processed: by the circuit breaker, by the user choosing abort, or by an
+// interrupt signal.
+var ErrAborted = abortedError("run aborted")
+
+// abortedError is an abort with its own message that still matches
+// ErrAborted under errors.Is.
+type abortedError string
+
+func (e abortedError) Error() string { return string(e) }
+
+func (e abortedError) Is(target error) bool { return target == ErrAborted }
+
+// IncompleteError is returned by a run that processed every bead but left
+// some stuck or skipped.
+type IncompleteError struct {
+	Completed int
+	Stuck     int
+	Skipped   int
+	Total     int
+}
+
+func (e *IncompleteError) Error() string {
+	return fmt.Sprintf("%d of %d beads did not complete (%d stuck, %d skipped)",
+		e.Stuck+e.Skipped, e.Total, e.Stuck, e.Skipped)
+}
+
+// Outcome returns an *IncompleteError when any bead ended stuck or skipped,
+// and nil when every processed bead completed.
+func (p *ExecutionPool) Outcome() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Stuck == 0 && p.Skipped == 0 {
+		return nil
+	}
+	return &IncompleteError{Completed: p.Completed, Stuck: p.Stuck, Skipped: p.Skipped, Total: p.Total}
+}
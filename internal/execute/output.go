@@ -2,6 +2,7 @@
 package execute
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 )
@@ -14,6 +15,7 @@ type ClaudeOutput struct {
 	DurationMS int64   `json:"duration_ms"`
 	SessionID  string  `json:"session_id"`
 	IsError    bool    `json:"is_error"`
+	Tokens     int     `json:"tokens"` // input + output tokens, when reported
 }
 
 // claudeRawOutput is the full JSON envelope returned by Claude CLI
@@ -27,6 +29,10 @@ type claudeRawOutput struct {
 	SessionID  string  `json:"session_id"`
 	IsError    bool    `json:"is_error"`
 	NumTurns   int     `json:"num_turns"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
 }
 
 // ParseClaudeOutput parses the raw JSON bytes from Claude's
@@ -52,5 +58,26 @@ func ParseClaudeOutput(raw []byte) (*ClaudeOutput, error) {
 		DurationMS: rawOut.DurationMS,
 		SessionID:  rawOut.SessionID,
 		IsError:    rawOut.IsError,
+		Tokens:     rawOut.Usage.InputTokens + rawOut.Usage.OutputTokens,
 	}, nil
 }
+
+// ParseStreamJSONOutput parses the raw bytes from Claude's
+// --output-format stream-json response. The final "result" line carries
+// the same envelope as --output-format json.
+func ParseStreamJSONOutput(raw []byte) (*ClaudeOutput, error) {
+	lines := bytes.Split(raw, []byte("\n"))
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := bytes.TrimSpace(lines[i])
+		if len(line) == 0 {
+			continue
+		}
+		var probe struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(line, &probe) == nil && probe.Type == "result" {
+			return ParseClaudeOutput(line)
+		}
+	}
+	return nil, fmt.Errorf("no result line in claude stream-json output")
+}
@@ -126,3 +126,28 @@ func TestParseClaudeOutput_ZeroCost(t *testing.T) {
 		t.Errorf("CostUSD = %f, want 0", out.CostUSD)
 	}
 }
+
+func TestParseStreamJSONOutput_UsesResultLine(t *testing.T) {
+	raw := []byte(`{"type":"system","subtype":"init","session_id":"sess-1"}
+{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"result","result":"All done","cost_usd":0.01,"session_id":"sess-1","is_error":false}
+`)
+
+	out, err := ParseStreamJSONOutput(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Result != "All done" {
+		t.Errorf("Result = %q, want %q", out.Result, "All done")
+	}
+	if out.SessionID != "sess-1" {
+		t.Errorf("SessionID = %q, want %q", out.SessionID, "sess-1")
+	}
+}
+
+func TestParseStreamJSONOutput_NoResult(t *testing.T) {
+	raw := []byte(`{"type":"assistant","message":{"id":"msg_1"}}` + "\n")
+	if _, err := ParseStreamJSONOutput(raw); err == nil {
+		t.Error("expected error when no result line is present")
+	}
+}
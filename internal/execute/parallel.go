@@ -5,7 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,11 +23,13 @@ import (
 
 // OutputEvent represents a streaming event from parallel bead execution.
 type OutputEvent struct {
-	Type     string // "output", "complete", "error", "token_update"
-	BeadID   string
-	Content  string
-	Tokens   int
-	IsStderr bool
+	Type        string // "output", "complete", "error", "token_update", "attempt"
+	BeadID      string
+	Content     string
+	Tokens      int
+	IsStderr    bool
+	Attempt     int
+	MaxAttempts int
 }
 
 // ParallelResult contains the outcome of a single bead's parallel execution.
@@ -34,6 +39,10 @@ type ParallelResult struct {
 	ClaudeOutput string
 	Error        error
 	WorktreePath string
+	Tokens       int
+	AttemptsUsed int      // attempts RetryBead made, as for a sequential bead
+	Protected    []string // protected files the bead touched; its merge was undone
+	Commits      []string // commits its merge brought onto the target branch
 }
 
 // ShouldRunParallel determines whether to use parallel execution based on
@@ -75,7 +84,16 @@ func countRootBeads(allBeads []beads.Bead) int {
 // coordinator server, worktree manager, merge queue, and scheduler, then
 // runs all beads concurrently up to MaxParallel. prefetchedBeads is the
 // bead list already fetched by RunExecute to avoid a redundant bd list call.
-func RunExecuteParallel(cfg config.Config, projectRoot string, runDir string, branchName string, prefetchedBeads []beads.Bead, verbose bool) error {
+// A non-nil state (restored from a checkpoint) skips beads it records as
+// completed and seeds the checkpoint saved after each merge. control, when
+// non-nil, pauses and resumes the scheduler's launching of beads.
+func RunExecuteParallel(cfg config.Config, projectRoot string, runDir string, branchName string, prefetchedBeads []beads.Bead, verbose bool, state *ExecuteState, control <-chan ControlSignal) error {
+	prefetchedBeads = remainingBeads(prefetchedBeads, state)
+	if len(prefetchedBeads) == 0 {
+		fmt.Println("Nothing to do: no open beads to execute.")
+		return nil
+	}
+
 	// 1. Create a git branch for this execution run.
 	if err := git.EnsureInitialCommit(); err != nil {
 		return fmt.Errorf("ensuring initial commit: %w", err)
@@ -115,7 +133,7 @@ func RunExecuteParallel(cfg config.Config, projectRoot string, runDir string, br
 		pool.Total, cfg.Execution.MaxParallel, branchName)
 
 	// 5. Create logger.
-	logger, err := log.NewLogger(projectRoot)
+	logger, err := log.NewLoggerWithPrivacy(projectRoot, cfg.Log.Privacy())
 	if err != nil {
 		return fmt.Errorf("creating logger: %w", err)
 	}
@@ -129,23 +147,33 @@ func RunExecuteParallel(cfg config.Config, projectRoot string, runDir string, br
 		fmt.Fprintf(os.Stderr, "Warning: failed to log run_started: %v\n", logErr)
 	}
 
-	// 6. Start coordinator HTTP server.
-	coordServer, err := coordinator.NewServer()
+	// 6. Start coordinator HTTP server, on the configured port if free.
+	coordServer, err := startCoordinator(cfg.Execution.CoordinatorPort)
 	if err != nil {
 		return fmt.Errorf("starting coordinator server: %w", err)
 	}
 	go func() { _ = coordServer.Start() }()
-	coordServer.StartLockReaper(5 * time.Minute)
+	coordServer.StartLockReaper(
+		time.Duration(cfg.Execution.LockTTL)*time.Second,
+		time.Duration(cfg.Execution.LockReapInterval)*time.Second,
+		time.Duration(cfg.Execution.LockReapGrace)*time.Second,
+	)
 	defer func() { _ = coordServer.Stop() }()
 
 	fmt.Printf("Coordinator server running on %s\n", coordServer.Addr())
+	if logErr := logger.Append(log.LogEvent{
+		Event: log.EventCoordinatorStarted,
+		Data:  map[string]interface{}{"addr": coordServer.Addr()},
+	}); logErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to log coordinator_started: %v\n", logErr)
+	}
 
 	// 7. Create worktree manager.
 	worktrees := NewWorktreeManager(projectRoot, branchName)
 	defer worktrees.CleanupAll()
 
 	// 8. Create merge queue.
-	mergeQueue := NewMergeQueue(cfg, projectRoot, branchName, kgClient, logger, worktrees, systemPrompt)
+	mergeQueue := NewMergeQueue(cfg, projectRoot, branchName, allBeads, kgClient, logger, worktrees, systemPrompt)
 	go mergeQueue.Start()
 
 	// 9. Create scheduler and run.
@@ -154,11 +182,23 @@ func RunExecuteParallel(cfg config.Config, projectRoot string, runDir string, br
 		worktrees, mergeQueue, coordServer,
 		kgClient, logger, systemPrompt, verbose,
 	)
+	scheduler.EnableCheckpoints(runDir, branchName, state)
+	snapshots := newSnapshotter(cfg.Execution, runDir, branchName)
+	scheduler.setSnapshots(snapshots)
+	states := openBeadStateRecorder(projectRoot, cfg.Project.Name, branchName)
+	defer states.close()
+	scheduler.setBeadStates(states)
+	scheduler.setControl(control)
 
 	if err := scheduler.Run(); err != nil {
 		mergeQueue.Close()
 		mergeQueue.Wait()
-		return fmt.Errorf("scheduler error: %w", err)
+		runErr := err
+		if !errors.Is(err, errBudgetExceeded) {
+			runErr = fmt.Errorf("scheduler error: %w", err)
+		}
+		runPostRunHook(cfg, projectRoot, runDir, branchName, pool, runErr, logger)
+		return runErr
 	}
 
 	// 10. Close merge queue and wait for completion.
@@ -168,17 +208,49 @@ func RunExecuteParallel(cfg config.Config, projectRoot string, runDir string, br
 	// 11. Log run complete.
 	if logErr := logger.Append(log.LogEvent{
 		Event:     log.EventRunComplete,
-		Completed: pool.Completed,
-		Stuck:     pool.Stuck,
+		Completed: pool.GetCompleted(),
+		Stuck:     pool.GetStuck(),
+		Skipped:   pool.GetSkipped(),
 		Total:     pool.Total,
 	}); logErr != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to log run_complete: %v\n", logErr)
 	}
+	states.complete()
+
+	// 12. Clear checkpoint and snapshot tags on successful completion.
+	if pool.GetStuck() == 0 && pool.GetSkipped() == 0 {
+		if err := ClearCheckpoint(runDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to clear checkpoint: %v\n", err)
+		}
+		snapshots.cleanup()
+	}
 
 	fmt.Printf("Parallel execution complete: %d completed, %d stuck, %d skipped out of %d total\n",
-		pool.Completed, pool.Stuck, pool.Skipped, pool.Total)
+		pool.GetCompleted(), pool.GetStuck(), pool.GetSkipped(), pool.Total)
+
+	runPostRunHook(cfg, projectRoot, runDir, branchName, pool, nil, logger)
 
-	return nil
+	return pool.Outcome()
+}
+
+// remainingBeads drops the beads a restored checkpoint records as completed.
+func remainingBeads(allBeads []beads.Bead, state *ExecuteState) []beads.Bead {
+	if state == nil || len(state.CompletedBeads) == 0 {
+		return allBeads
+	}
+	done := make(map[string]bool, len(state.CompletedBeads))
+	for _, id := range state.CompletedBeads {
+		done[id] = true
+	}
+	remaining := make([]beads.Bead, 0, len(allBeads))
+	for _, b := range allBeads {
+		if done[b.ID] {
+			fmt.Printf("Skipping %s: already completed before resume\n", b.ID)
+			continue
+		}
+		remaining = append(remaining, b)
+	}
+	return remaining
 }
 
 // RunParallel executes all beads in an ExecutionGroup concurrently.
@@ -216,7 +288,7 @@ func RunParallel(
 	resultsChan := make(chan ParallelResult, len(group.BeadIDs))
 
 	// Create a logger for this execution.
-	logger, logErr := log.NewLogger(projectRoot)
+	logger, logErr := log.NewLoggerWithPrivacy(projectRoot, cfg.Log.Privacy())
 	if logErr != nil {
 		logger = nil
 	}
@@ -283,10 +355,12 @@ func RunParallel(
 					bead.Files = meta.Files
 				}
 				bead.VerifyExtra = meta.VerifyExtra
+				bead.Meta = meta.Meta
+				bead.NoFiles = meta.NoFiles
 			}
 
 			// Pre-embed graph data for this bead's files.
-			graphData := preEmbedGraphData(kgClient, bead.Files)
+			graphData := embedBeadContext(*cfg, kgClient, projectRoot, bead)
 
 			// Build spawn opts with worktree as WorkDir.
 			opts := &SpawnClaudeOpts{
@@ -294,6 +368,31 @@ func RunParallel(
 				SystemPrompt: systemPrompt,
 			}
 
+			// Forward spawn-layer stream events (including token updates)
+			// onto outputChan while the bead runs.
+			var streamChan chan StreamEvent
+			var forwardDone chan struct{}
+			if outputChan != nil {
+				streamChan = make(chan StreamEvent, 16)
+				forwardDone = make(chan struct{})
+				opts.OutputChan = streamChan
+				opts.BeadID = beadID
+				go func() {
+					defer close(forwardDone)
+					for ev := range streamChan {
+						outputChan <- OutputEvent{
+							Type:        ev.Type,
+							BeadID:      ev.BeadID,
+							Content:     ev.Content,
+							Tokens:      ev.Tokens,
+							IsStderr:    ev.IsStderr,
+							Attempt:     ev.Attempt,
+							MaxAttempts: ev.MaxAttempts,
+						}
+					}
+				}()
+			}
+
 			// Send output event indicating start.
 			if outputChan != nil {
 				outputChan <- OutputEvent{
@@ -304,13 +403,20 @@ func RunParallel(
 			}
 
 			// Call RetryBead with worktree as WorkDir.
-			beadResult, retryErr := RetryBead(*cfg, bead, graphData, projectRoot, logger, kgClient, opts)
+			beadResult, retryErr := RetryBead(ctx, *cfg, bead, graphData, projectRoot, logger, kgClient, opts)
+			if streamChan != nil {
+				close(streamChan)
+				<-forwardDone
+			}
 
 			// Determine outcome.
 			passed := beadResult != nil && beadResult.Passed
 			var claudeOutput string
+			var tokens int
 			if beadResult != nil {
 				claudeOutput = beadResult.ClaudeOutput
+				bead.AttemptsUsed = beadResult.AttemptsUsed
+				tokens = beadResult.Tokens
 			}
 
 			// Send completion event.
@@ -332,6 +438,8 @@ func RunParallel(
 				ClaudeOutput: claudeOutput,
 				Error:        retryErr,
 				WorktreePath: worktreePath,
+				Tokens:       tokens,
+				AttemptsUsed: bead.AttemptsUsed,
 			}
 		}()
 	}
@@ -350,8 +458,12 @@ func RunParallel(
 }
 
 // MergeParallelResults merges successful bead worktrees into the target branch.
+// A merge that touches a protected file is undone, and its result is marked
+// failed with the files in Protected; otherwise the commits it brought in are
+// recorded in Commits.
 // Returns a slice of merge conflicts encountered during merging.
 func MergeParallelResults(
+	cfg *config.Config,
 	projectRoot string,
 	targetBranch string,
 	results []ParallelResult,
@@ -363,12 +475,18 @@ func MergeParallelResults(
 		return nil, fmt.Errorf("switching to target branch %s: %w", targetBranch, err)
 	}
 
-	for _, result := range results {
+	for i := range results {
+		result := &results[i]
 		// Skip failed beads.
 		if !result.Passed {
 			continue
 		}
 
+		baseRef, err := git.HeadSHA()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read HEAD before merging bead %s: %v\n", result.BeadID, err)
+		}
+
 		// Merge the worktree branch into target.
 		if err := git.MergeWorktreeForBead(projectRoot, result.BeadID, targetBranch); err != nil {
 			// Check if it's a merge conflict error.
@@ -388,6 +506,26 @@ func MergeParallelResults(
 			continue
 		}
 
+		// Undo the merge if the bead touched a protected file.
+		touched, err := rejectProtectedChanges(cfg, result.BeadID, baseRef)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: protected file check failed for bead %s: %v\n", result.BeadID, err)
+		}
+		if len(touched) > 0 {
+			result.Passed = false
+			result.Protected = touched
+			result.Error = fmt.Errorf("%s: %s", protectedFileReason, strings.Join(touched, ", "))
+			continue
+		}
+
+		if baseRef != "" {
+			commits, err := git.CommitsSince(baseRef)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to list commits for bead %s: %v\n", result.BeadID, err)
+			}
+			result.Commits = commits
+		}
+
 		// Remove worktree after successful merge.
 		if err := git.RemoveWorktreeForBead(projectRoot, result.BeadID); err != nil {
 			// Log warning but continue - worktree cleanup is best effort.
@@ -397,3 +535,13 @@ func MergeParallelResults(
 
 	return conflicts, nil
 }
+
+// startCoordinator binds the coordinator server to a random localhost port,
+// or to port when it is set, moving on to the next free port when that one
+// is taken.
+func startCoordinator(port int) (*coordinator.Server, error) {
+	if port == 0 {
+		return coordinator.NewServer()
+	}
+	return coordinator.NewServerPreferAddr(net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+}
@@ -0,0 +1,62 @@
+// pause.go lets a front end pause and resume a running execution. Pausing
+// never stops a bead that is already running: it only keeps new beads from
+// starting until execution is resumed.
+package execute
+
+import "context"
+
+// ControlSignal steers a running execution. Front ends send signals on the
+// control channel given to RunExecuteWithState.
+type ControlSignal int
+
+const (
+	ControlPause  ControlSignal = iota + 1 // start no new beads; running ones finish
+	ControlResume                          // start beads again
+)
+
+// pauseGate tracks whether execution is paused from the signals on a
+// control channel. A nil control channel never pauses.
+type pauseGate struct {
+	control <-chan ControlSignal
+	paused  bool
+}
+
+func newPauseGate(control <-chan ControlSignal) *pauseGate {
+	return &pauseGate{control: control}
+}
+
+// apply records one control signal.
+func (g *pauseGate) apply(sig ControlSignal) {
+	switch sig {
+	case ControlPause:
+		g.paused = true
+	case ControlResume:
+		g.paused = false
+	}
+}
+
+// poll applies the signals already waiting without blocking.
+func (g *pauseGate) poll() {
+	for {
+		select {
+		case sig := <-g.control:
+			g.apply(sig)
+		default:
+			return
+		}
+	}
+}
+
+// wait returns once execution is not paused, or when ctx is done. Call it
+// before starting a bead.
+func (g *pauseGate) wait(ctx context.Context) {
+	g.poll()
+	for g.paused {
+		select {
+		case sig := <-g.control:
+			g.apply(sig)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
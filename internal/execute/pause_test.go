@@ -0,0 +1,76 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+)
+
+func TestPauseGateWaitsUntilResumed(t *testing.T) {
+	control := make(chan ControlSignal, 1)
+	gate := newPauseGate(control)
+
+	control <- ControlPause
+	done := make(chan struct{})
+	go func() {
+		gate.wait(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("wait returned while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	control <- ControlResume
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("wait did not return after resume")
+	}
+}
+
+func TestPauseGateWaitStopsOnCancel(t *testing.T) {
+	control := make(chan ControlSignal, 1)
+	control <- ControlPause
+	gate := newPauseGate(control)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	gate.wait(ctx)
+	if !gate.paused {
+		t.Error("canceling the wait should not resume the gate")
+	}
+}
+
+func TestPauseGateWithoutControlNeverPauses(t *testing.T) {
+	gate := newPauseGate(nil)
+	gate.wait(context.Background())
+	if gate.paused {
+		t.Error("gate without a control channel is paused")
+	}
+}
+
+func TestSchedulerLaunchesNothingWhilePaused(t *testing.T) {
+	cfg := *config.DefaultConfig()
+	s := NewScheduler(cfg, t.TempDir(), []beads.Bead{{ID: "bt-1"}, {ID: "bt-2"}}, NewExecutionPool(2),
+		nil, nil, nil, nil, nil, "", false)
+	control := make(chan ControlSignal, 1)
+	control <- ControlPause
+	s.setControl(control)
+
+	s.launchReady()
+
+	if s.running != 0 {
+		t.Errorf("running = %d while paused, want 0", s.running)
+	}
+	for _, id := range []string{"bt-1", "bt-2"} {
+		if got := s.nodes[id].Status; got != "pending" {
+			t.Errorf("%s status = %q while paused, want pending", id, got)
+		}
+	}
+}
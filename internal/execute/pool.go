@@ -8,7 +8,8 @@ import (
 
 // ExecutionPool tracks progress across all beads in an execution run.
 // All methods are thread-safe via mu. In sequential mode the mutex is
-// uncontested, adding zero overhead.
+// uncontested, adding zero overhead. Read the counters through the Get
+// methods while workers may still be recording; Total is fixed at creation.
 type ExecutionPool struct {
 	mu        sync.Mutex
 	Total     int
@@ -45,6 +46,27 @@ func (p *ExecutionPool) RecordSkip() {
 	p.Skipped++
 }
 
+// GetCompleted returns the completed count.
+func (p *ExecutionPool) GetCompleted() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.Completed
+}
+
+// GetStuck returns the stuck count.
+func (p *ExecutionPool) GetStuck() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.Stuck
+}
+
+// GetSkipped returns the skipped count.
+func (p *ExecutionPool) GetSkipped() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.Skipped
+}
+
 // Progress returns a formatted progress string like "[2/5]".
 func (p *ExecutionPool) Progress() string {
 	p.mu.Lock()
@@ -0,0 +1,42 @@
+package execute
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestExecutionPoolConcurrent(t *testing.T) {
+	pool := NewExecutionPool(90)
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			pool.RecordCompletion()
+		}()
+		go func() {
+			defer wg.Done()
+			pool.RecordStuck()
+			_ = pool.Progress()
+		}()
+		go func() {
+			defer wg.Done()
+			pool.RecordSkip()
+			_ = pool.IsComplete()
+		}()
+	}
+	wg.Wait()
+
+	if got := pool.GetCompleted(); got != 30 {
+		t.Errorf("Completed = %d, want 30", got)
+	}
+	if got := pool.GetStuck(); got != 30 {
+		t.Errorf("Stuck = %d, want 30", got)
+	}
+	if got := pool.GetSkipped(); got != 30 {
+		t.Errorf("Skipped = %d, want 30", got)
+	}
+	if !pool.IsComplete() || pool.Progress() != "[90/90]" {
+		t.Errorf("pool not complete after all beads recorded: %s", pool.Progress())
+	}
+}
@@ -0,0 +1,109 @@
+// protected.go guards against beads modifying files listed in
+// execution.protected_files.
+package execute
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/git"
+	"github.com/berth-dev/berth/internal/log"
+)
+
+// protectedFileReason is the stuck reason recorded when a bead's changes
+// touch a protected file.
+const protectedFileReason = "touched protected file"
+
+// checkProtectedFiles returns the changed files since baseRef that match a
+// protected glob. Plan validation already rejects beads that list protected
+// files; this catches Claude editing one anyway. Returns nil when no globs
+// are configured or baseRef is unknown.
+func checkProtectedFiles(cfg *config.Config, baseRef string) ([]string, error) {
+	globs := cfg.Execution.ProtectedFiles
+	if len(globs) == 0 || baseRef == "" {
+		return nil, nil
+	}
+
+	changed, err := git.ChangedFilesSince(baseRef)
+	if err != nil {
+		return nil, fmt.Errorf("listing changed files: %w", err)
+	}
+	return matchProtected(globs, changed), nil
+}
+
+// checkProtectedCommits is checkProtectedFiles for the changes between two
+// commits, e.g. a merge prepared in another checkout.
+func checkProtectedCommits(cfg *config.Config, from, to string) ([]string, error) {
+	globs := cfg.Execution.ProtectedFiles
+	if len(globs) == 0 {
+		return nil, nil
+	}
+
+	changed, err := git.ChangedFilesBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("listing changed files: %w", err)
+	}
+	return matchProtected(globs, changed), nil
+}
+
+// matchProtected returns the files matching one of the protected globs.
+func matchProtected(globs, files []string) []string {
+	var touched []string
+	for _, f := range files {
+		if config.MatchProtected(f, globs) != "" {
+			touched = append(touched, f)
+		}
+	}
+	return touched
+}
+
+// rejectProtectedChanges undoes a bead's changes when they touch a protected
+// file, so none of them land: the current branch is reset to baseRef and
+// protected files left untracked are removed. It returns the protected files
+// touched, or nil when there were none; they are returned even when undoing
+// the changes fails.
+func rejectProtectedChanges(cfg *config.Config, beadID, baseRef string) ([]string, error) {
+	touched, err := checkProtectedFiles(cfg, baseRef)
+	if err != nil || len(touched) == 0 {
+		return nil, err
+	}
+
+	if err := git.ResetHard(baseRef); err != nil {
+		return touched, fmt.Errorf("discarding changes of bead %s: %w", beadID, err)
+	}
+	// Untracked files survive the reset.
+	leftover, err := checkProtectedFiles(cfg, baseRef)
+	if err != nil {
+		return touched, err
+	}
+	for _, f := range leftover {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return touched, fmt.Errorf("removing %s: %w", f, err)
+		}
+	}
+	return touched, nil
+}
+
+// markProtectedStuck marks a bead whose changes were rejected for touching
+// protected files as stuck for a human to review, and logs why.
+func markProtectedStuck(bead *beads.Bead, touched []string, logger *log.Logger) {
+	fmt.Fprintf(os.Stderr, "Warning: bead %s %s, its changes were discarded: %s\n", bead.ID, protectedFileReason, strings.Join(touched, ", "))
+	if err := beads.UpdateStatus(bead.ID, "stuck"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update bead %s status: %v\n", bead.ID, err)
+	}
+	if logger == nil {
+		return
+	}
+	if logErr := logger.Append(log.LogEvent{
+		Event:  log.EventTaskStuck,
+		BeadID: bead.ID,
+		Title:  bead.Title,
+		Reason: protectedFileReason,
+		Error:  strings.Join(touched, ", "),
+	}); logErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to log task_stuck: %v\n", logErr)
+	}
+}
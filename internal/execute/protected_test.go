@@ -0,0 +1,193 @@
+package execute
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/git"
+)
+
+// initTestRepo creates a git repo with one commit in a temp dir and chdirs
+// into it, since the git package runs commands in the working directory.
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	for name, content := range map[string]string{"LICENSE": "MIT\n", "main.go": "package main\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := git.CommitFiles([]string{"LICENSE", "main.go"}, "initial"); err != nil {
+		t.Fatalf("initial commit: %v", err)
+	}
+	return dir
+}
+
+func TestCheckProtectedFilesDetectsEdits(t *testing.T) {
+	dir := initTestRepo(t)
+
+	base, err := git.HeadSHA()
+	if err != nil {
+		t.Fatalf("HeadSHA: %v", err)
+	}
+
+	// Simulate Claude committing a protected edit and leaving an untracked one.
+	if err := os.WriteFile(filepath.Join(dir, "LICENSE"), []byte("GPL\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.CommitFiles([]string{"LICENSE"}, "change license"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".github", "ci.yml"), []byte("on: push\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Execution.ProtectedFiles = []string{"LICENSE", ".github/**"}
+
+	touched, err := checkProtectedFiles(cfg, base)
+	if err != nil {
+		t.Fatalf("checkProtectedFiles: %v", err)
+	}
+	if len(touched) != 2 {
+		t.Fatalf("touched = %v, want LICENSE and .github/ci.yml", touched)
+	}
+	want := map[string]bool{"LICENSE": true, ".github/ci.yml": true}
+	for _, f := range touched {
+		if !want[f] {
+			t.Errorf("unexpected protected file reported: %s", f)
+		}
+	}
+}
+
+func TestCheckProtectedFilesIgnoresUnprotectedEdits(t *testing.T) {
+	dir := initTestRepo(t)
+
+	base, err := git.HeadSHA()
+	if err != nil {
+		t.Fatalf("HeadSHA: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Execution.ProtectedFiles = []string{"LICENSE"}
+
+	touched, err := checkProtectedFiles(cfg, base)
+	if err != nil {
+		t.Fatalf("checkProtectedFiles: %v", err)
+	}
+	if len(touched) != 0 {
+		t.Errorf("touched = %v, want none", touched)
+	}
+
+	// No globs configured: check is skipped entirely.
+	cfg.Execution.ProtectedFiles = nil
+	if touched, _ := checkProtectedFiles(cfg, base); touched != nil {
+		t.Errorf("expected nil with no protected globs, got %v", touched)
+	}
+}
+
+func TestRejectProtectedChangesDiscardsBead(t *testing.T) {
+	dir := initTestRepo(t)
+
+	base, err := git.HeadSHA()
+	if err != nil {
+		t.Fatalf("HeadSHA: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "LICENSE"), []byte("GPL\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.CommitFiles([]string{"LICENSE"}, "change license"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".github", "ci.yml"), []byte("on: push\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Execution.ProtectedFiles = []string{"LICENSE", ".github/**"}
+
+	touched, err := rejectProtectedChanges(cfg, "bt-1", base)
+	if err != nil {
+		t.Fatalf("rejectProtectedChanges: %v", err)
+	}
+	if len(touched) != 2 {
+		t.Errorf("touched = %v, want LICENSE and .github/ci.yml", touched)
+	}
+	if head, _ := git.HeadSHA(); head != base {
+		t.Errorf("HEAD = %s, want it reset to %s", head, base)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".github", "ci.yml")); !os.IsNotExist(err) {
+		t.Errorf("untracked protected file was not removed: %v", err)
+	}
+	if left, _ := checkProtectedFiles(cfg, base); len(left) != 0 {
+		t.Errorf("protected changes left after rejecting: %v", left)
+	}
+}
+
+func TestMergeParallelResultsUndoesProtectedMerge(t *testing.T) {
+	dir := initTestRepo(t)
+
+	trunk, err := git.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+	base, err := git.HeadSHA()
+	if err != nil {
+		t.Fatalf("HeadSHA: %v", err)
+	}
+	if err := git.CreateBranch("berth/worker/bt-1"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "LICENSE"), []byte("GPL\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.CommitFiles([]string{"LICENSE"}, "change license"); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Execution.ProtectedFiles = []string{"LICENSE"}
+
+	results := []ParallelResult{{BeadID: "bt-1", Passed: true}}
+	conflicts, err := MergeParallelResults(cfg, dir, trunk, results)
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("MergeParallelResults = %v, %v", conflicts, err)
+	}
+	if results[0].Passed || len(results[0].Protected) != 1 || results[0].Protected[0] != "LICENSE" {
+		t.Errorf("result = %+v, want failed with LICENSE protected", results[0])
+	}
+	if head, _ := git.HeadSHA(); head != base {
+		t.Errorf("trunk HEAD = %s, want the merge undone to %s", head, base)
+	}
+}
@@ -2,10 +2,11 @@
 package execute
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
@@ -13,7 +14,8 @@ import (
 
 // RunRescue opens an interactive Claude session with full terminal access.
 // The session is pre-loaded with context about the stuck bead, including
-// error outputs, diagnostic analysis, and Knowledge Graph data. The user
+// error outputs, diagnostic analysis, Knowledge Graph data, and the files
+// the errors point at (see suggestRescueFiles). The user
 // interacts directly with Claude to resolve the issue. On exit, the
 // caller should run the verification pipeline.
 func RunRescue(
@@ -24,10 +26,11 @@ func RunRescue(
 	graphData string,
 	projectRoot string,
 ) error {
-	rescueContext := buildRescueContext(bead, verifyErrors, diagnostic, graphData)
+	errOutput := strings.Join(append(append([]string(nil), verifyErrors...), diagnostic), "\n")
+	files := suggestRescueFiles(errOutput, projectRoot)
+	rescueContext := buildRescueContext(bead, verifyErrors, diagnostic, graphData, files)
 
-	cmd := exec.Command(
-		"claude",
+	cmd := cfg.Agent.Cmd(context.Background(),
 		"--append-system-prompt", rescueContext,
 		"--dangerously-skip-permissions",
 	)
@@ -51,10 +54,50 @@ func RunRescue(
 	return nil
 }
 
+// headlessRescuePrompt is the task given to the rescue session that
+// execution.stuck_policy "rescue" runs without a user.
+const headlessRescuePrompt = "Fix the stuck bead described in the system prompt so that the verification pipeline passes. Nobody is available to answer questions; make the changes and commit them."
+
+// RunHeadlessRescue runs the rescue session non-interactively: Claude gets
+// the same context as RunRescue but works from a fixed prompt, with no
+// terminal attached. It is bounded by execution.timeout_per_bead. As with
+// RunRescue, the caller should run the verification pipeline afterwards.
+func RunHeadlessRescue(
+	cfg config.Config,
+	bead *beads.Bead,
+	verifyErrors []string,
+	diagnostic string,
+	graphData string,
+	projectRoot string,
+) error {
+	errOutput := strings.Join(append(append([]string(nil), verifyErrors...), diagnostic), "\n")
+	files := suggestRescueFiles(errOutput, projectRoot)
+	rescueContext := buildRescueContext(bead, verifyErrors, diagnostic, graphData, files)
+
+	timeout := time.Duration(cfg.Execution.TimeoutPerBead) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := cfg.Agent.Cmd(ctx,
+		"-p", headlessRescuePrompt,
+		"--append-system-prompt", rescueContext,
+		"--dangerously-skip-permissions",
+	)
+	cmd.Dir = projectRoot
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("rescue session for bead %s: %s: %w", bead.ID, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
 // buildRescueContext assembles the append-system-prompt content for the
 // rescue session. It includes the bead description, all error outputs,
-// the diagnostic analysis, and any Knowledge Graph context.
-func buildRescueContext(bead *beads.Bead, errors []string, diagnostic string, graphData string) string {
+// the diagnostic analysis, any Knowledge Graph context, and the suggested
+// files.
+func buildRescueContext(bead *beads.Bead, errors []string, diagnostic string, graphData string, files []string) string {
 	var b strings.Builder
 
 	b.WriteString("## Rescue Session: ")
@@ -89,6 +132,16 @@ func buildRescueContext(bead *beads.Bead, errors []string, diagnostic string, gr
 		b.WriteString("\n\n")
 	}
 
+	if len(files) > 0 {
+		b.WriteString("### Files Mentioned by the Errors\n")
+		for _, f := range files {
+			b.WriteString("- ")
+			b.WriteString(f)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
 	b.WriteString("Help the user fix this. The verification pipeline must pass.\n")
 
 	return b.String()
@@ -2,23 +2,33 @@
 package execute
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
 	berthcontext "github.com/berth-dev/berth/internal/context"
 	"github.com/berth-dev/berth/internal/graph"
 	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/trace"
 	"github.com/berth-dev/berth/prompts"
 )
 
 // maxBlindRetries is the number of retries before escalating to diagnostic.
 const maxBlindRetries = 3
 
+// maxPromptLearnings caps how many of the most recent learnings are embedded
+// in the executor prompt, keeping prompt size bounded on long runs.
+const maxPromptLearnings = 20
+
 // BeadResult contains the outcome of a bead execution attempt.
 type BeadResult struct {
 	Passed       bool   // Whether verification passed
 	ClaudeOutput string // Claude's output text (for close reason)
+	AttemptsUsed int    // Attempt that passed, or attempts made if none did
+	Tokens       int    // Tokens used across all attempts
 }
 
 // RetryBead implements the "3+1" retry strategy for a single bead:
@@ -32,8 +42,59 @@ type BeadResult struct {
 //     prompt. If verification passes, return true. Otherwise return false,
 //     signaling the bead is stuck and the caller should handle escalation.
 //
-// Returns BeadResult with the outcome and Claude's output text for close reasons.
+// Returns BeadResult with the outcome, Claude's output text for close reasons,
+// and the number of attempts used.
+//
+// The whole loop is bounded by ctx and by cfg.Execution.BeadTimeout: when
+// either ends, the running Claude process is killed, no further attempts are
+// made, and an error is returned ("bead timed out after ..." for the bead
+// timeout).
 func RetryBead(
+	ctx context.Context,
+	cfg config.Config,
+	bead *beads.Bead,
+	graphData string,
+	projectRoot string,
+	logger *log.Logger,
+	kgClient *graph.Client,
+	opts *SpawnClaudeOpts,
+) (*BeadResult, error) {
+	beadCtx := ctx
+	timeout := time.Duration(cfg.Execution.BeadTimeout) * time.Second
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		beadCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	spawnOpts := SpawnClaudeOpts{}
+	if opts != nil {
+		spawnOpts = *opts
+	}
+	spawnOpts.Ctx = beadCtx
+
+	endGuidance := beginGuidance(bead.ID)
+	defer endGuidance()
+
+	span := trace.Start("bead", trace.String("bead.id", bead.ID), trace.String("bead.title", bead.Title))
+	result, err := retryBead(beadCtx, cfg, bead, graphData, projectRoot, logger, kgClient, &spawnOpts, span)
+	if (result == nil || !result.Passed) && ctx.Err() == nil && errors.Is(beadCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("bead timed out after %s: %w", timeout, context.DeadlineExceeded)
+	}
+	if result != nil {
+		result.Tokens = spawnOpts.tokens
+		span.Set(trace.Bool("passed", result.Passed), trace.Int("attempts", result.AttemptsUsed))
+	}
+	span.SetError(err)
+	span.End()
+	return result, err
+}
+
+// retryBead is RetryBead's body; attempts are traced as children of span.
+// It stops before the next attempt once ctx is done. Guidance submitted
+// with SubmitGuidance is added to the next attempt's prompt, and guidance
+// that arrives during the last attempt earns the bead one more attempt.
+func retryBead(
+	ctx context.Context,
 	cfg config.Config,
 	bead *beads.Bead,
 	graphData string,
@@ -41,8 +102,11 @@ func RetryBead(
 	logger *log.Logger,
 	kgClient *graph.Client,
 	opts *SpawnClaudeOpts,
+	span *trace.Span,
 ) (*BeadResult, error) {
-	learnings := berthcontext.ReadLearnings(projectRoot)
+	// Learnings are re-read for every bead so that entries appended by
+	// earlier beads in this run are visible to later ones.
+	learnings := promptLearnings(projectRoot)
 	systemPrompt := prompts.ExecutorSystemPrompt
 	if opts != nil && opts.SystemPrompt != "" {
 		systemPrompt = opts.SystemPrompt
@@ -52,18 +116,27 @@ func RetryBead(
 
 	// Phase 1: blind retries (attempts 1-3).
 	for attempt := 1; attempt <= maxBlindRetries; attempt++ {
-		taskPrompt := BuildExecutorPrompt(bead, attempt, nil, graphData, learnings)
+		if err := ctx.Err(); err != nil {
+			return &BeadResult{Passed: false, AttemptsUsed: attempt - 1}, err
+		}
+		taskPrompt := appendGuidance(BuildExecutorPrompt(bead, attempt, nil, graphData, learnings), awaitGuidance(ctx, bead.ID))
 
+		emitAttempt(opts, bead.ID, attempt)
+		attemptSpan := span.Child("attempt", trace.Int("attempt", attempt))
 		output, err := SpawnClaude(cfg, systemPrompt, taskPrompt, projectRoot, opts)
 		if err != nil {
 			collectedErrors = append(collectedErrors, fmt.Sprintf("spawn error (attempt %d): %v", attempt, err))
 			logRetry(logger, bead, attempt, fmt.Sprintf("spawn error: %v", err))
+			endAttemptSpan(attemptSpan, "spawn_error", err)
 			continue
 		}
+		traceClaudeOutput(attemptSpan, output)
+		logUsage(logger, bead, attempt, output)
 
 		if output.IsError {
 			collectedErrors = append(collectedErrors, fmt.Sprintf("claude error (attempt %d): %s", attempt, output.Result))
 			logRetry(logger, bead, attempt, output.Result)
+			endAttemptSpan(attemptSpan, "claude_error", nil)
 			continue
 		}
 
@@ -71,59 +144,166 @@ func RetryBead(
 		if opts != nil {
 			workDir = opts.WorkDir
 		}
-		result, err := RunVerification(cfg, bead, workDir)
+		result, err := tracedVerification(attemptSpan, cfg, bead, workDir, opts != nil && opts.Verbose)
 		if err != nil {
 			collectedErrors = append(collectedErrors, fmt.Sprintf("verify error (attempt %d): %v", attempt, err))
 			logRetry(logger, bead, attempt, fmt.Sprintf("verify error: %v", err))
+			endAttemptSpan(attemptSpan, "verify_error", err)
 			continue
 		}
 
 		if result.Passed {
-			logVerifyPassed(logger, bead, attempt)
-			return &BeadResult{Passed: true, ClaudeOutput: output.Result}, nil
+			logVerifyPassed(logger, bead, attempt, result.Steps)
+			endAttemptSpan(attemptSpan, "passed", nil)
+			return &BeadResult{Passed: true, ClaudeOutput: output.Result, AttemptsUsed: attempt}, nil
 		}
 
 		// Verification failed: collect the error output.
 		errMsg := fmt.Sprintf("verify failed at '%s' (attempt %d):\n%s", result.FailedStep, attempt, result.Output)
 		collectedErrors = append(collectedErrors, errMsg)
-		logVerifyFailed(logger, bead, attempt, result.FailedStep, result.Output)
+		logVerifyFailed(logger, bead, attempt, result)
+		endAttemptSpan(attemptSpan, "verify_failed", nil)
 	}
 
 	// Phase 2: diagnostic retry (attempt 4).
+	if err := ctx.Err(); err != nil {
+		return &BeadResult{Passed: false, AttemptsUsed: maxBlindRetries}, err
+	}
 	logDiagnosing(logger, bead)
 
-	diagnosis, err := RunDiagnostic(cfg, bead, collectedErrors, projectRoot)
+	diagnosis, err := RunDiagnostic(ctx, cfg, bead, collectedErrors, projectRoot)
 	if err != nil {
-		return &BeadResult{Passed: false}, fmt.Errorf("diagnostic failed for bead %s: %w", bead.ID, err)
+		return &BeadResult{Passed: false, AttemptsUsed: maxBlindRetries}, fmt.Errorf("diagnostic failed for bead %s: %w", bead.ID, err)
+	}
+
+	taskPrompt := appendGuidance(BuildExecutorPrompt(bead, maxBlindRetries+1, &diagnosis, graphData, learnings), awaitGuidance(ctx, bead.ID))
+	result, err := diagnosedAttempt(cfg, bead, systemPrompt, taskPrompt, maxBlindRetries+1, projectRoot, logger, opts, span)
+	if err != nil || result.Passed {
+		return result, err
 	}
 
-	taskPrompt := BuildExecutorPrompt(bead, maxBlindRetries+1, &diagnosis, graphData, learnings)
+	// Guidance sent while the last attempt ran would otherwise be dropped.
+	userGuidance := awaitGuidance(ctx, bead.ID)
+	if userGuidance == "" || ctx.Err() != nil {
+		return result, nil
+	}
+	taskPrompt = appendGuidance(BuildExecutorPrompt(bead, maxBlindRetries+2, &diagnosis, graphData, learnings), userGuidance)
+	return diagnosedAttempt(cfg, bead, systemPrompt, taskPrompt, maxBlindRetries+2, projectRoot, logger, opts, span)
+}
 
+// diagnosedAttempt runs one attempt after the diagnostic: spawn Claude with
+// taskPrompt, then verify. Spawn and verification errors are returned;
+// a failed verification is reported in the result.
+func diagnosedAttempt(
+	cfg config.Config,
+	bead *beads.Bead,
+	systemPrompt, taskPrompt string,
+	attempt int,
+	projectRoot string,
+	logger *log.Logger,
+	opts *SpawnClaudeOpts,
+	span *trace.Span,
+) (*BeadResult, error) {
+	emitAttempt(opts, bead.ID, attempt)
+	attemptSpan := span.Child("attempt", trace.Int("attempt", attempt), trace.Bool("diagnostic", true))
 	output, err := SpawnClaude(cfg, systemPrompt, taskPrompt, projectRoot, opts)
 	if err != nil {
-		return &BeadResult{Passed: false}, fmt.Errorf("diagnostic spawn failed for bead %s: %w", bead.ID, err)
+		endAttemptSpan(attemptSpan, "spawn_error", err)
+		return &BeadResult{Passed: false, AttemptsUsed: attempt}, fmt.Errorf("diagnostic spawn failed for bead %s: %w", bead.ID, err)
 	}
+	traceClaudeOutput(attemptSpan, output)
+	logUsage(logger, bead, attempt, output)
 
 	if output.IsError {
-		return &BeadResult{Passed: false, ClaudeOutput: output.Result}, nil
+		endAttemptSpan(attemptSpan, "claude_error", nil)
+		return &BeadResult{Passed: false, ClaudeOutput: output.Result, AttemptsUsed: attempt}, nil
 	}
 
 	workDir := ""
 	if opts != nil {
 		workDir = opts.WorkDir
 	}
-	result, err := RunVerification(cfg, bead, workDir)
+	result, err := tracedVerification(attemptSpan, cfg, bead, workDir, opts != nil && opts.Verbose)
 	if err != nil {
-		return &BeadResult{Passed: false, ClaudeOutput: output.Result}, fmt.Errorf("post-diagnostic verify failed for bead %s: %w", bead.ID, err)
+		endAttemptSpan(attemptSpan, "verify_error", err)
+		return &BeadResult{Passed: false, ClaudeOutput: output.Result, AttemptsUsed: attempt}, fmt.Errorf("post-diagnostic verify failed for bead %s: %w", bead.ID, err)
 	}
 
 	if result.Passed {
-		logVerifyPassed(logger, bead, maxBlindRetries+1)
-		return &BeadResult{Passed: true, ClaudeOutput: output.Result}, nil
+		logVerifyPassed(logger, bead, attempt, result.Steps)
+		endAttemptSpan(attemptSpan, "passed", nil)
+		return &BeadResult{Passed: true, ClaudeOutput: output.Result, AttemptsUsed: attempt}, nil
 	}
 
-	logVerifyFailed(logger, bead, maxBlindRetries+1, result.FailedStep, result.Output)
-	return &BeadResult{Passed: false, ClaudeOutput: output.Result}, nil
+	logVerifyFailed(logger, bead, attempt, result)
+	endAttemptSpan(attemptSpan, "verify_failed", nil)
+	return &BeadResult{Passed: false, ClaudeOutput: output.Result, AttemptsUsed: attempt}, nil
+}
+
+// emitAttempt streams an "attempt" event for the attempt about to start when
+// opts streams to the TUI. The budget is the blind retries plus the
+// diagnostic retry; an attempt earned by late guidance extends it.
+func emitAttempt(opts *SpawnClaudeOpts, beadID string, attempt int) {
+	if opts == nil || opts.OutputChan == nil {
+		return
+	}
+	sendEvent(opts.OutputChan, StreamEvent{
+		Type:        "attempt",
+		BeadID:      beadID,
+		Attempt:     attempt,
+		MaxAttempts: max(attempt, maxBlindRetries+1),
+	})
+}
+
+// traceClaudeOutput records a Claude invocation's usage on span.
+func traceClaudeOutput(span *trace.Span, output *ClaudeOutput) {
+	span.Set(
+		trace.Int("tokens", output.Tokens),
+		trace.Float("cost_usd", output.CostUSD),
+		trace.Bool("claude.is_error", output.IsError),
+	)
+}
+
+// tracedVerification runs RunVerification inside a "verify" child of span.
+// In verbose mode each step that ran is printed with its source.
+func tracedVerification(span *trace.Span, cfg config.Config, bead *beads.Bead, workDir string, verbose bool) (*VerifyResult, error) {
+	verifySpan := span.Child("verify")
+	result, err := RunVerification(cfg, bead, workDir)
+	if result != nil && verbose {
+		printVerifySteps(result)
+	}
+	if result != nil {
+		verifySpan.Set(trace.Bool("passed", result.Passed))
+		if !result.Passed {
+			verifySpan.Set(trace.String("failed_step", result.FailedStep))
+		}
+	}
+	verifySpan.SetError(err)
+	verifySpan.End()
+	return result, err
+}
+
+// printVerifySteps prints each verification step that ran, its source, and
+// whether it passed.
+func printVerifySteps(result *VerifyResult) {
+	failed := make(map[VerifyStep]bool, len(result.FailedSteps))
+	for _, step := range result.FailedSteps {
+		failed[step] = true
+	}
+	for _, step := range result.Steps {
+		status := "passed"
+		if failed[step] {
+			status = "FAILED"
+		}
+		fmt.Printf("  verify %s: %s\n", step, status)
+	}
+}
+
+// endAttemptSpan records an attempt's outcome and ends its span.
+func endAttemptSpan(span *trace.Span, outcome string, err error) {
+	span.Set(trace.String("outcome", outcome))
+	span.SetError(err)
+	span.End()
 }
 
 // logRetry logs a task_retry event.
@@ -140,8 +320,24 @@ func logRetry(logger *log.Logger, bead *beads.Bead, attempt int, reason string)
 	})
 }
 
-// logVerifyPassed logs a verify_passed event.
-func logVerifyPassed(logger *log.Logger, bead *beads.Bead, attempt int) {
+// logUsage logs a claude_usage event with an attempt's tokens and cost.
+func logUsage(logger *log.Logger, bead *beads.Bead, attempt int, output *ClaudeOutput) {
+	if logger == nil {
+		return
+	}
+	_ = logger.Append(log.LogEvent{
+		Event:   log.EventClaudeUsage,
+		BeadID:  bead.ID,
+		Title:   bead.Title,
+		Attempt: attempt,
+		Tokens:  output.Tokens,
+		CostUSD: output.CostUSD,
+	})
+}
+
+// logVerifyPassed logs a verify_passed event listing the steps that ran and
+// their sources.
+func logVerifyPassed(logger *log.Logger, bead *beads.Bead, attempt int, steps []VerifyStep) {
 	if logger == nil {
 		return
 	}
@@ -150,11 +346,13 @@ func logVerifyPassed(logger *log.Logger, bead *beads.Bead, attempt int) {
 		BeadID:  bead.ID,
 		Title:   bead.Title,
 		Attempt: attempt,
+		Data:    map[string]interface{}{"steps": steps},
 	})
 }
 
-// logVerifyFailed logs a verify_failed event.
-func logVerifyFailed(logger *log.Logger, bead *beads.Bead, attempt int, step string, output string) {
+// logVerifyFailed logs a verify_failed event. Data records the failing
+// steps with their sources.
+func logVerifyFailed(logger *log.Logger, bead *beads.Bead, attempt int, result *VerifyResult) {
 	if logger == nil {
 		return
 	}
@@ -163,8 +361,9 @@ func logVerifyFailed(logger *log.Logger, bead *beads.Bead, attempt int, step str
 		BeadID:  bead.ID,
 		Title:   bead.Title,
 		Attempt: attempt,
-		Step:    step,
-		Error:   output,
+		Step:    result.FailedStep,
+		Error:   result.Output,
+		Data:    map[string]interface{}{"failed_steps": result.FailedSteps},
 	})
 }
 
@@ -181,3 +380,9 @@ func logDiagnosing(logger *log.Logger, bead *beads.Bead) {
 		Data:   map[string]interface{}{"phase": "diagnosing"},
 	})
 }
+
+// promptLearnings returns the most recent learnings to embed in the executor
+// prompt, bounded by maxPromptLearnings.
+func promptLearnings(projectRoot string) []string {
+	return berthcontext.ReadRecentLearnings(projectRoot, maxPromptLearnings)
+}
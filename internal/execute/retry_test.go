@@ -0,0 +1,116 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+// installFakeClaude puts a `claude` script on PATH that always reports a
+// successful result, so RetryBead's outcome depends only on verification.
+func installFakeClaude(t *testing.T) {
+	t.Helper()
+	testutil.FakeCommand(t, "claude", "echo '{\"type\":\"result\",\"result\":\"feat: done\",\"is_error\":false}'\n")
+}
+
+func TestRetryBeadReportsWinningAttempt(t *testing.T) {
+	installFakeClaude(t)
+	workDir := t.TempDir()
+
+	// Verification fails on the first two runs and passes on the third.
+	cfg := config.Config{
+		VerifyPipeline: []string{`n=$(cat attempts 2>/dev/null || echo 0); n=$((n+1)); echo $n > attempts; [ "$n" -ge 3 ]`},
+	}
+	bead := &beads.Bead{ID: "bt-1", Title: "Flaky bead"}
+	opts := &SpawnClaudeOpts{WorkDir: workDir, BeadID: bead.ID}
+
+	result, err := RetryBead(context.Background(), cfg, bead, "", workDir, nil, nil, opts)
+	if err != nil {
+		t.Fatalf("RetryBead: %v", err)
+	}
+	if !result.Passed {
+		t.Fatal("expected bead to pass on third attempt")
+	}
+	if result.AttemptsUsed != 3 {
+		t.Errorf("AttemptsUsed = %d, want 3", result.AttemptsUsed)
+	}
+}
+
+func TestRetryBeadFirstAttempt(t *testing.T) {
+	installFakeClaude(t)
+	workDir := t.TempDir()
+
+	cfg := config.Config{VerifyPipeline: []string{"true"}}
+	bead := &beads.Bead{ID: "bt-2", Title: "Easy bead"}
+	opts := &SpawnClaudeOpts{WorkDir: workDir, BeadID: bead.ID}
+
+	result, err := RetryBead(context.Background(), cfg, bead, "", workDir, nil, nil, opts)
+	if err != nil {
+		t.Fatalf("RetryBead: %v", err)
+	}
+	if !result.Passed || result.AttemptsUsed != 1 {
+		t.Errorf("got Passed=%v AttemptsUsed=%d, want true 1", result.Passed, result.AttemptsUsed)
+	}
+}
+
+func TestRetryBeadBeadTimeoutKillsClaude(t *testing.T) {
+	testutil.FakeCommand(t, "claude", "exec sleep 30\n")
+	workDir := t.TempDir()
+
+	cfg := config.Config{VerifyPipeline: []string{"true"}}
+	cfg.Execution.BeadTimeout = 1
+	bead := &beads.Bead{ID: "bt-3", Title: "Hanging bead"}
+
+	start := time.Now()
+	result, err := RetryBead(context.Background(), cfg, bead, "", workDir, nil, nil, &SpawnClaudeOpts{WorkDir: workDir})
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("RetryBead took %s; bead timeout did not stop it", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "bead timed out after 1s") {
+		t.Fatalf("err = %v, want bead timeout", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("timeout error should wrap context.DeadlineExceeded")
+	}
+	if result == nil || result.Passed {
+		t.Errorf("result = %+v, want a failed result", result)
+	}
+}
+
+func TestRunParallelReportsAttempts(t *testing.T) {
+	dir := initTestRepo(t)
+	installFakeClaude(t)
+	fakeBD(t, `[{"id":"bt-1","title":"Add search","status":"open"}]`)
+
+	cfg := config.DefaultConfig()
+	cfg.VerifyPipeline = []string{"true"}
+	results := RunParallel(context.Background(), ExecutionGroup{BeadIDs: []string{"bt-1"}}, dir, cfg, nil, "", nil)
+	if len(results) != 1 || !results[0].Passed {
+		t.Fatalf("results = %+v, want bt-1 passed", results)
+	}
+	if results[0].AttemptsUsed != 1 {
+		t.Errorf("AttemptsUsed = %d, want 1", results[0].AttemptsUsed)
+	}
+}
+
+func TestRunDiagnosticStopsWithContext(t *testing.T) {
+	claude := testutil.FakeScript(t, "claude", "exec sleep 10\n")
+	cfg := config.Config{Agent: config.AgentConfig{Command: claude}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := RunDiagnostic(ctx, cfg, &beads.Bead{ID: "bt-1"}, nil, t.TempDir())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("RunDiagnostic error = %v, want the context's deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RunDiagnostic took %s after its context expired", elapsed)
+	}
+}
@@ -2,6 +2,7 @@
 package execute
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/berth-dev/berth/internal/coordinator"
 	"github.com/berth-dev/berth/internal/graph"
 	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/session"
 	"github.com/berth-dev/berth/prompts"
 )
 
@@ -23,6 +25,7 @@ type BeadNode struct {
 	DependsOn  []string // bead IDs this node depends on
 	DependedBy []string // bead IDs that depend on this node
 	Status     string   // "pending"|"running"|"completed"|"failed"|"skipped"
+	Tokens     int      // tokens the bead's worker used
 }
 
 // Scheduler manages concurrent bead execution up to MaxParallel,
@@ -44,6 +47,23 @@ type Scheduler struct {
 	systemPrompt string
 	verbose      bool
 	wg           sync.WaitGroup
+
+	// Checkpoint state, saved after each merge when runDir is set.
+	runDir         string
+	runID          string
+	completedBeads []string
+	failedBeads    []string
+	retryCount     map[string]int
+	consecFailures int
+
+	// tokensUsed counts every finished bead's tokens against the token
+	// budget; once it is exceeded no new bead is launched.
+	tokensUsed int
+	overBudget bool
+
+	snapshots *snapshotter       // tags trunk every N completed beads, when enabled
+	states    *beadStateRecorder // mirrors bead transitions into the session store
+	pause     *pauseGate         // while paused, no new bead is launched
 }
 
 // NewScheduler builds a dependency graph from the bead list and returns a
@@ -108,28 +128,118 @@ func NewScheduler(
 		logger:       logger,
 		systemPrompt: systemPrompt,
 		verbose:      verbose,
+		pause:        newPauseGate(nil),
 	}
 }
 
+// setSnapshots makes Run tag the trunk branch as beads complete. A nil
+// snapshotter disables snapshots.
+func (s *Scheduler) setSnapshots(snapshots *snapshotter) {
+	s.snapshots = snapshots
+}
+
+// setControl makes the scheduler follow the pause and resume signals sent
+// on control. A nil channel never pauses.
+func (s *Scheduler) setControl(control <-chan ControlSignal) {
+	s.pause = newPauseGate(control)
+}
+
+// setBeadStates makes the scheduler record bead transitions in the session
+// store. A nil recorder disables recording.
+func (s *Scheduler) setBeadStates(states *beadStateRecorder) {
+	s.states = states
+}
+
+// EnableCheckpoints makes Run save a checkpoint to runDir after every merge
+// result so an interrupted parallel run can resume. state, when non-nil,
+// seeds the checkpoint with progress restored from a previous run.
+func (s *Scheduler) EnableCheckpoints(runDir, runID string, state *ExecuteState) {
+	s.runDir = runDir
+	s.runID = runID
+	s.completedBeads = []string{}
+	s.failedBeads = []string{}
+	s.retryCount = make(map[string]int)
+	if state != nil {
+		s.completedBeads = append(s.completedBeads, state.CompletedBeads...)
+		s.failedBeads = append(s.failedBeads, state.FailedBeads...)
+		if state.RetryCount != nil {
+			s.retryCount = state.RetryCount
+		}
+		s.consecFailures = state.ConsecFailures
+		s.tokensUsed = state.TokensUsed
+	}
+}
+
+// recordCheckpoint folds a merge result into the checkpoint state and saves
+// it. No-op unless EnableCheckpoints was called. Must be called with s.mu held.
+func (s *Scheduler) recordCheckpoint(result MergeResult) {
+	if s.runDir == "" {
+		return
+	}
+	lastError := ""
+	if result.Success {
+		s.completedBeads = append(s.completedBeads, result.BeadID)
+		s.consecFailures = 0
+	} else {
+		s.failedBeads = append(s.failedBeads, result.BeadID)
+		s.consecFailures++
+		if result.Error != nil {
+			lastError = result.Error.Error()
+		}
+	}
+	saveCheckpointState(s.runDir, s.runID, s.orderedIDs, result.BeadID, s.completedBeads, s.failedBeads, s.retryCount, s.consecFailures, s.tokensUsed, lastError)
+}
+
 // Run executes the scheduling loop: launch ready beads, process merge results,
-// repeat until all beads are done.
+// repeat until all beads are done. Once the token budget is exceeded it
+// stops launching beads, waits for the running ones and returns an error
+// matching ErrAborted. Pause and resume signals are applied as they arrive.
 func (s *Scheduler) Run() error {
+	defer s.watchLockWaits()()
 	s.launchReady()
 
-	for result := range s.mergeQueue.Results() {
+	results := s.mergeQueue.Results()
+loop:
+	for {
+		var result MergeResult
+		select {
+		case r, ok := <-results:
+			if !ok {
+				break loop
+			}
+			result = r
+		case sig := <-s.pause.control:
+			// Pausing only gates launches; running beads carry on.
+			s.mu.Lock()
+			s.pause.apply(sig)
+			s.mu.Unlock()
+			s.launchReady()
+			continue
+		}
+
 		s.mu.Lock()
 		node, ok := s.nodes[result.BeadID]
 		if ok {
 			if result.Success {
 				node.Status = "completed"
+				s.states.end(node.Bead.ID, session.BeadCompleted, node.Tokens)
 				s.pool.RecordCompletion()
+				s.snapshots.maybeSnapshot(s.pool.GetCompleted())
 			} else {
 				node.Status = "failed"
+				s.states.end(node.Bead.ID, session.BeadFailed, node.Tokens)
 				s.pool.RecordStuck()
 				s.cascadeFailure(node)
 			}
 			s.running--
+			s.tokensUsed += node.Tokens
+			if !s.overBudget && overBudget(&s.cfg, s.tokensUsed) {
+				s.overBudget = true
+				fmt.Fprintf(os.Stderr, "Token budget of %d exceeded (%d used); waiting for running beads to finish\n", s.cfg.Execution.TokenBudget, s.tokensUsed)
+			}
+			s.recordCheckpoint(result)
 		}
+		stop := s.overBudget && s.running == 0
 		s.mu.Unlock()
 
 		if result.Error != nil {
@@ -138,24 +248,33 @@ func (s *Scheduler) Run() error {
 
 		s.launchReady()
 
-		if s.pool.IsComplete() {
+		if stop || s.pool.IsComplete() {
 			break
 		}
 	}
 
 	s.wg.Wait()
+	if s.overBudget {
+		return budgetError(&s.cfg, s.tokensUsed)
+	}
 	return nil
 }
 
 // launchReady finds all unblocked pending beads and launches goroutines
 // for them, up to maxParallel concurrent workers. Iterates in sorted ID
-// order for deterministic, reproducible scheduling.
+// order for deterministic, reproducible scheduling. Nothing is launched
+// while the scheduler is paused.
 func (s *Scheduler) launchReady() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.pause.poll()
+	if s.pause.paused {
+		return
+	}
+
 	for _, id := range s.orderedIDs {
-		if s.running >= s.maxParallel {
+		if s.running >= s.maxParallel || s.overBudget {
 			break
 		}
 		node := s.nodes[id]
@@ -197,6 +316,7 @@ func (s *Scheduler) cascadeFailure(node *BeadNode) {
 			continue
 		}
 		depNode.Status = "skipped"
+		s.states.end(depID, session.BeadSkipped, 0)
 		s.pool.RecordSkip()
 		// Recurse to skip transitive dependents.
 		s.cascadeFailure(depNode)
@@ -204,6 +324,7 @@ func (s *Scheduler) cascadeFailure(node *BeadNode) {
 }
 
 // executeWorker runs a single bead in its own goroutine:
+// 0. Skip it if its work is already applied
 // 1. Create worktree
 // 2. Pre-embed graph data
 // 3. Generate MCP config
@@ -215,6 +336,25 @@ func (s *Scheduler) executeWorker(node *BeadNode) {
 	bead := node.Bead
 	beadID := bead.ID
 
+	// Load sidecar metadata.
+	if meta, err := beads.ReadBeadMeta(s.projectRoot, beadID); err == nil {
+		if len(bead.Files) == 0 && len(meta.Files) > 0 {
+			bead.Files = meta.Files
+		}
+		bead.VerifyExtra = meta.VerifyExtra
+		bead.Meta = meta.Meta
+		bead.NoFiles = meta.NoFiles
+	}
+
+	// Skip beads whose work is already present in the tree; the merge queue
+	// reports them as completed without merging anything.
+	if beads.IsApplied(s.projectRoot, bead) {
+		fmt.Printf("%s %s: %s (already applied, skipping)\n", s.pool.Progress(), beadID, bead.Title)
+		onBeadAlreadyApplied(bead, s.logger)
+		s.mergeQueue.Submit(MergeRequest{Bead: bead, Success: true, Applied: true})
+		return
+	}
+
 	// Log worker start.
 	if s.logger != nil {
 		_ = s.logger.Append(log.LogEvent{
@@ -227,18 +367,11 @@ func (s *Scheduler) executeWorker(node *BeadNode) {
 
 	fmt.Printf("%s %s: %s (parallel worker)\n", s.pool.Progress(), beadID, bead.Title)
 
-	// Load sidecar metadata.
-	if meta, err := beads.ReadBeadMeta(s.projectRoot, beadID); err == nil {
-		if len(bead.Files) == 0 && len(meta.Files) > 0 {
-			bead.Files = meta.Files
-		}
-		bead.VerifyExtra = meta.VerifyExtra
-	}
-
 	// Mark bead as in_progress.
 	if err := beads.UpdateStatus(beadID, "in_progress"); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to update bead %s status: %v\n", beadID, err)
 	}
+	s.states.begin(beadID)
 
 	// Create worktree.
 	worktreePath, err := s.worktrees.Create(beadID)
@@ -253,7 +386,7 @@ func (s *Scheduler) executeWorker(node *BeadNode) {
 	}
 
 	// Pre-embed graph data.
-	graphData := preEmbedGraphData(s.kgClient, bead.Files)
+	graphData := embedBeadContext(s.cfg, s.kgClient, s.projectRoot, bead)
 
 	// Generate MCP config for coordinator bridge.
 	mcpConfigPath := filepath.Join(worktreePath, "mcp-config.json")
@@ -272,13 +405,19 @@ func (s *Scheduler) executeWorker(node *BeadNode) {
 	}
 
 	// Run retry loop.
-	beadResult, retryErr := RetryBead(s.cfg, bead, graphData, s.projectRoot, s.logger, s.kgClient, opts)
+	beadResult, retryErr := RetryBead(context.Background(), s.cfg, bead, graphData, s.projectRoot, s.logger, s.kgClient, opts)
 	if retryErr != nil {
 		fmt.Fprintf(os.Stderr, "Error during parallel bead %s execution: %v\n", beadID, retryErr)
 	}
 
 	// Extract success status from result.
 	passed := beadResult != nil && beadResult.Passed
+	if beadResult != nil {
+		bead.AttemptsUsed = beadResult.AttemptsUsed
+		s.mu.Lock()
+		node.Tokens = beadResult.Tokens
+		s.mu.Unlock()
+	}
 
 	// Log worker completion.
 	if s.logger != nil {
@@ -0,0 +1,97 @@
+// sessionstate.go mirrors bead transitions into the session store so the
+// dashboard, ListSessions, and resume see a run's progress as it happens.
+package execute
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/berth-dev/berth/internal/session"
+)
+
+// beadStateRecorder writes each bead transition to the beads_state table of
+// one session. A nil recorder records nothing.
+type beadStateRecorder struct {
+	mu        sync.Mutex
+	store     *session.Store
+	sess      *session.Session
+	startedAt map[string]time.Time
+}
+
+// openBeadStateRecorder opens the project's session store and attaches to
+// the active session of the project for task, the run's branch (the one an
+// interrupted run on that branch left behind), creating one when there is
+// none. Sessions of other projects and runs are never written to. It warns and returns nil when the store is unavailable.
+func openBeadStateRecorder(projectRoot, project, task string) *beadStateRecorder {
+	store, err := session.NewStore(session.DefaultPath(projectRoot))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: session store unavailable, bead states will not be saved: %v\n", err)
+		return nil
+	}
+	sess, err := store.GetActiveForTask(project, task)
+	if err == nil && sess == nil {
+		sess, err = store.CreateSession(project, task)
+	}
+	if err != nil {
+		_ = store.Close()
+		fmt.Fprintf(os.Stderr, "Warning: no session for bead states: %v\n", err)
+		return nil
+	}
+	return &beadStateRecorder{store: store, sess: sess, startedAt: make(map[string]time.Time)}
+}
+
+// begin records the bead as in progress and starts its duration clock.
+func (r *beadStateRecorder) begin(beadID string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.startedAt[beadID] = time.Now()
+	r.mu.Unlock()
+	r.write(beadID, session.BeadInProgress, 0, 0)
+}
+
+// end records the bead's final status with the tokens it used and the time
+// since begin (zero for a bead that never began, e.g. one skipped because a
+// dependency failed).
+func (r *beadStateRecorder) end(beadID, status string, tokens int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	var durationMs int64
+	if start, ok := r.startedAt[beadID]; ok {
+		durationMs = time.Since(start).Milliseconds()
+		delete(r.startedAt, beadID)
+	}
+	r.mu.Unlock()
+	r.write(beadID, status, tokens, durationMs)
+}
+
+func (r *beadStateRecorder) write(beadID, status string, tokens int, durationMs int64) {
+	if err := r.store.UpdateBeadState(r.sess.ID, beadID, status, tokens, durationMs); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save state of bead %s: %v\n", beadID, err)
+	}
+}
+
+// complete marks the session completed once the run has finished, so the
+// next run starts a session of its own.
+func (r *beadStateRecorder) complete() {
+	if r == nil {
+		return
+	}
+	r.sess.Status = "completed"
+	if err := r.store.UpdateSession(r.sess); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to mark session completed: %v\n", err)
+	}
+}
+
+// close releases the session store.
+func (r *beadStateRecorder) close() {
+	if r == nil {
+		return
+	}
+	_ = r.store.Close()
+}
@@ -0,0 +1,136 @@
+package execute
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/session"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+func TestBeadStateRecorderWritesEachTransition(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".berth"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	r := openBeadStateRecorder(projectRoot, "proj", "berth/auth")
+	if r == nil {
+		t.Fatal("openBeadStateRecorder returned nil")
+	}
+	defer r.close()
+
+	store, err := session.NewStore(session.DefaultPath(projectRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	status := func(beadID string) session.BeadState {
+		t.Helper()
+		states, err := store.GetBeadStates(r.sess.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range states {
+			if s.BeadID == beadID {
+				return s
+			}
+		}
+		t.Fatalf("no state row for %s", beadID)
+		return session.BeadState{}
+	}
+
+	r.begin("bt-1")
+	if got := status("bt-1").Status; got != session.BeadInProgress {
+		t.Errorf("after begin: status = %q, want %q", got, session.BeadInProgress)
+	}
+	r.end("bt-1", session.BeadCompleted, 1200)
+	if got := status("bt-1"); got.Status != session.BeadCompleted || got.Tokens != 1200 {
+		t.Errorf("after end: status = %q, tokens = %d; want completed with 1200 tokens", got.Status, got.Tokens)
+	}
+
+	r.begin("bt-2")
+	r.end("bt-2", session.BeadFailed, 300)
+	r.end("bt-3", session.BeadSkipped, 0)
+	if got := status("bt-2").Status; got != session.BeadFailed {
+		t.Errorf("bt-2 status = %q, want %q", got, session.BeadFailed)
+	}
+	if got := status("bt-3"); got.Status != session.BeadSkipped || got.DurationMs != 0 {
+		t.Errorf("bt-3 = %q after %dms, want skipped without a duration", got.Status, got.DurationMs)
+	}
+
+	summaries, err := store.ListSessions(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].BeadsCompleted != 1 || summaries[0].BeadsTotal != 3 {
+		t.Errorf("summaries = %+v, want one session with 1 of 3 beads completed", summaries)
+	}
+
+	// A finished run's session is not picked up by the next run.
+	r.complete()
+	next := openBeadStateRecorder(projectRoot, "proj", "berth/next")
+	defer next.close()
+	if next.sess.ID == r.sess.ID {
+		t.Error("next run reused the completed session")
+	}
+}
+
+func TestBeadStateRecorderAttachesOnlyToItsRunsSession(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".berth"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	store, err := session.NewStore(session.DefaultPath(projectRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	interrupted, err := store.CreateSession("proj", "berth/auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Created later, so each is the latest active session of its project.
+	otherRun, err := store.CreateSession("proj", "berth/billing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherProject, err := store.CreateSession("other", "berth/auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := openBeadStateRecorder(projectRoot, "proj", "berth/auth")
+	if r == nil {
+		t.Fatal("openBeadStateRecorder returned nil")
+	}
+	defer r.close()
+	if r.sess.ID != interrupted.ID {
+		t.Errorf("attached to %s, want the interrupted run's session %s (not %s or %s)", r.sess.ID, interrupted.ID, otherRun.ID, otherProject.ID)
+	}
+}
+
+func TestRetryBeadSumsTokensAcrossAttempts(t *testing.T) {
+	testutil.FakeCommand(t, "claude", "echo '{\"type\":\"result\",\"result\":\"feat: done\",\"is_error\":false,\"usage\":{\"input_tokens\":100,\"output_tokens\":20}}'\n")
+	workDir := t.TempDir()
+
+	// Verification passes on the second run.
+	cfg := config.Config{
+		VerifyPipeline: []string{`n=$(cat attempts 2>/dev/null || echo 0); n=$((n+1)); echo $n > attempts; [ "$n" -ge 2 ]`},
+	}
+	bead := &beads.Bead{ID: "bt-1", Title: "Counted bead"}
+
+	result, err := RetryBead(context.Background(), cfg, bead, "", workDir, nil, nil, &SpawnClaudeOpts{WorkDir: workDir})
+	if err != nil {
+		t.Fatalf("RetryBead: %v", err)
+	}
+	if result.Tokens != 240 {
+		t.Errorf("Tokens = %d, want 240 over two attempts", result.Tokens)
+	}
+}
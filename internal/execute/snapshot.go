@@ -0,0 +1,93 @@
+// snapshot.go tags the execution branch periodically so a hard crash between
+// bead commits leaves a git-level recovery point.
+package execute
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/git"
+)
+
+// snapshotTagPrefix prefixes every snapshot tag:
+// berth-snapshot-<run>-<n>-<time>.
+const snapshotTagPrefix = "berth-snapshot-"
+
+// snapshotter creates snapshot tags on the execution branch every N completed
+// beads and/or every M minutes. A nil snapshotter is disabled.
+type snapshotter struct {
+	mu         sync.Mutex
+	branch     string
+	runID      string
+	everyBeads int
+	interval   time.Duration
+	lastCount  int
+	lastTime   time.Time
+	now        func() time.Time
+}
+
+// newSnapshotter returns a snapshotter for the run, or nil when neither
+// execution.snapshot_interval nor execution.snapshot_minutes is set.
+func newSnapshotter(cfg config.ExecutionConfig, runDir, branch string) *snapshotter {
+	if cfg.SnapshotInterval <= 0 && cfg.SnapshotMinutes <= 0 {
+		return nil
+	}
+	return &snapshotter{
+		branch:     branch,
+		runID:      filepath.Base(runDir),
+		everyBeads: cfg.SnapshotInterval,
+		interval:   time.Duration(cfg.SnapshotMinutes) * time.Minute,
+		lastTime:   time.Now(),
+		now:        time.Now,
+	}
+}
+
+// tagName returns the snapshot tag for the given completed-bead count taken
+// at now. The time keeps a resumed run, which counts its completed beads
+// again from the checkpoint, from colliding with the tags it made before.
+func (s *snapshotter) tagName(completed int, now time.Time) string {
+	return fmt.Sprintf("%s%s-%d-%s", snapshotTagPrefix, s.runID, completed, now.Format("20060102-150405"))
+}
+
+// maybeSnapshot tags the branch if enough beads have completed or enough time
+// has passed since the last snapshot. Nothing is tagged without new progress.
+func (s *snapshotter) maybeSnapshot(completed int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if completed <= s.lastCount {
+		return
+	}
+	now := s.now()
+	due := (s.everyBeads > 0 && completed-s.lastCount >= s.everyBeads) ||
+		(s.interval > 0 && now.Sub(s.lastTime) >= s.interval)
+	if !due {
+		return
+	}
+
+	name := s.tagName(completed, now)
+	if err := git.CreateTag(name, s.branch); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create snapshot tag %s: %v\n", name, err)
+		return
+	}
+	s.lastCount = completed
+	s.lastTime = now
+}
+
+// cleanup deletes this run's snapshot tags. Called once the run completes
+// successfully and the recovery points are no longer needed.
+func (s *snapshotter) cleanup() {
+	if s == nil {
+		return
+	}
+	if _, err := git.DeleteTagsMatching(snapshotTagPrefix + s.runID + "-*"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to delete snapshot tags: %v\n", err)
+	}
+}
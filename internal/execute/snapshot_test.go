@@ -0,0 +1,99 @@
+package execute
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/git"
+)
+
+func TestSnapshotterTagsAtInterval(t *testing.T) {
+	initTestRepo(t)
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if newSnapshotter(config.ExecutionConfig{}, "runs/x", branch) != nil {
+		t.Fatal("snapshots should be disabled by default")
+	}
+
+	snaps := newSnapshotter(config.ExecutionConfig{SnapshotInterval: 2}, ".berth/runs/20260101-120000", branch)
+	snaps.now = func() time.Time { return time.Date(2026, 1, 1, 12, 30, 0, 0, time.UTC) }
+	for completed := 1; completed <= 5; completed++ {
+		snaps.maybeSnapshot(completed)
+	}
+
+	tags, err := git.ListTags("berth-snapshot-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"berth-snapshot-20260101-120000-2-20260101-123000", "berth-snapshot-20260101-120000-4-20260101-123000"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	snaps.cleanup()
+	if tags, _ := git.ListTags("berth-snapshot-*"); len(tags) != 0 {
+		t.Errorf("tags after cleanup = %v, want none", tags)
+	}
+}
+
+func TestSnapshotterTagsAfterMinutes(t *testing.T) {
+	initTestRepo(t)
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	snaps := newSnapshotter(config.ExecutionConfig{SnapshotMinutes: 10}, "run", branch)
+	snaps.now = func() time.Time { return now }
+	snaps.lastTime = now
+
+	snaps.maybeSnapshot(1) // too soon
+	now = now.Add(11 * time.Minute)
+	snaps.maybeSnapshot(1) // due: tags at 1 completed bead
+	now = now.Add(11 * time.Minute)
+	snaps.maybeSnapshot(1) // due, but nothing completed since the last tag
+
+	tags, err := git.ListTags("berth-snapshot-run-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"berth-snapshot-run-1-20260101-121100"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+}
+
+func TestSnapshotterResumedRunDoesNotCollide(t *testing.T) {
+	initTestRepo(t)
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.ExecutionConfig{SnapshotInterval: 2}
+	before := newSnapshotter(cfg, "run", branch)
+	before.now = func() time.Time { return now }
+	before.maybeSnapshot(2)
+
+	// After a crash the resumed run starts counting from zero again and
+	// reaches the same completed-bead count.
+	now = now.Add(5 * time.Minute)
+	resumed := newSnapshotter(cfg, "run", branch)
+	resumed.now = func() time.Time { return now }
+	resumed.maybeSnapshot(2)
+
+	tags, err := git.ListTags("berth-snapshot-run-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"berth-snapshot-run-2-20260101-120000", "berth-snapshot-run-2-20260101-120500"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+}
@@ -22,11 +22,15 @@ type SpawnClaudeOpts struct {
 	Verbose       bool              // Stream Claude output to stdout/stderr in real-time
 	OutputChan    chan<- StreamEvent // Channel to stream output events to TUI (optional)
 	BeadID        string            // Bead ID for tagging StreamEvents
+	Ctx           context.Context   // Canceling it kills the Claude process (default: context.Background())
+
+	tokens int // Tokens used by every SpawnClaude call made with these opts
 }
 
 // SpawnClaude invokes the Claude CLI as a subprocess with the given system
 // and task prompts, waits for completion, and returns the parsed output.
-// It enforces cfg.Execution.TimeoutPerBead as a hard timeout.
+// It enforces cfg.Execution.TimeoutPerBead as a hard timeout, and opts.Ctx
+// (when set) can end the process earlier.
 // Pass nil for opts to use default behavior.
 func SpawnClaude(cfg config.Config, systemPrompt, taskPrompt string, projectRoot string, opts *SpawnClaudeOpts) (*ClaudeOutput, error) {
 	timeout := time.Duration(cfg.Execution.TimeoutPerBead) * time.Second
@@ -34,12 +38,16 @@ func SpawnClaude(cfg config.Config, systemPrompt, taskPrompt string, projectRoot
 		timeout = 10 * time.Minute
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	parent := context.Background()
+	if opts != nil && opts.Ctx != nil {
+		parent = opts.Ctx
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 
 	args := buildClaudeArgs(cfg, systemPrompt, taskPrompt, opts)
 
-	cmd := exec.CommandContext(ctx, "claude", args...)
+	cmd := cfg.Agent.Cmd(ctx, args...)
 	if opts != nil && opts.WorkDir != "" {
 		cmd.Dir = opts.WorkDir
 	} else {
@@ -59,10 +67,13 @@ func SpawnClaude(cfg config.Config, systemPrompt, taskPrompt string, projectRoot
 		stderrWriter = io.MultiWriter(&stderr, os.Stderr)
 	}
 
-	// When OutputChan is set, tee output to both buffer and channel for TUI streaming.
-	if opts != nil && opts.OutputChan != nil {
+	// When OutputChan is set, stdout is stream-json: tee it to a UsageWriter
+	// so the TUI receives Claude's text, tool calls and live token updates,
+	// and stream stderr as output.
+	streaming := opts != nil && opts.OutputChan != nil
+	if streaming {
 		stdoutWriter = io.MultiWriter(stdoutWriter,
-			NewChannelWriter(opts.OutputChan, opts.BeadID, false))
+			NewUsageWriter(opts.OutputChan, opts.BeadID))
 		stderrWriter = io.MultiWriter(stderrWriter,
 			NewChannelWriter(opts.OutputChan, opts.BeadID, true))
 	}
@@ -72,32 +83,65 @@ func SpawnClaude(cfg config.Config, systemPrompt, taskPrompt string, projectRoot
 
 	err := cmd.Run()
 	if err != nil {
-		// Check if the error was due to context timeout.
+		// Check if the error was due to the caller's context or the timeout.
+		if parent.Err() != nil {
+			return nil, fmt.Errorf("claude stopped: %w", parent.Err())
+		}
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("claude timed out after %s: %w", timeout, ctx.Err())
 		}
 		return nil, fmt.Errorf("claude exited with error: %w\nstderr: %s", err, stderr.String())
 	}
 
-	output, parseErr := ParseClaudeOutput(stdout.Bytes())
+	var output *ClaudeOutput
+	var parseErr error
+	if streaming {
+		output, parseErr = ParseStreamJSONOutput(stdout.Bytes())
+	} else {
+		output, parseErr = ParseClaudeOutput(stdout.Bytes())
+	}
 	if parseErr != nil {
 		return nil, fmt.Errorf("parsing claude output: %w\nraw stdout: %s", parseErr, stdout.String())
 	}
+	if opts != nil {
+		opts.tokens += output.Tokens
+	}
 
 	return output, nil
 }
 
+// CheckAgent verifies the configured Claude CLI can be found, so a bad
+// agent.command fails at startup rather than on the first bead.
+func CheckAgent(cfg config.Config) error {
+	if _, err := exec.LookPath(cfg.Agent.Binary()); err != nil {
+		return fmt.Errorf("claude CLI %q not found (set agent.command in .berth/config.yaml): %w", cfg.Agent.Binary(), err)
+	}
+	return nil
+}
+
 // buildClaudeArgs constructs the CLI argument slice for a Claude invocation.
+// When opts.OutputChan is set, Claude emits stream-json so token usage can be
+// reported while the bead runs.
 func buildClaudeArgs(cfg config.Config, systemPrompt, taskPrompt string, opts *SpawnClaudeOpts) []string {
+	outputFormat := "json"
+	if opts != nil && opts.OutputChan != nil {
+		outputFormat = "stream-json"
+	}
+
 	args := []string{
 		"-p", taskPrompt,
 		"--append-system-prompt", systemPrompt,
 		"--allowedTools", "Read,Write,Edit,Bash,Grep,Glob",
-		"--output-format", "json",
+		"--output-format", outputFormat,
 		"--dangerously-skip-permissions",
 		"--model", "opus",
 	}
 
+	// stream-json requires --verbose in print mode.
+	if outputFormat == "stream-json" {
+		args = append(args, "--verbose")
+	}
+
 	if opts != nil && opts.MCPConfigPath != "" {
 		args = append(args, "--mcp-config", opts.MCPConfigPath)
 	}
@@ -0,0 +1,37 @@
+package execute
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+func TestClaudeCmdUsesConfiguredAgent(t *testing.T) {
+	cfg := config.Config{Agent: config.AgentConfig{
+		Command:   "/opt/claude/bin/claude-wrapper",
+		ExtraArgs: []string{"--add-dir", "/shared"},
+	}}
+
+	args := buildClaudeArgs(cfg, "system", "task", nil)
+	cmd := cfg.Agent.Cmd(context.Background(), args...)
+
+	if cmd.Path != "/opt/claude/bin/claude-wrapper" {
+		t.Errorf("Path = %q, want configured command", cmd.Path)
+	}
+	got := strings.Join(cmd.Args[1:], " ")
+	if !strings.HasPrefix(got, "-p task --append-system-prompt system") {
+		t.Errorf("Args = %q, want default args first", got)
+	}
+	if !strings.HasSuffix(got, "--add-dir /shared") {
+		t.Errorf("Args = %q, want extra args appended", got)
+	}
+}
+
+func TestCheckAgentMissingBinary(t *testing.T) {
+	cfg := config.Config{Agent: config.AgentConfig{Command: "berth-no-such-claude"}}
+	if err := CheckAgent(cfg); err == nil {
+		t.Fatal("expected error for missing agent binary")
+	}
+}
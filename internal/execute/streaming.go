@@ -2,17 +2,25 @@
 package execute
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"time"
 )
 
 // StreamEvent represents a streaming event from bead execution to the TUI.
 // It extends OutputEvent with additional event types for TUI rendering.
 type StreamEvent struct {
-	Type     string // "output", "complete", "error", "token_update", "bead_init", "bead_complete", "group_start"
+	Type     string // "output", "complete", "error", "token_update", "bead_init", "bead_complete", "group_start", "attempt"
 	BeadID   string
 	Content  string
 	Tokens   int
 	IsStderr bool
+
+	// Attempt and MaxAttempts are set on "attempt" events, sent as each
+	// attempt at a bead starts.
+	Attempt     int
+	MaxAttempts int
 }
 
 // ChannelWriter implements io.Writer and sends output to a channel as StreamEvents.
@@ -47,13 +55,177 @@ func (cw *ChannelWriter) Write(p []byte) (n int, err error) {
 		IsStderr: cw.isStderr,
 	}
 
-	// Non-blocking send with timeout to prevent deadlocks.
+	sendEvent(cw.ch, event)
+
+	return len(p), nil
+}
+
+// sendEvent delivers event to ch without blocking execution.
+// Uses a non-blocking send with a timeout to prevent deadlocks.
+func sendEvent(ch chan<- StreamEvent, event StreamEvent) {
 	select {
-	case cw.ch <- event:
+	case ch <- event:
 		// Successfully sent.
 	case <-time.After(100 * time.Millisecond):
 		// Channel full or slow receiver; drop the event to avoid blocking execution.
 	}
+}
+
+// forwardParallelEvents returns a channel for RunParallel whose output,
+// token_update and attempt events are relayed to out as StreamEvents, so a
+// parallel group streams like a sequential one. Per-bead complete and error
+// events are dropped: the caller sends bead_complete and error once the
+// group is merged. Like every other event, a relayed one is dropped when out
+// is not drained in time. stop closes the channel and waits for the relay to
+// drain.
+func forwardParallelEvents(out chan<- StreamEvent) (events chan<- OutputEvent, stop func()) {
+	ch := make(chan OutputEvent, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ev := range ch {
+			if ev.Type == "complete" || ev.Type == "error" {
+				continue
+			}
+			sendEvent(out, StreamEvent{
+				Type:        ev.Type,
+				BeadID:      ev.BeadID,
+				Content:     ev.Content,
+				Tokens:      ev.Tokens,
+				IsStderr:    ev.IsStderr,
+				Attempt:     ev.Attempt,
+				MaxAttempts: ev.MaxAttempts,
+			})
+		}
+	}()
+	return ch, func() {
+		close(ch)
+		<-done
+	}
+}
+
+// streamUsageLine is the subset of a Claude stream-json line needed to
+// read token usage and content from assistant messages.
+type streamUsageLine struct {
+	Type    string `json:"type"`
+	Message struct {
+		ID      string `json:"id"`
+		Content []struct {
+			Type  string          `json:"type"`
+			Text  string          `json:"text"`
+			Name  string          `json:"name"`
+			Input json.RawMessage `json:"input"`
+		} `json:"content"`
+		Usage *struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// UsageWriter implements io.Writer over Claude's stream-json stdout. Each
+// complete assistant line produces an "output" event per text block or tool
+// call, and a "token_update" event whose Tokens field holds the tokens
+// spent since the previous update.
+type UsageWriter struct {
+	ch       chan<- StreamEvent
+	beadID   string
+	buf      []byte
+	reported map[string]int // message ID -> tokens already sent
+}
 
+// NewUsageWriter creates a UsageWriter that sends token updates for beadID
+// to the given channel.
+func NewUsageWriter(ch chan<- StreamEvent, beadID string) *UsageWriter {
+	return &UsageWriter{
+		ch:       ch,
+		beadID:   beadID,
+		reported: make(map[string]int),
+	}
+}
+
+// Write implements io.Writer. Partial lines are buffered until their
+// newline arrives.
+func (uw *UsageWriter) Write(p []byte) (n int, err error) {
+	uw.buf = append(uw.buf, p...)
+	for {
+		i := bytes.IndexByte(uw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := uw.buf[:i]
+		uw.buf = uw.buf[i+1:]
+		texts, delta := uw.consume(line)
+		for _, text := range texts {
+			sendEvent(uw.ch, StreamEvent{
+				Type:    "output",
+				BeadID:  uw.beadID,
+				Content: text,
+			})
+		}
+		if delta > 0 {
+			sendEvent(uw.ch, StreamEvent{
+				Type:   "token_update",
+				BeadID: uw.beadID,
+				Tokens: delta,
+			})
+		}
+	}
 	return len(p), nil
 }
+
+// consume parses one stream-json line and returns its assistant content
+// (see describeContent) and the number of tokens not yet reported. Claude
+// repeats a message's usage on every content block it streams, so usage is
+// tracked per message ID and only growth is counted.
+func (uw *UsageWriter) consume(line []byte) ([]string, int) {
+	var ev streamUsageLine
+	if err := json.Unmarshal(bytes.TrimSpace(line), &ev); err != nil {
+		return nil, 0
+	}
+	if ev.Type != "assistant" {
+		return nil, 0
+	}
+	texts := describeContent(ev)
+	if ev.Message.Usage == nil {
+		return texts, 0
+	}
+
+	total := ev.Message.Usage.InputTokens + ev.Message.Usage.OutputTokens
+	delta := total - uw.reported[ev.Message.ID]
+	if delta <= 0 {
+		return texts, 0
+	}
+	uw.reported[ev.Message.ID] = total
+	return texts, delta
+}
+
+// describeContent renders an assistant message's text blocks as-is and its
+// tool calls as one line each, e.g. "Read internal/auth/login.go".
+func describeContent(line streamUsageLine) []string {
+	var texts []string
+	for _, block := range line.Message.Content {
+		switch block.Type {
+		case "text":
+			if t := strings.TrimSpace(block.Text); t != "" {
+				texts = append(texts, t)
+			}
+		case "tool_use":
+			var input struct {
+				FilePath string `json:"file_path"`
+				Pattern  string `json:"pattern"`
+				Path     string `json:"path"`
+				Command  string `json:"command"`
+			}
+			_ = json.Unmarshal(block.Input, &input)
+			target := input.FilePath
+			for _, alt := range []string{input.Pattern, input.Path, input.Command} {
+				if target == "" {
+					target = alt
+				}
+			}
+			texts = append(texts, strings.TrimSpace(block.Name+" "+target))
+		}
+	}
+	return texts
+}
@@ -0,0 +1,135 @@
+package execute
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsageWriter_EmitsTokenDeltas(t *testing.T) {
+	ch := make(chan StreamEvent, 10)
+	uw := NewUsageWriter(ch, "bt-1")
+
+	stream := `{"type":"system","subtype":"init","session_id":"sess-1"}
+{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":100,"output_tokens":20}}}
+{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":100,"output_tokens":20}}}
+{"type":"user","message":{"content":[{"type":"tool_result"}]}}
+{"type":"assistant","message":{"id":"msg_2","usage":{"input_tokens":150,"output_tokens":30}}}
+{"type":"result","result":"done","usage":{"input_tokens":250,"output_tokens":50}}
+`
+	// Split mid-line to exercise buffering of partial writes.
+	split := 90
+	if _, err := uw.Write([]byte(stream[:split])); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := uw.Write([]byte(stream[split:])); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	close(ch)
+
+	var got []int
+	for ev := range ch {
+		if ev.Type != "token_update" {
+			t.Errorf("event type = %q, want %q", ev.Type, "token_update")
+		}
+		if ev.BeadID != "bt-1" {
+			t.Errorf("event bead = %q, want %q", ev.BeadID, "bt-1")
+		}
+		got = append(got, ev.Tokens)
+	}
+
+	want := []int{120, 180}
+	if len(got) != len(want) {
+		t.Fatalf("token updates = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("update %d = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUsageWriter_EmitsAssistantOutput(t *testing.T) {
+	ch := make(chan StreamEvent, 10)
+	uw := NewUsageWriter(ch, "bt-1")
+
+	stream := `{"type":"assistant","message":{"id":"msg_1","content":[{"type":"text","text":"Adding the handler.\n"}],"usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"assistant","message":{"id":"msg_1","content":[{"type":"tool_use","name":"Edit","input":{"file_path":"api/handler.go"}}],"usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"user","message":{"content":[{"type":"tool_result","content":"ok"}]}}
+`
+	if _, err := uw.Write([]byte(stream)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	close(ch)
+
+	var output []string
+	for ev := range ch {
+		if ev.Type == "output" {
+			output = append(output, ev.Content)
+		}
+	}
+	want := []string{"Adding the handler.", "Edit api/handler.go"}
+	if len(output) != len(want) || output[0] != want[0] || output[1] != want[1] {
+		t.Errorf("output events = %q, want %q", output, want)
+	}
+}
+
+func TestUsageWriter_IgnoresNonJSON(t *testing.T) {
+	ch := make(chan StreamEvent, 1)
+	uw := NewUsageWriter(ch, "bt-1")
+
+	if _, err := uw.Write([]byte("not json\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(ch) != 0 {
+		t.Errorf("expected no events, got %d", len(ch))
+	}
+}
+
+func TestForwardParallelEventsRelaysOutput(t *testing.T) {
+	out := make(chan StreamEvent, 10)
+	events, stop := forwardParallelEvents(out)
+
+	events <- OutputEvent{Type: "attempt", BeadID: "bt-1", Attempt: 2, MaxAttempts: 4}
+	events <- OutputEvent{Type: "output", BeadID: "bt-1", Content: "hello", IsStderr: true}
+	events <- OutputEvent{Type: "token_update", BeadID: "bt-1", Tokens: 42}
+	events <- OutputEvent{Type: "error", BeadID: "bt-1", Content: "failed"}
+	events <- OutputEvent{Type: "complete", BeadID: "bt-2"}
+	stop()
+	close(out)
+
+	var got []StreamEvent
+	for ev := range out {
+		got = append(got, ev)
+	}
+	if len(got) != 3 {
+		t.Fatalf("relayed %d events, want 3 (complete and error dropped): %+v", len(got), got)
+	}
+	if got[0].Type != "attempt" || got[0].Attempt != 2 || got[0].MaxAttempts != 4 {
+		t.Errorf("event 0 = %+v, want attempt 2/4", got[0])
+	}
+	if got[1].Type != "output" || got[1].Content != "hello" || !got[1].IsStderr {
+		t.Errorf("event 1 = %+v, want stderr output", got[1])
+	}
+	if got[2].Type != "token_update" || got[2].Tokens != 42 {
+		t.Errorf("event 2 = %+v, want 42 tokens", got[2])
+	}
+}
+
+func TestForwardParallelEventsDropsWhenNotDrained(t *testing.T) {
+	out := make(chan StreamEvent) // nobody reads it
+	events, stop := forwardParallelEvents(out)
+
+	stopped := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			events <- OutputEvent{Type: "output", BeadID: "bt-1", Content: "line"}
+		}
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay blocked on an undrained channel")
+	}
+}
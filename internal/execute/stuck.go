@@ -5,11 +5,15 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
 	berthcontext "github.com/berth-dev/berth/internal/context"
+	"github.com/berth-dev/berth/internal/graph"
 	"github.com/berth-dev/berth/prompts"
 )
 
@@ -21,7 +25,9 @@ type StuckAction struct {
 
 // HandleStuck pauses execution and presents the user with choices for
 // resolving a stuck bead. The menu loops until the user picks skip/abort
-// or until a hint/rescue attempt succeeds verification.
+// or until a hint/rescue attempt succeeds verification. A non-prompt
+// execution.stuck_policy picks the action up front instead; see
+// applyStuckPolicy.
 func HandleStuck(
 	cfg config.Config,
 	bead *beads.Bead,
@@ -30,6 +36,10 @@ func HandleStuck(
 	graphData string,
 	projectRoot string,
 ) (StuckAction, error) {
+	if cfg.Execution.StuckPolicy != "" && cfg.Execution.StuckPolicy != "prompt" {
+		return applyStuckPolicy(cfg, bead, verifyErrors, diagnostic, graphData, projectRoot)
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
@@ -98,6 +108,46 @@ func HandleStuck(
 	}
 }
 
+// applyStuckPolicy resolves a stuck bead per execution.stuck_policy without
+// reading stdin: "skip" leaves the bead stuck, "abort" stops the run, and
+// "rescue" runs a non-interactive rescue session (RunHeadlessRescue) straight
+// away, leaving the bead stuck if verification still fails afterwards.
+func applyStuckPolicy(
+	cfg config.Config,
+	bead *beads.Bead,
+	verifyErrors []string,
+	diagnostic string,
+	graphData string,
+	projectRoot string,
+) (StuckAction, error) {
+	policy := cfg.Execution.StuckPolicy
+	switch policy {
+	case stuckActionAbort:
+		fmt.Printf("Bead %s stuck: %q, aborting (stuck_policy: abort)\n", bead.ID, bead.Title)
+		return StuckAction{Action: stuckActionAbort}, nil
+
+	case stuckActionRescue:
+		fmt.Printf("Bead %s stuck: %q, running a rescue session (stuck_policy: rescue)\n", bead.ID, bead.Title)
+		if err := RunHeadlessRescue(cfg, bead, verifyErrors, diagnostic, graphData, projectRoot); err != nil {
+			fmt.Printf("  Rescue session error: %v\n", err)
+		} else if result, err := RunVerification(cfg, bead, ""); err != nil {
+			fmt.Printf("  Post-rescue verification error: %v\n", err)
+		} else if result.Passed {
+			return StuckAction{Action: stuckActionRescue}, nil
+		} else {
+			fmt.Printf("  Rescue session completed but verification still fails at %s; skipping.\n", result.FailedStep)
+		}
+
+	default:
+		fmt.Printf("Bead %s stuck: %q, skipping (stuck_policy: %s)\n", bead.ID, bead.Title, policy)
+	}
+
+	if err := beads.UpdateStatus(bead.ID, "stuck"); err != nil {
+		return StuckAction{}, fmt.Errorf("marking bead %s as stuck: %w", bead.ID, err)
+	}
+	return StuckAction{Action: stuckActionSkip}, nil
+}
+
 // printStuckMenu displays the stuck bead information and available actions.
 func printStuckMenu(bead *beads.Bead, diagnostic string) {
 	fmt.Println()
@@ -181,3 +231,97 @@ func retryWithHint(
 
 	return result.Passed, nil
 }
+
+// maxRescueSymbols and maxRescueFiles bound how many identifiers from the
+// error output are searched for and how many files are suggested.
+const (
+	maxRescueSymbols = 8
+	maxRescueFiles   = 5
+)
+
+// rescueToken matches identifier-like words. Dotted and scoped names such
+// as pkg.Func yield one token per part.
+var rescueToken = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// commonErrorNames are identifiers that show up in error output without
+// pointing at any project code.
+var commonErrorNames = map[string]bool{
+	"TypeError": true, "ReferenceError": true, "SyntaxError": true, "RangeError": true,
+	"AssertionError": true, "AttributeError": true, "ImportError": true, "KeyError": true,
+	"ValueError": true, "NameError": true, "ModuleNotFoundError": true, "RuntimeError": true,
+	"NullPointerException": true, "IllegalArgumentException": true, "IllegalStateException": true,
+	"node_modules": true,
+}
+
+// suggestRescueFiles proposes project files worth a look for a stuck bead.
+// Symbol-like tokens in errOutput (camelCase, PascalCase or snake_case
+// words) are searched for with graph.GrepFallback, and the files they
+// appear in are returned ranked by match count, at most maxRescueFiles of
+// them, relative to projectRoot. Returns nil when nothing matches or rg is
+// unavailable.
+func suggestRescueFiles(errOutput string, projectRoot string) []string {
+	counts := make(map[string]int)
+	for _, symbol := range rescueSymbols(errOutput) {
+		matches, err := graph.GrepFallback(projectRoot, `\b`+symbol+`\b`)
+		if err != nil {
+			return nil
+		}
+		for _, m := range matches {
+			file := m.File
+			if rel, err := filepath.Rel(projectRoot, file); err == nil {
+				file = rel
+			}
+			counts[file]++
+		}
+	}
+
+	files := make([]string, 0, len(counts))
+	for file := range counts {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if counts[files[i]] != counts[files[j]] {
+			return counts[files[i]] > counts[files[j]]
+		}
+		return files[i] < files[j]
+	})
+	if len(files) > maxRescueFiles {
+		files = files[:maxRescueFiles]
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	return files
+}
+
+// rescueSymbols returns the distinct symbol-like tokens of errOutput in the
+// order they first appear, at most maxRescueSymbols of them. Plain words
+// are skipped: a token must mix case after its first letter or contain an
+// inner underscore.
+func rescueSymbols(errOutput string) []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, tok := range rescueToken.FindAllString(errOutput, -1) {
+		if seen[tok] || commonErrorNames[tok] || !isSymbolLike(tok) {
+			continue
+		}
+		seen[tok] = true
+		symbols = append(symbols, tok)
+		if len(symbols) == maxRescueSymbols {
+			break
+		}
+	}
+	return symbols
+}
+
+// isSymbolLike reports whether tok looks like a code identifier rather than
+// an English word or an all-caps constant such as FAIL.
+func isSymbolLike(tok string) bool {
+	if len(tok) < 4 {
+		return false
+	}
+	if strings.Contains(strings.Trim(tok, "_"), "_") {
+		return true
+	}
+	return strings.ToLower(tok[1:]) != tok[1:] && strings.ToUpper(tok) != tok
+}
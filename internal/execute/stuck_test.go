@@ -0,0 +1,113 @@
+package execute
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+func TestRescueSymbols(t *testing.T) {
+	errOutput := `FAIL: TestLogin (0.01s)
+    auth_test.go:42: TypeError: Cannot read properties of undefined (reading 'userId')
+    at validateSession (src/auth/session.ts:17)
+    session_store.lookup failed for TestLogin`
+
+	got := rescueSymbols(errOutput)
+	want := []string{"TestLogin", "auth_test", "userId", "validateSession", "session_store"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rescueSymbols = %q, want %q", got, want)
+	}
+}
+
+func TestSuggestRescueFilesRanksByMatches(t *testing.T) {
+	if _, err := exec.LookPath("rg"); err != nil {
+		t.Skip("ripgrep not installed")
+	}
+	root := t.TempDir()
+	files := map[string]string{
+		"session.ts": "export function validateSession() {}\nconst userId = validateSession()\n",
+		"login.ts":   "import { validateSession } from './session'\n",
+		"other.ts":   "export const unrelated = 1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := suggestRescueFiles("TypeError: reading 'userId' at validateSession", root)
+	want := []string{"session.ts", "login.ts"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("suggestRescueFiles = %q, want %q", got, want)
+	}
+}
+
+// installRescueClaude puts a `claude` script on PATH that records its
+// arguments to args.txt and, when fix is set, creates fixed.txt in its
+// working directory.
+func installRescueClaude(t *testing.T, fix bool) {
+	t.Helper()
+	script := "echo \"$@\" > args.txt\n"
+	if fix {
+		script += "touch fixed.txt\n"
+	}
+	testutil.FakeCommand(t, "claude", script)
+}
+
+func TestStuckPolicyRescueRunsHeadless(t *testing.T) {
+	installRescueClaude(t, true)
+	calls := fakeBD(t, "[]")
+	root := t.TempDir()
+	t.Chdir(root)
+
+	cfg := config.Config{VerifyPipeline: []string{"test -f fixed.txt"}}
+	cfg.Execution.StuckPolicy = "rescue"
+	bead := &beads.Bead{ID: "bt-1", Title: "Stuck bead"}
+
+	action, err := HandleStuck(cfg, bead, []string{"FAIL"}, "diagnosis", "", root)
+	if err != nil {
+		t.Fatalf("HandleStuck: %v", err)
+	}
+	if action.Action != stuckActionRescue {
+		t.Errorf("action = %q, want rescue", action.Action)
+	}
+	args, err := os.ReadFile(filepath.Join(root, "args.txt"))
+	if err != nil {
+		t.Fatalf("claude was not run: %v", err)
+	}
+	if !strings.HasPrefix(string(args), "-p ") {
+		t.Errorf("rescue session args = %q, want a -p prompt", args)
+	}
+	if data, _ := os.ReadFile(calls); strings.Contains(string(data), "stuck") {
+		t.Errorf("rescued bead was marked stuck: %s", data)
+	}
+}
+
+func TestStuckPolicyRescueLeavesBeadStuckWhenVerifyFails(t *testing.T) {
+	installRescueClaude(t, false)
+	calls := fakeBD(t, "[]")
+	root := t.TempDir()
+	t.Chdir(root)
+
+	cfg := config.Config{VerifyPipeline: []string{"test -f fixed.txt"}}
+	cfg.Execution.StuckPolicy = "rescue"
+	bead := &beads.Bead{ID: "bt-1", Title: "Stuck bead"}
+
+	action, err := HandleStuck(cfg, bead, nil, "", "", root)
+	if err != nil {
+		t.Fatalf("HandleStuck: %v", err)
+	}
+	if action.Action != stuckActionSkip {
+		t.Errorf("action = %q, want skip", action.Action)
+	}
+	if data, _ := os.ReadFile(calls); !strings.Contains(string(data), "bt-1") || !strings.Contains(string(data), "stuck") {
+		t.Errorf("bd calls = %q, want bt-1 marked stuck", data)
+	}
+}
@@ -4,25 +4,51 @@ package execute
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
 )
 
+// Sources a verification step can come from.
+const (
+	VerifySourceConfig   = "config"   // verify_pipeline in .berth/config.yaml (detected at init)
+	VerifySourcePlan     = "plan"     // the bead's verify_extra from the plan
+	VerifySourceSecurity = "security" // verify.security in .berth/config.yaml
+)
+
+// VerifyStep is one verification command and where it came from.
+type VerifyStep struct {
+	Command string `json:"command"`
+	Source  string `json:"source"`
+}
+
+// String returns the step as "command [source]".
+func (s VerifyStep) String() string {
+	return fmt.Sprintf("%s [%s]", s.Command, s.Source)
+}
+
 // VerifyResult holds the outcome of a verification pipeline run.
 type VerifyResult struct {
-	Passed     bool
-	FailedStep string
-	Output     string // Output from the failed step (empty if passed)
-	AllOutput  string // All verification output combined
+	Passed      bool
+	FailedStep  string       // Failed command, or all failed commands comma-joined when not failing fast
+	FailedSteps []VerifyStep // Every step that failed
+	Steps       []VerifyStep // Every step that ran, in order
+	Output      string       // Output from the failed step(s) (empty if passed)
+	AllOutput   string       // All verification output combined
 }
 
 // RunVerification executes the verification pipeline commands in order.
 // It combines the default pipeline from config.VerifyPipeline with any
-// per-bead verify_extra commands. Execution stops on the first failure.
+// per-bead verify_extra commands. Execution stops on the first failure
+// unless cfg.Execution.VerifyFailFast is false, in which case every step
+// runs and all failures are reported together.
 // Pass an empty workDir to run in the current directory.
+// When cfg.Execution.VerifyContainer is set, each step runs inside that
+// Docker image with workDir mounted at /work.
 func RunVerification(cfg config.Config, bead *beads.Bead, workDir string) (*VerifyResult, error) {
 	pipeline := buildPipeline(cfg, bead)
 	if len(pipeline) == 0 {
@@ -32,67 +58,187 @@ func RunVerification(cfg config.Config, bead *beads.Bead, workDir string) (*Veri
 		}, nil
 	}
 
-	var allOutput strings.Builder
+	image := verifyImage(cfg)
+	failFast := cfg.Execution.FailFast()
+	var allOutput, failedOutput strings.Builder
+	var failed, ran []VerifyStep
 
 	for _, step := range pipeline {
-		stepOutput, err := runStep(step, workDir)
+		stepOutput, err := runStep(step.Command, workDir, image)
+		ran = append(ran, step)
 
 		allOutput.WriteString(fmt.Sprintf("=== %s ===\n", step))
 		allOutput.WriteString(stepOutput)
 		allOutput.WriteString("\n")
 
-		if err != nil {
+		if err == nil {
+			continue
+		}
+
+		if failFast {
 			return &VerifyResult{
-				Passed:     false,
-				FailedStep: step,
-				Output:     stepOutput,
-				AllOutput:  allOutput.String(),
+				Passed:      false,
+				FailedStep:  step.Command,
+				FailedSteps: []VerifyStep{step},
+				Steps:       ran,
+				Output:      stepOutput,
+				AllOutput:   allOutput.String(),
 			}, nil
 		}
+
+		failed = append(failed, step)
+		failedOutput.WriteString(fmt.Sprintf("=== %s ===\n", step))
+		failedOutput.WriteString(stepOutput)
+		failedOutput.WriteString("\n")
+	}
+
+	if len(failed) > 0 {
+		commands := make([]string, len(failed))
+		for i, step := range failed {
+			commands[i] = step.Command
+		}
+		return &VerifyResult{
+			Passed:      false,
+			FailedStep:  strings.Join(commands, ", "),
+			FailedSteps: failed,
+			Steps:       ran,
+			Output:      failedOutput.String(),
+			AllOutput:   allOutput.String(),
+		}, nil
 	}
 
 	return &VerifyResult{
 		Passed:    true,
+		Steps:     ran,
 		AllOutput: allOutput.String(),
 	}, nil
 }
 
 // buildPipeline combines the default verify pipeline with any per-bead
 // extra verification commands, and optionally the security scan command.
-// The default pipeline runs first, followed by bead-specific extras, and
-// finally the security scan (if configured).
-func buildPipeline(cfg config.Config, bead *beads.Bead) []string {
-	pipeline := make([]string, 0, len(cfg.VerifyPipeline))
-	pipeline = append(pipeline, cfg.VerifyPipeline...)
+// The default pipeline runs first, then the verify_pipelines of the
+// subdirectories the bead touches, followed by bead-specific extras, and
+// finally the security scan (if configured). Each step records its source.
+func buildPipeline(cfg config.Config, bead *beads.Bead) []VerifyStep {
+	pipeline := make([]VerifyStep, 0, len(cfg.VerifyPipeline)+len(bead.VerifyExtra)+1)
+	for _, command := range cfg.VerifyPipeline {
+		pipeline = append(pipeline, VerifyStep{Command: command, Source: VerifySourceConfig})
+	}
+
+	for _, pp := range cfg.VerifyPipelines {
+		if !touchesPath(bead.Files, pp.Path) {
+			continue
+		}
+		for _, command := range pp.Commands {
+			pipeline = append(pipeline, VerifyStep{Command: scopeCommand(pp.Path, command), Source: VerifySourceConfig})
+		}
+	}
 
-	if len(bead.VerifyExtra) > 0 {
-		pipeline = append(pipeline, bead.VerifyExtra...)
+	for _, command := range bead.VerifyExtra {
+		pipeline = append(pipeline, VerifyStep{Command: command, Source: VerifySourcePlan})
 	}
 
 	// Add security scan if configured (runs last, after lint/test)
 	if cfg.Verify.Security != "" {
-		pipeline = append(pipeline, cfg.Verify.Security)
+		pipeline = append(pipeline, VerifyStep{Command: cfg.Verify.Security, Source: VerifySourceSecurity})
 	}
 
 	return pipeline
 }
 
+// touchesPath reports whether any of files lies under dir. A bead that
+// lists no files may touch anything, so it touches every dir.
+func touchesPath(files []string, dir string) bool {
+	if len(files) == 0 {
+		return true
+	}
+	dir = filepath.ToSlash(filepath.Clean(dir))
+	if dir == "." {
+		return true
+	}
+	for _, f := range files {
+		f = filepath.ToSlash(filepath.Clean(f))
+		if f == dir || strings.HasPrefix(f, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeCommand makes command run from dir, relative to the verification
+// directory, so it works the same on the host and in a container.
+func scopeCommand(dir, command string) string {
+	dir = filepath.ToSlash(filepath.Clean(dir))
+	if dir == "." {
+		return command
+	}
+	if strings.ContainsAny(dir, " \t'\"$`\\;&|()<>*?") {
+		dir = "'" + strings.ReplaceAll(dir, "'", `'\''`) + "'"
+	}
+	return "cd " + dir + " && " + command
+}
+
+// verifyImage returns the container image verification should run in, or
+// "" to run on the host. Falls back to the host with a warning when an
+// image is configured but Docker is not installed.
+func verifyImage(cfg config.Config) string {
+	image := cfg.Execution.VerifyContainer
+	if image == "" {
+		return ""
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: verify_container %q set but docker is unavailable; running verification on host\n", image)
+		return ""
+	}
+	return image
+}
+
 // runStep executes a single shell command and returns the combined
 // stdout+stderr output. Returns a non-nil error if the command exits
 // with a non-zero status. If workDir is non-empty, the command runs
-// in that directory.
-func runStep(command string, workDir string) (string, error) {
-	cmd := exec.Command("sh", "-c", command)
-	if workDir != "" {
-		cmd.Dir = workDir
+// in that directory. If image is non-empty, the command runs inside
+// that container instead.
+func runStep(command string, workDir string, image string) (string, error) {
+	cmd, err := stepCommand(command, workDir, image)
+	if err != nil {
+		return err.Error(), err
 	}
 
 	var buf bytes.Buffer
 	cmd.Stdout = &buf
 	cmd.Stderr = &buf
 
-	err := cmd.Run()
+	err = cmd.Run()
 	output := buf.String()
 
 	return output, err
 }
+
+// stepCommand builds the command for a verification step. With an image it
+// wraps the step as: docker run --rm -v <workDir>:/work -w /work <image> sh -c <command>
+func stepCommand(command string, workDir string, image string) (*exec.Cmd, error) {
+	if image == "" {
+		cmd := exec.Command("sh", "-c", command)
+		if workDir != "" {
+			cmd.Dir = workDir
+		}
+		return cmd, nil
+	}
+
+	// Docker requires an absolute host path for bind mounts.
+	mountDir := workDir
+	if mountDir == "" {
+		mountDir = "."
+	}
+	absDir, err := filepath.Abs(mountDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving verification directory: %w", err)
+	}
+
+	return exec.Command("docker", "run", "--rm",
+		"-v", absDir+":/work",
+		"-w", "/work",
+		image,
+		"sh", "-c", command,
+	), nil
+}
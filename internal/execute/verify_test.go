@@ -1,6 +1,10 @@
 package execute
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/berth-dev/berth/internal/beads"
@@ -22,7 +26,7 @@ func TestBuildPipelineWithSecurity(t *testing.T) {
 		t.Errorf("expected 3 steps in pipeline, got %d", len(pipeline))
 	}
 	// Security scan should be last
-	if pipeline[2] != "gosec ./..." {
+	if pipeline[2].Command != "gosec ./..." {
 		t.Errorf("expected security scan as last step, got %s", pipeline[2])
 	}
 }
@@ -62,12 +66,56 @@ func TestBuildPipelineSecurityAfterBeadExtras(t *testing.T) {
 	// Order should be: default pipeline, bead extras, security
 	expected := []string{"go test ./...", "go vet ./...", "gosec ./..."}
 	for i, step := range pipeline {
-		if step != expected[i] {
+		if step.Command != expected[i] {
 			t.Errorf("pipeline[%d] = %s, want %s", i, step, expected[i])
 		}
 	}
 }
 
+func TestBuildPipelineAttributesSources(t *testing.T) {
+	cfg := config.Config{
+		VerifyPipeline: []string{"go test ./...", "go vet ./..."},
+		Verify:         config.VerifyConfig{Security: "gosec ./..."},
+	}
+	bead := &beads.Bead{VerifyExtra: []string{"go vet ./...", "./scripts/check-migrations.sh"}}
+
+	want := []VerifyStep{
+		{Command: "go test ./...", Source: VerifySourceConfig},
+		{Command: "go vet ./...", Source: VerifySourceConfig},
+		{Command: "go vet ./...", Source: VerifySourcePlan},
+		{Command: "./scripts/check-migrations.sh", Source: VerifySourcePlan},
+		{Command: "gosec ./...", Source: VerifySourceSecurity},
+	}
+	got := buildPipeline(cfg, bead)
+	if len(got) != len(want) {
+		t.Fatalf("pipeline = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pipeline[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRunVerificationReportsFailedStepSource(t *testing.T) {
+	cfg := config.Config{VerifyPipeline: []string{"true"}}
+	bead := &beads.Bead{VerifyExtra: []string{"exit 1"}}
+
+	result, err := RunVerification(cfg, bead, t.TempDir())
+	if err != nil {
+		t.Fatalf("RunVerification: %v", err)
+	}
+	if result.Passed || len(result.FailedSteps) != 1 || result.FailedSteps[0].Source != VerifySourcePlan {
+		t.Fatalf("FailedSteps = %v, want the plan's step", result.FailedSteps)
+	}
+	if len(result.Steps) != 2 || result.Steps[0].Source != VerifySourceConfig {
+		t.Errorf("Steps = %v, want the config step then the plan step", result.Steps)
+	}
+	if !strings.Contains(result.AllOutput, "=== exit 1 [plan] ===") {
+		t.Errorf("AllOutput does not tag the step source:\n%s", result.AllOutput)
+	}
+}
+
 func TestRunVerificationSecurityFailure(t *testing.T) {
 	cfg := config.Config{
 		VerifyPipeline: []string{"true"}, // always passes
@@ -127,3 +175,147 @@ func TestRunVerificationEmptySecuritySkipped(t *testing.T) {
 		t.Errorf("expected verification to pass, but failed at step: %s", result.FailedStep)
 	}
 }
+
+func TestStepCommandWrapsInContainer(t *testing.T) {
+	workDir := t.TempDir()
+
+	cmd, err := stepCommand("go test ./...", workDir, "golang:1.24")
+	if err != nil {
+		t.Fatalf("stepCommand: %v", err)
+	}
+
+	want := []string{"docker", "run", "--rm", "-v", workDir + ":/work", "-w", "/work", "golang:1.24", "sh", "-c", "go test ./..."}
+	if len(cmd.Args) != len(want) {
+		t.Fatalf("args = %q, want %q", cmd.Args, want)
+	}
+	for i := range want {
+		if cmd.Args[i] != want[i] {
+			t.Errorf("args[%d] = %q, want %q", i, cmd.Args[i], want[i])
+		}
+	}
+}
+
+func TestStepCommandHost(t *testing.T) {
+	cmd, err := stepCommand("go test ./...", "/tmp/work", "")
+	if err != nil {
+		t.Fatalf("stepCommand: %v", err)
+	}
+	if filepath.Base(cmd.Args[0]) != "sh" || cmd.Args[2] != "go test ./..." {
+		t.Errorf("args = %q, want sh -c wrapper", cmd.Args)
+	}
+	if cmd.Dir != "/tmp/work" {
+		t.Errorf("Dir = %q, want %q", cmd.Dir, "/tmp/work")
+	}
+}
+
+func TestVerifyImageFallsBackWithoutDocker(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	cfg := config.Config{
+		Execution: config.ExecutionConfig{VerifyContainer: "golang:1.24"},
+	}
+
+	if got := verifyImage(cfg); got != "" {
+		t.Errorf("verifyImage() = %q, want host fallback", got)
+	}
+}
+
+func TestRunVerificationInContainer(t *testing.T) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker not installed")
+	}
+	// Avoid pulling in tests: only run when the image is already present.
+	const image = "alpine"
+	if err := exec.Command("docker", "image", "inspect", image).Run(); err != nil {
+		t.Skipf("docker image %s not available locally", image)
+	}
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "marker"), []byte("ok"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{
+		VerifyPipeline: []string{"test -f /work/marker"},
+		Execution:      config.ExecutionConfig{VerifyContainer: image},
+	}
+	result, err := RunVerification(cfg, &beads.Bead{}, workDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Passed {
+		t.Errorf("expected verification to pass in container, output: %s", result.AllOutput)
+	}
+}
+
+func TestRunVerificationRunAllReportsEveryFailure(t *testing.T) {
+	failFast := false
+	cfg := config.Config{
+		VerifyPipeline: []string{"echo lint broke; exit 1", "true", "echo tests broke; exit 2"},
+		Execution:      config.ExecutionConfig{VerifyFailFast: &failFast},
+	}
+
+	result, err := RunVerification(cfg, &beads.Bead{}, t.TempDir())
+	if err != nil {
+		t.Fatalf("RunVerification: %v", err)
+	}
+	if result.Passed {
+		t.Fatal("expected verification to fail")
+	}
+	if len(result.FailedSteps) != 2 {
+		t.Fatalf("FailedSteps = %v, want both failing steps", result.FailedSteps)
+	}
+	for _, want := range []string{"lint broke", "tests broke"} {
+		if !strings.Contains(result.Output, want) {
+			t.Errorf("Output missing %q:\n%s", want, result.Output)
+		}
+	}
+}
+
+func TestRunVerificationFailFastByDefault(t *testing.T) {
+	cfg := config.Config{
+		VerifyPipeline: []string{"echo lint broke; exit 1", "echo tests broke; exit 2"},
+	}
+
+	result, err := RunVerification(cfg, &beads.Bead{}, t.TempDir())
+	if err != nil {
+		t.Fatalf("RunVerification: %v", err)
+	}
+	if result.Passed || result.FailedStep != "echo lint broke; exit 1" {
+		t.Fatalf("FailedStep = %q, want the first step", result.FailedStep)
+	}
+	if strings.Contains(result.AllOutput, "tests broke") {
+		t.Error("second step ran despite fail-fast")
+	}
+}
+
+func TestBuildPipelineScopesPathPipelines(t *testing.T) {
+	cfg := config.Config{
+		VerifyPipeline: []string{"go test ./..."},
+		VerifyPipelines: []config.PathPipeline{
+			{Path: "web", Commands: []string{"pnpm run lint", "pnpm run test"}},
+			{Path: "docs site", Commands: []string{"make check"}},
+		},
+	}
+
+	commands := func(bead *beads.Bead) []string {
+		var cmds []string
+		for _, step := range buildPipeline(cfg, bead) {
+			cmds = append(cmds, step.Command)
+		}
+		return cmds
+	}
+
+	got := commands(&beads.Bead{Files: []string{"internal/api/handler.go", "web/src/App.tsx"}})
+	want := []string{"go test ./...", "cd web && pnpm run lint", "cd web && pnpm run test"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("pipeline for a web bead = %q, want %q", got, want)
+	}
+
+	if got := commands(&beads.Bead{Files: []string{"webhooks/hook.go"}}); len(got) != 1 {
+		t.Errorf("pipeline for a bead outside web/ = %q, want only the root pipeline", got)
+	}
+
+	got = commands(&beads.Bead{})
+	if len(got) != 4 || got[3] != "cd 'docs site' && make check" {
+		t.Errorf("pipeline for a bead without files = %q, want every pipeline, quoted paths", got)
+	}
+}
@@ -1,11 +1,14 @@
-// worktree.go manages per-bead git worktrees for parallel execution.
+// worktree.go manages per-bead git worktrees for parallel execution and for
+// sequential beads when execution.sequential_worktrees is set.
 package execute
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"sync"
 
+	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/git"
 )
 
@@ -104,3 +107,36 @@ func (wm *WorktreeManager) CleanupAll() {
 		}
 	}
 }
+
+// startSequentialWorktree creates the worktree a sequential bead runs in
+// when cfg enables sequential_worktrees. It returns "" when the option is
+// off or the worktree cannot be created, in which case the bead runs in the
+// project root as usual.
+func startSequentialWorktree(cfg *config.Config, projectRoot, beadID string) string {
+	if !cfg.Execution.SequentialWorktrees {
+		return ""
+	}
+	path, err := git.CreateWorktreeForBead(projectRoot, beadID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: running bead %s in the project root: %v\n", beadID, err)
+		return ""
+	}
+	return path
+}
+
+// finishSequentialWorktree merges a sequential bead's worker branch into
+// the run branch when it passed, then removes the worktree either way, so a
+// failed bead leaves the run branch untouched. A failed merge is aborted
+// and returned.
+func finishSequentialWorktree(projectRoot, beadID, branchName string, passed bool) error {
+	var mergeErr error
+	if passed {
+		if mergeErr = git.MergeWorktreeForBead(projectRoot, beadID, branchName); mergeErr != nil {
+			_ = git.AbortMerge()
+		}
+	}
+	if err := git.RemoveWorktreeForBead(projectRoot, beadID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree for bead %s: %v\n", beadID, err)
+	}
+	return mergeErr
+}
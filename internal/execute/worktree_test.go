@@ -0,0 +1,69 @@
+package execute
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+// commitInWorktree simulates Claude committing a new file inside a bead's
+// worktree.
+func commitInWorktree(t *testing.T, wt, name string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(wt, name), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"add", name}, {"commit", "-q", "-m", "add " + name}} {
+		if out, err := exec.Command("git", append([]string{"-C", wt}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+}
+
+func TestSequentialWorktreeMergesPassedBead(t *testing.T) {
+	dir := initTestRepo(t)
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("git rev-parse: %v", err)
+	}
+	branch := strings.TrimSpace(string(out))
+
+	cfg := config.DefaultConfig()
+	if wt := startSequentialWorktree(cfg, dir, "bt-1"); wt != "" {
+		t.Fatalf("worktree created with sequential_worktrees off: %s", wt)
+	}
+	cfg.Execution.SequentialWorktrees = true
+
+	wt := startSequentialWorktree(cfg, dir, "bt-1")
+	if wt == "" {
+		t.Fatal("no worktree created")
+	}
+	commitInWorktree(t, wt, "feature.go")
+	if _, err := os.Stat(filepath.Join(dir, "feature.go")); !os.IsNotExist(err) {
+		t.Fatal("bead's change reached the project root before the merge")
+	}
+
+	if err := finishSequentialWorktree(dir, "bt-1", branch, true); err != nil {
+		t.Fatalf("finishSequentialWorktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "feature.go")); err != nil {
+		t.Errorf("merged change missing from the project root: %v", err)
+	}
+	if _, err := os.Stat(wt); !os.IsNotExist(err) {
+		t.Errorf("worktree %s not removed", wt)
+	}
+
+	// A failed bead's worktree is discarded without touching the branch.
+	wt = startSequentialWorktree(cfg, dir, "bt-2")
+	commitInWorktree(t, wt, "broken.go")
+	if err := finishSequentialWorktree(dir, "bt-2", branch, false); err != nil {
+		t.Fatalf("finishSequentialWorktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.go")); !os.IsNotExist(err) {
+		t.Error("failed bead's change was merged")
+	}
+}
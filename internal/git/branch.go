@@ -115,6 +115,8 @@ func CreateWorktree(path, branchName, baseBranch string) error {
 	if err := ensureGit(); err != nil {
 		return err
 	}
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
 	cmd := exec.Command("git", "worktree", "add", "-b", branchName, path, baseBranch)
 	if out, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("git worktree add: %s: %w", strings.TrimSpace(string(out)), err)
@@ -128,6 +130,8 @@ func RemoveWorktree(path string) error {
 	if err := ensureGit(); err != nil {
 		return err
 	}
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
 	cmd := exec.Command("git", "worktree", "remove", "--force", path)
 	if out, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("git worktree remove: %s: %w", strings.TrimSpace(string(out)), err)
@@ -4,6 +4,7 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
@@ -104,3 +105,135 @@ func IsIgnored(path string) bool {
 	cmd := exec.Command("git", "check-ignore", "-q", path)
 	return cmd.Run() == nil
 }
+
+// HeadSHA returns the full SHA of HEAD.
+// Shells out to: git rev-parse HEAD
+func HeadSHA() (string, error) {
+	if err := ensureGit(); err != nil {
+		return "", err
+	}
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CommitsSince returns the SHAs of commits reachable from HEAD but not from
+// ref, oldest first. Returns nil if HEAD has not moved past ref.
+// Shells out to: git rev-list --reverse <ref>..HEAD
+func CommitsSince(ref string) ([]string, error) {
+	if err := ensureGit(); err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("git", "rev-list", "--reverse", ref+"..HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git rev-list %s..HEAD: %w", ref, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// ChangedFilesSince returns every path that differs from ref: files changed
+// by commits since ref, uncommitted modifications, and untracked files.
+// Shells out to: git diff --name-only <ref> and git ls-files --others --exclude-standard
+func ChangedFilesSince(ref string) ([]string, error) {
+	if err := ensureGit(); err != nil {
+		return nil, err
+	}
+
+	diffOut, err := exec.Command("git", "diff", "--name-only", ref).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --name-only %s: %w", ref, err)
+	}
+	untrackedOut, err := exec.Command("git", "ls-files", "--others", "--exclude-standard").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files --others: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, line := range strings.Split(string(diffOut)+"\n"+string(untrackedOut), "\n") {
+		f := strings.TrimSpace(line)
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// ChangedFilesBetween returns the paths that differ between two commits.
+// Shells out to: git diff --name-only <from> <to>
+func ChangedFilesBetween(from, to string) ([]string, error) {
+	if err := ensureGit(); err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("git", "diff", "--name-only", from, to).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --name-only %s %s: %w", from, to, err)
+	}
+	var files []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if f := strings.TrimSpace(line); f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// FileChange is one entry of git diff --name-status output.
+type FileChange struct {
+	Status  byte   // 'A', 'M', 'D', 'R', ...
+	Path    string // current path (new path for renames)
+	OldPath string // previous path, set for renames and copies
+}
+
+// DiffStatusSince returns the files that differ between ref and the working
+// tree, with renames detected.
+// Shells out to: git diff --name-status -M <ref>
+func DiffStatusSince(ref string) ([]FileChange, error) {
+	if err := ensureGit(); err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("git", "diff", "--name-status", "-M", ref).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --name-status %s: %w", ref, err)
+	}
+
+	var changes []FileChange
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+		change := FileChange{Status: fields[0][0], Path: fields[len(fields)-1]}
+		if len(fields) == 3 {
+			change.OldPath = fields[1]
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// RecentCommitsForFile returns up to n commits that touched path, newest
+// first, each as "<short sha> <subject>". Returns nil for a file with no
+// history, such as one not yet committed.
+// Shells out to: git log -n <n> --format=%h %s -- <path>
+func RecentCommitsForFile(path string, n int) ([]string, error) {
+	if err := ensureGit(); err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("git", "log", "-n", strconv.Itoa(n), "--format=%h %s", "--", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s: %w", path, err)
+	}
+
+	var commits []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits, nil
+}
@@ -0,0 +1,85 @@
+// revert.go inspects and undoes the commits of a run.
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Commit is one commit in a LogSince listing.
+type Commit struct {
+	SHA     string
+	Subject string
+	Merge   bool // more than one parent
+}
+
+// LogSince returns the commits reachable from HEAD but not from ref, oldest
+// first.
+// Shells out to: git log --reverse --format=%H %P%x09%s <ref>..HEAD
+func LogSince(ref string) ([]Commit, error) {
+	if err := ensureGit(); err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("git", "log", "--reverse", "--format=%H %P%x09%s", ref+"..HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s..HEAD: %w", ref, err)
+	}
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		hashes, subject, _ := strings.Cut(line, "\t")
+		fields := strings.Fields(hashes)
+		commits = append(commits, Commit{SHA: fields[0], Subject: subject, Merge: len(fields) > 2})
+	}
+	return commits, nil
+}
+
+// MergeBase returns the best common ancestor of refs.
+// Shells out to: git merge-base --octopus <refs...>
+func MergeBase(refs ...string) (string, error) {
+	if err := ensureGit(); err != nil {
+		return "", err
+	}
+	out, err := exec.Command("git", append([]string{"merge-base", "--octopus"}, refs...)...).Output()
+	if err != nil {
+		return "", fmt.Errorf("git merge-base %s: %w", strings.Join(refs, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// IsAncestor reports whether ref is reachable from HEAD.
+// Shells out to: git merge-base --is-ancestor <ref> HEAD
+func IsAncestor(ref string) bool {
+	return exec.Command("git", "merge-base", "--is-ancestor", ref, "HEAD").Run() == nil
+}
+
+// Revert creates a revert commit for each of shas, in the order given. If a
+// revert fails, the revert in progress is aborted.
+// Shells out to: git revert --no-edit <shas...>
+func Revert(shas []string) error {
+	if err := ensureGit(); err != nil {
+		return err
+	}
+	out, err := exec.Command("git", append([]string{"revert", "--no-edit"}, shas...)...).CombinedOutput()
+	if err != nil {
+		_ = exec.Command("git", "revert", "--abort").Run()
+		return fmt.Errorf("git revert: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// ResetHard moves the current branch to ref, discarding every commit and
+// working tree change after it.
+// Shells out to: git reset --hard <ref>
+func ResetHard(ref string) error {
+	if err := ensureGit(); err != nil {
+		return err
+	}
+	if out, err := exec.Command("git", "reset", "--hard", ref).CombinedOutput(); err != nil {
+		return fmt.Errorf("git reset --hard %s: %s: %w", ref, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Package git wraps Git operations used by berth.
+// This file handles creating, listing, and deleting tags.
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CreateTag creates a lightweight tag pointing at ref.
+// Shells out to: git tag <name> <ref>
+func CreateTag(name, ref string) error {
+	if err := ensureGit(); err != nil {
+		return err
+	}
+	cmd := exec.Command("git", "tag", name, ref)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git tag %s: %s: %w", name, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// ListTags returns the tags matching a glob pattern, e.g. "berth-snapshot-*".
+// Shells out to: git tag --list <pattern>
+func ListTags(pattern string) ([]string, error) {
+	if err := ensureGit(); err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("git", "tag", "--list", pattern).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git tag --list %s: %w", pattern, err)
+	}
+	raw := strings.TrimSpace(string(out))
+	if raw == "" {
+		return nil, nil
+	}
+	return strings.Split(raw, "\n"), nil
+}
+
+// DeleteTagsMatching deletes every tag matching a glob pattern and returns
+// the deleted tag names.
+// Shells out to: git tag --delete <tags...>
+func DeleteTagsMatching(pattern string) ([]string, error) {
+	tags, err := ListTags(pattern)
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+	args := append([]string{"tag", "--delete"}, tags...)
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git tag --delete: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return tags, nil
+}
@@ -9,11 +9,16 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 // worktreeDir is the directory under the project root where worktrees are stored.
 const worktreeDir = ".berth/worktrees"
 
+// worktreeMu serializes adding and removing worktrees: concurrent git
+// worktree commands race on the administrative files under .git/worktrees.
+var worktreeMu sync.Mutex
+
 // MergeConflict is returned when a merge operation encounters conflicts.
 type MergeConflict struct {
 	BeadID string
@@ -45,6 +50,8 @@ func CreateWorktreeForBead(projectRoot, beadID string) (string, error) {
 	}
 
 	// Run: git worktree add -b {branchName} {wtPath} HEAD
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
 	cmd := exec.Command("git", "worktree", "add", "-b", branchName, wtPath, "HEAD")
 	cmd.Dir = projectRoot
 	if out, err := cmd.CombinedOutput(); err != nil {
@@ -65,6 +72,8 @@ func RemoveWorktreeForBead(projectRoot, beadID string) error {
 	branchName := fmt.Sprintf("berth/worker/%s", beadID)
 
 	// Run: git worktree remove --force {wtPath}
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
 	cmd := exec.Command("git", "worktree", "remove", "--force", wtPath)
 	cmd.Dir = projectRoot
 	if out, err := cmd.CombinedOutput(); err != nil {
@@ -186,3 +195,64 @@ func CleanupWorktrees(projectRoot string) error {
 
 	return nil
 }
+
+// AddDetachedWorktree creates a worktree at path with ref checked out on a
+// detached HEAD, so commits made there belong to no branch until a branch
+// is moved to them.
+// Shells out to: git worktree add --detach <path> <ref>
+func AddDetachedWorktree(path, ref string) error {
+	if err := ensureGit(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating worktree directory: %w", err)
+	}
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
+	cmd := exec.Command("git", "worktree", "add", "--detach", path, ref)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git worktree add --detach: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// MergeBranchIn merges branchName into the HEAD checked out in dir with a
+// merge commit. A merge that fails is aborted, leaving dir clean.
+// Shells out to: git -C <dir> merge --no-ff <branchName> -m <commitMsg>
+func MergeBranchIn(dir, branchName, commitMsg string) error {
+	if err := ensureGit(); err != nil {
+		return err
+	}
+	out, err := exec.Command("git", "-C", dir, "merge", "--no-ff", branchName, "-m", commitMsg).CombinedOutput()
+	if err != nil {
+		_ = exec.Command("git", "-C", dir, "merge", "--abort").Run()
+		return fmt.Errorf("git merge: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// HeadSHAIn returns the full SHA of HEAD in dir.
+// Shells out to: git -C <dir> rev-parse HEAD
+func HeadSHAIn(dir string) (string, error) {
+	if err := ensureGit(); err != nil {
+		return "", err
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse HEAD in %s: %w", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// FastForward moves the current branch to ref, failing unless ref descends
+// from it.
+// Shells out to: git merge --ff-only <ref>
+func FastForward(ref string) error {
+	if err := ensureGit(); err != nil {
+		return err
+	}
+	if out, err := exec.Command("git", "merge", "--ff-only", ref).CombinedOutput(); err != nil {
+		return fmt.Errorf("git merge --ff-only %s: %s: %w", ref, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// callpath.go traces how a bead's functions are reached from the program's
+// entry points, for the executor prompt.
+package graph
+
+import "errors"
+
+// callPathMaxNodes caps the functions TraceCallPaths expands, bounding the
+// get_callees queries made for one bead.
+const callPathMaxNodes = 200
+
+// EntryPoints returns the functions execution of a program in lang starts
+// from, or nil for languages without a conventional entry function, such as
+// TypeScript and JavaScript, whose modules run top to bottom.
+func EntryPoints(lang string) []string {
+	switch lang {
+	case "go", "rust", "python", "java", "kotlin", "c", "cpp":
+		return []string{"main"}
+	case "csharp":
+		return []string{"Main"}
+	default:
+		return nil
+	}
+}
+
+// TraceCallPaths returns the shortest call path from any of entries to each
+// of targets that is reachable, keyed by target. It runs a single
+// breadth-first search from all entries at once over get_callees, stopping
+// once every target is found or callPathMaxNodes functions were expanded.
+// Servers without get_callees yield an error wrapping ErrToolUnsupported.
+func (c *Client) TraceCallPaths(entries, targets []string) (map[string][]CallPathStep, error) {
+	return traceCallPaths(entries, targets, callPathMaxNodes, c.QueryCallees)
+}
+
+// traceCallPaths is TraceCallPaths over callees, expanding at most maxNodes
+// functions.
+func traceCallPaths(entries, targets []string, maxNodes int, callees func(name string) ([]CalleeResult, error)) (map[string][]CallPathStep, error) {
+	paths := make(map[string][]CallPathStep)
+	wanted := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		wanted[t] = true
+	}
+
+	// parent records how each visited function was first reached; entries
+	// have none.
+	type visit struct {
+		step   CallPathStep
+		parent string
+	}
+	visited := make(map[string]visit)
+	var queue []string
+	for _, e := range entries {
+		if _, ok := visited[e]; !ok {
+			visited[e] = visit{step: CallPathStep{Name: e}}
+			queue = append(queue, e)
+		}
+	}
+
+	pathTo := func(name string) []CallPathStep {
+		var path []CallPathStep
+		for {
+			v := visited[name]
+			path = append([]CallPathStep{v.step}, path...)
+			if v.parent == "" {
+				return path
+			}
+			name = v.parent
+		}
+	}
+
+	for expanded := 0; len(queue) > 0 && len(paths) < len(wanted) && expanded < maxNodes; expanded++ {
+		name := queue[0]
+		queue = queue[1:]
+		results, err := callees(name)
+		if errors.Is(err, ErrToolUnsupported) {
+			return nil, err
+		}
+		if err != nil {
+			continue // a function the server cannot resolve ends its branch
+		}
+		for _, r := range results {
+			if _, ok := visited[r.Name]; ok {
+				continue
+			}
+			visited[r.Name] = visit{step: CallPathStep(r), parent: name}
+			queue = append(queue, r.Name)
+			if wanted[r.Name] {
+				paths[r.Name] = pathTo(r.Name)
+			}
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fakeCallees answers get_callees from a call graph of name -> callees,
+// counting the queries made.
+func fakeCallees(graph map[string][]string, queries *int) func(string) ([]CalleeResult, error) {
+	return func(name string) ([]CalleeResult, error) {
+		*queries++
+		var results []CalleeResult
+		for i, callee := range graph[name] {
+			results = append(results, CalleeResult{File: callee + ".go", Line: i + 1, Name: callee})
+		}
+		return results, nil
+	}
+}
+
+func names(path []CallPathStep) []string {
+	var out []string
+	for _, step := range path {
+		out = append(out, step.Name)
+	}
+	return out
+}
+
+func TestTraceCallPathsSearchesFromAllEntriesAtOnce(t *testing.T) {
+	calls := map[string][]string{
+		"main":   {"serve", "setup"},
+		"Main":   {"Handler"},
+		"serve":  {"route"},
+		"route":  {"Handler", "Render"},
+		"setup":  {"Render"},
+		"Render": {"helper"},
+	}
+	var queries int
+	paths, err := traceCallPaths([]string{"main", "Main"}, []string{"Handler", "Render", "Missing"}, 100, fakeCallees(calls, &queries))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		"Handler": {"Main", "Handler"},
+		"Render":  {"main", "setup", "Render"},
+	}
+	got := map[string][]string{}
+	for target, path := range paths {
+		got[target] = names(path)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+	if step := paths["Render"][2]; step.File != "Render.go" || step.Line != 1 {
+		t.Errorf("Render step = %+v, want its callee location", step)
+	}
+	// Each reachable function is expanded at most once.
+	if queries > len(calls)+2 {
+		t.Errorf("queries = %d, want each function expanded once", queries)
+	}
+}
+
+func TestTraceCallPathsStopsAtMaxNodes(t *testing.T) {
+	calls := map[string][]string{}
+	for i := 0; i < 50; i++ {
+		calls[fmt.Sprintf("f%d", i)] = []string{fmt.Sprintf("f%d", i+1)}
+	}
+	calls["main"] = []string{"f0"}
+
+	var queries int
+	paths, err := traceCallPaths([]string{"main"}, []string{"f50"}, 10, fakeCallees(calls, &queries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 0 || queries != 10 {
+		t.Errorf("paths = %v after %d queries, want none after 10", paths, queries)
+	}
+}
+
+func TestTraceCallPathsUnsupported(t *testing.T) {
+	unsupported := func(string) ([]CalleeResult, error) {
+		return nil, fmt.Errorf("get_callees: %w", ErrToolUnsupported)
+	}
+	if _, err := traceCallPaths([]string{"main"}, []string{"Handler"}, 10, unsupported); !errors.Is(err, ErrToolUnsupported) {
+		t.Errorf("err = %v, want ErrToolUnsupported", err)
+	}
+}
+
+func TestEntryPoints(t *testing.T) {
+	if got := EntryPoints("go"); !reflect.DeepEqual(got, []string{"main"}) {
+		t.Errorf("EntryPoints(go) = %v, want [main]", got)
+	}
+	if got := EntryPoints("typescript"); got != nil {
+		t.Errorf("EntryPoints(typescript) = %v, want none", got)
+	}
+}
@@ -5,8 +5,10 @@ package graph
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"sync"
 	"sync/atomic"
@@ -27,6 +29,13 @@ type CalleeResult struct {
 	Name string `json:"name"`
 }
 
+// CallPathStep is one hop of a call path: the function, where it is defined.
+type CallPathStep struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Name string `json:"name"`
+}
+
 // DependentResult represents a module/symbol dependent on another.
 type DependentResult struct {
 	File string `json:"file"`
@@ -120,6 +129,10 @@ type mcpError struct {
 	Message string `json:"message"`
 }
 
+func (e *mcpError) Error() string {
+	return fmt.Sprintf("MCP error %d: %s", e.Code, e.Message)
+}
+
 // mcpContent represents a single content block in an MCP tool result.
 type mcpContent struct {
 	Type string `json:"type"`
@@ -138,6 +151,64 @@ type toolCallParams struct {
 	Arguments map[string]any `json:"arguments"`
 }
 
+// mcpProtocolVersion is the MCP protocol revision sent during initialize.
+const mcpProtocolVersion = "2024-11-05"
+
+// ServerInfo identifies the MCP server as reported during initialize.
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// initializeParams holds the parameters for an MCP initialize request.
+type initializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      ServerInfo     `json:"clientInfo"`
+}
+
+// initializeResult is the server's response to initialize.
+type initializeResult struct {
+	ProtocolVersion string                     `json:"protocolVersion"`
+	Capabilities    map[string]json.RawMessage `json:"capabilities"`
+	ServerInfo      ServerInfo                 `json:"serverInfo"`
+}
+
+// mcpNotification is a JSON-RPC 2.0 notification (no ID, no response).
+type mcpNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// RetryPolicy controls how read-only tool calls are retried when a call
+// times out. Attempts counts the first call;
+// the wait before retry n is BaseDelay doubled n-1 times, capped at MaxDelay.
+type RetryPolicy struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy NewClient sets.
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:  3,
+	BaseDelay: 200 * time.Millisecond,
+	MaxDelay:  2 * time.Second,
+}
+
+// delay returns how long to wait before retrying after the given attempt.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return d
+}
+
 // Client communicates with the Knowledge Graph MCP server.
 type Client struct {
 	cmd     *exec.Cmd
@@ -146,10 +217,28 @@ type Client struct {
 	mu      sync.RWMutex
 	nextID  atomic.Int64
 	timeout time.Duration
+
+	// RetryPolicy governs retries of read-only tool calls.
+	RetryPolicy RetryPolicy
+
+	// Calls waiting for a response, keyed by request ID. readResponses
+	// hands each response to its call; readErr is set once stdout ends.
+	pendingMu sync.Mutex
+	pending   map[int]chan callResult
+	readErr   error
+
+	// Populated by Initialize.
+	protocolVersion string
+	serverInfo      ServerInfo
+	capabilities    map[string]json.RawMessage
+	tools           map[string]bool // from tools/list; nil if not reported
+
+	// Batches reindex calls among concurrent ReindexFiles callers.
+	reindexes reindexCoalescer
 }
 
 // NewClient creates a new Client by attaching to the command's stdin/stdout
-// pipes and starting the process.
+// pipes, starting the process, and performing the MCP initialize handshake.
 func NewClient(cmd *exec.Cmd, timeout time.Duration) (*Client, error) {
 	stdinPipe, err := cmd.StdinPipe()
 	if err != nil {
@@ -172,15 +261,107 @@ func NewClient(cmd *exec.Cmd, timeout time.Duration) (*Client, error) {
 	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
 
 	client := &Client{
-		cmd:     cmd,
-		stdin:   stdinPipe,
-		stdout:  scanner,
-		timeout: timeout,
+		cmd:         cmd,
+		stdin:       stdinPipe,
+		stdout:      scanner,
+		timeout:     timeout,
+		RetryPolicy: DefaultRetryPolicy,
+		pending:     make(map[int]chan callResult),
 	}
 	client.nextID.Store(1)
+	go client.readResponses()
+
+	if err := client.Initialize(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	warnMissingTools(os.Stderr, client.MissingTools())
 	return client, nil
 }
 
+// Initialize performs the MCP handshake: it sends an initialize request,
+// records the server's info and capabilities, sends the
+// notifications/initialized notification, and fetches the tool list.
+// Servers that do not implement initialize (method not found) are tolerated
+// and left uninitialized.
+func (c *Client) Initialize() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	raw, err := c.callLocked("initialize", initializeParams{
+		ProtocolVersion: mcpProtocolVersion,
+		Capabilities:    map[string]any{},
+		ClientInfo:      ServerInfo{Name: "berth", Version: "1.0.0"},
+	})
+	if err != nil {
+		var rpcErr *mcpError
+		if errors.As(err, &rpcErr) && rpcErr.Code == -32601 {
+			return nil
+		}
+		return fmt.Errorf("graph: MCP initialize: %w", err)
+	}
+
+	var result initializeResult
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return fmt.Errorf("graph: unmarshalling initialize result: %w", err)
+		}
+	}
+	c.protocolVersion = result.ProtocolVersion
+	c.serverInfo = result.ServerInfo
+	c.capabilities = result.Capabilities
+	if c.capabilities == nil {
+		c.capabilities = map[string]json.RawMessage{}
+	}
+
+	if err := c.notifyLocked("notifications/initialized", nil); err != nil {
+		return fmt.Errorf("graph: MCP initialized notification: %w", err)
+	}
+
+	tools, err := c.listToolsLocked()
+	if err != nil {
+		return err
+	}
+	c.tools = tools
+	return nil
+}
+
+// ServerInfo returns the server name and version negotiated during
+// Initialize. It is empty if the handshake was not performed.
+func (c *Client) ServerInfo() ServerInfo {
+	if c == nil {
+		return ServerInfo{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.serverInfo
+}
+
+// HasCapability reports whether the server advertised the named capability
+// during Initialize, either at the top level (e.g. "tools") or under
+// "experimental" (e.g. "analyze_impact_batch").
+func (c *Client) HasCapability(name string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if _, ok := c.capabilities[name]; ok {
+		return true
+	}
+	raw, ok := c.capabilities["experimental"]
+	if !ok {
+		return false
+	}
+	var experimental map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &experimental); err != nil {
+		return false
+	}
+	_, ok = experimental[name]
+	return ok
+}
+
 // Close shuts down the MCP process gracefully.
 func (c *Client) Close() error {
 	c.mu.Lock()
@@ -197,10 +378,25 @@ func (c *Client) Close() error {
 
 // callToolRead sends a JSON-RPC tools/call request for read-only operations
 // and unmarshals the response into result. Uses RLock to allow concurrent reads.
+// A call that times out is retried per c.RetryPolicy; MCP tool errors are
+// deterministic and returned at once.
 func (c *Client) callToolRead(name string, args map[string]any, result any) error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return c.callToolLocked(name, args, result)
+	for attempt := 1; ; attempt++ {
+		c.mu.RLock()
+		err := c.callToolLocked(name, args, result)
+		c.mu.RUnlock()
+		if err == nil || !retryable(err) || attempt >= c.RetryPolicy.Attempts {
+			return err
+		}
+		time.Sleep(c.RetryPolicy.delay(attempt))
+	}
+}
+
+// retryable reports whether a failed tool call may succeed if repeated.
+// Once stdout has closed every later call fails the same way, so only
+// timeouts qualify; the caller restarts the server instead.
+func retryable(err error) bool {
+	return errors.Is(err, errCallTimeout)
 }
 
 // callToolWrite sends a JSON-RPC tools/call request for write operations
@@ -211,85 +407,174 @@ func (c *Client) callToolWrite(name string, args map[string]any, result any) err
 	return c.callToolLocked(name, args, result)
 }
 
-// callToolLocked performs the actual JSON-RPC call. Caller must hold the lock.
+// callToolLocked performs a tools/call request and unmarshals the tool's text
+// content into result. Caller must hold the lock.
 func (c *Client) callToolLocked(name string, args map[string]any, result any) error {
+	if !c.supportsToolLocked(name) {
+		return fmt.Errorf("%w: %s", ErrToolUnsupported, name)
+	}
+	raw, err := c.callLocked("tools/call", toolCallParams{
+		Name:      name,
+		Arguments: args,
+	})
+	if err != nil {
+		var rpcErr *mcpError
+		if errors.As(err, &rpcErr) {
+			if rpcErr.Code == -32601 {
+				return fmt.Errorf("%w: %s", ErrToolUnsupported, name)
+			}
+			return fmt.Errorf("graph: %w", err)
+		}
+		if errors.Is(err, errCallTimeout) {
+			return fmt.Errorf("graph: tool call %q %w after %s", name, err, c.timeout)
+		}
+		return err
+	}
+
+	if result != nil && raw != nil {
+		var envelope mcpToolResult
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			return fmt.Errorf("graph: unmarshalling MCP envelope: %w", err)
+		}
+		if envelope.IsError {
+			text := ""
+			if len(envelope.Content) > 0 {
+				text = envelope.Content[0].Text
+			}
+			return fmt.Errorf("graph: MCP tool error: %s", text)
+		}
+		if len(envelope.Content) == 0 || envelope.Content[0].Type != "text" {
+			return fmt.Errorf("graph: unexpected MCP response: no text content")
+		}
+		if err := json.Unmarshal([]byte(envelope.Content[0].Text), result); err != nil {
+			return fmt.Errorf("graph: unmarshalling result: %w", err)
+		}
+	}
+
+	return nil
+}
+
+var (
+	// errCallTimeout is returned by callLocked when no response arrives in time.
+	errCallTimeout = errors.New("timed out")
+	// errStdoutClosed is returned once the MCP process has closed stdout.
+	errStdoutClosed = errors.New("graph: MCP process closed stdout")
+)
+
+// callResult is a response (or read failure) handed to a waiting call.
+type callResult struct {
+	resp mcpResponse
+	err  error
+}
+
+// callLocked sends a JSON-RPC request and returns the raw result. A JSON-RPC
+// error response is returned as *mcpError. Caller must hold the lock.
+func (c *Client) callLocked(method string, params any) (json.RawMessage, error) {
 	id := int(c.nextID.Add(1))
 
 	req := mcpRequest{
 		JSONRPC: "2.0",
 		ID:      id,
-		Method:  "tools/call",
-		Params: toolCallParams{
-			Name:      name,
-			Arguments: args,
-		},
+		Method:  method,
+		Params:  params,
 	}
 
 	data, err := json.Marshal(req)
 	if err != nil {
-		return fmt.Errorf("graph: marshalling request: %w", err)
+		return nil, fmt.Errorf("graph: marshalling request: %w", err)
+	}
+
+	// Register for the response before sending so it cannot be missed.
+	ch := make(chan callResult, 1)
+	c.pendingMu.Lock()
+	if c.readErr != nil {
+		err := c.readErr
+		c.pendingMu.Unlock()
+		return nil, err
 	}
+	c.pending[id] = ch
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
 
 	// Write the request followed by a newline (line-delimited JSON-RPC).
 	data = append(data, '\n')
 	if _, err := c.stdin.Write(data); err != nil {
-		return fmt.Errorf("graph: writing request: %w", err)
-	}
-
-	// Read response with timeout.
-	type scanResult struct {
-		line []byte
-		ok   bool
+		return nil, fmt.Errorf("graph: writing request: %w", err)
 	}
-	ch := make(chan scanResult, 1)
-	go func() {
-		ok := c.stdout.Scan()
-		ch <- scanResult{line: c.stdout.Bytes(), ok: ok}
-	}()
 
 	select {
-	case sr := <-ch:
-		if !sr.ok {
-			if err := c.stdout.Err(); err != nil {
-				return fmt.Errorf("graph: reading response: %w", err)
-			}
-			return fmt.Errorf("graph: MCP process closed stdout")
+	case r := <-ch:
+		if r.err != nil {
+			return nil, r.err
 		}
-
-		var resp mcpResponse
-		if err := json.Unmarshal(sr.line, &resp); err != nil {
-			return fmt.Errorf("graph: unmarshalling response: %w", err)
+		if r.resp.Error != nil {
+			return nil, r.resp.Error
 		}
+		return r.resp.Result, nil
 
-		if resp.Error != nil {
-			return fmt.Errorf("graph: MCP error %d: %s", resp.Error.Code, resp.Error.Message)
-		}
+	case <-time.After(c.timeout):
+		return nil, errCallTimeout
+	}
+}
 
-		if result != nil && resp.Result != nil {
-			var envelope mcpToolResult
-			if err := json.Unmarshal(resp.Result, &envelope); err != nil {
-				return fmt.Errorf("graph: unmarshalling MCP envelope: %w", err)
-			}
-			if envelope.IsError {
-				text := ""
-				if len(envelope.Content) > 0 {
-					text = envelope.Content[0].Text
-				}
-				return fmt.Errorf("graph: MCP tool error: %s", text)
-			}
-			if len(envelope.Content) == 0 || envelope.Content[0].Type != "text" {
-				return fmt.Errorf("graph: unexpected MCP response: no text content")
-			}
-			if err := json.Unmarshal([]byte(envelope.Content[0].Text), result); err != nil {
-				return fmt.Errorf("graph: unmarshalling result: %w", err)
-			}
+// readResponses reads stdout until it ends, handing each response to the
+// call waiting for its ID. Server notifications, unparseable lines, and late
+// responses to calls that already timed out are dropped. When stdout ends,
+// waiting and later calls fail with the read error.
+func (c *Client) readResponses() {
+	for c.stdout.Scan() {
+		var msg struct {
+			mcpResponse
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(c.stdout.Bytes(), &msg); err != nil {
+			continue
 		}
+		// Skip server notifications (they carry a method and no ID).
+		if msg.Method != "" && msg.ID == 0 {
+			continue
+		}
+		c.pendingMu.Lock()
+		if ch, ok := c.pending[msg.ID]; ok {
+			ch <- callResult{resp: msg.mcpResponse}
+			delete(c.pending, msg.ID)
+		}
+		c.pendingMu.Unlock()
+	}
 
-		return nil
+	readErr := errStdoutClosed
+	if err := c.stdout.Err(); err != nil {
+		readErr = fmt.Errorf("graph: reading response: %w", err)
+	}
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	c.readErr = readErr
+	for id, ch := range c.pending {
+		ch <- callResult{err: readErr}
+		delete(c.pending, id)
+	}
+}
 
-	case <-time.After(c.timeout):
-		return fmt.Errorf("graph: tool call %q timed out after %s", name, c.timeout)
+// notifyLocked sends a JSON-RPC notification, which expects no response.
+// Caller must hold the lock.
+func (c *Client) notifyLocked(method string, params any) error {
+	data, err := json.Marshal(mcpNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("graph: marshalling notification: %w", err)
+	}
+	data = append(data, '\n')
+	if _, err := c.stdin.Write(data); err != nil {
+		return fmt.Errorf("graph: writing notification: %w", err)
 	}
+	return nil
 }
 
 // QueryCallers returns all callers of the named function.
@@ -306,6 +591,17 @@ func (c *Client) QueryCallees(name string) ([]CalleeResult, error) {
 	return results, err
 }
 
+// QueryCallPath returns the shortest call path from the function named from
+// to the one named to, both ends included, or nil when to is not reachable.
+// Servers without get_call_path yield an error wrapping ErrToolUnsupported.
+func (c *Client) QueryCallPath(from, to string) ([]CallPathStep, error) {
+	var result struct {
+		Path []CallPathStep `json:"path"`
+	}
+	err := c.callToolRead("get_call_path", map[string]any{"from": from, "to": to}, &result)
+	return result.Path, err
+}
+
 // QueryDependents returns all files dependent on the specified file.
 func (c *Client) QueryDependents(filePath string) ([]DependentResult, error) {
 	var results []DependentResult
@@ -355,11 +651,24 @@ func (c *Client) AnalyzeImpact(filePath string) (*ImpactAnalysis, error) {
 	return &result, nil
 }
 
-// ReindexFiles triggers reindexing of the specified files in the KG.
+// ReindexFiles triggers reindexing of the specified files in the KG. While
+// another reindex is running, the files are batched with other waiting
+// requests into one call made once it finishes; ReindexFiles returns that
+// call's error.
 func (c *Client) ReindexFiles(files []string) error {
+	return c.reindexes.do(files, c.reindex)
+}
+
+// reindex sends one batched reindex_files call.
+func (c *Client) reindex(files []string) error {
 	return c.callToolWrite("reindex_files", map[string]any{"file_paths": files}, nil)
 }
 
+// RemoveFiles drops the specified files from the KG.
+func (c *Client) RemoveFiles(files []string) error {
+	return c.callToolWrite("remove_files", map[string]any{"file_paths": files}, nil)
+}
+
 // FullReindex triggers a full reindex of the entire project.
 func (c *Client) FullReindex() error {
 	return c.callToolWrite("reindex", nil, nil)
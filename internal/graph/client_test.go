@@ -0,0 +1,422 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestHelperMCPServer is not a real test. It runs as a fake MCP server when
+// the test binary is re-executed with BERTH_FAKE_MCP=1. The server rejects
+// tool calls until the initialize handshake has completed. With
+// BERTH_FAKE_MCP_SLOW=N it answers the first N tool calls only after 500ms,
+// and its first call for broken.go returns a tool error.
+func TestHelperMCPServer(t *testing.T) {
+	if os.Getenv("BERTH_FAKE_MCP") != "1" {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	initialized := false
+	slow, _ := strconv.Atoi(os.Getenv("BERTH_FAKE_MCP_SLOW"))
+	brokenFailed := false
+	var outMu sync.Mutex
+	respond := func(id json.RawMessage, result any, rpcErr *mcpError) {
+		resp := map[string]any{"jsonrpc": "2.0", "id": id}
+		if rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		data, _ := json.Marshal(resp)
+		outMu.Lock()
+		defer outMu.Unlock()
+		fmt.Println(string(data))
+	}
+
+	for scanner.Scan() {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Name      string         `json:"name"`
+				Arguments map[string]any `json:"arguments"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			continue
+		}
+
+		switch req.Method {
+		case "initialize":
+			respond(req.ID, map[string]any{
+				"protocolVersion": mcpProtocolVersion,
+				"capabilities": map[string]any{
+					"tools":        map[string]any{},
+					"experimental": map[string]any{"analyze_impact_batch": map[string]any{}},
+				},
+				"serverInfo": map[string]any{"name": "fake-kg", "version": "0.1.0"},
+			}, nil)
+			// Emit a server notification to verify the client skips it.
+			fmt.Println(`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info"}}`)
+		case "notifications/initialized":
+			initialized = true
+		case "tools/list":
+			// Only advertise tools when the test asks for a partial set.
+			names := os.Getenv("BERTH_FAKE_MCP_TOOLS")
+			if names == "" {
+				respond(req.ID, nil, &mcpError{Code: -32601, Message: "method not found"})
+				continue
+			}
+			var tools []map[string]string
+			for _, name := range strings.Split(names, ",") {
+				tools = append(tools, map[string]string{"name": name})
+			}
+			respond(req.ID, map[string]any{"tools": tools}, nil)
+		case "tools/call":
+			if !initialized {
+				respond(req.ID, nil, &mcpError{Code: -32002, Message: "server not initialized"})
+				continue
+			}
+			if req.Params.Name == "get_call_path" {
+				if os.Getenv("BERTH_FAKE_MCP_NO_CALL_PATH") == "1" {
+					respond(req.ID, nil, &mcpError{Code: -32601, Message: "method not found"})
+					continue
+				}
+				text, _ := json.Marshal(map[string]any{"path": []CallPathStep{
+					{File: "main.go", Line: 1, Name: req.Params.Arguments["from"].(string)},
+					{File: "handler.go", Line: 3, Name: req.Params.Arguments["to"].(string)},
+				}})
+				respond(req.ID, mcpToolResult{Content: []mcpContent{{Type: "text", Text: string(text)}}}, nil)
+				continue
+			}
+			if os.Getenv("BERTH_FAKE_MCP_DEAD_CODE") == "1" {
+				if text, ok := fakeDeadCodeTool(req.Params.Name, req.Params.Arguments); ok {
+					respond(req.ID, mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}, nil)
+				} else {
+					respond(req.ID, nil, &mcpError{Code: -32601, Message: "method not found"})
+				}
+				continue
+			}
+			if req.Params.Arguments["file_path"] == "broken.go" && !brokenFailed {
+				brokenFailed = true
+				respond(req.ID, mcpToolResult{Content: []mcpContent{{Type: "text", Text: "parse failed"}}, IsError: true}, nil)
+				continue
+			}
+			text, _ := json.Marshal([]ExportResult{{Name: "Handler", Kind: "function", Line: 3}})
+			result := mcpToolResult{Content: []mcpContent{{Type: "text", Text: string(text)}}}
+			if slow > 0 {
+				slow--
+				go func(id json.RawMessage) {
+					time.Sleep(500 * time.Millisecond)
+					respond(id, result, nil)
+				}(req.ID)
+				continue
+			}
+			respond(req.ID, result, nil)
+		default:
+			respond(req.ID, nil, &mcpError{Code: -32601, Message: "method not found"})
+		}
+	}
+	os.Exit(0)
+}
+
+// fakeDeadCodeTool answers the fake server's tool calls with
+// BERTH_FAKE_MCP_DEAD_CODE=1. The indexed file exports Handler (called),
+// Version (imported by main.go), Config (a type nothing uses) and Unused (a
+// function nothing calls). get_unused_exports is missing with
+// BERTH_FAKE_MCP_NO_UNUSED=1. ok is false for a tool the server lacks.
+func fakeDeadCodeTool(name string, args map[string]any) (text string, ok bool) {
+	var result any
+	switch name {
+	case "get_unused_exports":
+		if os.Getenv("BERTH_FAKE_MCP_NO_UNUSED") == "1" {
+			return "", false
+		}
+		result = []ExportResult{{Name: "Config", Kind: "type", Line: 9}, {Name: "Unused", Kind: "function", Line: 12}}
+	case "get_exports":
+		result = []ExportResult{
+			{Name: "Handler", Kind: "function", Line: 3},
+			{Name: "Version", Kind: "const", Line: 7},
+			{Name: "Config", Kind: "type", Line: 9},
+			{Name: "Unused", Kind: "function", Line: 12},
+		}
+	case "get_importers":
+		result = []ImporterResult{{File: "main.go", ImportedNames: []string{"Version"}}}
+	case "get_callers":
+		callers := []CallerResult{}
+		if args["symbol_name"] == "Handler" {
+			callers = append(callers, CallerResult{File: "main.go", Line: 5, Name: "main"})
+		}
+		result = callers
+	case "get_type_usages":
+		result = []TypeUsageResult{}
+	default:
+		return "", false
+	}
+	data, _ := json.Marshal(result)
+	return string(data), true
+}
+
+func fakeMCPCommand(t *testing.T) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperMCPServer")
+	cmd.Env = append(os.Environ(), "BERTH_FAKE_MCP=1")
+	return cmd
+}
+
+func TestNewClientPerformsInitializeHandshake(t *testing.T) {
+	client, err := NewClient(fakeMCPCommand(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	info := client.ServerInfo()
+	if info.Name != "fake-kg" || info.Version != "0.1.0" {
+		t.Errorf("ServerInfo = %+v, want fake-kg 0.1.0", info)
+	}
+	if !client.HasCapability("tools") {
+		t.Error("HasCapability(tools) = false, want true")
+	}
+	if !client.HasCapability("analyze_impact_batch") {
+		t.Error("HasCapability(analyze_impact_batch) = false, want true")
+	}
+	if client.HasCapability("resources") {
+		t.Error("HasCapability(resources) = true, want false")
+	}
+
+	exports, err := client.QueryExports("main.go")
+	if err != nil {
+		t.Fatalf("QueryExports after handshake: %v", err)
+	}
+	if len(exports) != 1 || exports[0].Name != "Handler" {
+		t.Errorf("QueryExports = %+v, want [Handler]", exports)
+	}
+}
+
+func TestHasCapabilityNilClient(t *testing.T) {
+	var c *Client
+	if c.HasCapability("tools") {
+		t.Error("HasCapability on nil client should be false")
+	}
+	if info := c.ServerInfo(); info.Name != "" {
+		t.Errorf("ServerInfo on nil client = %+v, want empty", info)
+	}
+}
+
+func TestClientDegradesForMissingTools(t *testing.T) {
+	cmd := fakeMCPCommand(t)
+	cmd.Env = append(cmd.Env, "BERTH_FAKE_MCP_TOOLS=get_exports,understand_file,reindex_files")
+	client, err := NewClient(cmd, 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	tools := client.SupportedTools()
+	if len(tools) != 3 || !tools["get_exports"] || tools["get_callers"] {
+		t.Errorf("SupportedTools = %v, want the 3 advertised tools", tools)
+	}
+
+	missing := client.MissingTools()
+	if len(missing) != len(requiredTools)-3 {
+		t.Errorf("MissingTools = %v, want %d tools", missing, len(requiredTools)-3)
+	}
+	var warning strings.Builder
+	warnMissingTools(&warning, missing)
+	if !strings.Contains(warning.String(), "analyze_impact") || strings.Contains(warning.String(), "get_exports") {
+		t.Errorf("warning = %q, want missing tools only", warning.String())
+	}
+
+	// Advertised tools still work; missing ones fail fast for the caller's
+	// fallback instead of erroring per-query on the server.
+	if _, err := client.QueryExports("main.go"); err != nil {
+		t.Errorf("QueryExports: %v", err)
+	}
+	if _, err := client.AnalyzeImpact("main.go"); !errors.Is(err, ErrToolUnsupported) {
+		t.Errorf("AnalyzeImpact error = %v, want ErrToolUnsupported", err)
+	}
+}
+
+func TestMissingToolsUnknownWhenNotListed(t *testing.T) {
+	client, err := NewClient(fakeMCPCommand(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if tools := client.SupportedTools(); tools != nil {
+		t.Errorf("SupportedTools = %v, want nil when tools/list is unsupported", tools)
+	}
+	if missing := client.MissingTools(); len(missing) != 0 {
+		t.Errorf("MissingTools = %v, want none when tool support is unknown", missing)
+	}
+}
+
+func TestReadCallsRetrySlowResponses(t *testing.T) {
+	cmd := fakeMCPCommand(t)
+	cmd.Env = append(cmd.Env, "BERTH_FAKE_MCP_SLOW=1")
+	client, err := NewClient(cmd, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+	client.RetryPolicy = RetryPolicy{Attempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	// The first call times out; the retry is answered at once.
+	exports, err := client.QueryExports("main.go")
+	if err != nil {
+		t.Fatalf("QueryExports: %v", err)
+	}
+	if len(exports) != 1 || exports[0].Name != "Handler" {
+		t.Errorf("QueryExports = %+v, want [Handler]", exports)
+	}
+
+	// The first call's late response must not be taken as another call's.
+	time.Sleep(600 * time.Millisecond)
+	if _, err := client.QueryExports("main.go"); err != nil {
+		t.Errorf("QueryExports after late response: %v", err)
+	}
+}
+
+func TestReadCallsGiveUpAfterRetryPolicy(t *testing.T) {
+	cmd := fakeMCPCommand(t)
+	cmd.Env = append(cmd.Env, "BERTH_FAKE_MCP_SLOW=3")
+	client, err := NewClient(cmd, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+	client.RetryPolicy = RetryPolicy{Attempts: 2, BaseDelay: 10 * time.Millisecond}
+
+	_, err = client.QueryExports("main.go")
+	if !errors.Is(err, errCallTimeout) {
+		t.Fatalf("QueryExports error = %v, want a timeout", err)
+	}
+	if !strings.Contains(err.Error(), `tool call "get_exports" timed out after 100ms`) {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestReadCallsDoNotRetryToolErrors(t *testing.T) {
+	client, err := NewClient(fakeMCPCommand(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+	client.RetryPolicy = RetryPolicy{Attempts: 3, BaseDelay: 10 * time.Millisecond}
+
+	// The server fails broken.go only once, so a retry would succeed.
+	if _, err := client.QueryExports("broken.go"); err == nil || !strings.Contains(err.Error(), "MCP tool error: parse failed") {
+		t.Errorf("QueryExports error = %v, want the tool error", err)
+	}
+}
+
+func TestReadCallsDoNotRetryAfterStdoutCloses(t *testing.T) {
+	client, err := NewClient(fakeMCPCommand(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+	client.RetryPolicy = RetryPolicy{Attempts: 3, BaseDelay: 5 * time.Second}
+
+	if err := client.cmd.Process.Kill(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		client.pendingMu.Lock()
+		closed := client.readErr != nil
+		client.pendingMu.Unlock()
+		if closed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stdout never closed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	start := time.Now()
+	if _, err := client.QueryExports("main.go"); !errors.Is(err, errStdoutClosed) {
+		t.Fatalf("QueryExports error = %v, want errStdoutClosed", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("QueryExports took %s, want no retries", elapsed)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 5: 300 * time.Millisecond} {
+		if got := p.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
+
+func TestQueryCallPath(t *testing.T) {
+	client, err := NewClient(fakeMCPCommand(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	path, err := client.QueryCallPath("main", "Handler")
+	if err != nil {
+		t.Fatalf("QueryCallPath: %v", err)
+	}
+	if len(path) != 2 || path[0].Name != "main" || path[1] != (CallPathStep{File: "handler.go", Line: 3, Name: "Handler"}) {
+		t.Errorf("path = %+v, want main -> Handler", path)
+	}
+}
+
+func TestQueryCallPathMethodNotFound(t *testing.T) {
+	cmd := fakeMCPCommand(t)
+	cmd.Env = append(cmd.Env, "BERTH_FAKE_MCP_NO_CALL_PATH=1")
+	client, err := NewClient(cmd, 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if _, err := client.QueryCallPath("main", "Handler"); !errors.Is(err, ErrToolUnsupported) {
+		t.Errorf("QueryCallPath error = %v, want ErrToolUnsupported", err)
+	}
+}
+
+func TestQueryUnusedExports(t *testing.T) {
+	for _, fallback := range []bool{false, true} {
+		cmd := fakeMCPCommand(t)
+		cmd.Env = append(cmd.Env, "BERTH_FAKE_MCP_DEAD_CODE=1")
+		if fallback {
+			cmd.Env = append(cmd.Env, "BERTH_FAKE_MCP_NO_UNUSED=1")
+		}
+		client, err := NewClient(cmd, 5*time.Second)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+
+		unused, err := client.QueryUnusedExports("dead.go")
+		_ = client.Close()
+		if err != nil {
+			t.Fatalf("QueryUnusedExports (fallback %v): %v", fallback, err)
+		}
+		var names []string
+		for _, exp := range unused {
+			names = append(names, exp.Name)
+		}
+		if strings.Join(names, ",") != "Config,Unused" {
+			t.Errorf("fallback %v: unused exports = %v, want Config and Unused", fallback, names)
+		}
+	}
+}
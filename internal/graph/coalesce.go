@@ -0,0 +1,86 @@
+// coalesce.go batches concurrent reindex requests, so beads merging close
+// together in parallel mode do not queue up one write-locked reindex call
+// each, while every write still gets indexed after it happened.
+package graph
+
+import "sync"
+
+// reindexCoalescer runs at most one reindex call at a time. Requests that
+// arrive while a call is running are merged into a single follow-up call
+// that starts once the running one finishes, so a file written after the
+// running call read it is always reindexed again. The zero value is ready
+// to use.
+type reindexCoalescer struct {
+	mu      sync.Mutex
+	running *reindexCall
+	next    *reindexCall // follow-up collecting requests made during running
+}
+
+// reindexCall is one underlying reindex of the files of every request
+// merged into it.
+type reindexCall struct {
+	files []string
+	seen  map[string]bool
+
+	done chan struct{} // closed once err is set
+	err  error
+}
+
+func newReindexCall() *reindexCall {
+	return &reindexCall{seen: make(map[string]bool), done: make(chan struct{})}
+}
+
+// add merges a request's files into the call.
+func (c *reindexCall) add(files []string) {
+	for _, f := range files {
+		if !c.seen[f] {
+			c.seen[f] = true
+			c.files = append(c.files, f)
+		}
+	}
+}
+
+// do reindexes files, or merges them into the follow-up call when a call is
+// already running, and returns the error of the call that covered them.
+func (r *reindexCoalescer) do(files []string, reindex func(files []string) error) error {
+	r.mu.Lock()
+	if r.running == nil {
+		call := newReindexCall()
+		call.add(files)
+		r.running = call
+		r.mu.Unlock()
+		r.run(call, reindex)
+		return call.err
+	}
+
+	prev := r.running
+	first := r.next == nil
+	if first {
+		r.next = newReindexCall()
+	}
+	call := r.next
+	call.add(files)
+	r.mu.Unlock()
+
+	if !first {
+		<-call.done
+		return call.err
+	}
+	// The request that created the follow-up runs it. prev promotes it to
+	// running when it finishes, so no request can join it after that.
+	<-prev.done
+	r.run(call, reindex)
+	return call.err
+}
+
+// run performs call, then hands the coalescer over to the follow-up call,
+// if any.
+func (r *reindexCoalescer) run(call *reindexCall, reindex func(files []string) error) {
+	call.err = reindex(call.files)
+
+	r.mu.Lock()
+	r.running = r.next
+	r.next = nil
+	r.mu.Unlock()
+	close(call.done)
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForFollowUp polls until the follow-up call holds n files.
+func waitForFollowUp(t *testing.T, r *reindexCoalescer, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		r.mu.Lock()
+		got := 0
+		if r.next != nil {
+			got = len(r.next.files)
+		}
+		r.mu.Unlock()
+		if got == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("follow-up call has %d files, want %d", got, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// blockingReindex records every underlying call; the first one blocks
+// until release is closed and then fails with firstErr.
+type blockingReindex struct {
+	mu       sync.Mutex
+	files    [][]string
+	started  chan struct{}
+	release  chan struct{}
+	firstErr error
+}
+
+func newBlockingReindex(firstErr error) *blockingReindex {
+	return &blockingReindex{started: make(chan struct{}), release: make(chan struct{}), firstErr: firstErr}
+}
+
+func (b *blockingReindex) reindex(files []string) error {
+	b.mu.Lock()
+	b.files = append(b.files, append([]string(nil), files...))
+	first := len(b.files) == 1
+	b.mu.Unlock()
+	if first {
+		close(b.started)
+		<-b.release
+		return b.firstErr
+	}
+	return nil
+}
+
+func TestReindexCoalescerBatchesRequestsIntoOneFollowUp(t *testing.T) {
+	var r reindexCoalescer
+	boom := errors.New("reindex failed")
+	b := newBlockingReindex(boom)
+
+	errs := make([]error, 3)
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		errs[0] = r.do([]string{"a.go", "b.go"}, b.reindex)
+	}()
+	<-b.started
+
+	// Both arrive while a.go and b.go are being read, so they are reindexed
+	// again afterwards, together with c.go, in a single call.
+	go func() {
+		defer wg.Done()
+		errs[1] = r.do([]string{"b.go", "c.go", "b.go"}, b.reindex)
+	}()
+	waitForFollowUp(t, &r, 2)
+	go func() {
+		defer wg.Done()
+		errs[2] = r.do([]string{"a.go", "b.go"}, b.reindex)
+	}()
+	waitForFollowUp(t, &r, 3)
+	close(b.release)
+	wg.Wait()
+
+	if want := [][]string{{"a.go", "b.go"}, {"b.go", "c.go", "a.go"}}; !reflect.DeepEqual(b.files, want) {
+		t.Errorf("underlying calls = %v, want %v", b.files, want)
+	}
+	if !errors.Is(errs[0], boom) {
+		t.Errorf("first request error = %v, want its call's error", errs[0])
+	}
+	for i, err := range errs[1:] {
+		if err != nil {
+			t.Errorf("follow-up request %d error = %v, want the follow-up call's result", i+1, err)
+		}
+	}
+
+	// Nothing is left running, so the next request reindexes right away.
+	if err := r.do([]string{"a.go"}, b.reindex); err != nil {
+		t.Fatalf("later reindex: %v", err)
+	}
+	if len(b.files) != 3 {
+		t.Errorf("underlying calls after the burst = %d, want 3", len(b.files))
+	}
+}
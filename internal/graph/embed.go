@@ -4,6 +4,7 @@ package graph
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -20,6 +21,7 @@ type FileGraphData struct {
 	Importers  []ImporterResult
 	Callers    map[string][]CallerResult    // function name -> callers
 	TypeUsages map[string][]TypeUsageResult // type name -> usages
+	CallPaths  map[string][]CallPathStep    // function name -> call path from an entry point
 }
 
 // QueryGraphForFiles queries the SQLite database directly (not via MCP) to
@@ -206,7 +208,7 @@ func FormatGraphData(data *GraphData) string {
 
 	hasContent := false
 	for _, f := range data.Files {
-		if len(f.Exports) == 0 && len(f.Importers) == 0 && len(f.Callers) == 0 && len(f.TypeUsages) == 0 {
+		if len(f.Exports) == 0 && len(f.Importers) == 0 && len(f.Callers) == 0 && len(f.TypeUsages) == 0 && len(f.CallPaths) == 0 {
 			continue
 		}
 		hasContent = true
@@ -266,6 +268,28 @@ func FormatGraphData(data *GraphData) string {
 			b.WriteString(strings.Join(parts, ", "))
 			b.WriteString("\n")
 		}
+
+		// Call paths from the entry points, sorted for a stable prompt.
+		funcNames := make([]string, 0, len(f.CallPaths))
+		for funcName := range f.CallPaths {
+			funcNames = append(funcNames, funcName)
+		}
+		sort.Strings(funcNames)
+		for _, funcName := range funcNames {
+			b.WriteString("- ")
+			b.WriteString(funcName)
+			b.WriteString("() reached via: ")
+			parts := make([]string, 0, len(f.CallPaths[funcName]))
+			for _, step := range f.CallPaths[funcName] {
+				if step.File == "" {
+					parts = append(parts, step.Name) // an entry point
+					continue
+				}
+				parts = append(parts, fmt.Sprintf("%s (%s:%d)", step.Name, step.File, step.Line))
+			}
+			b.WriteString(strings.Join(parts, " -> "))
+			b.WriteString("\n")
+		}
 	}
 
 	// Impact analysis section.
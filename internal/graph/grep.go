@@ -113,7 +113,7 @@ func GrepFunctions(dir, lang string) ([]Symbol, error) {
 			return nil, err
 		}
 		for _, m := range matches {
-			name := extractName(m.Content, p.pattern)
+			name := symbolName(m.Content, p.pattern, lang)
 			if name == "" {
 				continue
 			}
@@ -169,7 +169,7 @@ func GrepTypes(dir, lang string) ([]Symbol, error) {
 			return nil, err
 		}
 		for _, m := range matches {
-			name := extractName(m.Content, p.pattern)
+			name := symbolName(m.Content, p.pattern, lang)
 			if name == "" {
 				continue
 			}
@@ -214,6 +214,12 @@ func funcPatterns(lang string) []langPattern {
 		return []langPattern{
 			{pattern: `(public|private|protected)\s+\w+\s+(\w+)\s*\(`, globs: []string{"*.java"}},
 		}
+	case "typescript", "javascript":
+		globs := jsGlobs(lang)
+		return []langPattern{
+			{pattern: `^(export\s+)?(default\s+)?(async\s+)?function\b`, globs: globs},
+			{pattern: `^(export\s+)?(const|let|var)\s+\w+\s*(:[^=]+)?=\s*(async\s+)?(\([^)]*\)|\w+)\s*(:[^=]+)?=>`, globs: globs},
+		}
 	default:
 		return nil
 	}
@@ -239,6 +245,14 @@ func importPatterns(lang string) []langPattern {
 		return []langPattern{
 			{pattern: `^import\s+`, globs: []string{"*.java"}},
 		}
+	case "typescript", "javascript":
+		globs := jsGlobs(lang)
+		return []langPattern{
+			{pattern: `^import\s+`, globs: globs},
+			{pattern: `^export\s+.*\sfrom\s+['"]`, globs: globs},
+			{pattern: `^\}\s*from\s+['"]`, globs: globs}, // last line of a multi-line import
+			{pattern: `^(export\s+)?(const|let|var)\s+.*\brequire\(`, globs: globs},
+		}
 	default:
 		return nil
 	}
@@ -265,11 +279,32 @@ func typePatterns(lang string) []langPattern {
 		return []langPattern{
 			{pattern: `(public\s+)?class\s+(\w+)`, globs: []string{"*.java"}, kind: "class"},
 		}
+	case "typescript":
+		globs := jsGlobs(lang)
+		return []langPattern{
+			{pattern: `^(export\s+)?(default\s+)?(abstract\s+)?class\s+\w+`, globs: globs, kind: "class"},
+			{pattern: `^(export\s+)?(declare\s+)?interface\s+\w+`, globs: globs, kind: "type"},
+			{pattern: `^(export\s+)?(declare\s+)?type\s+\w+`, globs: globs, kind: "type"},
+		}
+	case "javascript":
+		return []langPattern{
+			{pattern: `^(export\s+)?(default\s+)?class\s+\w+`, globs: jsGlobs(lang), kind: "class"},
+		}
 	default:
 		return nil
 	}
 }
 
+// jsGlobs returns the file globs searched for lang. TypeScript projects
+// usually keep some JavaScript around, so "typescript" searches both.
+func jsGlobs(lang string) []string {
+	globs := []string{"*.js", "*.jsx", "*.mjs", "*.cjs"}
+	if lang == "typescript" {
+		globs = append([]string{"*.ts", "*.tsx", "*.mts", "*.cts"}, globs...)
+	}
+	return globs
+}
+
 // grepWithPattern runs ripgrep with the given pattern and file globs.
 func grepWithPattern(dir, pattern string, globs []string) ([]Match, error) {
 	rgPath, err := exec.LookPath("rg")
@@ -295,6 +330,14 @@ func grepWithPattern(dir, pattern string, globs []string) ([]Match, error) {
 	return parseRgOutput(output)
 }
 
+// symbolName extracts the name of the symbol a matched line defines.
+func symbolName(content, pattern, lang string) string {
+	if lang == "typescript" || lang == "javascript" {
+		return extractTSName(content)
+	}
+	return extractName(content, pattern)
+}
+
 // extractName extracts the first captured group name from a line of code.
 // This is a simplified extraction that looks for the identifier after the
 // keyword pattern. It uses simple string parsing rather than full regex
@@ -332,6 +375,30 @@ func extractName(content, pattern string) string {
 	return ""
 }
 
+// extractTSName extracts the name declared by a TypeScript or JavaScript
+// line. Modifiers are stripped from the front so that keywords inside the
+// declaration (a parameter named "type", say) are never mistaken for it.
+// Anonymous default exports yield "".
+func extractTSName(content string) string {
+	rest := strings.TrimSpace(content)
+	for {
+		before := rest
+		for _, mod := range []string{"export ", "default ", "declare ", "abstract ", "async "} {
+			rest = strings.TrimLeft(strings.TrimPrefix(rest, mod), " ")
+		}
+		if rest == before {
+			break
+		}
+	}
+
+	for _, kw := range []string{"function", "class ", "interface ", "type ", "const ", "let ", "var "} {
+		if strings.HasPrefix(rest, kw) {
+			return extractIdentifier(strings.TrimLeft(rest[len(kw):], "* "))
+		}
+	}
+	return ""
+}
+
 // extractIdentifier extracts a valid identifier (word characters) from the
 // start of the string.
 func extractIdentifier(s string) string {
@@ -359,6 +426,8 @@ func parseImportLine(content, lang string) Import {
 		return parseRustImport(content)
 	case "java":
 		return parseJavaImport(content)
+	case "typescript", "javascript":
+		return parseTSImport(content)
 	default:
 		return Import{}
 	}
@@ -428,6 +497,53 @@ func parseJavaImport(line string) Import {
 	return Import{TargetPath: rest}
 }
 
+// parseTSImport extracts the module and the local names bound by a
+// TypeScript or JavaScript import, re-export or require() line.
+func parseTSImport(line string) Import {
+	var spec, clause string
+	switch {
+	case strings.Contains(line, "require("):
+		idx := strings.Index(line, "require(")
+		spec = line[idx:]
+		// "const { a, b } = require(...)" binds the destructured names.
+		clause = strings.TrimSuffix(strings.TrimSpace(line[:idx]), "=")
+		for _, kw := range []string{"export ", "const ", "let ", "var "} {
+			clause = strings.TrimPrefix(strings.TrimSpace(clause), kw)
+		}
+	case strings.Contains(line, "from "):
+		idx := strings.LastIndex(line, "from ")
+		spec = line[idx:]
+		if strings.HasPrefix(line, "import ") {
+			clause = strings.TrimPrefix(line[:idx], "import ")
+			clause = strings.TrimPrefix(strings.TrimSpace(clause), "type ")
+		}
+	case strings.HasPrefix(line, "import "):
+		// Side-effect import: import './polyfills'
+		spec = line
+	}
+
+	i := strings.IndexAny(spec, "'\"`")
+	if i < 0 {
+		return Import{}
+	}
+	target := extractQuoted(spec[i:], spec[i])
+	if target == "" {
+		return Import{}
+	}
+
+	// Default, named ({ a, b as c }) and namespace (* as ns) bindings all
+	// end with the local name.
+	var names []string
+	for _, part := range splitAndTrim(strings.NewReplacer("{", ",", "}", ",").Replace(clause), ",") {
+		fields := strings.Fields(part)
+		name := fields[len(fields)-1]
+		if name != "" && extractIdentifier(name) == name {
+			names = append(names, name)
+		}
+	}
+	return Import{TargetPath: target, Names: names}
+}
+
 // extractQuoted extracts the content between the first pair of the given
 // quote character.
 func extractQuoted(s string, quote byte) string {
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const sampleTS = `import { Router, type Request as Req } from 'express';
+import * as path from "node:path";
+import Default, { helper } from './helper';
+import './polyfills';
+import {
+  a,
+  b,
+} from '../shared/ab';
+export { formatDate } from './dates';
+const fs = require('fs');
+const { readFile: rf, writeFile } = require("fs/promises");
+
+export interface User {
+  id: string;
+}
+
+export type UserID = string;
+
+export default abstract class BaseService {}
+
+export async function loadUser(id: UserID): Promise<User> {
+  return { id };
+}
+
+function* ids() {}
+
+export const handler = async (req: Req, type: string): Promise<void> => {};
+const double = x => x * 2;
+const notAFunction = (1 + 2);
+
+export default function () {}
+`
+
+// grepSample matches each line of sampleTS against patterns as ripgrep
+// would and returns the matching lines.
+func grepSample(t *testing.T, patterns []langPattern) []string {
+	t.Helper()
+	var lines []string
+	for _, p := range patterns {
+		re := regexp.MustCompile(p.pattern)
+		for _, line := range strings.Split(sampleTS, "\n") {
+			if re.MatchString(line) {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+func TestTypeScriptFunctionsAndTypes(t *testing.T) {
+	var funcs []string
+	for _, line := range grepSample(t, funcPatterns("typescript")) {
+		if name := symbolName(line, "", "typescript"); name != "" {
+			funcs = append(funcs, name)
+		}
+	}
+	slices.Sort(funcs)
+	if want := []string{"double", "handler", "ids", "loadUser"}; !slices.Equal(funcs, want) {
+		t.Errorf("functions = %v, want %v", funcs, want)
+	}
+
+	var types []string
+	for _, line := range grepSample(t, typePatterns("typescript")) {
+		types = append(types, symbolName(line, "", "typescript"))
+	}
+	slices.Sort(types)
+	if want := []string{"BaseService", "User", "UserID"}; !slices.Equal(types, want) {
+		t.Errorf("types = %v, want %v", types, want)
+	}
+}
+
+func TestTypeScriptImports(t *testing.T) {
+	got := make(map[string][]string)
+	for _, line := range grepSample(t, importPatterns("typescript")) {
+		imp := parseImportLine(line, "typescript")
+		if imp.TargetPath == "" {
+			continue
+		}
+		got[imp.TargetPath] = imp.Names
+	}
+
+	want := map[string][]string{
+		"express":      {"Router", "Req"},
+		"node:path":    {"path"},
+		"./helper":     {"Default", "helper"},
+		"./polyfills":  nil,
+		"../shared/ab": nil,
+		"./dates":      nil,
+		"fs":           {"fs"},
+		"fs/promises":  {"rf", "writeFile"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("imports = %v, want %v", got, want)
+	}
+	for target, names := range want {
+		gotNames, ok := got[target]
+		if !ok {
+			t.Errorf("missing import of %q", target)
+			continue
+		}
+		if !slices.Equal(gotNames, names) {
+			t.Errorf("import %q names = %v, want %v", target, gotNames, names)
+		}
+	}
+}
+
+func TestGrepFunctionsTypeScript(t *testing.T) {
+	if _, err := exec.LookPath("rg"); err != nil {
+		t.Skip("ripgrep not installed")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "user.ts"), []byte(sampleTS), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	funcs, err := GrepFunctions(dir, "typescript")
+	if err != nil {
+		t.Fatalf("GrepFunctions: %v", err)
+	}
+	var names []string
+	for _, f := range funcs {
+		names = append(names, f.Name)
+	}
+	if !slices.Contains(names, "loadUser") || !slices.Contains(names, "handler") {
+		t.Errorf("GrepFunctions found %v, want loadUser and handler", names)
+	}
+}
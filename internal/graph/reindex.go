@@ -78,6 +78,26 @@ func ReindexChanged(client *Client, changedFiles []string) error {
 	return client.ReindexFiles(changedFiles)
 }
 
+// RemoveFromIndex drops files a bead deleted or renamed away from the KG so
+// later impact analysis does not reference files that no longer exist.
+func RemoveFromIndex(client *Client, removedFiles []string) error {
+	if len(removedFiles) == 0 {
+		return nil
+	}
+	return client.RemoveFiles(removedFiles)
+}
+
+// SourceFiles returns the entries of files that the KG indexes.
+func SourceFiles(files []string) []string {
+	var result []string
+	for _, f := range files {
+		if isSourceFile(f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
 // fullReindex reindexes all source files in the directory. It clears existing
 // index data and updates the last_index_time.
 func fullReindex(db *sql.DB, srcDir string) error {
@@ -0,0 +1,116 @@
+// snapshot.go pins analysis to a historical commit by extracting the
+// commit's files for the grep fallback.
+package graph
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Snapshot is the result of IndexAtCommit: Dir holds a read-only copy of
+// the files at Commit for grep-based analysis.
+type Snapshot struct {
+	Commit string // resolved commit SHA
+	Dir    string // extracted files
+}
+
+// Close removes the extracted files, if any.
+func (s *Snapshot) Close() error {
+	if s == nil || s.Dir == "" {
+		return nil
+	}
+	return os.RemoveAll(s.Dir)
+}
+
+// IndexAtCommit makes analysis reflect the project at sha rather than the
+// live tree by extracting the commit's files to a temp directory for the
+// grep fallback. The KG server is never asked to index sha: it serves the
+// live tree, and repointing it would leave every later query on the old
+// commit. Callers must Close the returned snapshot.
+func IndexAtCommit(sha string) (*Snapshot, error) {
+	out, err := exec.Command("git", "rev-parse", "--verify", sha+"^{commit}").Output()
+	if err != nil {
+		return nil, fmt.Errorf("graph: resolving commit %q: %w", sha, err)
+	}
+	commit := strings.TrimSpace(string(out))
+
+	dir, err := os.MkdirTemp("", "berth-snapshot-")
+	if err != nil {
+		return nil, fmt.Errorf("graph: creating snapshot directory: %w", err)
+	}
+	if err := extractCommit(commit, dir); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Snapshot{Commit: commit, Dir: dir}, nil
+}
+
+// extractCommit writes the tree of commit into dir using git archive.
+func extractCommit(commit, dir string) error {
+	cmd := exec.Command("git", "archive", "--format=tar", commit)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("graph: git archive: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("graph: git archive: %w", err)
+	}
+
+	extractErr := untar(stdout, dir)
+	waitErr := cmd.Wait()
+	if extractErr != nil {
+		return extractErr
+	}
+	if waitErr != nil {
+		return fmt.Errorf("graph: git archive %s: %w", commit, waitErr)
+	}
+	return nil
+}
+
+// untar extracts regular files and directories from r into dir. Symlinks
+// and other entry types are skipped; they are irrelevant to grep analysis.
+func untar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("graph: reading archive: %w", err)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			continue // never write outside dir
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("graph: extracting %s: %w", hdr.Name, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return fmt.Errorf("graph: extracting %s: %w", hdr.Name, err)
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return fmt.Errorf("graph: extracting %s: %w", hdr.Name, err)
+			}
+			_, copyErr := io.Copy(f, tr)
+			closeErr := f.Close()
+			if copyErr != nil {
+				return fmt.Errorf("graph: extracting %s: %w", hdr.Name, copyErr)
+			}
+			if closeErr != nil {
+				return fmt.Errorf("graph: extracting %s: %w", hdr.Name, closeErr)
+			}
+		}
+	}
+}
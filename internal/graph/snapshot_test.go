@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIndexAtCommitExtractsHistoricalFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	git := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test")
+	write("old.go", "package main\n\nfunc OldHelper() {}\n")
+	write("main.go", "package main\n\nfunc main() {}\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	first := git("rev-parse", "HEAD")
+
+	git("rm", "-q", "old.go")
+	write("main.go", "package main\n\nfunc main() { NewHelper() }\n\nfunc NewHelper() {}\n")
+	git("commit", "-q", "-am", "second")
+
+	snap, err := IndexAtCommit(first[:7])
+	if err != nil {
+		t.Fatalf("IndexAtCommit: %v", err)
+	}
+	if snap.Commit != first {
+		t.Errorf("Commit = %q, want %q", snap.Commit, first)
+	}
+
+	data, err := os.ReadFile(filepath.Join(snap.Dir, "old.go"))
+	if err != nil {
+		t.Fatalf("deleted file missing from snapshot: %v", err)
+	}
+	if !strings.Contains(string(data), "OldHelper") {
+		t.Errorf("old.go = %q, want the first commit's content", data)
+	}
+	data, err = os.ReadFile(filepath.Join(snap.Dir, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "NewHelper") {
+		t.Errorf("main.go reflects the live tree, not %s", first)
+	}
+
+	if _, err := exec.LookPath("rg"); err == nil {
+		funcs, err := GrepFunctions(snap.Dir, "go")
+		if err != nil {
+			t.Fatalf("GrepFunctions: %v", err)
+		}
+		found := map[string]bool{}
+		for _, f := range funcs {
+			found[f.Name] = true
+		}
+		if !found["OldHelper"] || found["NewHelper"] {
+			t.Errorf("grep over snapshot found %v, want OldHelper and not NewHelper", found)
+		}
+	}
+
+	snapDir := snap.Dir
+	if err := snap.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(snapDir); !os.IsNotExist(err) {
+		t.Errorf("snapshot dir %s still exists after Close", snapDir)
+	}
+}
+
+func TestIndexAtCommitUnknownRef(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := exec.Command("git", "init", "-q").Run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := IndexAtCommit("does-not-exist"); err == nil {
+		t.Fatal("expected an error for an unknown ref")
+	}
+}
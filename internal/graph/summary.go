@@ -0,0 +1,120 @@
+// Package graph provides Knowledge Graph integration.
+// This file summarizes the indexed codebase for the interview.
+package graph
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+const (
+	// summaryMaxFiles caps the files whose importers are counted, bounding
+	// the KG queries made before the interview starts.
+	summaryMaxFiles = 200
+	// summaryHubs is how many of the most imported files are listed.
+	summaryHubs = 5
+	// summaryExports is how many exports are named per listed file.
+	summaryExports = 6
+)
+
+// Summary describes the codebase for the interview: how many of files the
+// KG indexes and which of them the most other files import, with their
+// exports.
+func Summary(client *Client, files []string) (string, error) {
+	files = SourceFiles(files)
+	if len(files) == 0 {
+		return "", nil
+	}
+	sort.Strings(files)
+
+	type hub struct {
+		file      string
+		importers int
+	}
+	var hubs []hub
+	for i, f := range files {
+		if i == summaryMaxFiles {
+			break
+		}
+		importers, err := client.QueryImporters(f)
+		if err != nil {
+			return "", fmt.Errorf("graph: importers of %s: %w", f, err)
+		}
+		if len(importers) > 0 {
+			hubs = append(hubs, hub{file: f, importers: len(importers)})
+		}
+	}
+	sort.SliceStable(hubs, func(i, j int) bool { return hubs[i].importers > hubs[j].importers })
+	if len(hubs) > summaryHubs {
+		hubs = hubs[:summaryHubs]
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d source %s indexed.\n", len(files), plural(len(files), "file", "files"))
+	if len(hubs) == 0 {
+		return sb.String(), nil
+	}
+	sb.WriteString("Most imported files:\n")
+	for _, h := range hubs {
+		fmt.Fprintf(&sb, "- %s (%d %s)", h.file, h.importers, plural(h.importers, "importer", "importers"))
+		exports, err := client.QueryExports(h.file)
+		if err != nil {
+			return "", fmt.Errorf("graph: exports of %s: %w", h.file, err)
+		}
+		var names []string
+		for i, exp := range exports {
+			if i == summaryExports {
+				names = append(names, "...")
+				break
+			}
+			names = append(names, exp.Name)
+		}
+		if len(names) > 0 {
+			sb.WriteString(": " + strings.Join(names, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// ProjectSummary returns the Summary of the files git tracks in
+// projectRoot, starting the KG server if needed. It returns "" when the KG
+// is disabled or unavailable, since the interview works without it.
+func ProjectSummary(projectRoot string, cfg config.KGConfig) string {
+	if cfg.Enabled == "never" {
+		return ""
+	}
+	cmd := exec.Command("git", "ls-files")
+	cmd.Dir = projectRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	files := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(SourceFiles(files)) == 0 {
+		return ""
+	}
+
+	client, err := EnsureMCPAlive(projectRoot, cfg, nil)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = client.Close() }()
+
+	summary, err := Summary(client, files)
+	if err != nil {
+		return ""
+	}
+	return summary
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,29 @@
+package graph
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummaryListsMostImportedFiles(t *testing.T) {
+	cmd := fakeMCPCommand(t)
+	cmd.Env = append(cmd.Env, "BERTH_FAKE_MCP_DEAD_CODE=1")
+	client, err := NewClient(cmd, 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	got, err := Summary(client, []string{"dead.go", "README.md"})
+	if err != nil {
+		t.Fatalf("Summary: %v", err)
+	}
+	want := "1 source file indexed.\nMost imported files:\n- dead.go (1 importer): Handler, Version, Config, Unused\n"
+	if got != want {
+		t.Errorf("Summary =\n%s\nwant\n%s", got, want)
+	}
+
+	if got, err := Summary(client, []string{"README.md"}); err != nil || got != "" {
+		t.Errorf("Summary without source files = %q, %v; want empty", got, err)
+	}
+}
@@ -0,0 +1,123 @@
+// Package graph manages the Knowledge Graph MCP server integration.
+// This file checks the server's tool list against the tools berth calls.
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// requiredTools are the MCP tools the Client methods call.
+var requiredTools = []string{
+	"get_callers",
+	"get_callees",
+	"get_dependents",
+	"get_exports",
+	"get_importers",
+	"get_type_usages",
+	"understand_file",
+	"analyze_impact",
+	"reindex_files",
+	"remove_files",
+	"reindex",
+}
+
+// ErrToolUnsupported is returned, without contacting the server, when a
+// query needs a tool the server did not list in tools/list, and when the
+// server answers a tool call with method-not-found. Callers treat it like
+// any other KG failure and fall back to grep or skip the data.
+var ErrToolUnsupported = errors.New("graph: tool not supported by the KG server")
+
+// toolsListResult is the server's response to tools/list.
+type toolsListResult struct {
+	Tools []struct {
+		Name string `json:"name"`
+	} `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// listToolsLocked fetches every page of tools/list. A server that does not
+// implement tools/list yields nil (tool support unknown). Caller must hold
+// the lock.
+func (c *Client) listToolsLocked() (map[string]bool, error) {
+	tools := make(map[string]bool)
+	cursor := ""
+	for {
+		var params any
+		if cursor != "" {
+			params = map[string]string{"cursor": cursor}
+		}
+		raw, err := c.callLocked("tools/list", params)
+		if err != nil {
+			var rpcErr *mcpError
+			if errors.As(err, &rpcErr) && rpcErr.Code == -32601 {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("graph: MCP tools/list: %w", err)
+		}
+		var page toolsListResult
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return nil, fmt.Errorf("graph: unmarshalling tools/list result: %w", err)
+		}
+		for _, t := range page.Tools {
+			tools[t.Name] = true
+		}
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return tools, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// SupportedTools returns the tools the server listed during Initialize, or
+// nil if the server did not report its tools.
+func (c *Client) SupportedTools() map[string]bool {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.tools == nil {
+		return nil
+	}
+	out := make(map[string]bool, len(c.tools))
+	for name := range c.tools {
+		out[name] = true
+	}
+	return out
+}
+
+// MissingTools returns the required tools the server did not list, sorted.
+// It is empty when the server did not report its tools.
+func (c *Client) MissingTools() []string {
+	tools := c.SupportedTools()
+	if tools == nil {
+		return nil
+	}
+	var missing []string
+	for _, name := range requiredTools {
+		if !tools[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// supportsToolLocked reports whether a tool may be called. Unknown tool
+// support allows everything. Caller must hold the lock.
+func (c *Client) supportsToolLocked(name string) bool {
+	return c.tools == nil || c.tools[name]
+}
+
+// warnMissingTools writes a startup warning naming missing tools.
+func warnMissingTools(w io.Writer, missing []string) {
+	if len(missing) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Warning: KG server is missing tools: %s; those queries will fall back to grep or be skipped\n", strings.Join(missing, ", "))
+}
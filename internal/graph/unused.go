@@ -0,0 +1,70 @@
+// Package graph provides Knowledge Graph integration.
+// This file finds exported symbols nothing else uses, for cleanup tasks.
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+// QueryUnusedExports returns the exported symbols of file that have no
+// importers and no callers or type usages. Servers without
+// get_unused_exports are answered from get_exports, get_importers,
+// get_callers and get_type_usages instead.
+func (c *Client) QueryUnusedExports(file string) ([]ExportResult, error) {
+	var results []ExportResult
+	err := c.callToolRead("get_unused_exports", map[string]any{"file_path": file}, &results)
+	if errors.Is(err, ErrToolUnsupported) {
+		return c.unusedExportsFallback(file)
+	}
+	return results, err
+}
+
+// unusedExportsFallback derives the unused exports of file on the client.
+// An export is used when an importer names it, or when it has callers (for
+// functions) or type usages (for types, classes, interfaces and enums).
+// Callers and usages are matched by name only, so a same-named symbol
+// elsewhere keeps an export from being reported.
+func (c *Client) unusedExportsFallback(file string) ([]ExportResult, error) {
+	exports, err := c.QueryExports(file)
+	if err != nil {
+		return nil, err
+	}
+	importers, err := c.QueryImporters(file)
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]bool)
+	for _, imp := range importers {
+		for _, name := range imp.ImportedNames {
+			imported[name] = true
+		}
+	}
+
+	var unused []ExportResult
+	for _, exp := range exports {
+		if imported[exp.Name] {
+			continue
+		}
+		switch exp.Kind {
+		case "function":
+			callers, err := c.QueryCallers(exp.Name)
+			if err != nil {
+				return nil, fmt.Errorf("graph: callers of %s: %w", exp.Name, err)
+			}
+			if len(callers) > 0 {
+				continue
+			}
+		case "type", "class", "interface", "enum":
+			usages, err := c.QueryTypeUsages(exp.Name)
+			if err != nil {
+				return nil, fmt.Errorf("graph: usages of %s: %w", exp.Name, err)
+			}
+			if len(usages) > 0 {
+				continue
+			}
+		}
+		unused = append(unused, exp)
+	}
+	return unused, nil
+}
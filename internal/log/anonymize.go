@@ -0,0 +1,177 @@
+// Package log provides structured event logging.
+// This file pseudonymizes file paths and redacts titles in log events so run
+// artifacts can be shared without exposing the project's structure.
+package log
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Privacy selects what an Anonymizer hides.
+type Privacy struct {
+	AnonymizePaths bool // replace file paths with stable pseudonyms
+	RedactTitles   bool // drop bead titles, descriptions, and requirements
+}
+
+// Enabled reports whether any redaction is requested.
+func (p Privacy) Enabled() bool {
+	return p.AnonymizePaths || p.RedactTitles
+}
+
+// anonymizeKeyFile holds the per-project salt for path pseudonyms, so every
+// Logger in a run (and later runs) maps a path to the same pseudonym without
+// the mapping being guessable by whoever receives the artifacts.
+const anonymizeKeyFile = "anonymize.key"
+
+// dataPathKeys are LogEvent.Data keys whose string values are file paths.
+var dataPathKeys = []string{"path", "file", "dir"}
+
+// Anonymizer rewrites log events according to a Privacy setting.
+type Anonymizer struct {
+	privacy Privacy
+	root    string
+	salt    string
+
+	mu   sync.Mutex
+	seen map[string]string // original path -> pseudonym, for scrubbing free text
+}
+
+// NewAnonymizer returns an Anonymizer for the project at root, creating
+// .berth/anonymize.key on first use.
+func NewAnonymizer(root string, p Privacy) (*Anonymizer, error) {
+	a := &Anonymizer{privacy: p, root: root, seen: make(map[string]string)}
+	if !p.AnonymizePaths {
+		return a, nil
+	}
+
+	keyPath := filepath.Join(root, ".berth", anonymizeKeyFile)
+	data, err := os.ReadFile(keyPath)
+	if os.IsNotExist(err) {
+		key := make([]byte, 16)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generating anonymize key: %w", err)
+		}
+		data = []byte(hex.EncodeToString(key))
+		if err := os.MkdirAll(filepath.Dir(keyPath), 0755); err != nil {
+			return nil, fmt.Errorf("create .berth directory: %w", err)
+		}
+		if err := os.WriteFile(keyPath, data, 0600); err != nil {
+			return nil, fmt.Errorf("writing anonymize key: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("reading anonymize key: %w", err)
+	}
+	a.salt = strings.TrimSpace(string(data))
+	return a, nil
+}
+
+// Path returns the pseudonym for a file path, e.g. "path-1a2b3c4d5e.go".
+// Paths inside the project are made relative first so absolute and relative
+// spellings of the same file agree. The extension is kept.
+func (a *Anonymizer) Path(p string) string {
+	if a == nil || !a.privacy.AnonymizePaths || p == "" {
+		return p
+	}
+	rel := p
+	if filepath.IsAbs(p) {
+		if r, err := filepath.Rel(a.root, p); err == nil && !strings.HasPrefix(r, "..") {
+			rel = r
+		}
+	}
+	rel = filepath.ToSlash(filepath.Clean(rel))
+
+	sum := sha256.Sum256([]byte(a.salt + "\x00" + rel))
+	pseudo := "path-" + hex.EncodeToString(sum[:])[:10] + filepath.Ext(rel)
+
+	a.mu.Lock()
+	a.seen[p] = pseudo
+	a.seen[rel] = pseudo
+	a.mu.Unlock()
+	return pseudo
+}
+
+// Text scrubs free text: paths already pseudonymized are replaced with their
+// pseudonyms and the project root is replaced with ".".
+func (a *Anonymizer) Text(s string) string {
+	if a == nil || !a.privacy.AnonymizePaths || s == "" {
+		return s
+	}
+	a.mu.Lock()
+	known := make([]string, 0, len(a.seen))
+	for p := range a.seen {
+		known = append(known, p)
+	}
+	// Longest first so "src/a/b.go" wins over "b.go".
+	sort.Slice(known, func(i, j int) bool { return len(known[i]) > len(known[j]) })
+	pairs := make([]string, 0, 2*len(known)+2)
+	for _, p := range known {
+		pairs = append(pairs, p, a.seen[p])
+	}
+	a.mu.Unlock()
+
+	if a.root != "" {
+		pairs = append(pairs, a.root, ".")
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+// Event returns a copy of e with paths pseudonymized and, if requested,
+// titles redacted.
+func (a *Anonymizer) Event(e LogEvent) LogEvent {
+	if a == nil {
+		return e
+	}
+	if a.privacy.AnonymizePaths {
+		e.WorktreePath = a.Path(e.WorktreePath)
+		if len(e.ConflictFiles) > 0 {
+			files := make([]string, len(e.ConflictFiles))
+			for i, f := range e.ConflictFiles {
+				files[i] = a.Path(f)
+			}
+			e.ConflictFiles = files
+		}
+		if len(e.Data) > 0 {
+			data := make(map[string]interface{}, len(e.Data))
+			for k, v := range e.Data {
+				if s, ok := v.(string); ok {
+					if isPathKey(k) {
+						v = a.Path(s)
+					} else {
+						v = a.Text(s)
+					}
+				}
+				data[k] = v
+			}
+			e.Data = data
+		}
+		e.Error = a.Text(e.Error)
+		e.Reason = a.Text(e.Reason)
+		e.Requirements = a.Text(e.Requirements)
+	}
+	if a.privacy.RedactTitles {
+		e.Title = ""
+		e.Description = ""
+		e.Requirements = ""
+	}
+	return e
+}
+
+// isPathKey reports whether a Data key names a file path, e.g. "file" or
+// "worktree_path".
+func isPathKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range dataPathKeys {
+		if key == k || strings.HasSuffix(key, "_"+k) {
+			return true
+		}
+	}
+	return false
+}
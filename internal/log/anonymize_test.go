@@ -0,0 +1,81 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnonymizePathsConsistentAcrossEvents(t *testing.T) {
+	root := t.TempDir()
+	privacy := Privacy{AnonymizePaths: true}
+
+	logger, err := NewLoggerWithPrivacy(root, privacy)
+	if err != nil {
+		t.Fatalf("NewLoggerWithPrivacy: %v", err)
+	}
+	worktree := filepath.Join(root, ".berth", "worktrees", "bt-1")
+	events := []LogEvent{
+		{Event: EventMergeFailed, ConflictFiles: []string{"internal/auth/login.go", "README.md"}},
+		{Event: EventWorkerStarted, WorktreePath: worktree, Data: map[string]interface{}{"file": "internal/auth/login.go"}},
+		{Event: EventVerifyFailed, Error: "internal/auth/login.go:12: undefined: Session\nin " + root},
+	}
+	for _, e := range events {
+		if err := logger.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A second logger in the same project, as another phase of the run
+	// would create, must agree on pseudonyms.
+	second, err := NewLoggerWithPrivacy(root, privacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Append(LogEvent{Event: EventMergeFailed, ConflictFiles: []string{filepath.Join(root, "internal/auth/login.go")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := logger.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pseudo := got[0].ConflictFiles[0]
+	if !strings.HasPrefix(pseudo, "path-") || !strings.HasSuffix(pseudo, ".go") {
+		t.Fatalf("pseudonym = %q, want path-<hash>.go", pseudo)
+	}
+	if got[0].ConflictFiles[1] == pseudo {
+		t.Error("different files share a pseudonym")
+	}
+	if got[1].Data["file"] != pseudo {
+		t.Errorf("Data[file] = %v, want %s", got[1].Data["file"], pseudo)
+	}
+	if got[3].ConflictFiles[0] != pseudo {
+		t.Errorf("absolute path from second logger = %q, want %s", got[3].ConflictFiles[0], pseudo)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(root, ".berth", "log.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leak := range []string{"login.go", "auth", root} {
+		if strings.Contains(string(raw), leak) {
+			t.Errorf("log still contains %q:\n%s", leak, raw)
+		}
+	}
+	if !strings.Contains(got[2].Error, pseudo+":12: undefined: Session") {
+		t.Errorf("Error = %q, want the path scrubbed to %s", got[2].Error, pseudo)
+	}
+}
+
+func TestRedactTitles(t *testing.T) {
+	a, err := NewAnonymizer(t.TempDir(), Privacy{RedactTitles: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := a.Event(LogEvent{Event: EventTaskCompleted, BeadID: "bt-1", Title: "Add SSO for Acme", Description: "secret"})
+	if e.Title != "" || e.Description != "" || e.BeadID != "bt-1" {
+		t.Errorf("redacted event = %+v, want title and description dropped, ID kept", e)
+	}
+}
@@ -4,9 +4,11 @@ package log
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -24,6 +26,7 @@ const (
 	EventVerifyFailed            = "verify_failed"
 	EventTaskRetry               = "task_retry"
 	EventTaskCompleted           = "task_completed"
+	EventTaskStuck               = "task_stuck"
 	EventRunComplete             = "run_complete"
 	EventWorkerStarted           = "worker_started"
 	EventWorkerCompleted         = "worker_completed"
@@ -33,6 +36,12 @@ const (
 	EventReconcileStarted        = "reconcile_started"
 	EventReconcileCompleted      = "reconcile_completed"
 	EventReconcileFailed         = "reconcile_failed"
+	EventPostRunHook             = "post_run_hook"
+	EventCoordinatorStarted      = "coordinator_started"
+	EventCircuitBreakerCooldown  = "circuit_breaker_cooldown"
+	EventClaudeUsage             = "claude_usage"
+	EventLockWait                = "lock_wait"
+	EventRunManifest             = "run_manifest"
 )
 
 // LogEvent represents a single structured event written to the log.
@@ -51,10 +60,12 @@ type LogEvent struct {
 	Attempt       int                    `json:"attempt,omitempty"`
 	Completed     int                    `json:"completed,omitempty"`
 	Stuck         int                    `json:"stuck,omitempty"`
+	Skipped       int                    `json:"skipped,omitempty"`
 	Total         int                    `json:"total,omitempty"`
 	Requirements  string                 `json:"requirements,omitempty"`
 	DurationMs    int64                  `json:"duration_ms,omitempty"`
 	CostUSD       float64                `json:"cost_usd,omitempty"`
+	Tokens        int                    `json:"tokens,omitempty"`
 	Data          map[string]interface{} `json:"data,omitempty"`
 	WorkerID      string                 `json:"worker_id,omitempty"`
 	WorktreePath  string                 `json:"worktree_path,omitempty"`
@@ -62,12 +73,14 @@ type LogEvent struct {
 	MergeTo       string                 `json:"merge_to,omitempty"`
 	ConflictFiles []string               `json:"conflict_files,omitempty"`
 	Choice        string                 `json:"choice,omitempty"`
+	Meta          map[string]string      `json:"meta,omitempty"`
 }
 
 // Logger writes append-only JSONL events to a log file.
 type Logger struct {
 	path string
 	mu   sync.Mutex
+	anon *Anonymizer // applied to every event when set
 }
 
 // NewLogger creates a Logger that writes to .berth/log.jsonl inside dir.
@@ -84,6 +97,19 @@ func NewLogger(dir string) (*Logger, error) {
 	}, nil
 }
 
+// NewLoggerWithPrivacy is NewLogger with events rewritten by an Anonymizer
+// when p enables any redaction.
+func NewLoggerWithPrivacy(dir string, p Privacy) (*Logger, error) {
+	l, err := NewLogger(dir)
+	if err != nil || !p.Enabled() {
+		return l, err
+	}
+	if l.anon, err = NewAnonymizer(dir, p); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
 // Append writes a single LogEvent as one JSON line to the log file.
 // If event.Time is the zero value, it is automatically set to time.Now().UTC().
 // The file is opened in append mode, written to, and then closed.
@@ -92,6 +118,9 @@ func (l *Logger) Append(event LogEvent) (retErr error) {
 	if event.Time.IsZero() {
 		event.Time = time.Now().UTC()
 	}
+	if l.anon != nil {
+		event = l.anon.Event(event)
+	}
 
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -121,8 +150,48 @@ func (l *Logger) Append(event LogEvent) (retErr error) {
 
 // ReadAll reads and parses all events from the log file.
 // Returns an empty slice (not an error) if the file does not exist.
-func (l *Logger) ReadAll() (events []LogEvent, retErr error) {
-	f, err := os.Open(l.path)
+func (l *Logger) ReadAll() ([]LogEvent, error) {
+	return readEvents(l.path, EventFilter{})
+}
+
+// EventFilter selects log events. Zero fields match everything.
+type EventFilter struct {
+	Events []string  // event types to keep, e.g. EventTaskCompleted
+	Since  time.Time // keep events at or after this time
+	Until  time.Time // keep events before this time
+}
+
+// Match reports whether e passes the filter.
+func (f EventFilter) Match(e LogEvent) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.Time.Before(f.Until) {
+		return false
+	}
+	if len(f.Events) == 0 {
+		return true
+	}
+	for _, name := range f.Events {
+		if e.Event == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadEvents reads the events matching filter from .berth/log.jsonl inside
+// dir without creating anything, for read-only commands. Like ReadAll, a
+// missing log yields an empty slice.
+func ReadEvents(dir string, filter EventFilter) ([]LogEvent, error) {
+	return readEvents(filepath.Join(dir, ".berth", "log.jsonl"), filter)
+}
+
+// readEvents streams the log at path line by line, keeping the events that
+// match filter. A corrupt last line, as left by a write that was cut short,
+// is ignored; a corrupt line followed by others is an error.
+func readEvents(path string, filter EventFilter) (events []LogEvent, retErr error) {
+	f, err := os.Open(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return []LogEvent{}, nil
@@ -136,22 +205,30 @@ func (l *Logger) ReadAll() (events []LogEvent, retErr error) {
 	}()
 
 	events = []LogEvent{}
-	scanner := bufio.NewScanner(f)
+	reader := bufio.NewReader(f)
 	lineNum := 0
-	for scanner.Scan() {
+	var corrupt error // parse error of the latest line, if it was corrupt
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return nil, fmt.Errorf("read log file: %w", readErr)
+		}
 		lineNum++
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			if corrupt != nil {
+				return nil, corrupt
+			}
+			var event LogEvent
+			if err := json.Unmarshal(line, &event); err != nil {
+				corrupt = fmt.Errorf("parse log line %d: %w", lineNum, err)
+			} else if filter.Match(event) {
+				events = append(events, event)
+			}
 		}
-		var event LogEvent
-		if err := json.Unmarshal(line, &event); err != nil {
-			return nil, fmt.Errorf("parse log line %d: %w", lineNum, err)
+		if readErr != nil {
+			break
 		}
-		events = append(events, event)
-	}
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("read log file: %w", err)
 	}
 
 	return events, nil
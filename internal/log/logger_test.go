@@ -0,0 +1,95 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadEvents(t *testing.T) {
+	dir := t.TempDir()
+
+	events, err := ReadEvents(dir, EventFilter{})
+	if err != nil || len(events) != 0 {
+		t.Fatalf("ReadEvents on a missing log = %v, %v; want no events", events, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".berth")); !os.IsNotExist(err) {
+		t.Errorf("ReadEvents created .berth/ (stat err %v)", err)
+	}
+
+	l, err := NewLogger(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []LogEvent{{Event: EventRunStarted}, {Event: EventTaskStarted, BeadID: "bt-1"}} {
+		if err := l.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err = ReadEvents(dir, EventFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Event != EventTaskStarted || events[1].BeadID != "bt-1" {
+		t.Errorf("ReadEvents = %+v, want the two appended events in order", events)
+	}
+}
+
+func TestReadEventsFilter(t *testing.T) {
+	dir := t.TempDir()
+	l, err := NewLogger(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i, e := range []LogEvent{
+		{Event: EventRunStarted},
+		{Event: EventTaskCompleted, BeadID: "bt-1"},
+		{Event: EventTaskStuck, BeadID: "bt-2"},
+		{Event: EventTaskCompleted, BeadID: "bt-3"},
+	} {
+		e.Time = base.Add(time.Duration(i) * time.Minute)
+		if err := l.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := ReadEvents(dir, EventFilter{
+		Events: []string{EventTaskCompleted, EventTaskStuck},
+		Since:  base.Add(time.Minute),
+		Until:  base.Add(3 * time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].BeadID != "bt-1" || events[1].BeadID != "bt-2" {
+		t.Errorf("ReadEvents = %+v, want bt-1 and bt-2", events)
+	}
+}
+
+func TestReadEventsCorruptLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".berth", "log.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A write cut short leaves a partial last line, which is skipped.
+	if err := os.WriteFile(path, []byte(`{"event":"run_started"}`+"\n"+`{"event":"task_sta`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	events, err := ReadEvents(dir, EventFilter{})
+	if err != nil || len(events) != 1 {
+		t.Errorf("ReadEvents with a partial last line = %v, %v; want the one complete event", events, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"event":"run_sta`+"\n"+`{"event":"run_complete"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadEvents(dir, EventFilter{}); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("ReadEvents with a corrupt first line: err = %v, want a line 1 parse error", err)
+	}
+}
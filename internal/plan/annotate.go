@@ -0,0 +1,76 @@
+// annotate.go explains each bead's place in the plan's dependency graph for
+// the --explain-plan approval screen.
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+)
+
+// Annotation explains why a bead is ordered where it is. It is derived from
+// the parsed DependsOn fields alone.
+type Annotation struct {
+	BeadID     string
+	Group      int      // 1-based execution group, per beads.Levels
+	DependsOn  []string // direct dependencies on other beads in the plan
+	Dependents []string // beads that directly depend on this one
+	Unknown    []string // dependencies naming no bead in the plan (ignored)
+}
+
+// Annotate returns one Annotation per bead, in plan order.
+func Annotate(p *Plan) []Annotation {
+	inPlan := make(map[string]bool, len(p.Beads))
+	for _, b := range p.Beads {
+		inPlan[b.ID] = true
+	}
+
+	group := make(map[string]int, len(p.Beads))
+	for level, ids := range beads.Levels(ConvertToExecutionBeads(p.Beads)) {
+		for _, id := range ids {
+			group[id] = level + 1
+		}
+	}
+
+	dependents := make(map[string][]string)
+	for _, b := range p.Beads {
+		for _, dep := range b.DependsOn {
+			if inPlan[dep] {
+				dependents[dep] = append(dependents[dep], b.ID)
+			}
+		}
+	}
+
+	annotations := make([]Annotation, len(p.Beads))
+	for i, b := range p.Beads {
+		a := Annotation{BeadID: b.ID, Group: group[b.ID], Dependents: dependents[b.ID]}
+		for _, dep := range b.DependsOn {
+			if inPlan[dep] {
+				a.DependsOn = append(a.DependsOn, dep)
+			} else {
+				a.Unknown = append(a.Unknown, dep)
+			}
+		}
+		annotations[i] = a
+	}
+	return annotations
+}
+
+// String renders the annotation as inline tags, e.g.
+// "group 2 · after bt-1 · unblocks bt-4".
+func (a Annotation) String() string {
+	parts := []string{fmt.Sprintf("group %d", a.Group)}
+	if len(a.DependsOn) > 0 {
+		parts = append(parts, "after "+strings.Join(a.DependsOn, ", "))
+	} else {
+		parts = append(parts, "no dependencies")
+	}
+	if len(a.Dependents) > 0 {
+		parts = append(parts, "unblocks "+strings.Join(a.Dependents, ", "))
+	}
+	if len(a.Unknown) > 0 {
+		parts = append(parts, "unknown dependency "+strings.Join(a.Unknown, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
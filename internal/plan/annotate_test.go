@@ -0,0 +1,37 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnnotate(t *testing.T) {
+	p := &Plan{Beads: []BeadSpec{
+		{ID: "bt-1", Title: "Model"},
+		{ID: "bt-2", Title: "Store"},
+		{ID: "bt-3", Title: "Handler", DependsOn: []string{"bt-1", "bt-2"}},
+		{ID: "bt-4", Title: "Docs", DependsOn: []string{"bt-3", "bt-9"}},
+	}}
+
+	got := Annotate(p)
+	want := []Annotation{
+		{BeadID: "bt-1", Group: 1, Dependents: []string{"bt-3"}},
+		{BeadID: "bt-2", Group: 1, Dependents: []string{"bt-3"}},
+		{BeadID: "bt-3", Group: 2, DependsOn: []string{"bt-1", "bt-2"}, Dependents: []string{"bt-4"}},
+		{BeadID: "bt-4", Group: 3, DependsOn: []string{"bt-3"}, Unknown: []string{"bt-9"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Annotate =\n%+v\nwant\n%+v", got, want)
+	}
+
+	for i, wantStr := range []string{
+		"group 1 · no dependencies · unblocks bt-3",
+		"group 1 · no dependencies · unblocks bt-3",
+		"group 2 · after bt-1, bt-2 · unblocks bt-4",
+		"group 3 · after bt-3 · unknown dependency bt-9",
+	} {
+		if s := got[i].String(); s != wantStr {
+			t.Errorf("%s: String() = %q, want %q", got[i].BeadID, s, wantStr)
+		}
+	}
+}
@@ -10,7 +10,7 @@ import (
 // CreateBeads creates beads in the beads system for each bead spec in the plan,
 // then wires up dependencies between them. It maps plan IDs (bt-1, bt-2, etc.)
 // to the actual bead IDs returned by the beads CLI. It also writes sidecar
-// metadata (files, verify_extra) for each bead.
+// metadata (files, verify_extra, meta) for each bead.
 func CreateBeads(plan *Plan, projectRoot string) error {
 	idMap := make(map[string]string) // plan ID -> actual bead ID
 
@@ -22,10 +22,12 @@ func CreateBeads(plan *Plan, projectRoot string) error {
 		idMap[spec.ID] = actualID
 		fmt.Printf("  Created bead %s -> %s\n", spec.ID, actualID)
 
-		// Write sidecar metadata for files and verify_extra.
+		// Write sidecar metadata for files, verify_extra and meta.
 		if err := beads.WriteBeadMeta(projectRoot, actualID, beads.BeadMeta{
 			Files:       spec.Files,
 			VerifyExtra: spec.VerifyExtra,
+			Meta:        spec.Meta,
+			NoFiles:     spec.NoFiles,
 		}); err != nil {
 			fmt.Printf("  Warning: failed to write metadata for %s: %v\n", actualID, err)
 		}
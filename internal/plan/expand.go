@@ -0,0 +1,242 @@
+// expand.go breaks one bead of an approved-looking plan into sub-beads
+// ("break this down" on the approval screen) without re-planning the rest.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+)
+
+// ExpandBead asks Claude to break bead id of p into smaller sub-beads,
+// guided by feedback (which may be empty), and returns a new plan with the
+// bead replaced by them (see SpliceSubBeads). The new plan is written to
+// runDir's plan.md and plan.json. p is left unchanged.
+func ExpandBead(ctx context.Context, cfg config.Config, p *Plan, id, feedback, runDir string) (*Plan, error) {
+	bead := findSpec(p, id)
+	if bead == nil {
+		return nil, fmt.Errorf("bead %s is not in the plan", id)
+	}
+
+	rawOutput, err := spawnClaude(ctx, cfg.Agent, BuildExpandPrompt(p, *bead, feedback), spawnTimeout(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("claude failed: %w", err)
+	}
+	sub, err := ParsePlan(rawOutput)
+	if err != nil {
+		return nil, fmt.Errorf("parsing sub-beads of %s: %w", id, err)
+	}
+
+	expanded, err := SpliceSubBeads(p, id, sub.Beads)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateProtectedFiles(expanded, cfg.Execution.ProtectedFiles); err != nil {
+		return nil, err
+	}
+	if err := ValidateBeadCount(expanded, cfg.Execution.MaxBeads); err != nil {
+		return nil, err
+	}
+
+	if err := writePlan(runDir, expanded.RawOutput, maxPlanBytes(cfg)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to persist plan: %v\n", err)
+	}
+	if err := WritePlanJSON(runDir, expanded); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to persist plan.json: %v\n", err)
+	}
+	return expanded, nil
+}
+
+// SpliceSubBeads returns a copy of p with bead id replaced, in place, by
+// sub. Dependencies among sub-beads use their own IDs, and any other
+// dependency a sub-bead lists is dropped. Sub-beads without a dependency
+// inherit the bead's own dependencies, and beads that depended on id depend
+// on the sub-beads' terminus instead: every sub-bead no other sub-bead
+// depends on. All beads are then renumbered bt-1..bt-N in plan order, dropping
+// dependencies on beads not in the plan, and the result is checked with
+// Validate. RawOutput is regenerated with FormatPlan.
+func SpliceSubBeads(p *Plan, id string, sub []BeadSpec) (*Plan, error) {
+	target := findSpec(p, id)
+	if target == nil {
+		return nil, fmt.Errorf("bead %s is not in the plan", id)
+	}
+	if len(sub) == 0 {
+		return nil, fmt.Errorf("no sub-beads to replace %s with", id)
+	}
+
+	subIDs := make(map[string]bool, len(sub))
+	for _, s := range sub {
+		subIDs[s.ID] = true
+	}
+	dependedOn := make(map[string]bool)
+	for _, s := range sub {
+		for _, dep := range s.DependsOn {
+			if subIDs[dep] && dep != s.ID {
+				dependedOn[dep] = true
+			}
+		}
+	}
+
+	// Keys are "sub:<id>" for sub-beads and "plan:<id>" for the plan's own
+	// beads, since Claude numbers sub-beads from bt-1 as well.
+	type entry struct {
+		key  string
+		spec BeadSpec
+		deps []string // keys
+	}
+	var terminus []string
+	var entries []entry
+	for _, spec := range p.Beads {
+		if spec.ID != id {
+			e := entry{key: "plan:" + spec.ID, spec: spec}
+			for _, dep := range spec.DependsOn {
+				if dep == id {
+					e.deps = append(e.deps, "terminus")
+				} else {
+					e.deps = append(e.deps, "plan:"+dep)
+				}
+			}
+			entries = append(entries, e)
+			continue
+		}
+		for _, s := range sub {
+			e := entry{key: "sub:" + s.ID, spec: s}
+			for _, dep := range s.DependsOn {
+				if subIDs[dep] && dep != s.ID {
+					e.deps = append(e.deps, "sub:"+dep)
+				}
+			}
+			if len(e.deps) == 0 {
+				for _, dep := range target.DependsOn {
+					e.deps = append(e.deps, "plan:"+dep)
+				}
+			}
+			if !dependedOn[s.ID] {
+				terminus = append(terminus, e.key)
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	newID := make(map[string]string, len(entries))
+	for i, e := range entries {
+		newID[e.key] = fmt.Sprintf("bt-%d", i+1)
+	}
+
+	out := &Plan{Title: p.Title, Description: p.Description, Truncated: p.Truncated}
+	for _, e := range entries {
+		spec := e.spec
+		spec.ID = newID[e.key]
+		spec.DependsOn = nil
+		seen := make(map[string]bool)
+		for _, key := range e.deps {
+			keys := []string{key}
+			if key == "terminus" {
+				keys = terminus
+			}
+			for _, k := range keys {
+				if dep, ok := newID[k]; ok && !seen[dep] {
+					seen[dep] = true
+					spec.DependsOn = append(spec.DependsOn, dep)
+				}
+			}
+		}
+		out.Beads = append(out.Beads, spec)
+	}
+
+	if err := Validate(out); err != nil {
+		return nil, fmt.Errorf("expanding %s: %w", id, err)
+	}
+	out.RawOutput = FormatPlan(out)
+	return out, nil
+}
+
+// Validate checks a plan's structure: every bead has an ID no other bead
+// has, and dependencies name other beads of the plan without forming a
+// cycle.
+func Validate(p *Plan) error {
+	index := make(map[string]int, len(p.Beads))
+	for i, spec := range p.Beads {
+		if spec.ID == "" {
+			return fmt.Errorf("bead %d has no ID", i+1)
+		}
+		if _, dup := index[spec.ID]; dup {
+			return fmt.Errorf("duplicate bead ID %s", spec.ID)
+		}
+		index[spec.ID] = i
+	}
+	for _, spec := range p.Beads {
+		for _, dep := range spec.DependsOn {
+			if dep == spec.ID {
+				return fmt.Errorf("%s depends on itself", spec.ID)
+			}
+			if _, ok := index[dep]; !ok {
+				return fmt.Errorf("%s depends on %s, which is not in the plan", spec.ID, dep)
+			}
+		}
+	}
+
+	return beads.ValidateDependencies(ConvertToExecutionBeads(p.Beads))
+}
+
+// FormatPlan renders p in the structured markdown format ParsePlan reads,
+// e.g. to persist a plan edited after generation.
+func FormatPlan(p *Plan) string {
+	var b strings.Builder
+	if p.Title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", p.Title)
+	}
+	if p.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", p.Description)
+	}
+	for _, spec := range p.Beads {
+		fmt.Fprintf(&b, "### %s: %s\n", spec.ID, spec.Title)
+		switch {
+		case len(spec.Files) > 0:
+			fmt.Fprintf(&b, "- files: [%s]\n", strings.Join(spec.Files, ", "))
+		case spec.NoFiles:
+			b.WriteString("- files: none\n")
+		}
+		fmt.Fprintf(&b, "- context: %s\n", spec.Description)
+		if len(spec.DependsOn) > 0 {
+			fmt.Fprintf(&b, "- depends: %s\n", strings.Join(spec.DependsOn, ", "))
+		} else {
+			b.WriteString("- depends: none\n")
+		}
+		verify, _ := json.Marshal(spec.VerifyExtra)
+		if spec.VerifyExtra == nil {
+			verify = []byte("[]")
+		}
+		fmt.Fprintf(&b, "- verify_extra: %s\n", verify)
+		if len(spec.Meta) > 0 {
+			keys := make([]string, 0, len(spec.Meta))
+			for k := range spec.Meta {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			pairs := make([]string, len(keys))
+			for i, k := range keys {
+				pairs[i] = k + "=" + spec.Meta[k]
+			}
+			fmt.Fprintf(&b, "- meta: %s\n", strings.Join(pairs, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// findSpec returns the bead with the given ID, or nil.
+func findSpec(p *Plan, id string) *BeadSpec {
+	for i := range p.Beads {
+		if p.Beads[i].ID == id {
+			return &p.Beads[i]
+		}
+	}
+	return nil
+}
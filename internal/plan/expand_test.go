@@ -0,0 +1,120 @@
+package plan
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpliceSubBeadsRewiresDependencies(t *testing.T) {
+	p := &Plan{Title: "Add users", Beads: []BeadSpec{
+		{ID: "bt-1", Title: "Model", Files: []string{"user.go"}},
+		{ID: "bt-2", Title: "Store", Files: []string{"store.go"}, DependsOn: []string{"bt-1"}},
+		{ID: "bt-3", Title: "Handler", Files: []string{"handler.go"}, DependsOn: []string{"bt-2"}},
+		{ID: "bt-4", Title: "Docs", NoFiles: true, DependsOn: []string{"bt-2"}},
+	}}
+	// Claude numbers sub-beads from bt-1; bt-7 is neither a sub-bead nor
+	// meaningful to them and is dropped.
+	sub := []BeadSpec{
+		{ID: "bt-1", Title: "Schema", Files: []string{"schema.sql"}},
+		{ID: "bt-2", Title: "Queries", Files: []string{"store.go"}, DependsOn: []string{"bt-1", "bt-7"}},
+		{ID: "bt-3", Title: "Store tests", Files: []string{"store_test.go"}},
+	}
+
+	got, err := SpliceSubBeads(p, "bt-2", sub)
+	if err != nil {
+		t.Fatalf("SpliceSubBeads: %v", err)
+	}
+
+	type bead struct {
+		ID, Title string
+		DependsOn []string
+	}
+	var beads []bead
+	for _, b := range got.Beads {
+		beads = append(beads, bead{b.ID, b.Title, b.DependsOn})
+	}
+	want := []bead{
+		{"bt-1", "Model", nil},
+		{"bt-2", "Schema", []string{"bt-1"}},          // inherits the bead's dependency
+		{"bt-3", "Queries", []string{"bt-2"}},         // local dependency kept
+		{"bt-4", "Store tests", []string{"bt-1"}},     // inherits the bead's dependency
+		{"bt-5", "Handler", []string{"bt-3", "bt-4"}}, // depends on the terminus
+		{"bt-6", "Docs", []string{"bt-3", "bt-4"}},
+	}
+	if !reflect.DeepEqual(beads, want) {
+		t.Fatalf("beads =\n%+v\nwant\n%+v", beads, want)
+	}
+	if len(p.Beads) != 4 || p.Beads[2].DependsOn[0] != "bt-2" {
+		t.Errorf("original plan was modified: %+v", p.Beads)
+	}
+
+	reparsed, err := ParsePlan(got.RawOutput)
+	if err != nil {
+		t.Fatalf("ParsePlan(RawOutput): %v", err)
+	}
+	if reparsed.Title != "Add users" || len(reparsed.Beads) != len(got.Beads) {
+		t.Fatalf("round trip = %+v", reparsed)
+	}
+	for i, b := range reparsed.Beads {
+		if b.ID != got.Beads[i].ID || !reflect.DeepEqual(b.DependsOn, got.Beads[i].DependsOn) || b.NoFiles != got.Beads[i].NoFiles {
+			t.Errorf("round trip bead %d = %+v, want %+v", i, b, got.Beads[i])
+		}
+	}
+}
+
+func TestSpliceSubBeadsRejectsUnknownBead(t *testing.T) {
+	p := &Plan{Beads: []BeadSpec{{ID: "bt-1", Title: "Model"}}}
+	if _, err := SpliceSubBeads(p, "bt-9", []BeadSpec{{ID: "bt-1"}}); err == nil {
+		t.Fatal("SpliceSubBeads(bt-9) = nil error, want bead not in plan")
+	}
+	if _, err := SpliceSubBeads(p, "bt-1", nil); err == nil {
+		t.Fatal("SpliceSubBeads with no sub-beads = nil error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		beads []BeadSpec
+		want  string // substring of the error; empty for a valid plan
+	}{
+		{
+			name:  "valid",
+			beads: []BeadSpec{{ID: "bt-1"}, {ID: "bt-2", DependsOn: []string{"bt-1"}}},
+		},
+		{
+			name:  "duplicate",
+			beads: []BeadSpec{{ID: "bt-1"}, {ID: "bt-1"}},
+			want:  "duplicate bead ID bt-1",
+		},
+		{
+			name:  "unknown dependency",
+			beads: []BeadSpec{{ID: "bt-1", DependsOn: []string{"bt-5"}}},
+			want:  "not in the plan",
+		},
+		{
+			name: "cycle",
+			beads: []BeadSpec{
+				{ID: "bt-1", DependsOn: []string{"bt-3"}},
+				{ID: "bt-2", DependsOn: []string{"bt-1"}},
+				{ID: "bt-3", DependsOn: []string{"bt-2"}},
+			},
+			want: "dependency cycle: bt-1 -> bt-3 -> bt-2 -> bt-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&Plan{Beads: tt.beads})
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Validate = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -12,25 +12,28 @@ import (
 
 // Plan represents a parsed execution plan consisting of multiple bead specifications.
 type Plan struct {
-	Title       string
-	Description string
-	Beads       []BeadSpec
-	RawOutput   string // Original Claude output for "view details"
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Beads       []BeadSpec `json:"beads"`
+	RawOutput   string     `json:"-"` // Original Claude output for "view details"
+	Truncated   bool       `json:"-"` // output looks cut off (e.g. by a length limit); see looksTruncated
 }
 
 // BeadSpec defines a single bead (unit of work) within a plan.
 type BeadSpec struct {
-	ID          string
-	Title       string
-	Description string   // from the "context" field in the plan
-	Files       []string
-	DependsOn   []string
-	VerifyExtra []string
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"` // from the "context" field in the plan
+	Files       []string          `json:"files,omitempty"`
+	DependsOn   []string          `json:"depends_on,omitempty"`
+	VerifyExtra []string          `json:"verify_extra,omitempty"`
+	Meta        map[string]string `json:"meta,omitempty"`     // arbitrary key=value pairs, e.g. external ticket IDs
+	NoFiles     bool              `json:"no_files,omitempty"` // files explicitly declared as "none" (e.g. a docs-only bead)
 }
 
 // ParsePlan parses Claude's structured markdown plan output into a Plan struct.
 // It extracts the plan title from the first heading, then parses each bead
-// definition (### bt-N: Title) with its fields: files, context, depends, verify_extra.
+// definition (### bt-N: Title) with its fields: files, context, depends, verify_extra, meta.
 // Returns an error if no beads are found.
 func ParsePlan(output string) (*Plan, error) {
 	plan := &Plan{
@@ -88,9 +91,50 @@ func ParsePlan(output string) (*Plan, error) {
 		return nil, fmt.Errorf("no beads found in plan output")
 	}
 
+	plan.Truncated = looksTruncated(lines, plan.Beads)
+
 	return plan, nil
 }
 
+// looksTruncated reports whether the plan output appears to have been cut off
+// mid-plan: a code fence is left open, the last line ends inside a bracketed
+// list, or the last bead has no fields (or lacks the context every earlier
+// bead has).
+func looksTruncated(lines []string, beads []BeadSpec) bool {
+	fences := 0
+	last := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			fences++
+		}
+		if trimmed != "" {
+			last = trimmed
+		}
+	}
+	if fences%2 != 0 {
+		return true
+	}
+	if strings.Count(last, "[") > strings.Count(last, "]") {
+		return true
+	}
+
+	tail := beads[len(beads)-1]
+	if tail.Description == "" && len(tail.Files) == 0 && !tail.NoFiles &&
+		len(tail.DependsOn) == 0 && len(tail.VerifyExtra) == 0 && len(tail.Meta) == 0 {
+		return true
+	}
+	if tail.Description == "" && len(beads) > 1 {
+		for _, b := range beads[:len(beads)-1] {
+			if b.Description == "" {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
 // isBeadHeading returns true if the line matches the pattern "### bt-N: Title".
 func isBeadHeading(line string) bool {
 	return strings.HasPrefix(line, "### bt-") || strings.HasPrefix(line, "###bt-")
@@ -149,8 +193,13 @@ func parseBeadHeading(heading string) (string, string) {
 
 // parseBeadField parses a single field line within a bead definition.
 func parseBeadField(bead *BeadSpec, line string) {
-	// Match "- files:", "- context:", "- depends:", "- verify_extra:"
+	// Match "- files:", "- context:", "- depends:", "- verify_extra:", "- meta:"
 	if val, ok := extractField(line, "files"); ok {
+		if isNoneValue(val) {
+			bead.Files = nil
+			bead.NoFiles = true
+			return
+		}
 		bead.Files = parseFilesList(val)
 		return
 	}
@@ -166,6 +215,10 @@ func parseBeadField(bead *BeadSpec, line string) {
 		bead.VerifyExtra = parseVerifyExtra(val)
 		return
 	}
+	if val, ok := extractField(line, "meta"); ok {
+		bead.Meta = parseMeta(val)
+		return
+	}
 }
 
 // extractField checks if the line matches "- fieldName: value" and returns the value.
@@ -213,6 +266,13 @@ func parseFilesList(val string) []string {
 	return trimAll(parts)
 }
 
+// isNoneValue reports whether a field value explicitly declares "nothing",
+// e.g. "none", "n/a" or "[]".
+func isNoneValue(val string) bool {
+	lower := strings.ToLower(strings.TrimSpace(val))
+	return lower == "none" || lower == "[none]" || lower == "[]" || lower == "n/a"
+}
+
 // parseDependsList parses a dependency list.
 // Input: "none" -> empty, "bt-1, bt-2" -> ["bt-1", "bt-2"]
 // Also handles bracketed form: "[bt-1, bt-2]" -> ["bt-1", "bt-2"]
@@ -271,6 +331,39 @@ func parseVerifyExtra(val string) []string {
 	return []string{stripped}
 }
 
+// parseMeta parses key=value metadata pairs.
+// Input: "jira=PROJ-123, team=core" -> {"jira": "PROJ-123", "team": "core"}
+// Also handles bracketed form: "[jira=PROJ-123]". Entries without "=" or with
+// an empty key are ignored.
+func parseMeta(val string) map[string]string {
+	val = strings.TrimSpace(val)
+	lower := strings.ToLower(val)
+	if lower == "none" || lower == "" || lower == "[]" || lower == "n/a" {
+		return nil
+	}
+
+	val = strings.TrimPrefix(val, "[")
+	val = strings.TrimSuffix(val, "]")
+
+	meta := make(map[string]string)
+	for _, part := range strings.Split(val, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		meta[key] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+
+	if len(meta) == 0 {
+		return nil
+	}
+	return meta
+}
+
 // trimAll trims whitespace from all strings in a slice and removes empty entries.
 func trimAll(ss []string) []string {
 	var result []string
@@ -294,6 +387,8 @@ func ConvertToTUIPlan(p *Plan) *tui.Plan {
 			Files:       spec.Files,
 			DependsOn:   spec.DependsOn,
 			VerifyExtra: spec.VerifyExtra,
+			Meta:        spec.Meta,
+			NoFiles:     spec.NoFiles,
 		}
 	}
 	return &tui.Plan{
@@ -301,6 +396,7 @@ func ConvertToTUIPlan(p *Plan) *tui.Plan {
 		Description: p.Description,
 		Beads:       tuiBeads,
 		RawOutput:   p.RawOutput,
+		Warnings:    PlanWarnings(p),
 	}
 }
 
@@ -316,6 +412,8 @@ func ConvertFromTUIPlan(p *tui.Plan) *Plan {
 			Files:       spec.Files,
 			DependsOn:   spec.DependsOn,
 			VerifyExtra: spec.VerifyExtra,
+			Meta:        spec.Meta,
+			NoFiles:     spec.NoFiles,
 		}
 	}
 	return &Plan{
@@ -339,6 +437,8 @@ func ConvertToExecutionBeads(specs []BeadSpec) []beads.Bead {
 			DependsOn:   spec.DependsOn,
 			Files:       spec.Files,
 			VerifyExtra: spec.VerifyExtra,
+			Meta:        spec.Meta,
+			NoFiles:     spec.NoFiles,
 		}
 	}
 	return result
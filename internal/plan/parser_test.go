@@ -1,6 +1,7 @@
 package plan
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -326,3 +327,191 @@ func TestParsePlan_RawOutputPreserved(t *testing.T) {
 		t.Error("RawOutput should preserve original input")
 	}
 }
+
+func TestParsePlan_Meta(t *testing.T) {
+	input := `# Plan
+
+### bt-1: Task with ticket
+- files: [x.go]
+- context: do it
+- depends: none
+- meta: jira=PROJ-123, team=core
+
+### bt-2: Task without meta
+- files: [y.go]
+- context: do it too
+- depends: bt-1
+- meta: none
+`
+
+	plan, err := ParsePlan(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b0 := plan.Beads[0]
+	if len(b0.Meta) != 2 || b0.Meta["jira"] != "PROJ-123" || b0.Meta["team"] != "core" {
+		t.Errorf("Beads[0].Meta = %v, want map[jira:PROJ-123 team:core]", b0.Meta)
+	}
+	if plan.Beads[1].Meta != nil {
+		t.Errorf("Beads[1].Meta = %v, want nil", plan.Beads[1].Meta)
+	}
+
+	execBeads := ConvertToExecutionBeads(plan.Beads)
+	if execBeads[0].Meta["jira"] != "PROJ-123" {
+		t.Errorf("execution bead Meta = %v, want jira=PROJ-123", execBeads[0].Meta)
+	}
+}
+
+func TestParseMeta(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+	}{
+		{"single pair", "jira=PROJ-1", map[string]string{"jira": "PROJ-1"}},
+		{"multiple pairs", "jira=PROJ-1, owner=alice", map[string]string{"jira": "PROJ-1", "owner": "alice"}},
+		{"bracketed", "[jira=PROJ-1]", map[string]string{"jira": "PROJ-1"}},
+		{"quoted value", `url="https://x.test/1"`, map[string]string{"url": "https://x.test/1"}},
+		{"value with equals", "q=a=b", map[string]string{"q": "a=b"}},
+		{"skips malformed", "jira=PROJ-1, junk, =empty", map[string]string{"jira": "PROJ-1"}},
+		{"none", "none", nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseMeta(tt.input)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("got %v, want %v", result, tt.expected)
+			}
+			for k, v := range tt.expected {
+				if result[k] != v {
+					t.Errorf("meta[%q] = %q, want %q", k, result[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePlan_FilesNone(t *testing.T) {
+	input := `# Docs and code
+
+### bt-1: Update the changelog
+- files: none
+- context: Summarize the release notes
+- depends: none
+
+### bt-2: Tweak wording
+- context: Files were forgotten here
+- depends: none
+
+### bt-3: Fix handler
+- files: [internal/api/handler.go]
+- context: Handle nil request
+- depends: none
+`
+
+	plan, err := ParsePlan(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Beads) != 3 {
+		t.Fatalf("expected 3 beads, got %d", len(plan.Beads))
+	}
+
+	explicit := plan.Beads[0]
+	if !explicit.NoFiles || len(explicit.Files) != 0 {
+		t.Errorf("bt-1: NoFiles = %v, Files = %v; want true, empty", explicit.NoFiles, explicit.Files)
+	}
+	missing := plan.Beads[1]
+	if missing.NoFiles || len(missing.Files) != 0 {
+		t.Errorf("bt-2: NoFiles = %v, Files = %v; want false, empty", missing.NoFiles, missing.Files)
+	}
+	if plan.Beads[2].NoFiles {
+		t.Error("bt-3 lists files and should not be NoFiles")
+	}
+
+	warnings := FilelessWarnings(plan)
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "bt-2") {
+		t.Errorf("FilelessWarnings = %v, want a single warning for bt-2", warnings)
+	}
+
+	if tuiPlan := ConvertToTUIPlan(plan); len(tuiPlan.Warnings) != 1 || !tuiPlan.Beads[0].NoFiles {
+		t.Errorf("TUI plan should carry warnings and NoFiles, got %+v", tuiPlan)
+	}
+}
+
+func TestParsePlan_Truncated(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "trailing bead with no fields",
+			input: `# Add OAuth
+
+### bt-1: Add auth store
+- files: [src/stores/auth.ts]
+- context: Store the session
+- depends: none
+
+### bt-2: Add login button
+`,
+		},
+		{
+			name:  "unclosed code fence",
+			input: "```markdown\n# Add OAuth\n\n### bt-1: Add auth store\n- files: [src/stores/auth.ts]\n- context: Store the session\n",
+		},
+		{
+			name: "cut off inside a list",
+			input: `# Add OAuth
+
+### bt-1: Add auth store
+- files: [src/stores/auth.ts]
+- context: Store the session
+
+### bt-2: Add login button
+- files: [src/components/Login.tsx, src/comp`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := ParsePlan(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !plan.Truncated {
+				t.Error("Truncated = false, want true")
+			}
+		})
+	}
+}
+
+func TestParsePlan_CompleteNotTruncated(t *testing.T) {
+	input := "```markdown\n" + `# Add OAuth
+
+### bt-1: Add auth store
+- files: [src/stores/auth.ts]
+- context: Store the session
+- depends: none
+
+### bt-2: Add login button
+- files: [src/components/Login.tsx]
+- context: Render the Google button
+- depends: bt-1
+- verify_extra: ["pnpm test"]
+` + "```\n"
+
+	plan, err := ParsePlan(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan.Truncated {
+		t.Error("Truncated = true, want false")
+	}
+	if len(plan.Beads) != 2 {
+		t.Errorf("expected 2 beads, got %d", len(plan.Beads))
+	}
+}
@@ -4,18 +4,48 @@ package plan
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/berth-dev/berth/internal/config"
-	"github.com/berth-dev/berth/internal/context"
+	berthcontext "github.com/berth-dev/berth/internal/context"
 	"github.com/berth-dev/berth/internal/detect"
+	"github.com/berth-dev/berth/internal/ui"
 )
 
+// defaultSpawnTimeout bounds each planning Claude call when
+// plan.spawn_timeout is unset.
+const defaultSpawnTimeout = 10 * time.Minute
+
+// errSpawnTimeout is wrapped by the error of a planning Claude call that ran
+// past its timeout.
+var errSpawnTimeout = errors.New("claude timed out")
+
+// spawnTimeout returns how long each planning Claude call may run.
+func spawnTimeout(cfg config.Config) time.Duration {
+	if cfg.Plan.SpawnTimeout > 0 {
+		return time.Duration(cfg.Plan.SpawnTimeout) * time.Second
+	}
+	return defaultSpawnTimeout
+}
+
+// defaultMaxPlanBytes caps plan.md when plan.max_plan_bytes is unset.
+const defaultMaxPlanBytes = 1024 * 1024
+
+// maxPlanBytes returns the size plan.md is truncated past.
+func maxPlanBytes(cfg config.Config) int {
+	if cfg.Plan.MaxPlanBytes > 0 {
+		return cfg.Plan.MaxPlanBytes
+	}
+	return defaultMaxPlanBytes
+}
+
 // Requirements represents the gathered requirements from the understand phase.
 // Defined locally for decoupling from the understand package.
 type Requirements struct {
@@ -38,24 +68,34 @@ type claudeJSONOutput struct {
 
 // RunPlan orchestrates the planning phase. It generates a plan prompt, spawns
 // Claude to produce a plan, parses the output, and runs an interactive approval
-// loop. Returns the approved plan or an error.
-func RunPlan(cfg config.Config, requirements *Requirements, graphData string, runDir string, isGreenfield bool) (*Plan, error) {
+// loop. Returns the approved plan or an error. With explain set, the approval
+// screen also lists each bead's dependency reasoning (see Annotate).
+func RunPlan(cfg config.Config, requirements *Requirements, graphData string, runDir string, isGreenfield bool, explain bool) (*Plan, error) {
 	stackInfo := detect.StackInfo{
 		Language:       cfg.Project.Language,
 		Framework:      cfg.Project.Framework,
 		PackageManager: cfg.Project.PackageManager,
 	}
 
-	learnings := context.ReadLearnings(runDir)
+	learnings := berthcontext.ReadLearnings(runDir)
 
 	var feedback string
 	reader := bufio.NewReader(os.Stdin)
+	regenerated := false
 
 	for {
 		prompt := BuildPlanPrompt(requirements, stackInfo, graphData, learnings, feedback, isGreenfield)
 
-		fmt.Println("Generating plan with Claude...")
-		rawOutput, err := spawnClaude(prompt)
+		rawOutput, err := ui.RunWithSpinner("Generating plan with Claude...", func(ctx context.Context) (string, error) {
+			return spawnClaude(ctx, cfg.Agent, prompt, spawnTimeout(cfg))
+		})
+		if errors.Is(err, errSpawnTimeout) {
+			fmt.Printf("%v. Retry? [y/N] ", err)
+			line, readErr := reader.ReadString('\n')
+			if answer := strings.ToLower(strings.TrimSpace(line)); readErr == nil && (answer == "y" || answer == "yes") {
+				continue
+			}
+		}
 		if err != nil {
 			return nil, fmt.Errorf("spawning Claude for planning: %w", err)
 		}
@@ -65,48 +105,130 @@ func RunPlan(cfg config.Config, requirements *Requirements, graphData string, ru
 			return nil, fmt.Errorf("parsing plan output: %w\n\nClaude's raw response:\n%s", err, rawOutput)
 		}
 
-		if err := writePlan(runDir, rawOutput); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to persist plan: %v\n", err)
+		if plan.Truncated {
+			if !regenerated {
+				regenerated = true
+				fmt.Println("Plan output looks truncated. Regenerating...")
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "Warning: regenerated plan still looks truncated; review it before approving\n")
 		}
 
-		choice, err := presentApprovalUI(plan, reader)
-		if err != nil {
-			return nil, fmt.Errorf("reading user input: %w", err)
+		if err := ValidateProtectedFiles(plan, cfg.Execution.ProtectedFiles); err != nil {
+			return nil, err
 		}
 
-		switch choice {
-		case "1":
-			fmt.Println("Plan approved. Creating beads...")
-			return plan, nil
-		case "2":
-			fmt.Print("What should be changed? > ")
-			line, err := reader.ReadString('\n')
-			if err != nil {
-				return nil, fmt.Errorf("reading feedback: %w", err)
+		if err := ValidateBeadCount(plan, cfg.Execution.MaxBeads); err != nil {
+			fmt.Println(err)
+			fmt.Print("How should the task be narrowed? (empty to abort) > ")
+			line, readErr := reader.ReadString('\n')
+			if readErr != nil {
+				return nil, fmt.Errorf("reading feedback: %w", readErr)
 			}
 			feedback = strings.TrimSpace(line)
+			if feedback == "" {
+				return nil, err
+			}
 			fmt.Println("Re-planning with your feedback...")
-		case "3":
-			printPlanDetails(plan)
-		default:
-			fmt.Println("Invalid choice. Please enter 1, 2, or 3.")
+			continue
+		}
+
+		if err := writePlan(runDir, rawOutput, maxPlanBytes(cfg)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to persist plan: %v\n", err)
+		}
+		if err := WritePlanJSON(runDir, plan); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to persist plan.json: %v\n", err)
+		}
+
+		// Ask until the plan is approved or rejected; viewing details or
+		// breaking a bead down keeps the current plan on screen.
+	approval:
+		for {
+			choice, err := presentApprovalUI(plan, reader, explain)
+			if err != nil {
+				return nil, fmt.Errorf("reading user input: %w", err)
+			}
+
+			switch choice {
+			case "1":
+				fmt.Println("Plan approved. Creating beads...")
+				return plan, nil
+			case "2":
+				fmt.Print("What should be changed? > ")
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return nil, fmt.Errorf("reading feedback: %w", err)
+				}
+				feedback = strings.TrimSpace(line)
+				fmt.Println("Re-planning with your feedback...")
+				break approval
+			case "3":
+				printPlanDetails(plan)
+			case "4":
+				fmt.Print("Bead to break down (e.g. bt-2) > ")
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return nil, fmt.Errorf("reading bead ID: %w", err)
+				}
+				id := strings.TrimSpace(line)
+				if findSpec(plan, id) == nil {
+					fmt.Printf("No bead %q in the plan.\n", id)
+					continue
+				}
+				fmt.Print("How should it be split? (empty to let Claude decide) > ")
+				line, err = reader.ReadString('\n')
+				if err != nil {
+					return nil, fmt.Errorf("reading feedback: %w", err)
+				}
+				expanded, err := breakDownBead(cfg, plan, id, strings.TrimSpace(line), runDir)
+				if err != nil {
+					fmt.Printf("Could not break %s down, keeping the current plan: %v\n", id, err)
+					continue
+				}
+				plan = expanded
+			default:
+				fmt.Println("Invalid choice. Please enter 1, 2, 3, or 4.")
+			}
 		}
 	}
 }
 
+// breakDownBead expands bead id into sub-beads with ExpandBead, showing a
+// spinner while Claude works.
+func breakDownBead(cfg config.Config, plan *Plan, id, feedback, runDir string) (*Plan, error) {
+	var expanded *Plan
+	_, err := ui.RunWithSpinner("Breaking down "+id+" with Claude...", func(ctx context.Context) (string, error) {
+		var err error
+		expanded, err = ExpandBead(ctx, cfg, plan, id, feedback, runDir)
+		return "", err
+	})
+	return expanded, err
+}
+
 // spawnClaude runs `claude -p` with the given prompt and returns the result
-// text extracted from Claude's JSON output envelope.
-func spawnClaude(prompt string) (string, error) {
-	cmd := exec.Command(
-		"claude",
+// text extracted from Claude's JSON output envelope. Cancelling ctx kills
+// the Claude process, as does running past timeout. agent selects the CLI
+// binary and any extra args.
+func spawnClaude(parent context.Context, agent config.AgentConfig, prompt string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	args := []string{
 		"-p", prompt,
 		"--allowedTools", "Read,Grep,Glob",
 		"--output-format", "json",
 		"--dangerously-skip-permissions",
 		"--model", "opus",
-	)
+	}
+	cmd := agent.Cmd(ctx, args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("%w after %v", errSpawnTimeout, timeout)
+		}
+		if ctx.Err() == context.Canceled {
+			return "", fmt.Errorf("claude was canceled")
+		}
 		return "", fmt.Errorf("claude command failed: %w: %s", err, output)
 	}
 
@@ -123,8 +245,8 @@ func spawnClaude(prompt string) (string, error) {
 }
 
 // presentApprovalUI displays the plan summary and prompts the user for a choice.
-// Returns the user's choice as a string ("1", "2", or "3").
-func presentApprovalUI(plan *Plan, reader *bufio.Reader) (string, error) {
+// Returns the user's choice as a string ("1" through "4").
+func presentApprovalUI(plan *Plan, reader *bufio.Reader, explain bool) (string, error) {
 	fmt.Println()
 	fmt.Println("+---------------------------------------------------------+")
 	fmt.Printf("|  Plan: %s (%d beads)%s|\n",
@@ -151,9 +273,16 @@ func presentApprovalUI(plan *Plan, reader *bufio.Reader) (string, error) {
 	fmt.Println("|  [1] Approve -- start execution                         |")
 	fmt.Println("|  [2] Reject -- explain what to change (re-plans)        |")
 	fmt.Println("|  [3] View details -- show full bead descriptions        |")
+	fmt.Println("|  [4] Break down -- split one bead into smaller beads    |")
 	fmt.Println("+---------------------------------------------------------+")
+	if explain {
+		printAnnotations(plan)
+	}
+	for _, w := range PlanWarnings(plan) {
+		fmt.Printf("Warning: %s\n", w)
+	}
 	fmt.Println()
-	fmt.Print("Choice [1/2/3]: ")
+	fmt.Print("Choice [1/2/3/4]: ")
 
 	line, err := reader.ReadString('\n')
 	if err != nil {
@@ -162,6 +291,15 @@ func presentApprovalUI(plan *Plan, reader *bufio.Reader) (string, error) {
 	return strings.TrimSpace(line), nil
 }
 
+// printAnnotations prints each bead's dependency reasoning below the
+// approval box.
+func printAnnotations(plan *Plan) {
+	fmt.Println("Dependency reasoning:")
+	for _, a := range Annotate(plan) {
+		fmt.Printf("  %s: %s\n", a.BeadID, a)
+	}
+}
+
 // printPlanDetails prints the full plan output including all bead descriptions.
 func printPlanDetails(plan *Plan) {
 	fmt.Println()
@@ -180,6 +318,8 @@ func printPlanDetails(plan *Plan) {
 		}
 		if len(bead.Files) > 0 {
 			fmt.Printf("    Files: %s\n", strings.Join(bead.Files, ", "))
+		} else if bead.NoFiles {
+			fmt.Println("    Files: none")
 		}
 		if len(bead.DependsOn) > 0 {
 			fmt.Printf("    Depends: %s\n", strings.Join(bead.DependsOn, ", "))
@@ -216,7 +356,9 @@ func padding(n int) string {
 // RunPlanNonInteractive generates a plan without the interactive approval loop.
 // The TUI handles approval UI separately.
 // If feedback is provided, it's incorporated into the prompt for re-planning.
+// Canceling ctx stops the in-flight Claude call.
 func RunPlanNonInteractive(
+	ctx context.Context,
 	cfg config.Config,
 	requirements *Requirements,
 	graphData, runDir string,
@@ -229,34 +371,67 @@ func RunPlanNonInteractive(
 		PackageManager: cfg.Project.PackageManager,
 	}
 
-	learnings := context.ReadLearnings(runDir)
+	learnings := berthcontext.ReadLearnings(runDir)
 
 	prompt := BuildPlanPrompt(requirements, stackInfo, graphData, learnings, feedback, isGreenfield)
 
-	rawOutput, err := spawnClaude(prompt)
-	if err != nil {
-		return nil, fmt.Errorf("claude failed: %w", err)
+	// Output cut off by a length limit is regenerated once rather than
+	// executed as a partial plan.
+	var rawOutput string
+	var plan *Plan
+	for attempt := 1; ; attempt++ {
+		var err error
+		rawOutput, err = spawnClaude(ctx, cfg.Agent, prompt, spawnTimeout(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("claude failed: %w", err)
+		}
+
+		plan, err = ParsePlan(rawOutput)
+		if err != nil {
+			return nil, fmt.Errorf("parse failed: %w", err)
+		}
+		if !plan.Truncated {
+			break
+		}
+		if attempt > 1 {
+			fmt.Fprintf(os.Stderr, "Warning: regenerated plan still looks truncated; continuing with %d beads\n", len(plan.Beads))
+			break
+		}
+		fmt.Fprintf(os.Stderr, "Plan output looks truncated. Regenerating...\n")
 	}
 
-	plan, err := ParsePlan(rawOutput)
-	if err != nil {
-		return nil, fmt.Errorf("parse failed: %w", err)
+	if err := ValidateProtectedFiles(plan, cfg.Execution.ProtectedFiles); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateBeadCount(plan, cfg.Execution.MaxBeads); err != nil {
+		return nil, err
 	}
 
 	// Write plan to disk for persistence
-	if err := writePlan(runDir, rawOutput); err != nil {
+	if err := writePlan(runDir, rawOutput, maxPlanBytes(cfg)); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to persist plan: %v\n", err)
 	}
+	if err := WritePlanJSON(runDir, plan); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to persist plan.json: %v\n", err)
+	}
 
 	return plan, nil
 }
 
-// writePlan persists the raw plan content to the run directory.
-func writePlan(runDir string, content string) error {
+// writePlan persists the raw plan content to the run directory. Content
+// over maxBytes is truncated with a marker and a warning; the parsed plan
+// is unaffected.
+func writePlan(runDir string, content string, maxBytes int) error {
 	if err := os.MkdirAll(runDir, 0755); err != nil {
 		return fmt.Errorf("plan: creating run directory: %w", err)
 	}
 
+	if cut, truncated := ui.TruncateBytes(content, maxBytes); truncated {
+		fmt.Fprintf(os.Stderr, "Warning: plan is %d bytes, over the %d-byte limit (plan.max_plan_bytes); truncating plan.md\n", len(content), maxBytes)
+		content = cut + fmt.Sprintf("\n<!-- berth: truncated, %d bytes omitted (plan.max_plan_bytes) -->\n", len(content)-len(cut))
+	}
+
 	path := filepath.Join(runDir, "plan.md")
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 		return fmt.Errorf("plan: writing plan: %w", err)
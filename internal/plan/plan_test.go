@@ -0,0 +1,107 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+func TestRunPlanNonInteractiveTimesOut(t *testing.T) {
+	agent := testutil.FakeScript(t, "claude", "exec sleep 10\n")
+	cfg := *config.DefaultConfig()
+	cfg.Agent.Command = agent
+	cfg.Plan.SpawnTimeout = 1
+
+	start := time.Now()
+	_, err := RunPlanNonInteractive(context.Background(), cfg, &Requirements{Title: "Auth"}, "", t.TempDir(), false, "")
+	if !errors.Is(err, errSpawnTimeout) {
+		t.Fatalf("error = %v, want a spawn timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %v, want soon after the 1s timeout", elapsed)
+	}
+}
+
+func TestWritePlanTruncatesOverCap(t *testing.T) {
+	runDir := t.TempDir()
+	content := "# Plan\n\n### bt-1: First\n" + strings.Repeat("x", 100) + "\n"
+
+	if err := writePlan(runDir, content, 30); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(runDir, "plan.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if !strings.HasPrefix(got, "# Plan\n\n### bt-1: First\n\n<!-- berth: truncated") {
+		t.Errorf("plan.md = %q, want it cut at the last whole line with a marker", got)
+	}
+	if strings.Contains(got, "xxx") {
+		t.Errorf("plan.md kept content past the cap: %q", got)
+	}
+
+	if err := writePlan(runDir, content, len(content)); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(runDir, "plan.md")); string(data) != content {
+		t.Errorf("plan.md at the cap = %q, want it unchanged", data)
+	}
+}
+
+func TestLoadPlanPrefersPlanJSON(t *testing.T) {
+	runDir := t.TempDir()
+	p := &Plan{
+		Title: "Auth",
+		Beads: []BeadSpec{
+			{ID: "bt-1", Title: "Add model", Description: "Context: with a colon", Files: []string{"models/user.go"}, Meta: map[string]string{"jira": "AUTH-1"}},
+			{ID: "bt-2", Title: "Document it", DependsOn: []string{"bt-1"}, VerifyExtra: []string{"make docs"}, NoFiles: true},
+		},
+	}
+	// plan.md was truncated, so only plan.json still has bt-2.
+	if err := writePlan(runDir, "# Plan: Auth\n\n### bt-1: Add model\n", defaultMaxPlanBytes); err != nil {
+		t.Fatal(err)
+	}
+	if err := WritePlanJSON(runDir, p); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadPlan(runDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Beads, p.Beads) || got.Title != p.Title {
+		t.Errorf("LoadPlan = %+v, want %+v", got, p)
+	}
+	if !strings.HasPrefix(got.RawOutput, "# Plan: Auth") {
+		t.Errorf("RawOutput = %q, want plan.md's content", got.RawOutput)
+	}
+}
+
+func TestLoadPlanFallsBackToMarkdown(t *testing.T) {
+	runDir := t.TempDir()
+	p := &Plan{Title: "Auth", Beads: []BeadSpec{{ID: "bt-1", Title: "Add model", Files: []string{"models/user.go"}}}}
+	if err := writePlan(runDir, FormatPlan(p), defaultMaxPlanBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadPlan(runDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Beads) != 1 || got.Beads[0].ID != "bt-1" || got.Beads[0].Files[0] != "models/user.go" {
+		t.Errorf("LoadPlan beads = %+v, want bt-1 parsed from plan.md", got.Beads)
+	}
+
+	if _, err := LoadPlan(t.TempDir()); err == nil {
+		t.Error("LoadPlan of an empty run directory succeeded, want an error")
+	}
+}
@@ -0,0 +1,74 @@
+// planjson.go persists plans as plan.json, a lossless companion to plan.md.
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+// ErrNoPlan is returned by LoadPlan for a run directory with neither
+// plan.json nor plan.md.
+var ErrNoPlan = errors.New("plan: no plan.json or plan.md")
+
+// WritePlanJSON writes p to runDir's plan.json. Unlike plan.md it is not
+// truncated, so LoadPlan gets back exactly the beads that were written.
+func WritePlanJSON(runDir string, p *Plan) error {
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		return fmt.Errorf("plan: creating run directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("plan: encoding plan.json: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "plan.json"), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("plan: writing plan.json: %w", err)
+	}
+	return nil
+}
+
+// SavePlan rewrites runDir's plan.md, rendered by FormatPlan, and plan.json
+// from p, e.g. after the plan was edited on the approval screen.
+func SavePlan(cfg config.Config, runDir string, p *Plan) error {
+	if err := writePlan(runDir, FormatPlan(p), maxPlanBytes(cfg)); err != nil {
+		return err
+	}
+	return WritePlanJSON(runDir, p)
+}
+
+// LoadPlan reads the plan persisted in runDir. plan.json is preferred when
+// present; otherwise plan.md is parsed, as it was before plan.json existed.
+// RawOutput is plan.md's content, or FormatPlan's rendering if there is
+// none.
+func LoadPlan(runDir string) (*Plan, error) {
+	md, mdErr := os.ReadFile(filepath.Join(runDir, "plan.md"))
+	if mdErr != nil && !errors.Is(mdErr, fs.ErrNotExist) {
+		return nil, fmt.Errorf("plan: reading plan.md: %w", mdErr)
+	}
+
+	data, err := os.ReadFile(filepath.Join(runDir, "plan.json"))
+	switch {
+	case err == nil:
+		var p Plan
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("plan: parsing plan.json: %w", err)
+		}
+		if mdErr == nil {
+			p.RawOutput = string(md)
+		} else {
+			p.RawOutput = FormatPlan(&p)
+		}
+		return &p, nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("plan: reading plan.json: %w", err)
+	case mdErr != nil:
+		return nil, fmt.Errorf("%w in %s", ErrNoPlan, runDir)
+	}
+	return ParsePlan(string(md))
+}
@@ -210,11 +210,12 @@ Then define each bead using this template:
 
 Rules for the output:
 - Number beads sequentially: bt-1, bt-2, bt-3, etc.
-- The "files" field is a bracketed comma-separated list of file paths
+- The "files" field is a bracketed comma-separated list of file paths; use "- files: none" only for a bead that intentionally changes no files
 - The "context" field is a short paragraph (becomes the bead description)
 - The "depends" field is either "none" or a comma-separated list of bead IDs (e.g., "bt-1, bt-2")
 - The "verify_extra" field is a JSON array of shell commands to run for verification beyond the default pipeline
 - Each bead MUST have all four fields: files, context, depends, verify_extra
+- The optional "meta" field is a comma-separated list of key=value pairs (e.g., "- meta: jira=PROJ-123") for external references named in the requirements; omit it otherwise
 
 Output ONLY the structured plan markdown. Do not include any other text, explanations, or commentary outside the plan structure.
 Return the plan as your text response. Do NOT write it to a file.
@@ -222,3 +223,58 @@ Return the plan as your text response. Do NOT write it to a file.
 
 	return b.String()
 }
+
+// BuildExpandPrompt constructs the prompt asking Claude to break bead of p
+// into smaller sub-beads, optionally guided by the user's feedback. The
+// rest of the plan is included for context only.
+func BuildExpandPrompt(p *Plan, bead BeadSpec, feedback string) string {
+	var b strings.Builder
+
+	b.WriteString("# Task: Break a Bead Down into Sub-Beads\n\n")
+	b.WriteString("The plan below was generated for the user's task. One bead is too big; ")
+	b.WriteString(fmt.Sprintf("split %s into smaller sub-beads that together do exactly its work.\n\n", bead.ID))
+
+	b.WriteString("## Plan (for context)\n\n")
+	for _, spec := range p.Beads {
+		b.WriteString(fmt.Sprintf("- %s: %s\n", spec.ID, spec.Title))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("## Bead to Break Down\n\n### %s: %s\n", bead.ID, bead.Title))
+	if len(bead.Files) > 0 {
+		b.WriteString(fmt.Sprintf("- files: [%s]\n", strings.Join(bead.Files, ", ")))
+	}
+	if bead.Description != "" {
+		b.WriteString(fmt.Sprintf("- context: %s\n", bead.Description))
+	}
+	if len(bead.VerifyExtra) > 0 {
+		b.WriteString(fmt.Sprintf("- verify_extra: %s\n", strings.Join(bead.VerifyExtra, ", ")))
+	}
+	b.WriteString("\n")
+
+	if feedback != "" {
+		b.WriteString("## User Feedback\n\n")
+		b.WriteString(fmt.Sprintf("> %s\n\n", feedback))
+	}
+
+	b.WriteString(`## Output Format
+
+Output ONLY the sub-beads, in the same structured markdown format as the plan:
+
+### bt-1: Short title describing the sub-bead
+- files: [path/to/file1.ts]
+- context: What exists and what this sub-bead should do.
+- depends: none
+- verify_extra: ["command1"]
+
+Rules for the output:
+- Number sub-beads sequentially from bt-1; these numbers are local to your answer
+- The "depends" field lists only other sub-beads from your answer (or "none"); dependencies on the rest of the plan are wired automatically
+- Each sub-bead has exactly 1 responsibility and all four fields: files, context, depends, verify_extra
+- Produce at least 2 sub-beads; do not include work outside the bead being broken down
+
+Return the sub-beads as your text response. Do NOT write them to a file.
+`)
+
+	return b.String()
+}
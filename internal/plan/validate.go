@@ -0,0 +1,108 @@
+// validate.go checks a parsed plan against project constraints before beads
+// are created.
+package plan
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+// ValidateProtectedFiles rejects a plan in which any bead lists a file that
+// matches one of the protected globs. The error names every offending bead
+// and file so the plan can be regenerated with that feedback.
+func ValidateProtectedFiles(p *Plan, protected []string) error {
+	if len(protected) == 0 {
+		return nil
+	}
+
+	var violations []string
+	for _, spec := range p.Beads {
+		for _, f := range spec.Files {
+			if glob := config.MatchProtected(f, protected); glob != "" {
+				violations = append(violations, fmt.Sprintf("%s: %s (matches %q)", spec.ID, f, glob))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("plan touches protected files:\n  %s", strings.Join(violations, "\n  "))
+	}
+	return nil
+}
+
+// ValidateBeadCount rejects a plan with more beads than limit. A limit of
+// zero or less falls back to config.DefaultMaxBeads.
+func ValidateBeadCount(p *Plan, limit int) error {
+	if limit <= 0 {
+		limit = config.DefaultMaxBeads
+	}
+	if len(p.Beads) > limit {
+		return fmt.Errorf("plan has %d beads, exceeding the cap of %d: narrow the task or raise execution.max_beads (--max-beads)", len(p.Beads), limit)
+	}
+	return nil
+}
+
+// FilelessWarnings returns one warning per bead that lists no files without
+// declaring "- files: none". Such beads get no pre-embedded graph context,
+// which is usually a planning mistake rather than a deliberate choice.
+func FilelessWarnings(p *Plan) []string {
+	var warnings []string
+	for _, spec := range p.Beads {
+		if len(spec.Files) == 0 && !spec.NoFiles {
+			warnings = append(warnings, fmt.Sprintf("%s declares no files (use \"- files: none\" if intentional)", spec.ID))
+		}
+	}
+	return warnings
+}
+
+// shellBuiltins are verify-command words that don't resolve through PATH.
+var shellBuiltins = map[string]bool{
+	"cd": true, "echo": true, "test": true, "[": true, "true": true, "false": true,
+	"exit": true, "export": true, "set": true, "source": true, ".": true, ":": true,
+}
+
+// MissingVerifyCommands checks each distinct verify_extra command in the plan
+// and returns one warning per command whose executable is not in PATH, so a
+// typo or uninstalled tool is caught before execution instead of after every
+// retry of the bead.
+func MissingVerifyCommands(p *Plan) []string {
+	var warnings []string
+	checked := make(map[string]bool)
+	for _, spec := range p.Beads {
+		for _, command := range spec.VerifyExtra {
+			if checked[command] {
+				continue
+			}
+			checked[command] = true
+
+			name := verifyExecutable(command)
+			if name == "" || shellBuiltins[name] {
+				continue
+			}
+			if _, err := exec.LookPath(name); err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: verify command %q needs %q, which is not in PATH", spec.ID, command, name))
+			}
+		}
+	}
+	return warnings
+}
+
+// verifyExecutable returns the program a shell verify command runs first,
+// skipping leading VAR=value assignments.
+func verifyExecutable(command string) string {
+	for _, field := range strings.Fields(command) {
+		if strings.Contains(field, "=") && !strings.HasPrefix(field, "=") {
+			continue
+		}
+		return field
+	}
+	return ""
+}
+
+// PlanWarnings collects every non-fatal plan check shown on the approval screen.
+func PlanWarnings(p *Plan) []string {
+	return append(FilelessWarnings(p), MissingVerifyCommands(p)...)
+}
@@ -0,0 +1,84 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateProtectedFiles_RejectsProtectedBead(t *testing.T) {
+	p := &Plan{
+		Beads: []BeadSpec{
+			{ID: "bt-1", Title: "Add handler", Files: []string{"internal/api/handler.go"}},
+			{ID: "bt-2", Title: "Update CI", Files: []string{".github/workflows/ci.yml"}},
+		},
+	}
+
+	err := ValidateProtectedFiles(p, []string{".github/**", "LICENSE"})
+	if err == nil {
+		t.Fatal("expected error for bead touching protected file")
+	}
+	if !strings.Contains(err.Error(), "bt-2") || !strings.Contains(err.Error(), ".github/workflows/ci.yml") {
+		t.Errorf("error should name the bead and file, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "bt-1") {
+		t.Errorf("error should not name unprotected bead, got: %v", err)
+	}
+}
+
+func TestValidateProtectedFiles_AllowsCleanPlan(t *testing.T) {
+	p := &Plan{
+		Beads: []BeadSpec{
+			{ID: "bt-1", Title: "Add handler", Files: []string{"internal/api/handler.go"}},
+		},
+	}
+
+	if err := ValidateProtectedFiles(p, []string{".github/**"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateProtectedFiles(p, nil); err != nil {
+		t.Errorf("unexpected error with no protected globs: %v", err)
+	}
+}
+
+func TestValidateBeadCount_RejectsOversizedPlan(t *testing.T) {
+	p := &Plan{}
+	for i := 1; i <= 4; i++ {
+		p.Beads = append(p.Beads, BeadSpec{ID: fmt.Sprintf("bt-%d", i), Title: "Step"})
+	}
+
+	err := ValidateBeadCount(p, 3)
+	if err == nil {
+		t.Fatal("expected error for plan exceeding max beads")
+	}
+	if !strings.Contains(err.Error(), "4 beads") || !strings.Contains(err.Error(), "cap of 3") {
+		t.Errorf("error should report bead count and cap, got: %v", err)
+	}
+
+	if err := ValidateBeadCount(p, 4); err != nil {
+		t.Errorf("unexpected error for plan at the cap: %v", err)
+	}
+	if err := ValidateBeadCount(p, 0); err != nil {
+		t.Errorf("unexpected error with default cap: %v", err)
+	}
+}
+
+func TestMissingVerifyCommands_FlagsUnknownExecutable(t *testing.T) {
+	p := &Plan{
+		Beads: []BeadSpec{
+			{ID: "bt-1", VerifyExtra: []string{"sh -c 'exit 0'", "CGO_ENABLED=0 berth-no-such-tool ./..."}},
+			{ID: "bt-2", VerifyExtra: []string{"CGO_ENABLED=0 berth-no-such-tool ./...", "cd web && sh -c true"}},
+		},
+	}
+
+	warnings := MissingVerifyCommands(p)
+	if len(warnings) != 1 {
+		t.Fatalf("MissingVerifyCommands = %v, want one warning for the distinct missing command", warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "bt-1") || !strings.Contains(warnings[0], `"berth-no-such-tool"`) {
+		t.Errorf("warning = %q, want bt-1 and the missing executable named", warnings[0])
+	}
+	if got := PlanWarnings(p); len(got) != 3 {
+		t.Errorf("PlanWarnings = %v, want 2 fileless warnings plus the missing command", got)
+	}
+}
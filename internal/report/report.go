@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,6 +30,10 @@ type Report struct {
 	Learnings    int
 	Duration     time.Duration
 	CostUSD      float64
+	BeadMeta     map[string]map[string]string // bead ID -> metadata from task_completed events
+	FirstTry     int                          // beads that passed verification on attempt 1
+	Attempted    int                          // beads with a recorded winning attempt
+	BeadCommits  map[string][]string          // bead ID -> commit SHAs from task_completed events
 }
 
 // GenerateReport gathers all run data and produces a Report.
@@ -79,23 +84,66 @@ func GenerateReport(cfg config.Config, projectRoot string, runDir string) (*Repo
 	r.Learnings = len(learnings)
 
 	// Calculate duration and cost from log events.
+	var events []log.LogEvent
 	logger, err := log.NewLogger(projectRoot)
 	if err == nil {
-		events, readErr := logger.ReadAll()
+		var readErr error
+		events, readErr = logger.ReadAll()
 		if readErr == nil && len(events) > 0 {
 			r.Duration = computeDuration(events)
 			r.CostUSD = computeCost(events)
+			r.BeadMeta = collectBeadMeta(events)
+			r.FirstTry, r.Attempted = countFirstTry(events)
+			r.BeadCommits = collectBeadCommits(events)
 		}
 	}
 
+	summary := BuildSummary(runDir, r.Branch, events)
+	if privacy := cfg.Log.Privacy(); privacy.Enabled() {
+		anon, anonErr := log.NewAnonymizer(projectRoot, privacy)
+		if anonErr != nil {
+			return r, fmt.Errorf("anonymizing report: %w", anonErr)
+		}
+		redactReport(r, summary, anon, privacy)
+	}
+
 	// Write the report to the run directory.
 	if writeErr := WriteReport(runDir, r); writeErr != nil {
 		return r, fmt.Errorf("writing report: %w", writeErr)
 	}
 
+	// Write the structured summary used by "berth runs diff".
+	if writeErr := WriteSummary(runDir, summary); writeErr != nil {
+		return r, fmt.Errorf("writing summary: %w", writeErr)
+	}
+
 	return r, nil
 }
 
+// redactReport applies the log privacy settings to the report and summary:
+// paths in the diff stat are pseudonymized, and with RedactTitles commit
+// subjects and bead titles are dropped.
+func redactReport(r *Report, s *RunSummary, anon *log.Anonymizer, p log.Privacy) {
+	if p.AnonymizePaths && r.FilesChanged != "" {
+		lines := strings.Split(r.FilesChanged, "\n")
+		for i, line := range lines {
+			// Stat lines look like " path/to/file.go | 12 +++--".
+			if path, stat, ok := strings.Cut(line, " | "); ok {
+				lines[i] = " " + anon.Path(strings.TrimSpace(path)) + " | " + stat
+			}
+		}
+		r.FilesChanged = strings.Join(lines, "\n")
+	}
+	if p.RedactTitles {
+		for i, c := range r.Commits {
+			r.Commits[i], _, _ = strings.Cut(c, " ")
+		}
+		for i := range s.Beads {
+			s.Beads[i].Title = ""
+		}
+	}
+}
+
 // FormatReport produces a terminal-friendly, human-readable summary string.
 func FormatReport(r *Report) string {
 	var b strings.Builder
@@ -117,6 +165,9 @@ func FormatReport(r *Report) string {
 	fmt.Fprintf(&b, "  Completed: %d\n", r.Completed)
 	fmt.Fprintf(&b, "  Stuck:     %d\n", r.Stuck)
 	fmt.Fprintf(&b, "  Skipped:   %d\n", r.Skipped)
+	if r.Attempted > 0 {
+		fmt.Fprintf(&b, "  %d/%d beads passed on first attempt\n", r.FirstTry, r.Attempted)
+	}
 	b.WriteString("\n")
 
 	if len(r.Commits) > 0 {
@@ -127,6 +178,19 @@ func FormatReport(r *Report) string {
 		b.WriteString("\n")
 	}
 
+	if len(r.BeadCommits) > 0 {
+		b.WriteString("Bead Commits:\n")
+		ids := make([]string, 0, len(r.BeadCommits))
+		for id := range r.BeadCommits {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Fprintf(&b, "  - %s: %s\n", id, strings.Join(shortSHAs(r.BeadCommits[id]), " "))
+		}
+		b.WriteString("\n")
+	}
+
 	if r.FilesChanged != "" {
 		b.WriteString("Files Changed:\n")
 		for _, line := range strings.Split(r.FilesChanged, "\n") {
@@ -137,6 +201,19 @@ func FormatReport(r *Report) string {
 		b.WriteString("\n")
 	}
 
+	if len(r.BeadMeta) > 0 {
+		b.WriteString("Bead Metadata:\n")
+		ids := make([]string, 0, len(r.BeadMeta))
+		for id := range r.BeadMeta {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Fprintf(&b, "  - %s: %s\n", id, formatMeta(r.BeadMeta[id]))
+		}
+		b.WriteString("\n")
+	}
+
 	if r.Learnings > 0 {
 		fmt.Fprintf(&b, "Learnings:   %d new entries\n", r.Learnings)
 		b.WriteString("\n")
@@ -267,6 +344,85 @@ func computeCost(events []log.LogEvent) float64 {
 	return total
 }
 
+// collectBeadMeta gathers per-bead metadata from task_completed events.
+// Later events for the same bead overwrite earlier ones.
+func collectBeadMeta(events []log.LogEvent) map[string]map[string]string {
+	var result map[string]map[string]string
+	for _, e := range events {
+		if e.Event != log.EventTaskCompleted || e.BeadID == "" || len(e.Meta) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]map[string]string)
+		}
+		result[e.BeadID] = e.Meta
+	}
+	return result
+}
+
+// collectBeadCommits gathers per-bead commit SHAs from task_completed events.
+// Later events for the same bead overwrite earlier ones.
+func collectBeadCommits(events []log.LogEvent) map[string][]string {
+	var result map[string][]string
+	for _, e := range events {
+		if e.Event != log.EventTaskCompleted || e.BeadID == "" || len(e.Commits) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string][]string)
+		}
+		result[e.BeadID] = e.Commits
+	}
+	return result
+}
+
+// shortSHAs abbreviates commit SHAs to 7 characters for display.
+func shortSHAs(shas []string) []string {
+	short := make([]string, len(shas))
+	for i, sha := range shas {
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		short[i] = sha
+	}
+	return short
+}
+
+// countFirstTry returns how many beads passed on their first attempt and how
+// many beads have a recorded winning attempt, based on the latest
+// task_completed event per bead. Events without an attempt (e.g. beads
+// skipped as already applied) are ignored.
+func countFirstTry(events []log.LogEvent) (firstTry, attempted int) {
+	attempts := make(map[string]int)
+	for _, e := range events {
+		if e.Event != log.EventTaskCompleted || e.BeadID == "" || e.Attempt == 0 {
+			continue
+		}
+		attempts[e.BeadID] = e.Attempt
+	}
+	for _, a := range attempts {
+		if a == 1 {
+			firstTry++
+		}
+	}
+	return firstTry, len(attempts)
+}
+
+// formatMeta renders metadata as sorted "key=value" pairs joined by ", ".
+func formatMeta(meta map[string]string) string {
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+meta[k])
+	}
+	return strings.Join(pairs, ", ")
+}
+
 // formatDuration produces a human-readable duration string such as "5m 32s"
 // or "1h 12m 5s". Sub-second durations are shown as "< 1s".
 func formatDuration(d time.Duration) string {
@@ -0,0 +1,276 @@
+// summary.go writes a structured per-run summary and compares two of them.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/berth-dev/berth/internal/log"
+)
+
+// summaryFile is the name of the structured summary inside a run directory.
+const summaryFile = "summary.json"
+
+// runDirLayout is the timestamp format used for run directory names.
+const runDirLayout = "20060102-150405"
+
+// RunSummary is the machine-readable outcome of a single run, written next to
+// report.md so runs can be compared later.
+type RunSummary struct {
+	Branch     string        `json:"branch,omitempty"`
+	DurationMs int64         `json:"duration_ms"`
+	CostUSD    float64       `json:"cost_usd"`
+	Tokens     int           `json:"tokens"`
+	Beads      []BeadOutcome `json:"beads"`
+}
+
+// BeadOutcome is one bead's result within a RunSummary.
+type BeadOutcome struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Status   string  `json:"status"` // "completed" | "stuck" | "incomplete"
+	Attempts int     `json:"attempts,omitempty"`
+	Tokens   int     `json:"tokens,omitempty"`
+	CostUSD  float64 `json:"cost_usd,omitempty"`
+}
+
+// Duration returns the run's wall-clock duration.
+func (s *RunSummary) Duration() time.Duration {
+	return time.Duration(s.DurationMs) * time.Millisecond
+}
+
+// Count returns how many beads ended with the given status.
+func (s *RunSummary) Count(status string) int {
+	n := 0
+	for _, b := range s.Beads {
+		if b.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// BuildSummary derives a RunSummary from log events. Only events at or after
+// the run directory's timestamp are considered, so earlier runs sharing the
+// same log don't leak in; a resumed run keeps its original directory and is
+// summarized as a whole.
+func BuildSummary(runDir, branch string, events []log.LogEvent) *RunSummary {
+	events = eventsSince(events, runDir)
+
+	s := &RunSummary{Branch: branch, Beads: []BeadOutcome{}}
+	s.DurationMs = computeDuration(events).Milliseconds()
+
+	index := make(map[string]int)
+	bead := func(e log.LogEvent) *BeadOutcome {
+		i, ok := index[e.BeadID]
+		if !ok {
+			i = len(s.Beads)
+			index[e.BeadID] = i
+			s.Beads = append(s.Beads, BeadOutcome{ID: e.BeadID, Status: "incomplete"})
+		}
+		if e.Title != "" {
+			s.Beads[i].Title = e.Title
+		}
+		return &s.Beads[i]
+	}
+
+	for _, e := range events {
+		s.CostUSD += e.CostUSD
+		s.Tokens += e.Tokens
+		if e.BeadID == "" {
+			continue
+		}
+		b := bead(e)
+		b.CostUSD += e.CostUSD
+		b.Tokens += e.Tokens
+		switch e.Event {
+		case log.EventTaskCompleted:
+			b.Status = "completed"
+			if e.Attempt > 0 {
+				b.Attempts = e.Attempt
+			}
+		case log.EventTaskStuck:
+			b.Status = "stuck"
+		}
+	}
+	return s
+}
+
+// eventsSince drops events older than the run directory's timestamp. Events
+// are returned unchanged when the directory name isn't a run timestamp.
+func eventsSince(events []log.LogEvent, runDir string) []log.LogEvent {
+	start, err := time.ParseInLocation(runDirLayout, filepath.Base(runDir), time.Local)
+	if err != nil {
+		return events
+	}
+	var out []log.LogEvent
+	for _, e := range events {
+		if !e.Time.Before(start) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// RunCommits returns the commits each bead of the run in runDir recorded in
+// its task_completed event. Only events within the run's RunWindow are
+// considered.
+func RunCommits(runDir string, events []log.LogEvent) map[string][]string {
+	start, end := RunWindow(runDir)
+	filter := log.EventFilter{Since: start, Until: end}
+	var within []log.LogEvent
+	for _, e := range events {
+		if filter.Match(e) {
+			within = append(within, e)
+		}
+	}
+	return collectBeadCommits(within)
+}
+
+// RunWindow returns the span of time the run in runDir covers: from its
+// start to the start of the next run in the same runs directory. start is
+// zero when the directory name isn't a run timestamp, and end is zero when
+// no later run exists.
+func RunWindow(runDir string) (start, end time.Time) {
+	start, _ = time.ParseInLocation(runDirLayout, filepath.Base(runDir), time.Local)
+	end, _ = nextRunStart(runDir)
+	return start, end
+}
+
+// nextRunStart returns the timestamp of the earliest run directory started
+// after runDir, if there is one.
+func nextRunStart(runDir string) (time.Time, bool) {
+	entries, err := os.ReadDir(filepath.Dir(runDir))
+	if err != nil {
+		return time.Time{}, false
+	}
+	name := filepath.Base(runDir)
+	for _, e := range entries { // sorted by name, and so by start time
+		if !e.IsDir() || e.Name() <= name {
+			continue
+		}
+		if start, err := time.ParseInLocation(runDirLayout, e.Name(), time.Local); err == nil {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// WriteSummary writes s to {runDir}/summary.json.
+func WriteSummary(runDir string, s *RunSummary) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling summary: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, summaryFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing summary file: %w", err)
+	}
+	return nil
+}
+
+// ReadSummary reads {runDir}/summary.json.
+func ReadSummary(runDir string) (*RunSummary, error) {
+	data, err := os.ReadFile(filepath.Join(runDir, summaryFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading summary for %s: %w", runDir, err)
+	}
+	var s RunSummary
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing summary for %s: %w", runDir, err)
+	}
+	return &s, nil
+}
+
+// FormatRunDiff renders the differences between two runs: beads only in one
+// of them, beads whose status changed, and deltas for the run metrics. Bead
+// IDs are regenerated on every plan, so beads are matched by title.
+func FormatRunDiff(nameA, nameB string, a, b *RunSummary) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "Run diff: %s -> %s\n\n", nameA, nameB)
+
+	beadsA, beadsB := beadsByKey(a), beadsByKey(b)
+	keys := make(map[string]bool)
+	for k := range beadsA {
+		keys[k] = true
+	}
+	for k := range beadsB {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var lines []string
+	for _, k := range sorted {
+		ba, inA := beadsA[k]
+		bb, inB := beadsB[k]
+		switch {
+		case !inA:
+			lines = append(lines, fmt.Sprintf("  + %s (%s)", k, bb.Status))
+		case !inB:
+			lines = append(lines, fmt.Sprintf("  - %s (%s)", k, ba.Status))
+		case ba.Status != bb.Status:
+			lines = append(lines, fmt.Sprintf("  ~ %s: %s -> %s", k, ba.Status, bb.Status))
+		}
+	}
+	out.WriteString("Beads:\n")
+	if len(lines) == 0 {
+		out.WriteString("  (no changes)\n")
+	} else {
+		out.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+
+	out.WriteString("\nMetrics:\n")
+	countDelta := func(label string, x, y int) {
+		fmt.Fprintf(&out, "  %-10s %d -> %d (%+d)\n", label+":", x, y, y-x)
+	}
+	countDelta("Beads", len(a.Beads), len(b.Beads))
+	countDelta("Completed", a.Count("completed"), b.Count("completed"))
+	countDelta("Stuck", a.Count("stuck"), b.Count("stuck"))
+	countDelta("Tokens", a.Tokens, b.Tokens)
+	fmt.Fprintf(&out, "  %-10s $%.2f -> $%.2f (%s)\n", "Cost:", a.CostUSD, b.CostUSD, signedDollars(b.CostUSD-a.CostUSD))
+	fmt.Fprintf(&out, "  %-10s %s -> %s (%s)\n", "Duration:",
+		formatDuration(a.Duration()), formatDuration(b.Duration()), signedDuration(b.Duration()-a.Duration()))
+
+	return out.String()
+}
+
+// beadsByKey indexes a summary's beads by title, falling back to ID.
+func beadsByKey(s *RunSummary) map[string]BeadOutcome {
+	m := make(map[string]BeadOutcome, len(s.Beads))
+	for _, b := range s.Beads {
+		key := b.Title
+		if key == "" {
+			key = b.ID
+		}
+		m[key] = b
+	}
+	return m
+}
+
+// signedDollars formats a cost delta as "+$0.25" or "-$0.25".
+func signedDollars(d float64) string {
+	if d < 0 {
+		return fmt.Sprintf("-$%.2f", -d)
+	}
+	return fmt.Sprintf("+$%.2f", d)
+}
+
+// signedDuration formats a duration delta as "+1m 5s" or "-30s".
+func signedDuration(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "0s"
+	case d < 0:
+		return "-" + formatDuration(-d)
+	default:
+		return "+" + formatDuration(d)
+	}
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/log"
+)
+
+func TestBuildSummaryScopesEventsToRun(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)
+	at := func(d time.Duration) time.Time { return start.Add(d).UTC() }
+	events := []log.LogEvent{
+		{Time: at(-time.Hour), Event: log.EventTaskCompleted, BeadID: "bt-0", Title: "Earlier run"},
+		{Time: at(0), Event: log.EventRunStarted},
+		{Time: at(time.Minute), Event: log.EventClaudeUsage, BeadID: "bt-1", Title: "Add form", Tokens: 500, CostUSD: 0.1},
+		{Time: at(2 * time.Minute), Event: log.EventClaudeUsage, BeadID: "bt-1", Title: "Add form", Tokens: 300, CostUSD: 0.05},
+		{Time: at(3 * time.Minute), Event: log.EventTaskCompleted, BeadID: "bt-1", Title: "Add form", Attempt: 2},
+		{Time: at(4 * time.Minute), Event: log.EventTaskStuck, BeadID: "bt-2", Title: "Wire API"},
+		{Time: at(5 * time.Minute), Event: log.EventRunComplete},
+	}
+
+	s := BuildSummary("/proj/.berth/runs/20260301-100000", "berth/x", events)
+
+	if len(s.Beads) != 2 {
+		t.Fatalf("beads = %+v, want 2 from this run only", s.Beads)
+	}
+	if b := s.Beads[0]; b.Status != "completed" || b.Attempts != 2 || b.Tokens != 800 {
+		t.Errorf("bt-1 = %+v, want completed on attempt 2 with 800 tokens", b)
+	}
+	if s.Beads[1].Status != "stuck" {
+		t.Errorf("bt-2 status = %q, want stuck", s.Beads[1].Status)
+	}
+	if s.Tokens != 800 || s.Duration() != 5*time.Minute {
+		t.Errorf("tokens = %d, duration = %s; want 800 and 5m", s.Tokens, s.Duration())
+	}
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"database/sql"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +15,12 @@ type Store struct {
 	db *sql.DB
 }
 
+// DefaultPath returns the session database location for a project:
+// .berth/sessions.db under projectRoot.
+func DefaultPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".berth", "sessions.db")
+}
+
 // NewStore opens the SQLite database at dbPath and creates tables if they don't exist.
 func NewStore(dbPath string) (*Store, error) {
 	db, err := sql.Open("sqlite3", dbPath)
@@ -162,6 +169,30 @@ func (s *Store) GetLatestActive(project string) (*Session, error) {
 	return &sess, nil
 }
 
+// GetActiveForTask returns the most recently updated active session of the
+// given project for task, or nil when there is none.
+func (s *Store) GetActiveForTask(project, task string) (*Session, error) {
+	row := s.db.QueryRow(
+		`SELECT id, project, task, status, created_at, updated_at
+		 FROM sessions
+		 WHERE project = ? AND task = ? AND status = 'active'
+		 ORDER BY updated_at DESC
+		 LIMIT 1`,
+		project, task,
+	)
+
+	var sess Session
+	err := row.Scan(&sess.ID, &sess.Project, &sess.Task, &sess.Status, &sess.CreatedAt, &sess.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan session: %w", err)
+	}
+
+	return &sess, nil
+}
+
 // ListSessions returns summaries of the most recent sessions.
 func (s *Store) ListSessions(limit int) ([]Summary, error) {
 	rows, err := s.db.Query(
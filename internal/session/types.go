@@ -31,6 +31,14 @@ type Answer struct {
 	Timestamp  time.Time
 }
 
+// Bead statuses recorded in beads_state during execution.
+const (
+	BeadInProgress = "in_progress"
+	BeadCompleted  = "completed"
+	BeadFailed     = "failed"
+	BeadSkipped    = "skipped"
+)
+
 // BeadState represents the execution state of a bead within a session.
 type BeadState struct {
 	ID         int
@@ -0,0 +1,33 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// FakeScript writes a POSIX shell script called name, running script, into a
+// fresh temporary directory and returns its path. The test is skipped on
+// Windows, where the script cannot run.
+func FakeScript(t *testing.T, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skipf("fake %s script requires a POSIX shell", name)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("writing fake %s: %v", name, err)
+	}
+	return path
+}
+
+// FakeCommand is FakeScript with the script's directory put first on PATH
+// for the rest of the test, so it replaces a real command such as claude or
+// bd.
+func FakeCommand(t *testing.T, name, script string) string {
+	t.Helper()
+	path := FakeScript(t, name, script)
+	t.Setenv("PATH", filepath.Dir(path)+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return path
+}
@@ -0,0 +1,203 @@
+// Package trace records OpenTelemetry-style spans for a berth run.
+// Tracing is off until Init is called; every function and *Span method is a
+// no-op while it is off, so call sites need no guards.
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// flushThreshold is how many finished spans are buffered before an export.
+const flushThreshold = 100
+
+// Attr is a single span attribute.
+type Attr struct {
+	Key   string
+	Value any // string, int, int64, float64 or bool
+}
+
+// String returns a string attribute.
+func String(key, value string) Attr { return Attr{Key: key, Value: value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attr { return Attr{Key: key, Value: value} }
+
+// Float returns a floating-point attribute.
+func Float(key string, value float64) Attr { return Attr{Key: key, Value: value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attr { return Attr{Key: key, Value: value} }
+
+// Span is a timed operation within a run. A nil *Span is valid and ignores
+// every call.
+type Span struct {
+	TraceID   string
+	SpanID    string
+	ParentID  string
+	Name      string
+	StartTime time.Time
+	EndTime   time.Time
+	Attrs     []Attr
+	Err       string // non-empty marks the span as failed
+
+	tracer *tracer
+}
+
+// Exporter ships finished spans to a backend.
+type Exporter interface {
+	Export(spans []*Span) error
+}
+
+// tracer holds the active run's root span and the finished-span buffer.
+type tracer struct {
+	exporter Exporter
+	root     *Span
+
+	mu      sync.Mutex
+	pending []*Span
+}
+
+var (
+	activeMu sync.Mutex
+	active   *tracer
+)
+
+// Init enables tracing for this process, exporting through exporter, and
+// starts the run's root span. Call Shutdown to end it and flush.
+func Init(exporter Exporter, rootName string, attrs ...Attr) {
+	t := &tracer{exporter: exporter}
+	t.root = t.newSpan(rootName, newID(16), "", attrs)
+
+	activeMu.Lock()
+	active = t
+	activeMu.Unlock()
+}
+
+// Shutdown ends the root span, exports everything still buffered, and turns
+// tracing off. Returns the export error, if any.
+func Shutdown() error {
+	activeMu.Lock()
+	t := active
+	active = nil
+	activeMu.Unlock()
+
+	if t == nil {
+		return nil
+	}
+	t.root.End()
+	return t.flush()
+}
+
+// Start begins a span directly under the run's root span.
+func Start(name string, attrs ...Attr) *Span {
+	activeMu.Lock()
+	t := active
+	activeMu.Unlock()
+
+	if t == nil {
+		return nil
+	}
+	return t.root.Child(name, attrs...)
+}
+
+// Child begins a span nested under s.
+func (s *Span) Child(name string, attrs ...Attr) *Span {
+	if s == nil {
+		return nil
+	}
+	return s.tracer.newSpan(name, s.TraceID, s.SpanID, attrs)
+}
+
+// Set adds attributes to s.
+func (s *Span) Set(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.Attrs = append(s.Attrs, attrs...)
+}
+
+// SetError marks s as failed with err's message. A nil err is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.Err = err.Error()
+}
+
+// End finishes s and queues it for export.
+func (s *Span) End() {
+	if s == nil || !s.EndTime.IsZero() {
+		return
+	}
+	s.EndTime = time.Now()
+	s.tracer.finish(s)
+}
+
+func (t *tracer) newSpan(name, traceID, parentID string, attrs []Attr) *Span {
+	return &Span{
+		TraceID:   traceID,
+		SpanID:    newID(8),
+		ParentID:  parentID,
+		Name:      name,
+		StartTime: time.Now(),
+		Attrs:     append([]Attr(nil), attrs...),
+		tracer:    t,
+	}
+}
+
+// finish buffers a finished span, exporting once the buffer is large enough
+// that long runs don't hold every span in memory.
+func (t *tracer) finish(s *Span) {
+	t.mu.Lock()
+	t.pending = append(t.pending, s)
+	full := len(t.pending) >= flushThreshold
+	t.mu.Unlock()
+
+	if full {
+		_ = t.flush()
+	}
+}
+
+// flush exports the buffered spans.
+func (t *tracer) flush() error {
+	t.mu.Lock()
+	spans := t.pending
+	t.pending = nil
+	t.mu.Unlock()
+
+	if len(spans) == 0 {
+		return nil
+	}
+	return t.exporter.Export(spans)
+}
+
+// newID returns n random bytes hex-encoded, the OTLP trace/span ID format.
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// MemoryExporter keeps exported spans in memory. Used by tests.
+type MemoryExporter struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// Export implements Exporter.
+func (m *MemoryExporter) Export(spans []*Span) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spans = append(m.spans, spans...)
+	return nil
+}
+
+// Spans returns the spans exported so far.
+func (m *MemoryExporter) Spans() []*Span {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Span(nil), m.spans...)
+}
@@ -0,0 +1,96 @@
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSpansExportedToMemoryExporter(t *testing.T) {
+	exp := &MemoryExporter{}
+	Init(exp, "berth.run", String("branch", "berth/auth"))
+
+	bead := Start("bead", String("bead.id", "bt-1"))
+	attempt := bead.Child("attempt", Int("attempt", 1))
+	attempt.Set(Int("tokens", 1200), String("outcome", "verify_failed"))
+	attempt.SetError(errors.New("tests failed"))
+	attempt.End()
+	bead.Set(Bool("passed", false))
+	bead.End()
+	bead.End() // ending twice must not export twice
+
+	if err := Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	spans := exp.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("exported %d spans, want 3 (attempt, bead, root)", len(spans))
+	}
+	byName := make(map[string]*Span)
+	for _, s := range spans {
+		byName[s.Name] = s
+		if s.TraceID != spans[0].TraceID {
+			t.Errorf("span %s has trace %s, want one trace per run", s.Name, s.TraceID)
+		}
+		if s.EndTime.Before(s.StartTime) {
+			t.Errorf("span %s ends before it starts", s.Name)
+		}
+	}
+	root, b, a := byName["berth.run"], byName["bead"], byName["attempt"]
+	if root == nil || b == nil || a == nil {
+		t.Fatalf("missing spans: %v", byName)
+	}
+	if root.ParentID != "" || b.ParentID != root.SpanID || a.ParentID != b.SpanID {
+		t.Errorf("parent chain broken: root=%q bead->%q attempt->%q", root.SpanID, b.ParentID, a.ParentID)
+	}
+	if a.Err != "tests failed" || len(a.Attrs) != 3 {
+		t.Errorf("attempt span = %+v", a)
+	}
+}
+
+func TestTracingDisabledIsNoop(t *testing.T) {
+	span := Start("bead")
+	if span != nil {
+		t.Fatal("Start without Init should return nil")
+	}
+	// Every method tolerates the nil span.
+	child := span.Child("attempt")
+	child.Set(Int("attempt", 1))
+	child.SetError(errors.New("boom"))
+	child.End()
+	span.End()
+	if err := Shutdown(); err != nil {
+		t.Errorf("Shutdown without Init: %v", err)
+	}
+}
+
+func TestOTLPExporterPostsJSON(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("path = %s, want /v1/traces", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	Init(NewOTLPExporter(srv.URL), "berth.run")
+	Start("plan", Int("beads", 4)).End()
+	if err := Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	resourceSpans, _ := body["resourceSpans"].([]any)
+	if len(resourceSpans) != 1 {
+		t.Fatalf("resourceSpans = %v", body["resourceSpans"])
+	}
+	scope := resourceSpans[0].(map[string]any)["scopeSpans"].([]any)[0].(map[string]any)
+	if spans := scope["spans"].([]any); len(spans) != 2 {
+		t.Errorf("exported %d spans, want 2", len(spans))
+	}
+}
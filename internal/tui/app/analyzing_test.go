@@ -0,0 +1,98 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/tui"
+	"github.com/berth-dev/berth/internal/understand"
+)
+
+func TestEscCancelsAnalyzingAndKeepsTask(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.model.State = tui.StateHome
+	for _, r := range "add search" {
+		a.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+
+	a.model.State = tui.StateAnalyzing
+	ctx := a.analyzingContext()
+
+	a.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if ctx.Err() == nil {
+		t.Error("Esc did not cancel the in-flight command")
+	}
+	if a.model.State != tui.StateHome {
+		t.Fatalf("state = %v, want StateHome", a.model.State)
+	}
+	if !strings.Contains(a.homeView.View(), "add search") {
+		t.Error("task text was not preserved on the home screen")
+	}
+
+	// The canceled command's reply is dropped without an error.
+	a.Update(tui.OperationCanceledMsg{Operation: "plan"})
+	if a.model.Err != nil {
+		t.Errorf("Err = %v after cancellation, want nil", a.model.Err)
+	}
+}
+
+func TestEscIgnoredWhenNothingToCancel(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.model.State = tui.StateAnalyzing
+
+	a.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if a.model.State != tui.StateAnalyzing {
+		t.Errorf("state = %v, want StateAnalyzing (e.g. init can't be canceled)", a.model.State)
+	}
+}
+
+func TestResultReleasesAnalyzingContext(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.model.State = tui.StateAnalyzing
+	a.analyzingContext()
+
+	a.Update(tui.PlanErrorMsg{Err: errors.New("claude failed")})
+	if a.cancelAnalyzing != nil {
+		t.Error("cancel func kept after the command reported back")
+	}
+}
+
+func TestUnrelatedMessagesKeepAnalyzingRunning(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.model.State = tui.StateAnalyzing
+	ctx := a.analyzingContext()
+
+	for _, msg := range []tea.Msg{tea.FocusMsg{}, tea.WindowSizeMsg{Width: 80, Height: 24}, tui.ToastExpireMsg{}} {
+		a.Update(msg)
+	}
+	if ctx.Err() != nil {
+		t.Error("an unrelated message canceled the in-flight command")
+	}
+	if a.model.State != tui.StateAnalyzing {
+		t.Errorf("state = %v, want StateAnalyzing", a.model.State)
+	}
+}
+
+func TestInterviewShowsSessionGraphSummary(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.model.State = tui.StateAnalyzing
+	a.analyzingContext()
+
+	session := &understand.InterviewSession{CurrentRound: 1, GraphSummary: "12 source files indexed.\n"}
+	a.Update(tui.InterviewReadyMsg{
+		Session:   session,
+		Questions: []tui.Question{{ID: "q1", Text: "Which database?"}},
+		Round:     1,
+	})
+
+	if a.model.GraphSummary != session.GraphSummary {
+		t.Errorf("GraphSummary = %q, want the session's", a.model.GraphSummary)
+	}
+	if !strings.Contains(a.interviewView.View(), "12 source files indexed.") {
+		t.Error("codebase context panel does not show the KG summary")
+	}
+}
@@ -0,0 +1,164 @@
+// answers.go persists interview answers to the session store as they are
+// given, so a restarted TUI working on the same task picks up where the
+// user left off.
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/berth-dev/berth/internal/session"
+	"github.com/berth-dev/berth/internal/tui"
+	"github.com/berth-dev/berth/internal/understand"
+)
+
+// storedAnswer is the JSON form of an answer in the session store.
+type storedAnswer struct {
+	Value  string   `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// answerKey is the question ID an answer is stored under. Claude numbers
+// the questions of every round afresh, so an ID says nothing about which
+// question it was: the key is the round plus a hash of the question text,
+// falling back to the ID for a question without text.
+func answerKey(round int, q tui.Question) string {
+	if q.Text == "" {
+		return fmt.Sprintf("round%d/%s", round, q.ID)
+	}
+	sum := sha256.Sum256([]byte(q.Text))
+	return fmt.Sprintf("round%d/%s", round, hex.EncodeToString(sum[:8]))
+}
+
+// currentQuestion returns the question of the current interview with the
+// given ID, or one with only the ID when there is none.
+func (a *App) currentQuestion(id string) tui.Question {
+	for _, q := range a.model.Questions {
+		if q.ID == id {
+			return q
+		}
+	}
+	return tui.Question{ID: id}
+}
+
+// interviewRound returns the round of the current interview, 1 before the
+// interview has started.
+func (a *App) interviewRound() int {
+	if a.model.InterviewSession == nil {
+		return 1
+	}
+	return a.model.InterviewSession.CurrentRound
+}
+
+// attachSession opens the project's session store and attaches the model to
+// its active session for the same task, loading the answers given in it. Otherwise it starts a new session for the task.
+// It warns and leaves the model without a session when the store is
+// unavailable.
+func (a *App) attachSession(task string) {
+	a.priorAnswers = nil
+	store, ok := a.model.Store.(*session.Store)
+	if !ok || store == nil {
+		var err error
+		store, err = session.NewStore(session.DefaultPath(a.model.ProjectRoot))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: session store unavailable, answers will not be saved: %v\n", err)
+			return
+		}
+		a.model.Store = store
+	}
+
+	var project string
+	if a.model.Cfg != nil {
+		project = a.model.Cfg.Project.Name
+	}
+	sess, err := store.GetActiveForTask(project, task)
+	if err == nil && sess == nil {
+		sess, err = store.CreateSession(project, task)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: no session for interview answers: %v\n", err)
+		a.model.Session = nil
+		return
+	}
+	a.model.Session = sess
+
+	saved, err := store.GetAnswers(sess.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load saved answers: %v\n", err)
+		return
+	}
+	a.priorAnswers = make(map[string]tui.Answer, len(saved))
+	for _, ans := range saved {
+		var stored storedAnswer
+		if err := json.Unmarshal([]byte(ans.Answer), &stored); err != nil {
+			continue
+		}
+		// Later answers to the same question replace earlier ones.
+		a.priorAnswers[ans.QuestionID] = tui.Answer{Value: stored.Value, Values: stored.Values}
+	}
+}
+
+// restoredAnswers returns the saved answers to questions in the current
+// round.
+func (a *App) restoredAnswers(questions []tui.Question) []tui.Answer {
+	var answers []tui.Answer
+	round := a.interviewRound()
+	for _, q := range questions {
+		if ans, ok := a.priorAnswers[answerKey(round, q)]; ok {
+			ans.ID = q.ID
+			answers = append(answers, ans)
+		}
+	}
+	return answers
+}
+
+// acceptDefaults reports whether understand.accept_defaults is set.
+func (a *App) acceptDefaults() bool {
+	return a.model.Cfg != nil && a.model.Cfg.Understand.AcceptDefaults
+}
+
+// defaultAnswers answers the questions not in answered with
+// understand.DefaultAnswer, in question order. Questions without options
+// are left for the user.
+func defaultAnswers(questions []tui.Question, answered []tui.Answer) []tui.Answer {
+	done := make(map[string]bool, len(answered))
+	for _, ans := range answered {
+		done[ans.ID] = true
+	}
+	var answers []tui.Answer
+	for _, q := range questions {
+		if done[q.ID] {
+			continue
+		}
+		uq := understand.Question{ID: q.ID, MultiSelect: q.MultiSelect, Ordered: q.Ordered}
+		for _, o := range q.Options {
+			uq.Options = append(uq.Options, understand.Option{Key: o.Key, Label: o.Label, Recommended: o.Recommended})
+		}
+		if ans, ok := understand.DefaultAnswer(uq); ok {
+			answers = append(answers, tui.Answer{ID: ans.ID, Value: ans.Value, Values: ans.Values})
+		}
+	}
+	return answers
+}
+
+// saveAnswer saves an answer to the attached session, if any.
+func (a *App) saveAnswer(ans tui.Answer) {
+	store, ok := a.model.Store.(*session.Store)
+	if !ok || store == nil {
+		return
+	}
+	sess, ok := a.model.Session.(*session.Session)
+	if !ok || sess == nil {
+		return
+	}
+	data, err := json.Marshal(storedAnswer{Value: ans.Value, Values: ans.Values})
+	if err != nil {
+		return
+	}
+	if err := store.SaveAnswer(sess.ID, answerKey(a.interviewRound(), a.currentQuestion(ans.ID)), string(data)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save answer to %s: %v\n", ans.ID, err)
+	}
+}
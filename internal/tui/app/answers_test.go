@@ -0,0 +1,144 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/session"
+	"github.com/berth-dev/berth/internal/tui"
+	"github.com/berth-dev/berth/internal/tui/views"
+	"github.com/berth-dev/berth/internal/understand"
+)
+
+func closeStore(t *testing.T, a *App) {
+	t.Helper()
+	if store, ok := a.model.Store.(*session.Store); ok {
+		_ = store.Close()
+	}
+}
+
+// berthRoot returns a project root with the .berth directory the session
+// store lives in.
+func berthRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".berth"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestInterviewAnswersSurviveRestart(t *testing.T) {
+	root := berthRoot(t)
+	questions := []tui.Question{{ID: "q1", Text: "Which database?"}, {ID: "q2", Text: "Which auth provider?"}}
+
+	first := New(config.DefaultConfig(), root)
+	first.attachSession("Add auth")
+	first.model.InterviewSession = &understand.InterviewSession{CurrentRound: 1}
+	first.transitionToInterview(questions)
+	first.Update(tui.AnswerMsg{QuestionID: "q1", Value: "Postgres"})
+	first.Update(tui.AnswerMsg{QuestionID: "q1", Value: "SQLite"})
+	if len(first.model.Answers) != 1 || first.model.Answers[0].Value != "SQLite" {
+		t.Errorf("Answers = %+v, want q1=SQLite only", first.model.Answers)
+	}
+	closeStore(t, first)
+
+	second := New(config.DefaultConfig(), root)
+	second.attachSession("Add auth")
+	defer closeStore(t, second)
+	second.model.InterviewSession = &understand.InterviewSession{CurrentRound: 1}
+	second.transitionToInterview(questions)
+	if len(second.model.Answers) != 1 || second.model.Answers[0].Value != "SQLite" {
+		t.Fatalf("restored answers = %+v, want q1=SQLite", second.model.Answers)
+	}
+
+	// A resumed interview can ask different questions under the same IDs.
+	second.transitionToInterview([]tui.Question{{ID: "q1", Text: "Which cache?"}, {ID: "q2", Text: "Which database?"}})
+	if len(second.model.Answers) != 1 || second.model.Answers[0].ID != "q2" || second.model.Answers[0].Value != "SQLite" {
+		t.Errorf("restored answers = %+v, want q2=SQLite", second.model.Answers)
+	}
+
+	// The same question in a later round is asked anew.
+	second.model.InterviewSession.CurrentRound = 2
+	second.transitionToInterview(questions)
+	if len(second.model.Answers) != 0 {
+		t.Errorf("round 2 restored %+v, want nothing", second.model.Answers)
+	}
+}
+
+func TestInterviewAnswersNotRestoredForOtherTask(t *testing.T) {
+	root := berthRoot(t)
+	first := New(config.DefaultConfig(), root)
+	first.attachSession("Add auth")
+	first.model.InterviewSession = &understand.InterviewSession{CurrentRound: 1}
+	first.transitionToInterview([]tui.Question{{ID: "db"}})
+	first.Update(tui.AnswerMsg{QuestionID: "db", Value: "SQLite"})
+	closeStore(t, first)
+
+	second := New(config.DefaultConfig(), root)
+	second.attachSession("Add billing")
+	defer closeStore(t, second)
+	if len(second.priorAnswers) != 0 {
+		t.Errorf("priorAnswers = %+v, want none for a different task", second.priorAnswers)
+	}
+}
+
+func TestInterviewAcceptsDefaults(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Understand.AcceptDefaults = true
+	a := New(cfg, t.TempDir())
+	a.model.State = tui.StateAnalyzing
+	a.analyzingContext()
+
+	choices := []tui.Question{
+		{ID: "q1", Text: "Which database?", Options: []tui.Option{{Label: "Postgres"}, {Label: "SQLite", Recommended: true}}},
+		{ID: "q2", Text: "Rank the goals", Ordered: true, Options: []tui.Option{{Label: "Speed"}, {Label: "Safety", Recommended: true}}},
+	}
+	_, cmd := a.Update(tui.InterviewReadyMsg{Session: &understand.InterviewSession{CurrentRound: 1}, Questions: choices, Round: 1})
+	want := []tui.Answer{{ID: "q1", Value: "SQLite"}, {ID: "q2", Values: []string{"Safety", "Speed"}}}
+	if !reflect.DeepEqual(a.model.Answers, want) {
+		t.Errorf("Answers = %+v, want %+v", a.model.Answers, want)
+	}
+	if cmd == nil {
+		t.Fatal("no command after every question was answered by default")
+	}
+	if _, ok := cmd().(tui.SkipInterviewMsg); !ok {
+		t.Error("answers were not submitted right away")
+	}
+
+	// A question without options still waits for the user.
+	a.model.State = tui.StateAnalyzing
+	typed := append(choices, tui.Question{ID: "q3", Text: "Anything else?", AllowCustom: true})
+	a.Update(tui.InterviewQuestionsMsg{Questions: typed})
+	if a.model.State != tui.StateInterview || len(a.model.Answers) != 2 {
+		t.Errorf("state = %v, answers = %+v; want the interview open with q1 and q2 answered", a.model.State, a.model.Answers)
+	}
+}
+
+func TestInterviewChatRecordsClaudeReply(t *testing.T) {
+	a := New(config.DefaultConfig(), berthRoot(t))
+	a.attachSession("Add auth")
+	defer closeStore(t, a)
+	a.model.InterviewSession = &understand.InterviewSession{CurrentRound: 1}
+	a.transitionToInterview([]tui.Question{{ID: "q1", Text: "Which database?"}})
+	a.Update(tui.EnterChatMsg{QuestionID: "q1"})
+
+	if _, cmd := a.Update(views.SendChatMsg{Content: "What does SQLite cost us?"}); cmd == nil {
+		t.Fatal("sending a chat message asked Claude nothing")
+	}
+	a.Update(tui.InterviewChatReplyMsg{Err: errors.New("claude timed out")})
+	a.Update(tui.InterviewChatReplyMsg{Content: "Concurrent writes."})
+
+	store := a.model.Store.(*session.Store)
+	msgs, err := store.GetMessages(a.model.Session.(*session.Session).ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 || msgs[0].Role != "user" || msgs[1].Role != "assistant" || msgs[1].Content != "Concurrent writes." {
+		t.Errorf("recorded messages = %+v, want the question and Claude's reply only", msgs)
+	}
+}
@@ -2,6 +2,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,13 +13,16 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/execute"
+	"github.com/berth-dev/berth/internal/plan"
 	"github.com/berth-dev/berth/internal/session"
 	"github.com/berth-dev/berth/internal/tui"
 	"github.com/berth-dev/berth/internal/tui/commands"
 	"github.com/berth-dev/berth/internal/tui/terminal"
 	"github.com/berth-dev/berth/internal/tui/views"
+	"github.com/berth-dev/berth/internal/understand"
 )
 
 const analyzingTimeout = 5 * time.Minute
@@ -30,6 +34,7 @@ type App struct {
 	// View models
 	terminalSetupView views.TerminalSetupModel
 	initView          views.InitModel
+	resumeView        views.ResumeModel
 	homeView          views.HomeModel
 	interviewView     views.InterviewModel
 	chatView          views.ChatModel
@@ -42,16 +47,60 @@ type App struct {
 
 	// Keyboard enhancement support (detected once at startup)
 	hasKeyboardEnhancements bool
+
+	// Transient notifications, oldest first
+	toasts []toast
+
+	// Cancels the in-flight interview or plan command while analyzing
+	cancelAnalyzing context.CancelFunc
+
+	// Output streamed by the in-flight interview round, shown under the
+	// analyzing spinner
+	analyzingOutput chan string
+	analyzingView   views.AnalyzingOutputModel
+
+	// Interview question the chat view was opened on
+	chatQuestionID string
+
+	// Bead being discussed in the chat view during execution, and the
+	// guidance the user has typed so far
+	chatBeadID   string
+	beadGuidance []string
+
+	// How the approved plan would execute, shown instead of running it in
+	// dry-run mode
+	dryRunSummary string
+
+	// Answers saved in the attached session, keyed by answerKey
+	priorAnswers map[string]tui.Answer
+
+	// Interrupted run offered for resume at startup, and its checkpoint
+	resumeRunDir     string
+	resumeCheckpoint *execute.Checkpoint
 }
 
 // New creates a new App with the given configuration.
 func New(cfg *config.Config, projectRoot string) *App {
 	model := tui.NewModel(cfg, projectRoot)
 
-	return &App{
+	a := &App{
 		model:    model,
 		homeView: views.NewHomeModel(nil, model.Width, model.Height),
 	}
+	a.homeView.SetSubmitKey(a.submitKey())
+	return a
+}
+
+// SetDryRun makes plan approval show how the plan would execute instead of
+// creating beads and running them.
+func (a *App) SetDryRun(dryRun bool) {
+	a.model.DryRun = dryRun
+}
+
+// SetExplainPlan makes the approval screen annotate each bead with its
+// dependencies, dependents, and execution group.
+func (a *App) SetExplainPlan(explain bool) {
+	a.model.ExplainPlan = explain
 }
 
 // Init returns the initial command for the TUI.
@@ -73,6 +122,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch a.model.State {
 		case tui.StateTerminalSetup:
 			a.terminalSetupView, cmd = a.terminalSetupView.Update(msg)
+		case tui.StateResume:
+			a.resumeView, cmd = a.resumeView.Update(msg)
 		case tui.StateHome:
 			a.homeView, cmd = a.homeView.Update(msg)
 		case tui.StateInterview:
@@ -115,6 +166,13 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.model.CtrlCPending = false
 		return a, nil
 
+	case tui.NotifyMsg:
+		return a, a.notify(msg.Text, msg.Level)
+
+	case tui.ToastExpireMsg:
+		a.expireToasts(time.Now())
+		return a, nil
+
 	case tea.KeyboardEnhancementsMsg:
 		// Store keyboard enhancement support at app level
 		// This allows us to propagate to views created later
@@ -138,6 +196,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tui.StateInit:
 		return a.updateInit(msg)
 
+	case tui.StateResume:
+		return a.updateResume(msg)
+
 	case tui.StateHome:
 		return a.updateHome(msg)
 
@@ -190,6 +251,10 @@ func (a *App) View() tea.View {
 		content = a.initView.View()
 		needsCentering = true
 
+	case tui.StateResume:
+		content = a.resumeView.View()
+		needsCentering = true
+
 	case tui.StateHome:
 		content = a.homeView.View()
 		needsCentering = true
@@ -230,6 +295,11 @@ func (a *App) View() tea.View {
 		content = lipgloss.JoinVertical(lipgloss.Center, content, "", tabBar)
 	}
 
+	// Show transient notifications below the view
+	if toasts := a.renderToasts(); toasts != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, "", toasts)
+	}
+
 	// Center content both horizontally and vertically for applicable states
 	if needsCentering {
 		content = a.centerContent(content)
@@ -303,7 +373,11 @@ func (a *App) proceedFromInitCheck(msg tui.InitCheckMsg) (tea.Model, tea.Cmd) {
 		return a, a.initView.Init()
 	}
 
-	// Project already initialized - go to home
+	// Project already initialized - offer to resume an interrupted run,
+	// else go to home
+	if ok, cmd := a.offerResume(); ok {
+		return a, cmd
+	}
 	a.model.State = tui.StateHome
 	return a, a.homeView.Init()
 }
@@ -365,9 +439,7 @@ func (a *App) updateHome(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case views.ResumeSessionMsg:
 		// Session resume is not yet implemented
-		a.model.Err = fmt.Errorf("session resume not yet available (session: %s)", msg.SessionID)
-		a.homeView.Err = a.model.Err
-		return a, nil
+		return a, a.notify(fmt.Sprintf("Session resume not yet available (session: %s)", msg.SessionID), tui.ToastWarning)
 	}
 
 	return a, cmd
@@ -376,7 +448,42 @@ func (a *App) updateHome(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (a *App) updateAnalyzing(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	switch msg.(type) {
+	case tui.InterviewStartedMsg, tui.InterviewQuestionsMsg, tui.InterviewReadyMsg,
+		tui.InterviewCompleteMsg, tui.InterviewErrorMsg, tui.PlanGeneratedMsg,
+		tui.PlanErrorMsg, tui.ExpandBeadErrorMsg, tui.OperationTimeoutMsg:
+		// The command reported back, so there is nothing left to cancel.
+		// Anything else (ticks, focus, resizes, toasts) leaves it running.
+		a.releaseAnalyzing()
+	}
+
 	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if msg.String() == tui.KeyEsc && a.cancelAnalyzing != nil {
+			// Abort generation and go back to the task input, which still
+			// holds the task text.
+			a.releaseAnalyzing()
+			a.model.State = tui.StateHome
+			a.model.ActiveTab = tui.TabChat
+			a.model.AnalyzingStartTime = time.Time{}
+			return a, tea.Batch(a.homeView.Init(), a.notify("Canceled", tui.ToastInfo))
+		}
+		return a, nil
+
+	case tui.OperationCanceledMsg:
+		// Sent by a command canceled above; already handled.
+		return a, nil
+
+	case tui.AnalyzingOutputMsg:
+		// Output from an earlier round stops being polled.
+		if a.analyzingOutput == nil || msg.Source != (<-chan string)(a.analyzingOutput) {
+			return a, nil
+		}
+		if msg.Text != "" {
+			a.analyzingView.Append(msg.Text)
+		}
+		return a, commands.ListenAnalyzingCmd(a.analyzingOutput)
+
 	case spinner.TickMsg:
 		a.model.Spinner, cmd = a.model.Spinner.Update(msg)
 		if !a.model.AnalyzingStartTime.IsZero() && time.Since(a.model.AnalyzingStartTime) > analyzingTimeout {
@@ -416,24 +523,21 @@ func (a *App) updateAnalyzing(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.homeView.Init()
 
 	case tui.AnalysisCompleteMsg:
-		a.transitionToInterview(msg.Questions)
-		return a, a.interviewView.Init()
+		return a, a.startInterview(msg.Questions)
 
 	case tui.InterviewStartedMsg:
-		a.model.InterviewSession = msg.Session
+		a.setInterviewSession(msg.Session)
 		return a, nil
 
 	case tui.InterviewQuestionsMsg:
 		// Transition to interview state with questions
-		a.transitionToInterview(msg.Questions)
-		return a, a.interviewView.Init()
+		return a, a.startInterview(msg.Questions)
 
 	case tui.InterviewReadyMsg:
 		// Composite message: store session and transition to interview in one step.
 		// This replaces the tea.Batch()() pattern that was causing context issues.
-		a.model.InterviewSession = msg.Session
-		a.transitionToInterview(msg.Questions)
-		return a, a.interviewView.Init()
+		a.setInterviewSession(msg.Session)
+		return a, a.startInterview(msg.Questions)
 
 	case tui.InterviewCompleteMsg:
 		a.model.Requirements = msg.Requirements
@@ -442,6 +546,7 @@ func (a *App) updateAnalyzing(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, tea.Batch(
 			a.model.Spinner.Tick,
 			commands.GeneratePlanCmd(
+				a.analyzingContext(),
 				*a.model.Cfg,
 				msg.Requirements,
 				a.model.GraphSummary,
@@ -467,8 +572,10 @@ func (a *App) updateAnalyzing(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		a.transitionToExecuting(beads)
 
-		// Create output channel for streaming events
+		// Create output channel for streaming events and control channel
+		// for pause and resume
 		a.model.OutputChan = make(chan execute.StreamEvent, 100)
+		a.model.ControlChan = make(chan execute.ControlSignal, 1)
 
 		// Compute branch name from plan title or use default
 		branchName := a.model.BranchName
@@ -483,10 +590,23 @@ func (a *App) updateAnalyzing(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.model.ProjectRoot,
 				a.model.RunDir,
 				branchName,
+				nil, // fresh execution, no checkpoint
 				a.model.OutputChan,
+				a.model.ControlChan,
 			),
 		)
 
+	case tui.ResumeReadyMsg:
+		// Interrupted run is ready; resume it from its checkpoint.
+		return a, a.startResumedExecution(msg.Beads)
+
+	case tui.ResumeErrorMsg:
+		a.model.Err = msg.Err
+		a.homeView.Err = msg.Err
+		a.model.State = tui.StateHome
+		a.model.AnalyzingStartTime = time.Time{}
+		return a, nil
+
 	case tui.BeadsCreateErrorMsg:
 		a.model.Err = msg.Err
 		a.homeView.Err = msg.Err
@@ -494,6 +614,11 @@ func (a *App) updateAnalyzing(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.model.AnalyzingStartTime = time.Time{}
 		return a, nil
 
+	case tui.ExpandBeadErrorMsg:
+		// Keep the plan that was on the approval screen.
+		a.TransitionToApproval(a.model.Plan, a.model.Groups)
+		return a, a.notify(fmt.Sprintf("Could not break %s down: %v", msg.BeadID, msg.Err), tui.ToastWarning)
+
 	case tui.PlanErrorMsg:
 		a.model.Err = msg.Err
 		a.homeView.Err = msg.Err
@@ -538,40 +663,35 @@ func (a *App) updateInterview(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.model.Answers = msg.Answers
 		a.model.State = tui.StateAnalyzing
 		a.model.AnalyzingStartTime = time.Now()
+		ctx := a.analyzingContext()
 		return a, tea.Batch(
 			a.model.Spinner.Tick,
-			commands.ProcessAnswersCmd(a.model.InterviewSession, msg.Answers),
+			a.streamInterviewRound(),
+			commands.ProcessAnswersCmd(ctx, a.model.InterviewSession, msg.Answers),
 		)
 
 	case tui.AnswerMsg:
-		// Legacy single answer handling (kept for compatibility)
-		a.model.Answers = append(a.model.Answers, tui.Answer{
-			ID:    msg.QuestionID,
-			Value: msg.Value,
-		})
-		a.model.CurrentQ++
-
-		// Check if more questions in current round
-		if a.model.CurrentQ < len(a.model.Questions) {
-			a.interviewView = views.NewInterviewModel(
-				a.model.Questions,
-				a.model.Width,
-				a.model.Height,
-			)
-			return a, a.interviewView.Init()
+		// One question answered in the interview view: keep it for
+		// SkipInterviewMsg and save it so a restart can restore it
+		ans := tui.Answer{ID: msg.QuestionID, Value: msg.Value, Values: msg.Values}
+		replaced := false
+		for i := range a.model.Answers {
+			if a.model.Answers[i].ID == ans.ID {
+				a.model.Answers[i] = ans
+				replaced = true
+			}
 		}
-		// All questions in this round answered - process and get next round or complete
-		a.model.State = tui.StateAnalyzing
-		a.model.AnalyzingStartTime = time.Now()
-		return a, tea.Batch(
-			a.model.Spinner.Tick,
-			commands.ProcessAnswersCmd(a.model.InterviewSession, a.model.Answers),
-		)
+		if !replaced {
+			a.model.Answers = append(a.model.Answers, ans)
+		}
+		a.saveAnswer(ans)
+		return a, cmd
 
 	case tui.EnterChatMsg:
 		// Transition to chat mode for this question
 		a.model.InChatMode = true
 		a.model.State = tui.StateChat
+		a.chatQuestionID = msg.QuestionID
 		a.chatView = views.NewChatModel(
 			msg.QuestionID,
 			a.model.ChatHistory,
@@ -579,15 +699,18 @@ func (a *App) updateInterview(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.model.Height,
 		)
 		a.chatView.SetHasKeyboardEnhancements(a.hasKeyboardEnhancements)
+		a.chatView.SetSubmitKey(a.submitKey())
 		return a, a.chatView.Init()
 
 	case tui.SkipInterviewMsg:
 		// Skip remaining questions and go directly to planning
 		a.model.State = tui.StateAnalyzing
 		a.model.AnalyzingStartTime = time.Now()
+		ctx := a.analyzingContext()
 		return a, tea.Batch(
 			a.model.Spinner.Tick,
-			commands.ProcessAnswersCmd(a.model.InterviewSession, a.model.Answers),
+			a.streamInterviewRound(),
+			commands.ProcessAnswersCmd(ctx, a.model.InterviewSession, a.model.Answers),
 		)
 
 	case tui.GoHomeMsg:
@@ -601,18 +724,29 @@ func (a *App) updateInterview(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (a *App) updateChat(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if a.chatBeadID != "" {
+		return a.updateBeadChat(msg)
+	}
+
 	var cmd tea.Cmd
 	a.chatView, cmd = a.chatView.Update(msg)
 
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case views.SendChatMsg:
-		// Chat feature is not yet fully implemented
-		// Return a placeholder response for now
-		return a, func() tea.Msg {
-			return views.ChatResponseMsg{
-				Content: "Chat feature is not yet available. Please use the interview flow to provide answers.",
-			}
+		a.recordChatMessage("user", msg.Content)
+		question := a.currentQuestion(a.chatQuestionID).Text
+		return a, commands.InterviewChatCmd(a.model.InterviewSession, question, msg.Content)
+
+	case tui.InterviewChatReplyMsg:
+		// Only Claude's actual reply is part of the recorded discussion.
+		content := msg.Content
+		if msg.Err != nil {
+			content = "Error getting response: " + msg.Err.Error()
+		} else {
+			a.recordChatMessage("assistant", content)
 		}
+		a.chatView, cmd = a.chatView.Update(views.ChatResponseMsg{Content: content})
+		return a, cmd
 
 	case views.ExitChatMsg:
 		// Return to previous state (interview or execution)
@@ -626,12 +760,147 @@ func (a *App) updateChat(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
+// openBeadChat holds a running bead before its next attempt and opens the
+// chat view seeded with the bead's context and recent output.
+func (a *App) openBeadChat(beadID string) tea.Cmd {
+	execute.HoldBead(beadID)
+	a.chatBeadID = beadID
+	a.beadGuidance = nil
+	a.model.State = tui.StateChat
+	a.chatView = views.NewChatModel(
+		"Bead "+beadID,
+		[]tui.ChatMessage{{Role: "assistant", Content: a.beadChatContext(beadID)}},
+		a.model.Width,
+		a.model.Height,
+	)
+	a.chatView.SetHasKeyboardEnhancements(a.hasKeyboardEnhancements)
+	a.chatView.SetSubmitKey(a.submitKey())
+	return a.chatView.Init()
+}
+
+// beadChatOutputLines is how much of the bead's recent output seeds the chat.
+const beadChatOutputLines = 20
+
+// beadChatContext describes a bead for the opening chat message: its title,
+// plan context, and its most recent output.
+func (a *App) beadChatContext(beadID string) string {
+	var b strings.Builder
+	title, description := beadID, ""
+	for _, bead := range a.model.Beads {
+		if bead.ID == beadID {
+			title = bead.ID + ": " + bead.Title
+			break
+		}
+	}
+	if a.model.Plan != nil {
+		for _, spec := range a.model.Plan.Beads {
+			if spec.ID == beadID {
+				description = spec.Description
+				break
+			}
+		}
+	}
+	fmt.Fprintf(&b, "Bead %s\n", title)
+	if description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+	if out := a.model.BeadOutput; len(out) > 0 {
+		if len(out) > beadChatOutputLines {
+			out = out[len(out)-beadChatOutputLines:]
+		}
+		fmt.Fprintf(&b, "\nRecent output:\n%s\n", strings.Join(out, "\n"))
+	}
+	b.WriteString("\nThe bead will wait before its next attempt. Tell me how it should proceed; press Esc when you're done and your guidance is added to that attempt.")
+	return b.String()
+}
+
+// updateBeadChat handles the chat view while it is open for a running bead.
+// Execution events keep flowing to the execution view underneath.
+func (a *App) updateBeadChat(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case tui.ExecutionEventMsg, tui.TickMsg:
+		_, cmd := a.updateExecuting(msg)
+		return a, cmd
+	case tui.ExecutionCompleteMsg:
+		execute.ReleaseBead(a.chatBeadID)
+		a.chatBeadID, a.beadGuidance = "", nil
+		return a.updateExecuting(msg)
+	}
+
+	var cmd tea.Cmd
+	a.chatView, cmd = a.chatView.Update(msg)
+
+	switch msg := msg.(type) {
+	case views.SendChatMsg:
+		a.beadGuidance = append(a.beadGuidance, msg.Content)
+		beadID := a.chatBeadID
+		return a, func() tea.Msg {
+			return views.ChatResponseMsg{
+				Content: fmt.Sprintf("Noted. This will be passed to %s's next attempt when you leave the chat.", beadID),
+			}
+		}
+
+	case views.ExitChatMsg:
+		return a, a.closeBeadChat()
+	}
+
+	return a, cmd
+}
+
+// closeBeadChat hands the collected guidance to the bead (or just releases
+// it when there is none) and returns to the execution view.
+func (a *App) closeBeadChat() tea.Cmd {
+	beadID := a.chatBeadID
+	text := strings.TrimSpace(strings.Join(a.beadGuidance, "\n"))
+	a.chatBeadID, a.beadGuidance = "", nil
+	a.model.State = tui.StateExecuting
+
+	// The execution spinner's ticks went to the chat view; restart them.
+	spin := a.executionView.Init()
+	if text == "" {
+		execute.ReleaseBead(beadID)
+		return spin
+	}
+	if !execute.SubmitGuidance(beadID, text) {
+		return tea.Batch(spin, a.notify(fmt.Sprintf("%s already finished; guidance not used", beadID), tui.ToastWarning))
+	}
+	return tea.Batch(spin, a.notify(fmt.Sprintf("Guidance added to %s's next attempt", beadID), tui.ToastInfo))
+}
+
+// recordChatMessage saves a chat message to the session store when both a
+// store and a session are attached to the model.
+func (a *App) recordChatMessage(role, content string) {
+	store, ok := a.model.Store.(*session.Store)
+	if !ok || store == nil {
+		return
+	}
+	sess, ok := a.model.Session.(*session.Session)
+	if !ok || sess == nil {
+		return
+	}
+	recorder := &understand.ChatRecorder{Store: store, SessionID: sess.ID}
+	recorder.Record(understand.ChatMessage{Role: role, Content: content})
+}
+
 func (a *App) updateApproval(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	a.planView, cmd = a.planView.Update(msg)
 
 	switch msg := msg.(type) {
 	case tui.ApproveMsg:
+		// A dependency cycle would stall execution; keep the plan on screen.
+		executionBeads := plan.ConvertToExecutionBeads(plan.ConvertFromTUIPlan(a.model.Plan).Beads)
+		if err := beads.ValidateDependencies(executionBeads); err != nil {
+			return a, tea.Batch(cmd, a.notify("Cannot approve plan: "+err.Error(), tui.ToastError))
+		}
+
+		if a.model.DryRun {
+			// Stop here: no beads, branch, or worktrees.
+			a.dryRunSummary = execute.FormatDryRun(*a.model.Cfg, executionBeads)
+			a.model.State = tui.StateComplete
+			return a, nil
+		}
+
 		// First, create beads in the beads system before execution.
 		// Show spinner while creating beads.
 		a.model.State = tui.StateAnalyzing
@@ -647,6 +916,7 @@ func (a *App) updateApproval(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, tea.Batch(
 			a.model.Spinner.Tick,
 			commands.RegeneratePlanCmd(
+				a.analyzingContext(),
 				*a.model.Cfg,
 				a.model.Requirements,
 				a.model.GraphSummary,
@@ -655,6 +925,37 @@ func (a *App) updateApproval(msg tea.Msg) (tea.Model, tea.Cmd) {
 				msg.Feedback,
 			),
 		)
+
+	case tui.EditBeadMsg:
+		if err := applyBeadEdit(a.model.Cfg, a.model.Plan, msg); err != nil {
+			return a, tea.Batch(cmd, a.notify("Cannot edit bead: "+err.Error(), tui.ToastError))
+		}
+		if a.model.ExplainPlan {
+			a.model.Plan.Reasoning = planReasoning(a.model.Plan)
+		}
+		a.model.Groups = commands.PlanGroups(a.model.Plan)
+		a.planView.SetGroups(a.model.Groups)
+		if a.model.RunDir != "" {
+			if err := plan.SavePlan(*a.model.Cfg, a.model.RunDir, plan.ConvertFromTUIPlan(a.model.Plan)); err != nil {
+				return a, tea.Batch(cmd, a.notify("Failed to save the edited plan: "+err.Error(), tui.ToastWarning))
+			}
+		}
+		return a, cmd
+
+	case tui.ExpandBeadMsg:
+		a.model.State = tui.StateAnalyzing
+		a.model.AnalyzingStartTime = time.Now()
+		return a, tea.Batch(
+			a.model.Spinner.Tick,
+			commands.ExpandBeadCmd(
+				a.analyzingContext(),
+				*a.model.Cfg,
+				a.model.Plan,
+				msg.BeadID,
+				msg.Feedback,
+				a.model.RunDir,
+			),
+		)
 	}
 
 	return a, cmd
@@ -677,13 +978,25 @@ func (a *App) updateExecuting(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.updateBeadStatus(msg.Event.BeadID, "running")
 		case "output":
 			// Append to current bead output
-			a.model.BeadOutput = append(a.model.BeadOutput, msg.Event.Content)
+			a.model.BeadOutput = tui.AppendOutputLine(a.model.BeadOutput, msg.Event.Content, a.maxOutputLines())
 		case "bead_complete":
 			a.updateBeadStatus(msg.Event.BeadID, "success")
 		case "error":
 			a.updateBeadStatus(msg.Event.BeadID, "failed")
 		case "token_update":
 			a.model.TokenCount += msg.Event.Tokens
+			a.executionView, _ = a.executionView.Update(tui.OutputEvent{
+				Type:   "token_update",
+				BeadID: msg.Event.BeadID,
+				Tokens: msg.Event.Tokens,
+			})
+		case "attempt":
+			a.executionView, _ = a.executionView.Update(tui.OutputEvent{
+				Type:        "attempt",
+				BeadID:      msg.Event.BeadID,
+				Attempt:     msg.Event.Attempt,
+				MaxAttempts: msg.Event.MaxAttempts,
+			})
 		}
 		// Continue listening for more events
 		return a, commands.ListenExecutionCmd(a.model.OutputChan)
@@ -700,8 +1013,12 @@ func (a *App) updateExecuting(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case tui.PauseMsg:
+		// Pausing only holds back beads that have not started yet.
 		a.model.IsPaused = msg.Paused
-		return a, cmd
+		return a, tea.Batch(cmd, commands.SendControlCmd(a.model.ControlChan, msg.Paused))
+
+	case tui.ChatAboutBeadMsg:
+		return a, a.openBeadChat(msg.BeadID)
 
 	case tui.SkipBeadMsg:
 		// Mark bead as skipped and continue
@@ -738,17 +1055,19 @@ func (a *App) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case views.LoadSessionMsg:
 		// Session resume is not yet implemented
-		a.model.Err = fmt.Errorf("session resume not yet available (session: %s)", msg.SessionID)
-		a.homeView.Err = a.model.Err
-		a.model.State = tui.StateHome
-		return a, nil
+		return a, a.notify(fmt.Sprintf("Session resume not yet available (session: %s)", msg.SessionID), tui.ToastWarning)
+
+	case views.DeleteSessionMsg:
+		// Session deletion is not yet implemented
+		return a, a.notify(fmt.Sprintf("Session deletion not yet available (session: %s)", msg.SessionID), tui.ToastWarning)
 
 	case tui.ArchitectureDiagramMsg:
 		// Cache diagram in model for future use
 		if msg.Err == nil {
 			a.model.Diagram = msg.Diagram
+			return a, cmd
 		}
-		return a, cmd
+		return a, tea.Batch(cmd, a.notify("Knowledge graph unavailable: "+msg.Err.Error(), tui.ToastWarning))
 
 	case tui.LearningsLoadMsg:
 		// Cache learnings in model for future use
@@ -790,44 +1109,119 @@ func (a *App) transitionToAnalyzing(description string) tea.Cmd {
 		}
 	}
 
+	// Attach to the task's session so answers are saved as they are given
+	a.attachSession(description)
+
 	// Add initial system message
 	a.model.ChatHistory = append(a.model.ChatHistory, tui.ChatMessage{
 		Role:    "system",
 		Content: fmt.Sprintf("Task: %s", description),
 	})
 
-	// Start spinner and interview command
+	// Start spinner and interview command, streaming its output
+	ctx := a.analyzingContext()
+	a.analyzingOutput = make(chan string, 64)
 	return tea.Batch(
 		a.model.Spinner.Tick,
+		commands.ListenAnalyzingCmd(a.analyzingOutput),
 		commands.StartInterviewCmd(
+			ctx,
 			*a.model.Cfg,
 			a.model.StackInfo,
 			description,
 			a.model.RunDir,
 			a.model.GraphSummary,
+			a.analyzingOutput,
 		),
 	)
 }
 
+// analyzingContext returns the context for an interview or plan command
+// started in StateAnalyzing. Pressing Esc cancels it.
+func (a *App) analyzingContext() context.Context {
+	a.releaseAnalyzing()
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancelAnalyzing = cancel
+	return ctx
+}
+
+// releaseAnalyzing cancels and forgets the current analyzing context and
+// the output it streamed.
+func (a *App) releaseAnalyzing() {
+	if a.cancelAnalyzing != nil {
+		a.cancelAnalyzing()
+		a.cancelAnalyzing = nil
+	}
+	a.analyzingOutput = nil
+	a.analyzingView = views.AnalyzingOutputModel{}
+}
+
+// streamInterviewRound points the interview session's output at a fresh
+// channel for the round about to start and returns the command listening to
+// it. Call it after analyzingContext, which drops the previous round's
+// output.
+func (a *App) streamInterviewRound() tea.Cmd {
+	if a.model.InterviewSession == nil {
+		return nil
+	}
+	a.analyzingOutput = make(chan string, 64)
+	a.model.InterviewSession.Output = a.analyzingOutput
+	return commands.ListenAnalyzingCmd(a.analyzingOutput)
+}
+
+// setInterviewSession stores a started interview session and adopts the KG
+// summary it was started with, so the codebase context panel can show it.
+func (a *App) setInterviewSession(session *understand.InterviewSession) {
+	a.model.InterviewSession = session
+	if session != nil && a.model.GraphSummary == "" {
+		a.model.GraphSummary = session.GraphSummary
+	}
+}
+
+// startInterview moves to the interview phase with questions. When
+// understand.accept_defaults answers all of them, the answers are submitted
+// right away instead of waiting on the Submit screen.
+func (a *App) startInterview(questions []tui.Question) tea.Cmd {
+	a.transitionToInterview(questions)
+	if a.acceptDefaults() && len(questions) > 0 && len(a.model.Answers) == len(questions) {
+		return func() tea.Msg { return tui.SkipInterviewMsg{} }
+	}
+	return a.interviewView.Init()
+}
+
 // transitionToInterview sets up the interview phase with questions.
 func (a *App) transitionToInterview(questions []tui.Question) {
 	a.model.State = tui.StateInterview
 	a.model.Questions = questions
 	a.model.CurrentQ = 0
-	a.model.Answers = nil                    // Clear answers for new round
+	a.model.Answers = a.restoredAnswers(questions) // Answers saved by an earlier run, if any
+	if a.acceptDefaults() {
+		a.model.Answers = append(a.model.Answers, defaultAnswers(questions, a.model.Answers)...)
+	}
 	a.model.AnalyzingStartTime = time.Time{} // Reset timeout tracker
 
 	// Pass ALL questions to the interview view (not just the first one)
-	a.interviewView = views.NewInterviewModel(
+	a.interviewView = views.NewInterviewModelWithAnswers(
 		questions,
+		a.model.Answers,
 		a.model.Width,
 		a.model.Height,
 	)
+	a.interviewView.SetRound(a.interviewRound())
+
+	// Show what berth knows about the codebase before the first question.
+	if a.model.InterviewSession != nil && a.model.InterviewSession.CurrentRound == 1 &&
+		(a.model.Cfg == nil || !a.model.Cfg.Understand.HideContext) {
+		a.interviewView.SetCodebaseContext(a.model.GraphSummary)
+	}
 }
 
 // TransitionToApproval sets up the plan approval phase.
 func (a *App) TransitionToApproval(plan *tui.Plan, groups []tui.ExecutionGroup) {
 	a.model.State = tui.StateApproval
+	if a.model.ExplainPlan && plan != nil {
+		plan.Reasoning = planReasoning(plan)
+	}
 	a.model.Plan = plan
 	a.model.Groups = groups
 	a.model.AnalyzingStartTime = time.Time{} // Reset timeout tracker
@@ -840,6 +1234,15 @@ func (a *App) TransitionToApproval(plan *tui.Plan, groups []tui.ExecutionGroup)
 	)
 }
 
+// planReasoning annotates each bead of p with its dependency reasoning.
+func planReasoning(p *tui.Plan) map[string]string {
+	reasoning := make(map[string]string, len(p.Beads))
+	for _, a := range plan.Annotate(plan.ConvertFromTUIPlan(p)) {
+		reasoning[a.BeadID] = a.String()
+	}
+	return reasoning
+}
+
 // transitionToExecuting sets up the bead execution phase.
 func (a *App) transitionToExecuting(beads []tui.BeadState) {
 	a.model.State = tui.StateExecuting
@@ -852,6 +1255,24 @@ func (a *App) transitionToExecuting(beads []tui.BeadState) {
 		a.model.Width,
 		a.model.Height,
 	)
+	a.executionView.SetMaxOutputLines(a.maxOutputLines())
+}
+
+// maxOutputLines returns the configured cap on retained bead output.
+func (a *App) maxOutputLines() int {
+	if a.model.Cfg == nil {
+		return 0
+	}
+	return a.model.Cfg.TUI.MaxOutputLines
+}
+
+// submitKey is the configured tui.submit_key mode for the home and chat
+// inputs.
+func (a *App) submitKey() string {
+	if a.model.Cfg == nil {
+		return ""
+	}
+	return a.model.Cfg.TUI.SubmitKey
 }
 
 // transitionToComplete marks the session as complete.
@@ -988,17 +1409,6 @@ func (a *App) renderAnalyzingView() string {
 	b.WriteString(spinnerLine)
 	b.WriteString("\n\n")
 
-	// Progress hints
-	hints := []string{
-		"Detecting project structure",
-		"Querying knowledge graph",
-		"Generating clarifying questions",
-	}
-	for _, hint := range hints {
-		b.WriteString(tui.DimStyle.Render("  - " + hint))
-		b.WriteString("\n")
-	}
-
 	// Determine box width - use max width or screen width, whichever is smaller
 	const maxBoxWidth = 70
 	boxWidth := maxBoxWidth
@@ -1006,6 +1416,25 @@ func (a *App) renderAnalyzingView() string {
 		boxWidth = a.model.Width - 4
 	}
 
+	// What the model is doing, once it streams output; progress hints until then
+	if a.analyzingView.HasOutput() {
+		b.WriteString(a.analyzingView.View(boxWidth - 4))
+	} else {
+		hints := []string{
+			"Detecting project structure",
+			"Querying knowledge graph",
+			"Generating clarifying questions",
+		}
+		for _, hint := range hints {
+			b.WriteString(tui.DimStyle.Render("  - " + hint))
+			b.WriteString("\n")
+		}
+	}
+	if a.cancelAnalyzing != nil {
+		b.WriteString("\n")
+		b.WriteString(tui.DimStyle.Render("Esc to cancel"))
+	}
+
 	// Wrap in box with fixed max width
 	content := b.String()
 	boxed := tui.BoxStyle.
@@ -1019,6 +1448,15 @@ func (a *App) renderAnalyzingView() string {
 func (a *App) renderCompleteView() string {
 	var b strings.Builder
 
+	if a.dryRunSummary != "" {
+		b.WriteString(tui.SuccessStyle.Render("Dry Run: " + a.model.Plan.Title))
+		b.WriteString("\n\n")
+		b.WriteString(a.dryRunSummary)
+		b.WriteString("\n")
+		b.WriteString(tui.DimStyle.Render("No branch, beads, or worktrees were created. Press any key to exit..."))
+		return a.boxComplete(b.String())
+	}
+
 	// Header
 	header := tui.SuccessStyle.Render("Execution Complete!")
 	b.WriteString(header)
@@ -1055,6 +1493,11 @@ func (a *App) renderCompleteView() string {
 	b.WriteString("\n")
 	b.WriteString(tui.DimStyle.Render("Press any key to exit..."))
 
+	return a.boxComplete(b.String())
+}
+
+// boxComplete wraps completion content in a box.
+func (a *App) boxComplete(content string) string {
 	// Determine box width - use max width or screen width, whichever is smaller
 	const maxBoxWidth = 70
 	boxWidth := maxBoxWidth
@@ -1063,7 +1506,6 @@ func (a *App) renderCompleteView() string {
 	}
 
 	// Wrap in box with fixed max width
-	content := b.String()
 	boxed := tui.BoxStyle.
 		Width(boxWidth).
 		Render(content)
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/execute"
+	"github.com/berth-dev/berth/internal/tui"
+	"github.com/berth-dev/berth/internal/tui/views"
+)
+
+func TestChatAboutBeadCollectsGuidance(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.transitionToExecuting([]tui.BeadState{{ID: "bt-chat", Title: "Wire auth API", Status: "running"}})
+	a.model.Plan = &tui.Plan{Beads: []tui.BeadSpec{{ID: "bt-chat", Description: "Call the auth endpoint"}}}
+	a.model.BeadOutput = []string{"running tests", "FAIL auth_test.go"}
+
+	a.Update(tui.ChatAboutBeadMsg{BeadID: "bt-chat"})
+	if a.model.State != tui.StateChat || a.chatBeadID != "bt-chat" {
+		t.Fatalf("state = %v, chatBeadID = %q; want bead chat", a.model.State, a.chatBeadID)
+	}
+	seed := a.beadChatContext("bt-chat")
+	for _, want := range []string{"bt-chat: Wire auth API", "Call the auth endpoint", "FAIL auth_test.go"} {
+		if !strings.Contains(seed, want) {
+			t.Errorf("chat seed missing %q:\n%s", want, seed)
+		}
+	}
+
+	// Execution events keep reaching the execution side while chatting.
+	a.Update(tui.ExecutionEventMsg{Event: execute.StreamEvent{Type: "output", Content: "retrying"}})
+	if got := a.model.BeadOutput[len(a.model.BeadOutput)-1]; got != "retrying" {
+		t.Errorf("last output = %q, want output recorded during chat", got)
+	}
+
+	a.Update(views.SendChatMsg{Content: "Mock the HTTP client"})
+	a.Update(views.ExitChatMsg{})
+	if a.model.State != tui.StateExecuting || a.chatBeadID != "" {
+		t.Fatalf("state = %v, chatBeadID = %q; want back to execution", a.model.State, a.chatBeadID)
+	}
+
+	// The bead was held, so the guidance was queued rather than dropped; a
+	// second submission now finds the bead neither held nor running.
+	if execute.SubmitGuidance("bt-chat", "again") {
+		t.Error("bead still held after the chat closed")
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+func TestApproveInDryRunSkipsBeadCreation(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.SetDryRun(true)
+	a.TransitionToApproval(&tui.Plan{
+		Title: "Auth",
+		Beads: []tui.BeadSpec{
+			{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}},
+			{ID: "bt-2", Title: "Wire handler", DependsOn: []string{"bt-1"}},
+		},
+	}, nil)
+
+	_, cmd := a.Update(tui.ApproveMsg{})
+	if cmd != nil {
+		t.Error("approve in dry-run returned a command; want no bead creation")
+	}
+	if a.model.State != tui.StateComplete {
+		t.Fatalf("state = %v, want StateComplete", a.model.State)
+	}
+	view := a.renderCompleteView()
+	for _, want := range []string{"Dry Run: Auth", "bt-1: Add model", "depends on: bt-1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("complete view missing %q:\n%s", want, view)
+		}
+	}
+}
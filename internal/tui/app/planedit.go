@@ -0,0 +1,86 @@
+// planedit.go applies edits made to single beads on the plan approval
+// screen, so small corrections do not need the whole plan regenerated.
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/beads"
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/plan"
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+// applyBeadEdit changes one field of a bead of p in place. The edit is
+// rejected, leaving p unchanged, when it names an unknown bead or field,
+// empties the title, gives the bead a file protected by cfg's
+// execution.protected_files, or makes the bead depend on itself, on a bead
+// not in the plan, or on a dependency cycle.
+func applyBeadEdit(cfg *config.Config, p *tui.Plan, edit tui.EditBeadMsg) error {
+	if p == nil {
+		return fmt.Errorf("no plan to edit")
+	}
+	idx := slices.IndexFunc(p.Beads, func(b tui.BeadSpec) bool { return b.ID == edit.BeadID })
+	if idx < 0 {
+		return fmt.Errorf("bead %s is not in the plan", edit.BeadID)
+	}
+
+	bead := p.Beads[idx]
+	switch edit.Field {
+	case tui.EditFieldTitle:
+		title := strings.TrimSpace(edit.Value)
+		if title == "" {
+			return fmt.Errorf("title of %s cannot be empty", bead.ID)
+		}
+		bead.Title = title
+
+	case tui.EditFieldFiles:
+		bead.Files = splitList(edit.Value)
+		if len(bead.Files) > 0 {
+			bead.NoFiles = false
+		}
+		if cfg != nil {
+			edited := plan.ConvertFromTUIPlan(&tui.Plan{Beads: []tui.BeadSpec{bead}})
+			if err := plan.ValidateProtectedFiles(edited, cfg.Execution.ProtectedFiles); err != nil {
+				return err
+			}
+		}
+
+	case tui.EditFieldDependsOn:
+		deps := splitList(edit.Value)
+		for _, dep := range deps {
+			if dep == bead.ID {
+				return fmt.Errorf("%s cannot depend on itself", bead.ID)
+			}
+			if !slices.ContainsFunc(p.Beads, func(b tui.BeadSpec) bool { return b.ID == dep }) {
+				return fmt.Errorf("%s depends on %s, which is not in the plan", bead.ID, dep)
+			}
+		}
+		bead.DependsOn = deps
+
+		edited := &tui.Plan{Beads: slices.Clone(p.Beads)}
+		edited.Beads[idx] = bead
+		if err := beads.ValidateDependencies(plan.ConvertToExecutionBeads(plan.ConvertFromTUIPlan(edited).Beads)); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown bead field %q", edit.Field)
+	}
+
+	p.Beads[idx] = bead
+	return nil
+}
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+func TestEditBeadUpdatesPlanInPlace(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.TransitionToApproval(&tui.Plan{
+		Title: "Auth",
+		Beads: []tui.BeadSpec{
+			{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}},
+			{ID: "bt-2", Title: "Wire handler", DependsOn: []string{"bt-1"}},
+			{ID: "bt-3", Title: "Add tests", NoFiles: true},
+		},
+	}, nil)
+
+	a.Update(tui.EditBeadMsg{BeadID: "bt-2", Field: tui.EditFieldTitle, Value: "  Wire login handler "})
+	a.Update(tui.EditBeadMsg{BeadID: "bt-3", Field: tui.EditFieldFiles, Value: "auth_test.go, ,handler_test.go"})
+	a.Update(tui.EditBeadMsg{BeadID: "bt-3", Field: tui.EditFieldDependsOn, Value: "bt-1, bt-2"})
+
+	beads := a.model.Plan.Beads
+	if beads[1].Title != "Wire login handler" {
+		t.Errorf("bt-2 title = %q", beads[1].Title)
+	}
+	if want := []string{"auth_test.go", "handler_test.go"}; !slices.Equal(beads[2].Files, want) || beads[2].NoFiles {
+		t.Errorf("bt-3 files = %v (no files %v), want %v", beads[2].Files, beads[2].NoFiles, want)
+	}
+	if want := []string{"bt-1", "bt-2"}; !slices.Equal(beads[2].DependsOn, want) {
+		t.Errorf("bt-3 depends on %v, want %v", beads[2].DependsOn, want)
+	}
+	if len(a.model.Groups) != 3 {
+		t.Errorf("got %d groups after the edit, want 3: %+v", len(a.model.Groups), a.model.Groups)
+	}
+}
+
+func TestEditBeadSavesPlan(t *testing.T) {
+	a := New(config.DefaultConfig(), t.TempDir())
+	a.model.RunDir = t.TempDir()
+	a.TransitionToApproval(&tui.Plan{
+		Title: "Auth",
+		Beads: []tui.BeadSpec{{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}}},
+	}, nil)
+
+	a.Update(tui.EditBeadMsg{BeadID: "bt-1", Field: tui.EditFieldTitle, Value: "Add user model"})
+
+	for _, name := range []string{"plan.json", "plan.md"} {
+		data, err := os.ReadFile(filepath.Join(a.model.RunDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "Add user model") {
+			t.Errorf("%s does not have the edited title:\n%s", name, data)
+		}
+	}
+}
+
+func TestEditBeadRejectsInvalidDependencies(t *testing.T) {
+	p := &tui.Plan{Beads: []tui.BeadSpec{
+		{ID: "bt-1", Title: "Add model"},
+		{ID: "bt-2", Title: "Wire handler", DependsOn: []string{"bt-1"}},
+	}}
+
+	for _, tc := range []struct {
+		edit tui.EditBeadMsg
+		want string
+	}{
+		{tui.EditBeadMsg{BeadID: "bt-1", Field: tui.EditFieldDependsOn, Value: "bt-9"}, "not in the plan"},
+		{tui.EditBeadMsg{BeadID: "bt-1", Field: tui.EditFieldDependsOn, Value: "bt-1"}, "itself"},
+		{tui.EditBeadMsg{BeadID: "bt-1", Field: tui.EditFieldDependsOn, Value: "bt-2"}, "dependency cycle"},
+		{tui.EditBeadMsg{BeadID: "bt-1", Field: tui.EditFieldTitle, Value: " "}, "cannot be empty"},
+		{tui.EditBeadMsg{BeadID: "bt-9", Field: tui.EditFieldTitle, Value: "x"}, "not in the plan"},
+	} {
+		err := applyBeadEdit(nil, p, tc.edit)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: error %v, want %q", tc.edit, err, tc.want)
+		}
+	}
+	if len(p.Beads[0].DependsOn) != 0 || p.Beads[0].Title != "Add model" {
+		t.Errorf("rejected edit changed bt-1: %+v", p.Beads[0])
+	}
+}
+
+func TestEditBeadRejectsProtectedFiles(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Execution.ProtectedFiles = []string{".github/**"}
+	p := &tui.Plan{Beads: []tui.BeadSpec{{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}}}}
+
+	err := applyBeadEdit(cfg, p, tui.EditBeadMsg{BeadID: "bt-1", Field: tui.EditFieldFiles, Value: "model.go, .github/workflows/ci.yml"})
+	if err == nil || !strings.Contains(err.Error(), "protected") {
+		t.Errorf("error = %v, want the protected file rejected", err)
+	}
+	if len(p.Beads[0].Files) != 1 {
+		t.Errorf("rejected edit changed bt-1's files: %v", p.Beads[0].Files)
+	}
+}
@@ -0,0 +1,149 @@
+// resume.go offers to resume a run that was interrupted, whether it was
+// started headless or from the TUI, when the TUI starts.
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/berth-dev/berth/internal/execute"
+	"github.com/berth-dev/berth/internal/plan"
+	"github.com/berth-dev/berth/internal/tui"
+	"github.com/berth-dev/berth/internal/tui/commands"
+	"github.com/berth-dev/berth/internal/tui/views"
+)
+
+// findInterruptedRun returns the latest run directory under
+// .berth/runs and its checkpoint, or a nil checkpoint when the latest run
+// finished (or never started executing).
+func findInterruptedRun(projectRoot string) (string, *execute.Checkpoint) {
+	runsDir := filepath.Join(projectRoot, ".berth", "runs")
+	entries, err := os.ReadDir(runsDir)
+	if err != nil {
+		return "", nil
+	}
+
+	// Timestamped names sort chronologically; newest first.
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() > entries[j].Name()
+	})
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		runDir := filepath.Join(runsDir, entry.Name())
+		cp, err := execute.LoadCheckpoint(runDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring unreadable checkpoint in %s: %v\n", runDir, err)
+			return "", nil
+		}
+		return runDir, cp
+	}
+	return "", nil
+}
+
+// offerResume shows the resume prompt when the latest run was interrupted.
+// It reports false, changing nothing, when there is nothing to resume.
+func (a *App) offerResume() (bool, tea.Cmd) {
+	runDir, cp := findInterruptedRun(a.model.ProjectRoot)
+	if cp == nil {
+		return false, nil
+	}
+	a.resumeRunDir = runDir
+	a.resumeCheckpoint = cp
+
+	a.model.State = tui.StateResume
+	a.resumeView = views.NewResumeModel(a.model.Width, a.model.Height, views.InterruptedRun{
+		ID:        filepath.Base(runDir),
+		Branch:    a.resumeBranch(),
+		Completed: len(cp.CompletedBeads),
+		Failed:    len(cp.FailedBeads),
+		SavedAt:   cp.Timestamp,
+		LastError: cp.LastError,
+	})
+	return true, a.resumeView.Init()
+}
+
+// resumeBranch is the branch the interrupted run executes on: the one its
+// checkpoint recorded, else the configured default for the project.
+func (a *App) resumeBranch() string {
+	if a.resumeCheckpoint != nil && a.resumeCheckpoint.RunID != "" {
+		return a.resumeCheckpoint.RunID
+	}
+	if a.model.Cfg != nil && a.model.Cfg.Project.Name != "" {
+		return a.model.Cfg.Execution.BranchPrefix + a.model.Cfg.Project.Name
+	}
+	return ""
+}
+
+// updateResume handles messages for the resume prompt state.
+func (a *App) updateResume(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	a.resumeView, cmd = a.resumeView.Update(msg)
+
+	switch msg.(type) {
+	case tui.ResumeConfirmMsg:
+		// Reopen interrupted beads and list the run's beads with spinner
+		a.model.State = tui.StateAnalyzing
+		a.model.AnalyzingStartTime = time.Now()
+		return a, tea.Batch(
+			a.model.Spinner.Tick,
+			commands.PrepareResumeCmd(a.resumeCheckpoint),
+		)
+
+	case tui.ResumeDeclineMsg:
+		// Leave the checkpoint for "berth resume" and go home
+		a.resumeCheckpoint = nil
+		a.model.State = tui.StateHome
+		return a, a.homeView.Init()
+	}
+
+	return a, cmd
+}
+
+// startResumedExecution shows the interrupted run's beads and resumes the
+// execution loop from its checkpoint.
+func (a *App) startResumedExecution(beadStates []tui.BeadState) tea.Cmd {
+	cp := a.resumeCheckpoint
+	a.model.RunDir = a.resumeRunDir
+	a.model.BranchName = a.resumeBranch()
+	if a.model.BranchName == "" {
+		a.model.BranchName = "berth-execution"
+	}
+	a.resumeCheckpoint = nil
+
+	// Bring back the run's plan so bead descriptions are at hand again.
+	if p, err := plan.LoadPlan(a.model.RunDir); err == nil {
+		a.model.Plan = plan.ConvertToTUIPlan(p)
+	}
+
+	a.transitionToExecuting(beadStates)
+	a.model.OutputChan = make(chan execute.StreamEvent, 100)
+	a.model.ControlChan = make(chan execute.ControlSignal, 1)
+
+	state := &execute.ExecuteState{
+		RetryCount:     cp.RetryCount,
+		ConsecFailures: cp.ConsecFailures,
+		CompletedBeads: cp.CompletedBeads,
+		FailedBeads:    cp.FailedBeads,
+		Beads:          cp.Beads,
+		TokensUsed:     cp.TokensUsed,
+	}
+	return tea.Batch(
+		a.executionView.Init(),
+		commands.StartExecutionCmd(
+			*a.model.Cfg,
+			a.model.ProjectRoot,
+			a.model.RunDir,
+			a.model.BranchName,
+			state,
+			a.model.OutputChan,
+			a.model.ControlChan,
+		),
+	)
+}
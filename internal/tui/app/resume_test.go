@@ -0,0 +1,103 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/execute"
+	"github.com/berth-dev/berth/internal/plan"
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+// makeRun creates .berth/runs/<id> under root, with a checkpoint if cp is
+// not nil, and returns the run directory.
+func makeRun(t *testing.T, root, id string, cp *execute.Checkpoint) string {
+	t.Helper()
+	runDir := filepath.Join(root, ".berth", "runs", id)
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if cp != nil {
+		if err := execute.SaveCheckpoint(runDir, cp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return runDir
+}
+
+func TestInitCheckOffersToResumeCheckpoint(t *testing.T) {
+	root := t.TempDir()
+	makeRun(t, root, "20260101-090000", nil)
+	runDir := makeRun(t, root, "20260102-090000", &execute.Checkpoint{
+		RunID:          "berth/auth",
+		Beads:          []string{"bt-1", "bt-2", "bt-3"},
+		CompletedBeads: []string{"bt-1", "bt-2"},
+		RetryCount:     map[string]int{"bt-3": 1},
+	})
+
+	a := New(config.DefaultConfig(), root)
+	a.proceedFromInitCheck(tui.InitCheckMsg{})
+	if a.model.State != tui.StateResume {
+		t.Fatalf("state = %v, want StateResume", a.model.State)
+	}
+	view := a.resumeView.View()
+	for _, want := range []string{"Resume interrupted run?", "20260102-090000", "2 completed, 0 failed", "berth/auth"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("resume prompt missing %q:\n%s", want, view)
+		}
+	}
+
+	if _, cmd := a.Update(tui.ResumeConfirmMsg{}); cmd == nil || a.model.State != tui.StateAnalyzing {
+		t.Fatalf("confirm: state = %v, cmd = %v; want StateAnalyzing while preparing", a.model.State, cmd)
+	}
+
+	if err := plan.WritePlanJSON(runDir, &plan.Plan{Title: "Auth", Beads: []plan.BeadSpec{{ID: "bt-1", Title: "Add model"}}}); err != nil {
+		t.Fatal(err)
+	}
+	_, cmd := a.Update(tui.ResumeReadyMsg{Beads: []tui.BeadState{
+		{ID: "bt-1", Status: "success"},
+		{ID: "bt-3", Status: "pending"},
+	}})
+	if cmd == nil {
+		t.Fatal("ResumeReadyMsg did not start execution")
+	}
+	if a.model.State != tui.StateExecuting {
+		t.Fatalf("state = %v, want StateExecuting", a.model.State)
+	}
+	if a.model.RunDir != runDir || a.model.BranchName != "berth/auth" {
+		t.Errorf("resumed in %q on %q, want %q on berth/auth", a.model.RunDir, a.model.BranchName, runDir)
+	}
+	if a.model.Plan == nil || a.model.Plan.Title != "Auth" {
+		t.Errorf("plan = %+v, want the run's plan loaded from plan.json", a.model.Plan)
+	}
+}
+
+func TestInitCheckSkipsFinishedRuns(t *testing.T) {
+	root := t.TempDir()
+	makeRun(t, root, "20260101-090000", &execute.Checkpoint{RunID: "berth/old"})
+	makeRun(t, root, "20260102-090000", nil)
+
+	a := New(config.DefaultConfig(), root)
+	a.proceedFromInitCheck(tui.InitCheckMsg{})
+	if a.model.State != tui.StateHome {
+		t.Errorf("state = %v, want StateHome when the latest run has no checkpoint", a.model.State)
+	}
+}
+
+func TestDecliningResumeGoesHome(t *testing.T) {
+	root := t.TempDir()
+	runDir := makeRun(t, root, "20260102-090000", &execute.Checkpoint{RunID: "berth/auth"})
+
+	a := New(config.DefaultConfig(), root)
+	a.proceedFromInitCheck(tui.InitCheckMsg{})
+	a.Update(tui.ResumeDeclineMsg{})
+	if a.model.State != tui.StateHome {
+		t.Fatalf("state = %v, want StateHome", a.model.State)
+	}
+	if cp, err := execute.LoadCheckpoint(runDir); err != nil || cp == nil {
+		t.Errorf("checkpoint = %v, %v; want it kept for berth resume", cp, err)
+	}
+}
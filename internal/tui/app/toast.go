@@ -0,0 +1,79 @@
+// toast.go implements transient notifications shown below the active view.
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+// toastDuration is how long a toast stays on screen.
+const toastDuration = 3 * time.Second
+
+// maxToasts caps how many toasts are shown at once; older ones are dropped.
+const maxToasts = 3
+
+// toast is a single queued notification.
+type toast struct {
+	text    string
+	level   tui.ToastLevel
+	expires time.Time
+}
+
+// notify queues a toast and returns the command that dismisses it once
+// toastDuration has elapsed.
+func (a *App) notify(msg string, level tui.ToastLevel) tea.Cmd {
+	return a.pushToast(msg, level, time.Now())
+}
+
+// pushToast is notify with an explicit clock, for tests.
+func (a *App) pushToast(msg string, level tui.ToastLevel, now time.Time) tea.Cmd {
+	a.toasts = append(a.toasts, toast{text: msg, level: level, expires: now.Add(toastDuration)})
+	if len(a.toasts) > maxToasts {
+		a.toasts = a.toasts[len(a.toasts)-maxToasts:]
+	}
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return tui.ToastExpireMsg{}
+	})
+}
+
+// expireToasts drops every toast whose display time has passed at now.
+func (a *App) expireToasts(now time.Time) {
+	kept := a.toasts[:0]
+	for _, t := range a.toasts {
+		if now.Before(t.expires) {
+			kept = append(kept, t)
+		}
+	}
+	a.toasts = kept
+}
+
+// renderToasts renders the queued toasts, one per line, or "" when empty.
+func (a *App) renderToasts() string {
+	if len(a.toasts) == 0 {
+		return ""
+	}
+	lines := make([]string, len(a.toasts))
+	for i, t := range a.toasts {
+		lines[i] = toastStyle(t.level).Render(t.text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// toastStyle picks the style matching a toast level.
+func toastStyle(level tui.ToastLevel) lipgloss.Style {
+	switch level {
+	case tui.ToastSuccess:
+		return tui.SuccessStyle
+	case tui.ToastWarning:
+		return tui.WarningStyle
+	case tui.ToastError:
+		return tui.ErrorStyle
+	default:
+		return tui.DimStyle
+	}
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+func TestToastExpiresAfterDuration(t *testing.T) {
+	a := &App{}
+	now := time.Now()
+
+	if cmd := a.pushToast("Session saved", tui.ToastSuccess, now); cmd == nil {
+		t.Fatal("pushToast returned no expiry command")
+	}
+	a.pushToast("KG unavailable", tui.ToastWarning, now.Add(time.Second))
+
+	a.expireToasts(now.Add(toastDuration - time.Millisecond))
+	if len(a.toasts) != 2 {
+		t.Fatalf("toasts before expiry = %d, want 2", len(a.toasts))
+	}
+	if got := a.renderToasts(); !strings.Contains(got, "Session saved") || !strings.Contains(got, "KG unavailable") {
+		t.Errorf("renderToasts() = %q, want both toasts", got)
+	}
+
+	// The first toast expires; the later one is still showing.
+	a.expireToasts(now.Add(toastDuration))
+	if len(a.toasts) != 1 || a.toasts[0].text != "KG unavailable" {
+		t.Fatalf("toasts = %+v, want only the later toast", a.toasts)
+	}
+
+	a.expireToasts(now.Add(toastDuration + time.Second))
+	if len(a.toasts) != 0 || a.renderToasts() != "" {
+		t.Errorf("toasts = %+v, want none", a.toasts)
+	}
+}
+
+func TestToastQueueIsCapped(t *testing.T) {
+	a := &App{}
+	now := time.Now()
+	for i := 0; i < maxToasts+2; i++ {
+		a.pushToast(string(rune('a'+i)), tui.ToastInfo, now)
+	}
+	if len(a.toasts) != maxToasts {
+		t.Fatalf("toasts = %d, want %d", len(a.toasts), maxToasts)
+	}
+	if a.toasts[0].text != "c" {
+		t.Errorf("oldest kept toast = %q, want %q", a.toasts[0].text, "c")
+	}
+}
@@ -3,6 +3,7 @@ package commands
 
 import (
 	"fmt"
+	"sort"
 
 	tea "charm.land/bubbletea/v2"
 
@@ -32,6 +33,49 @@ func LoadDiagramCmd(kgClient *graph.Client, rootFile string) tea.Cmd {
 	}
 }
 
+// maxUnusedExportFiles caps the files LoadUnusedExportsCmd checks. Without
+// a get_unused_exports tool on the server, each file costs several KG
+// queries per export.
+const maxUnusedExportFiles = 30
+
+// LoadUnusedExportsCmd finds the unused exports of the files reachable
+// from rootFile in the architecture diagram, checking at most
+// maxUnusedExportFiles of them.
+func LoadUnusedExportsCmd(kgClient *graph.Client, rootFile string) tea.Cmd {
+	return func() tea.Msg {
+		if kgClient == nil {
+			return tui.UnusedExportsMsg{Err: fmt.Errorf("KG not connected")}
+		}
+
+		nodes, err := kgClient.GetArchitectureDiagram(rootFile, 5)
+		if err != nil {
+			return tui.UnusedExportsMsg{Err: err}
+		}
+		files := make([]string, 0, len(nodes))
+		for file := range nodes {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+		skipped := 0
+		if len(files) > maxUnusedExportFiles {
+			skipped = len(files) - maxUnusedExportFiles
+			files = files[:maxUnusedExportFiles]
+		}
+
+		var unused []tui.UnusedExport
+		for _, file := range files {
+			exports, err := kgClient.QueryUnusedExports(file)
+			if err != nil {
+				return tui.UnusedExportsMsg{Err: err}
+			}
+			for _, exp := range exports {
+				unused = append(unused, tui.UnusedExport{File: file, Name: exp.Name, Kind: exp.Kind, Line: exp.Line})
+			}
+		}
+		return tui.UnusedExportsMsg{Exports: unused, Skipped: skipped}
+	}
+}
+
 // LoadLearningsCmd fetches learnings from context.
 func LoadLearningsCmd(projectRoot string) tea.Cmd {
 	return func() tea.Msg {
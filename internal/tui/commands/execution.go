@@ -2,10 +2,13 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/berth-dev/berth/internal/beads"
 	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/execute"
 	"github.com/berth-dev/berth/internal/plan"
@@ -31,27 +34,34 @@ func CreateBeadsCmd(tuiPlan *tui.Plan, projectRoot string) tea.Cmd {
 
 // StartExecutionCmd launches the execution loop in a background goroutine.
 // The execution runs asynchronously and streams events to outputChan.
+// state is nil for a fresh execution, or the state restored from a
+// checkpoint when resuming an interrupted run. controlChan carries the pause
+// and resume signals sent by SendControlCmd.
 // Returns ExecutionStartedMsg to signal the TUI that execution has begun.
 func StartExecutionCmd(
 	cfg config.Config,
 	projectRoot, runDir, branchName string,
+	state *execute.ExecuteState,
 	outputChan chan execute.StreamEvent,
+	controlChan chan execute.ControlSignal,
 ) tea.Cmd {
 	return func() tea.Msg {
 		go func() {
 			defer close(outputChan)
-			// Create execution state and run with streaming output.
-			// verbose=false for TUI mode, state=nil for fresh execution.
+			// Run with streaming output; verbose=false for TUI mode.
 			err := execute.RunExecuteWithState(
 				cfg,
 				projectRoot,
 				runDir,
 				branchName,
 				false, // verbose
-				nil,   // fresh execution, no checkpoint
+				state,
 				outputChan,
+				controlChan,
 			)
-			if err != nil {
+			// Stuck and skipped beads were reported by execution_complete.
+			var incomplete *execute.IncompleteError
+			if err != nil && !errors.As(err, &incomplete) {
 				outputChan <- execute.StreamEvent{
 					Type:    "error",
 					Content: err.Error(),
@@ -62,6 +72,56 @@ func StartExecutionCmd(
 	}
 }
 
+// PrepareResumeCmd readies an interrupted run for the execution view, like
+// "berth resume" does: beads the interruption left in_progress are reopened
+// so they are retried, and the run's beads are listed with the status the
+// checkpoint recorded for them.
+// Returns ResumeReadyMsg on success, or ResumeErrorMsg on failure.
+func PrepareResumeCmd(cp *execute.Checkpoint) tea.Cmd {
+	return func() tea.Msg {
+		all, err := beads.ListAll()
+		if err != nil {
+			return tui.ResumeErrorMsg{Err: fmt.Errorf("listing beads: %w", err)}
+		}
+
+		status := make(map[string]string)
+		for _, id := range cp.Beads {
+			status[id] = "pending"
+		}
+		for _, id := range cp.CompletedBeads {
+			status[id] = "success"
+		}
+		for _, id := range cp.FailedBeads {
+			status[id] = "failed"
+		}
+
+		var states []tui.BeadState
+		for _, b := range all {
+			if b.Status == "in_progress" {
+				if err := beads.UpdateStatus(b.ID, "open"); err != nil {
+					return tui.ResumeErrorMsg{Err: fmt.Errorf("reopening bead %s: %w", b.ID, err)}
+				}
+				b.Status = "open"
+			}
+			s, inRun := status[b.ID]
+			switch {
+			case inRun:
+			case b.Status == "closed" || b.Status == "done":
+				continue // finished before this run
+			default:
+				s = "pending"
+			}
+			states = append(states, tui.BeadState{
+				ID:        b.ID,
+				Title:     b.Title,
+				Status:    s,
+				BlockedBy: b.DependsOn,
+			})
+		}
+		return tui.ResumeReadyMsg{Beads: states}
+	}
+}
+
 // ListenExecutionCmd polls the output channel for streaming events.
 // Returns ExecutionEventMsg for each event, ExecutionCompleteMsg when the
 // channel closes, or TickMsg on timeout to keep polling.
@@ -79,8 +139,34 @@ func ListenExecutionCmd(outputChan <-chan execute.StreamEvent) tea.Cmd {
 	}
 }
 
+// SendControlCmd passes a pause or resume signal to the running execution.
+// Pausing stops new beads from starting; running beads always finish. Only
+// the latest signal matters, so when the channel is full the oldest waiting
+// signal is dropped to make room.
+func SendControlCmd(controlChan chan execute.ControlSignal, paused bool) tea.Cmd {
+	if controlChan == nil {
+		return nil
+	}
+	sig := execute.ControlResume
+	if paused {
+		sig = execute.ControlPause
+	}
+	return func() tea.Msg {
+		for {
+			select {
+			case controlChan <- sig:
+				return nil
+			default:
+			}
+			select {
+			case <-controlChan:
+			default:
+			}
+		}
+	}
+}
+
 // PauseExecutionCmd signals that execution should be paused.
-// Note: actual pause requires context cancellation in the execution loop.
 func PauseExecutionCmd() tea.Cmd {
 	return func() tea.Msg {
 		return tui.PauseMsg{Paused: true}
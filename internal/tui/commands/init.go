@@ -70,17 +70,15 @@ func RunInitCmd(projectRoot string) tea.Cmd {
 			cfg.Project.PackageManager = stackInfo.PackageManager
 
 			// Build verify pipeline from detected commands
-			var pipeline []string
-			if stackInfo.BuildCmd != "" {
-				pipeline = append(pipeline, stackInfo.BuildCmd)
+			cfg.VerifyPipeline = stackInfo.Commands()
+
+			// Stacks in subdirectories (e.g. a frontend next to a Go backend)
+			// get pipelines of their own, run from their directory
+			for _, sub := range detect.DetectStacks(projectRoot) {
+				if cmds := sub.Commands(); sub.Path != "." && len(cmds) > 0 {
+					cfg.VerifyPipelines = append(cfg.VerifyPipelines, config.PathPipeline{Path: sub.Path, Commands: cmds})
+				}
 			}
-			if stackInfo.LintCmd != "" {
-				pipeline = append(pipeline, stackInfo.LintCmd)
-			}
-			if stackInfo.TestCmd != "" {
-				pipeline = append(pipeline, stackInfo.TestCmd)
-			}
-			cfg.VerifyPipeline = pipeline
 
 			// Write config
 			if err := config.WriteConfig(projectRoot, cfg); err != nil {
@@ -142,7 +140,9 @@ func ensureGitignore(dir string) error {
 		".berth/log.jsonl",
 		".berth/mcp.pid",
 		".berth/mcp.log",
+		".berth/anonymize.key",
 		".berth/runs/",
+		".berth/sessions.db*",
 		".beads/",
 	}
 
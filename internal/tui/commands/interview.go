@@ -5,29 +5,44 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/detect"
+	"github.com/berth-dev/berth/internal/graph"
 	"github.com/berth-dev/berth/internal/tui"
 	"github.com/berth-dev/berth/internal/understand"
 )
 
 // StartInterviewCmd starts an interview session and returns the first questions.
 // It spawns Claude to generate initial questions based on the project context.
+// Without a graphSummary, the KG summary of the project is computed first;
+// the session carries it back to the caller.
 // Returns InterviewStartedMsg with the session, followed by InterviewQuestionsMsg
-// with the first set of questions, or InterviewErrorMsg on failure.
+// with the first set of questions, or InterviewErrorMsg on failure. Canceling
+// ctx stops the interview and returns OperationCanceledMsg. Claude's output
+// is streamed to output, if non-nil; see ListenAnalyzingCmd.
 func StartInterviewCmd(
+	ctx context.Context,
 	cfg config.Config,
 	stackInfo detect.StackInfo,
 	description, runDir, graphSummary string,
+	output chan<- string,
 ) tea.Cmd {
 	return func() tea.Msg {
-		session, questions, err := understand.StartInterviewSession(
-			context.Background(),
+		if graphSummary == "" {
+			graphSummary = graph.ProjectSummary(".", cfg.KnowledgeGraph)
+		}
+		session, questions, err := understand.StartInterviewSessionWithOutput(
+			ctx,
 			cfg, stackInfo, description, runDir, graphSummary,
+			output,
 		)
+		if ctx.Err() != nil {
+			return tui.OperationCanceledMsg{Operation: "interview"}
+		}
 		if err != nil {
 			return tui.InterviewErrorMsg{Err: err}
 		}
@@ -56,8 +71,9 @@ func StartInterviewCmd(
 // ProcessAnswersCmd sends user answers to the interview session and returns
 // either the next set of questions or the final requirements.
 // Returns InterviewQuestionsMsg for more questions, InterviewCompleteMsg when
-// done, or InterviewErrorMsg on failure.
-func ProcessAnswersCmd(session *understand.InterviewSession, answers []tui.Answer) tea.Cmd {
+// done, or InterviewErrorMsg on failure. Canceling ctx returns
+// OperationCanceledMsg. Claude's output is streamed to session.Output.
+func ProcessAnswersCmd(ctx context.Context, session *understand.InterviewSession, answers []tui.Answer) tea.Cmd {
 	return func() tea.Msg {
 		// Validate answers before processing
 		if len(answers) == 0 {
@@ -78,7 +94,10 @@ func ProcessAnswersCmd(session *understand.InterviewSession, answers []tui.Answe
 		// Convert tui.Answer to understand.Answer
 		understandAnswers := convertToUnderstandAnswers(answers)
 
-		questions, isDone, reqs, err := session.ContinueInterview(understandAnswers)
+		questions, isDone, reqs, err := session.ContinueInterview(ctx, understandAnswers)
+		if ctx.Err() != nil {
+			return tui.OperationCanceledMsg{Operation: "interview"}
+		}
 		if err != nil {
 			return tui.InterviewErrorMsg{Err: err}
 		}
@@ -94,6 +113,32 @@ func ProcessAnswersCmd(session *understand.InterviewSession, answers []tui.Answe
 	}
 }
 
+// InterviewChatCmd asks Claude the message typed in the chat opened on the
+// interview question questionText.
+func InterviewChatCmd(session *understand.InterviewSession, questionText, message string) tea.Cmd {
+	return func() tea.Msg {
+		if session == nil {
+			return tui.InterviewChatReplyMsg{Err: fmt.Errorf("no interview in progress")}
+		}
+		reply, err := session.Chat(context.Background(), questionText, message)
+		return tui.InterviewChatReplyMsg{Content: reply, Err: err}
+	}
+}
+
+// ListenAnalyzingCmd waits for output streamed by an interview round.
+// Returns AnalyzingOutputMsg with the text, or with empty text on timeout so
+// the caller can keep polling while the round runs.
+func ListenAnalyzingCmd(output <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case text := <-output:
+			return tui.AnalyzingOutputMsg{Source: output, Text: text}
+		case <-time.After(100 * time.Millisecond):
+			return tui.AnalyzingOutputMsg{Source: output}
+		}
+	}
+}
+
 // convertQuestions converts a slice of understand.Question to tui.Question.
 func convertQuestions(questions []understand.Question) []tui.Question {
 	result := make([]tui.Question, len(questions))
@@ -106,6 +151,7 @@ func convertQuestions(questions []understand.Question) []tui.Question {
 			AllowCustom: q.AllowCustom,
 			AllowHelp:   q.AllowHelp,
 			MultiSelect: q.MultiSelect,
+			Ordered:     q.Ordered,
 		}
 	}
 	return result
@@ -2,6 +2,8 @@
 package commands
 
 import (
+	"context"
+
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/berth-dev/berth/internal/config"
@@ -15,7 +17,9 @@ import (
 // It spawns Claude to create an execution plan based on the gathered requirements,
 // then computes execution groups for parallel bead execution.
 // Returns PlanGeneratedMsg with the plan and groups, or PlanErrorMsg on failure.
+// Canceling ctx stops generation and returns OperationCanceledMsg.
 func GeneratePlanCmd(
+	ctx context.Context,
 	cfg config.Config,
 	requirements *understand.Requirements,
 	graphSummary, runDir string,
@@ -23,6 +27,7 @@ func GeneratePlanCmd(
 ) tea.Cmd {
 	return func() tea.Msg {
 		planResult, err := plan.RunPlanNonInteractive(
+			ctx,
 			cfg,
 			&plan.Requirements{Title: requirements.Title, Content: requirements.Content},
 			graphSummary,
@@ -30,6 +35,9 @@ func GeneratePlanCmd(
 			isGreenfield,
 			"", // no feedback for initial generation
 		)
+		if ctx.Err() != nil {
+			return tui.OperationCanceledMsg{Operation: "plan"}
+		}
 		if err != nil {
 			return tui.PlanErrorMsg{Err: err}
 		}
@@ -46,7 +54,9 @@ func GeneratePlanCmd(
 // RegeneratePlanCmd regenerates plan with user feedback.
 // It spawns Claude to create a new execution plan incorporating the user's feedback.
 // Returns PlanGeneratedMsg with the updated plan and groups, or PlanErrorMsg on failure.
+// Canceling ctx stops generation and returns OperationCanceledMsg.
 func RegeneratePlanCmd(
+	ctx context.Context,
 	cfg config.Config,
 	requirements *understand.Requirements,
 	graphSummary, runDir string,
@@ -55,6 +65,7 @@ func RegeneratePlanCmd(
 ) tea.Cmd {
 	return func() tea.Msg {
 		planResult, err := plan.RunPlanNonInteractive(
+			ctx,
 			cfg,
 			&plan.Requirements{Title: requirements.Title, Content: requirements.Content},
 			graphSummary,
@@ -62,6 +73,9 @@ func RegeneratePlanCmd(
 			isGreenfield,
 			feedback,
 		)
+		if ctx.Err() != nil {
+			return tui.OperationCanceledMsg{Operation: "plan"}
+		}
 		if err != nil {
 			return tui.PlanErrorMsg{Err: err}
 		}
@@ -75,6 +89,30 @@ func RegeneratePlanCmd(
 	}
 }
 
+// ExpandBeadCmd returns a tea.Cmd that breaks bead id of the plan being
+// approved into sub-beads, returning the new plan as a PlanGeneratedMsg.
+func ExpandBeadCmd(ctx context.Context, cfg config.Config, tuiPlan *tui.Plan, id, feedback, runDir string) tea.Cmd {
+	return func() tea.Msg {
+		planResult, err := plan.ExpandBead(ctx, cfg, plan.ConvertFromTUIPlan(tuiPlan), id, feedback, runDir)
+		if ctx.Err() != nil {
+			return tui.OperationCanceledMsg{Operation: "plan"}
+		}
+		if err != nil {
+			return tui.ExpandBeadErrorMsg{BeadID: id, Err: err}
+		}
+
+		groups := execute.ComputeGroups(plan.ConvertToExecutionBeads(planResult.Beads))
+		return tui.PlanGeneratedMsg{Plan: plan.ConvertToTUIPlan(planResult), Groups: convertGroups(groups)}
+	}
+}
+
+// PlanGroups computes the execution groups of a TUI plan, for when the plan
+// changes without being regenerated.
+func PlanGroups(tuiPlan *tui.Plan) []tui.ExecutionGroup {
+	executionBeads := plan.ConvertToExecutionBeads(plan.ConvertFromTUIPlan(tuiPlan).Beads)
+	return convertGroups(execute.ComputeGroups(executionBeads))
+}
+
 // convertGroups converts execute.ExecutionGroup to tui.ExecutionGroup.
 func convertGroups(groups []execute.ExecutionGroup) []tui.ExecutionGroup {
 	result := make([]tui.ExecutionGroup, len(groups))
@@ -8,6 +8,8 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"golang.org/x/term"
+
+	"github.com/berth-dev/berth/internal/config"
 )
 
 // Common key binding constants.
@@ -21,8 +23,16 @@ const (
 	KeyDown       = "down"
 	KeyLeft  = "left"
 	KeyRight = "right"
+
+	KeyCtrlEnter = "ctrl+enter" // Submit in ctrl-enter mode (needs keyboard enhancements)
+	KeyCtrlD     = "ctrl+d"     // Submit in ctrl-enter mode (any terminal)
 )
 
+// SubmitKeyCtrlEnter is the tui.submit_key mode in which Enter inserts a
+// newline in the home and chat inputs and Ctrl+Enter or Ctrl+D submits, so
+// pasting multi-line text cannot submit it early.
+const SubmitKeyCtrlEnter = "ctrl-enter"
+
 // TerminalType represents the detected terminal emulator.
 type TerminalType string
 
@@ -163,3 +173,27 @@ func runFallback(_ tea.Model) error {
 	fmt.Println("Please use 'berth run <description>' for non-interactive execution.")
 	return nil
 }
+
+// OutputElidedMarker replaces streamed output dropped by AppendOutputLine.
+const OutputElidedMarker = "... earlier output elided"
+
+// AppendOutputLine appends line to lines, keeping at most limit entries. Once
+// the cap is reached the oldest lines are dropped and the first entry becomes
+// OutputElidedMarker. limit <= 0 uses config.DefaultMaxOutputLines.
+func AppendOutputLine(lines []string, line string, limit int) []string {
+	if limit <= 0 {
+		limit = config.DefaultMaxOutputLines
+	}
+	lines = append(lines, line)
+	if len(lines) <= limit {
+		return lines
+	}
+	if limit == 1 {
+		return []string{OutputElidedMarker}
+	}
+	// Keep the marker plus the newest limit-1 lines, reusing the backing array.
+	keep := lines[len(lines)-(limit-1):]
+	lines[0] = OutputElidedMarker
+	n := copy(lines[1:], keep)
+	return lines[:n+1]
+}
@@ -0,0 +1,107 @@
+package diagram
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/berth-dev/berth/internal/graph"
+)
+
+// maxLabelExports caps the exports listed in an exported node's label.
+const maxLabelExports = 5
+
+// GenerateMermaid renders nodes as a Mermaid flowchart: one box per file,
+// labeled with its exports, and an edge from each importer to the file it
+// imports. Importers outside nodes (beyond the diagram's depth) are left out.
+func GenerateMermaid(nodes map[string]graph.ArchitectureNode) string {
+	files := sortedFiles(nodes)
+	ids := make(map[string]string, len(files))
+	for i, file := range files {
+		ids[file] = fmt.Sprintf("n%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	for _, file := range files {
+		label := mermaidEscape(file)
+		if exports := truncateList(nodes[file].Exports, maxLabelExports); exports != "" {
+			label += "<br/>" + mermaidEscape(exports)
+		}
+		fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[file], label)
+	}
+	for _, e := range edges(nodes, files) {
+		fmt.Fprintf(&b, "    %s --> %s\n", ids[e[0]], ids[e[1]])
+	}
+	return b.String()
+}
+
+// GenerateDOT renders nodes as a Graphviz digraph with the same boxes and
+// edges as GenerateMermaid.
+func GenerateDOT(nodes map[string]graph.ArchitectureNode) string {
+	files := sortedFiles(nodes)
+
+	var b strings.Builder
+	b.WriteString("digraph architecture {\n")
+	b.WriteString("    node [shape=box];\n")
+	for _, file := range files {
+		label := dotEscape(file)
+		if exports := truncateList(nodes[file].Exports, maxLabelExports); exports != "" {
+			label += `\n` + dotEscape(exports)
+		}
+		fmt.Fprintf(&b, "    \"%s\" [label=\"%s\"];\n", dotEscape(file), label)
+	}
+	for _, e := range edges(nodes, files) {
+		fmt.Fprintf(&b, "    \"%s\" -> \"%s\";\n", dotEscape(e[0]), dotEscape(e[1]))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// sortedFiles returns the files of nodes in a deterministic order: by depth,
+// then path.
+func sortedFiles(nodes map[string]graph.ArchitectureNode) []string {
+	files := make([]string, 0, len(nodes))
+	for file := range nodes {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		di, dj := nodes[files[i]].Depth, nodes[files[j]].Depth
+		if di != dj {
+			return di < dj
+		}
+		return files[i] < files[j]
+	})
+	return files
+}
+
+// edges returns each {importer, imported} pair between files of nodes once,
+// in the order of files. Import cycles simply yield an edge each way.
+func edges(nodes map[string]graph.ArchitectureNode, files []string) [][2]string {
+	var out [][2]string
+	seen := make(map[[2]string]bool)
+	for _, file := range files {
+		importers := append([]string(nil), nodes[file].Imports...)
+		sort.Strings(importers)
+		for _, imp := range importers {
+			e := [2]string{imp, file}
+			if _, ok := nodes[imp]; !ok || seen[e] {
+				continue
+			}
+			seen[e] = true
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// mermaidEscape replaces the characters that would end a quoted Mermaid
+// label with their entity codes.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+// dotEscape escapes s for use inside a double-quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,61 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/berth-dev/berth/internal/graph"
+)
+
+// cyclicNodes is main.go imported by cmd.go, with util.go and cmd.go
+// importing each other and far.go beyond the diagram's depth.
+func cyclicNodes() map[string]graph.ArchitectureNode {
+	return map[string]graph.ArchitectureNode{
+		"main.go":      {File: "main.go", Exports: []string{"Run"}, Imports: []string{"cmd/cmd.go"}, Depth: 0},
+		"cmd/cmd.go":   {File: "cmd/cmd.go", Exports: []string{"Execute", "Flags"}, Imports: []string{"util/util.go"}, Depth: 1},
+		"util/util.go": {File: "util/util.go", Imports: []string{"cmd/cmd.go", "far.go"}, Depth: 2},
+	}
+}
+
+func TestGenerateMermaid(t *testing.T) {
+	got := GenerateMermaid(cyclicNodes())
+	want := `flowchart TD
+    n0["main.go<br/>Run"]
+    n1["cmd/cmd.go<br/>Execute, Flags"]
+    n2["util/util.go"]
+    n1 --> n0
+    n2 --> n1
+    n1 --> n2
+`
+	if got != want {
+		t.Errorf("GenerateMermaid =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateDOT(t *testing.T) {
+	got := GenerateDOT(cyclicNodes())
+	want := `digraph architecture {
+    node [shape=box];
+    "main.go" [label="main.go\nRun"];
+    "cmd/cmd.go" [label="cmd/cmd.go\nExecute, Flags"];
+    "util/util.go" [label="util/util.go"];
+    "cmd/cmd.go" -> "main.go";
+    "util/util.go" -> "cmd/cmd.go";
+    "cmd/cmd.go" -> "util/util.go";
+}
+`
+	if got != want {
+		t.Errorf("GenerateDOT =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExportEscapesLabels(t *testing.T) {
+	nodes := map[string]graph.ArchitectureNode{
+		`a"b.go`: {File: `a"b.go`, Exports: []string{"<T>"}},
+	}
+	if got, want := GenerateMermaid(nodes), "flowchart TD\n    n0[\"a#quot;b.go<br/>#lt;T#gt;\"]\n"; got != want {
+		t.Errorf("GenerateMermaid = %q, want %q", got, want)
+	}
+	if got, want := GenerateDOT(nodes), "digraph architecture {\n    node [shape=box];\n    \"a\\\"b.go\" [label=\"a\\\"b.go\\n<T>\"];\n}\n"; got != want {
+		t.Errorf("GenerateDOT = %q, want %q", got, want)
+	}
+}
@@ -23,6 +23,22 @@ type InitConfirmMsg struct{}
 // InitDeclineMsg signals the user declined initialization.
 type InitDeclineMsg struct{}
 
+// ResumeConfirmMsg signals the user chose to resume an interrupted run.
+type ResumeConfirmMsg struct{}
+
+// ResumeDeclineMsg signals the user chose not to resume an interrupted run.
+type ResumeDeclineMsg struct{}
+
+// ResumeReadyMsg carries the beads of an interrupted run once it can resume.
+type ResumeReadyMsg struct {
+	Beads []BeadState
+}
+
+// ResumeErrorMsg signals an error preparing an interrupted run to resume.
+type ResumeErrorMsg struct {
+	Err error
+}
+
 // InitCompleteMsg signals initialization completed successfully.
 type InitCompleteMsg struct {
 	StackInfo detect.StackInfo
@@ -45,6 +61,13 @@ type EnterChatMsg struct {
 	QuestionID string
 }
 
+// InterviewChatReplyMsg carries Claude's reply to a message sent from the
+// chat opened on an interview question.
+type InterviewChatReplyMsg struct {
+	Content string
+	Err     error
+}
+
 // SkipInterviewMsg signals that the interview phase should be skipped.
 type SkipInterviewMsg struct{}
 
@@ -52,6 +75,7 @@ type SkipInterviewMsg struct{}
 type AnswerMsg struct {
 	QuestionID string
 	Value      string
+	Values     []string // for multi-select and ordered questions
 }
 
 // ApproveMsg signals that the user approved the plan.
@@ -62,6 +86,29 @@ type RejectMsg struct {
 	Feedback string
 }
 
+// ExpandBeadMsg signals that the user asked for one bead of the plan to be
+// broken down into smaller beads, with optional guidance.
+type ExpandBeadMsg struct {
+	BeadID   string
+	Feedback string
+}
+
+// Fields of a plan bead that EditBeadMsg can change.
+const (
+	EditFieldTitle     = "title"
+	EditFieldFiles     = "files"
+	EditFieldDependsOn = "depends_on"
+)
+
+// EditBeadMsg signals that the user edited one field of a plan bead on the
+// approval screen. Value is comma-separated for the files and depends_on
+// fields.
+type EditBeadMsg struct {
+	BeadID string
+	Field  string
+	Value  string
+}
+
 // ============================================================================
 // Execution Messages
 // ============================================================================
@@ -222,6 +269,15 @@ type InterviewReadyMsg struct {
 	Round     int
 }
 
+// AnalyzingOutputMsg carries model output streamed while an interview round
+// is generated. Text is empty when nothing arrived before the poll timed out.
+// Source identifies the round's channel so output from an earlier round is
+// ignored.
+type AnalyzingOutputMsg struct {
+	Source <-chan string
+	Text   string
+}
+
 // InterviewCompleteMsg signals that the interview is done with requirements.
 type InterviewCompleteMsg struct {
 	Requirements *understand.Requirements
@@ -252,6 +308,13 @@ type PlanErrorMsg struct {
 	Err error
 }
 
+// ExpandBeadErrorMsg signals that breaking a bead down failed; the plan
+// being approved is unchanged.
+type ExpandBeadErrorMsg struct {
+	BeadID string
+	Err    error
+}
+
 // PlanRegenerateMsg requests re-planning with user feedback.
 type PlanRegenerateMsg struct {
 	Feedback string
@@ -280,11 +343,37 @@ type ErrorMsg struct {
 	Err error
 }
 
+// ToastLevel sets how a transient notification is styled.
+type ToastLevel int
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarning
+	ToastError
+)
+
+// NotifyMsg asks the app to show a transient toast notification. Views
+// return it for feedback that should not replace the sticky error display.
+type NotifyMsg struct {
+	Text  string
+	Level ToastLevel
+}
+
+// ToastExpireMsg is sent when a toast's display time may have elapsed.
+type ToastExpireMsg struct{}
+
 // OperationTimeoutMsg signals that an operation has timed out.
 type OperationTimeoutMsg struct {
 	Operation string
 }
 
+// OperationCanceledMsg signals that an operation stopped because the user
+// canceled it. It carries no error: the cancellation was already handled.
+type OperationCanceledMsg struct {
+	Operation string
+}
+
 // WindowSizeMsg signals that the terminal window has been resized.
 type WindowSizeMsg struct {
 	Width  int
@@ -301,6 +390,22 @@ type ArchitectureDiagramMsg struct {
 	Err     error
 }
 
+// UnusedExport is an exported symbol nothing imports or uses.
+type UnusedExport struct {
+	File string
+	Name string
+	Kind string
+	Line int
+}
+
+// UnusedExportsMsg provides the unused exports of the files in the
+// architecture diagram.
+type UnusedExportsMsg struct {
+	Exports []UnusedExport
+	Skipped int // diagram files left unchecked to bound the KG queries
+	Err     error
+}
+
 // LearningsLoadMsg provides learnings data.
 type LearningsLoadMsg struct {
 	Learnings []string
@@ -23,6 +23,7 @@ type ViewState int
 const (
 	StateTerminalSetup ViewState = iota // Terminal setup prompt (first run)
 	StateInit                           // Project needs initialization
+	StateResume                         // Interrupted run found; offer to resume it
 	StateHome
 	StateAnalyzing
 	StateInterview
@@ -65,24 +66,26 @@ type Question struct {
 	AllowCustom bool
 	AllowHelp   bool
 	MultiSelect bool // Allow multiple option selection
+	Ordered     bool // Rank selected options; implies multi-select
 }
 
 // Answer represents a user's response to a question.
 type Answer struct {
 	ID     string
 	Value  string   // Single value for single-select
-	Values []string // Multiple values for multi-select
+	Values []string // Multiple values for multi-select (in rank order if ordered)
 }
 
 // BeadState represents the current state of a bead during execution.
 type BeadState struct {
-	ID         string
-	Title      string
-	Status     string // "pending", "running", "success", "failed", "blocked"
-	TokenCount int
-	Duration   time.Duration
-	Attempt    int
-	BlockedBy  []string
+	ID          string
+	Title       string
+	Status      string // "pending", "running", "success", "failed", "blocked"
+	TokenCount  int
+	Duration    time.Duration
+	Attempt     int // current attempt, 0 until the first one starts
+	MaxAttempts int
+	BlockedBy   []string
 }
 
 // ExecutionGroup represents a group of beads that can be executed together.
@@ -100,6 +103,8 @@ type BeadSpec struct {
 	Files       []string
 	DependsOn   []string
 	VerifyExtra []string
+	Meta        map[string]string
+	NoFiles     bool // files explicitly declared as "none"
 }
 
 // Plan represents the execution plan generated during planning phase.
@@ -108,15 +113,19 @@ type Plan struct {
 	Description string
 	Beads       []BeadSpec
 	RawOutput   string
+	Warnings    []string          // plan-level warnings shown on the approval screen
+	Reasoning   map[string]string // per-bead dependency reasoning, keyed by bead ID; set in explain-plan mode
 }
 
 // OutputEvent represents an event from bead execution output.
 type OutputEvent struct {
-	Type     string // "stdout", "stderr", "token", "status"
-	BeadID   string
-	Content  string
-	Tokens   int
-	IsStderr bool
+	Type        string // "stdout", "stderr", "token", "status", "attempt"
+	BeadID      string
+	Content     string
+	Tokens      int
+	IsStderr    bool
+	Attempt     int
+	MaxAttempts int
 }
 
 // SessionInfo represents a saved session for the sessions view.
@@ -154,6 +163,8 @@ type Model struct {
 
 	// Project flags
 	IsGreenfield bool
+	DryRun       bool // stop at plan approval without creating beads
+	ExplainPlan  bool // annotate beads on the approval screen with their dependency reasoning
 
 	// Interview state
 	ChatHistory      []ChatMessage
@@ -195,6 +206,9 @@ type Model struct {
 	// Output channel for streaming bead output
 	OutputChan chan execute.StreamEvent
 
+	// Control channel for pausing and resuming execution
+	ControlChan chan execute.ControlSignal
+
 	// Branch name for execution
 	BranchName string
 
@@ -0,0 +1,46 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+// analyzingShownLines is how many of the latest output lines the analyzing
+// view shows under its spinner.
+const analyzingShownLines = 8
+
+// AnalyzingOutputModel collects the model output streamed while an interview
+// round is generated, so the analyzing screen can show activity instead of a
+// bare spinner. The zero value is ready to use.
+type AnalyzingOutputModel struct {
+	lines []string
+}
+
+// Append adds streamed text, one entry per non-blank line. Only the lines
+// the view can show are kept.
+func (m *AnalyzingOutputModel) Append(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			m.lines = append(m.lines, line)
+		}
+	}
+	if len(m.lines) > analyzingShownLines {
+		m.lines = m.lines[len(m.lines)-analyzingShownLines:]
+	}
+}
+
+// HasOutput reports whether any output has arrived.
+func (m AnalyzingOutputModel) HasOutput() bool {
+	return len(m.lines) > 0
+}
+
+// View renders the latest output lines, each cut to width.
+func (m AnalyzingOutputModel) View(width int) string {
+	var b strings.Builder
+	for _, line := range m.lines {
+		b.WriteString(tui.DimStyle.Render("  " + truncate(line, width-2)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
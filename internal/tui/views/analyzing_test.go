@@ -0,0 +1,35 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzingOutputKeepsLatestLines(t *testing.T) {
+	var m AnalyzingOutputModel
+	if m.HasOutput() {
+		t.Fatal("HasOutput = true before any output")
+	}
+
+	m.Append("Looking at the auth flow.\n\n")
+	for i := 1; i <= analyzingShownLines; i++ {
+		m.Append(fmt.Sprintf("Read file%d.go", i))
+	}
+
+	if len(m.lines) != analyzingShownLines || m.lines[0] != "Read file1.go" {
+		t.Errorf("lines = %q, want the last %d", m.lines, analyzingShownLines)
+	}
+	if !strings.Contains(m.View(20), "Read file8.go") {
+		t.Errorf("View missing the newest line:\n%s", m.View(20))
+	}
+}
+
+func TestAnalyzingOutputTruncatesToWidth(t *testing.T) {
+	var m AnalyzingOutputModel
+	m.Append(strings.Repeat("x", 50))
+
+	if out := m.View(20); strings.Contains(out, strings.Repeat("x", 19)) || !strings.Contains(out, "...") {
+		t.Errorf("View(20) = %q, want the line cut to fit", out)
+	}
+}
@@ -52,6 +52,7 @@ type ChatModel struct {
 	height                  int
 	escPending              bool // For ESC+CR sequence detection (terminals without native Shift+Enter)
 	hasKeyboardEnhancements bool // True if terminal supports Shift+Enter natively
+	ctrlEnterSubmits        bool // Enter inserts a newline; Ctrl+Enter or Ctrl+D sends
 }
 
 // NewChatModel creates a new ChatModel with the given context and initial messages.
@@ -157,6 +158,17 @@ func (m ChatModel) Update(msg tea.Msg) (ChatModel, tea.Cmd) {
 		// Reset ESC pending on any other key
 		m.escPending = false
 
+		// ctrl-enter mode: Enter is a newline, so a paste cannot send early
+		if m.ctrlEnterSubmits {
+			switch keyStr {
+			case tui.KeyCtrlEnter, tui.KeyCtrlD:
+				return m, m.send()
+			case tui.KeyEnter, tui.KeyShiftEnter:
+				m.textarea.InsertString("\n")
+				return m, nil
+			}
+		}
+
 		// Enter submits (only if not part of ESC+CR sequence)
 		if keyStr == tui.KeyEnter {
 			text := m.textarea.Value()
@@ -172,27 +184,7 @@ func (m ChatModel) Update(msg tea.Msg) (ChatModel, tea.Cmd) {
 			}
 
 			// Send message if textarea has content
-			content := strings.TrimSpace(text)
-			if content != "" {
-				// Add user message to history
-				m.messages = append(m.messages, tui.ChatMessage{
-					Role:    "user",
-					Content: content,
-				})
-
-				// Update viewport with new messages
-				m.viewport.SetContent(formatMessages(m.messages))
-				m.viewport.GotoBottom()
-
-				// Clear textarea and set loading state
-				m.textarea.Reset()
-				m.isLoading = true
-
-				return m, func() tea.Msg {
-					return SendChatMsg{Content: content}
-				}
-			}
-			return m, nil
+			return m, m.send()
 		}
 
 		// Shift+Enter inserts newline (native Kitty protocol support)
@@ -256,6 +248,33 @@ func (m ChatModel) Update(msg tea.Msg) (ChatModel, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// send moves the typed message into the history and returns the command
+// sending it, or nil while the textarea is blank.
+func (m *ChatModel) send() tea.Cmd {
+	content := strings.TrimSpace(m.textarea.Value())
+	if content == "" {
+		return nil
+	}
+
+	// Add user message to history
+	m.messages = append(m.messages, tui.ChatMessage{
+		Role:    "user",
+		Content: content,
+	})
+
+	// Update viewport with new messages
+	m.viewport.SetContent(formatMessages(m.messages))
+	m.viewport.GotoBottom()
+
+	// Clear textarea and set loading state
+	m.textarea.Reset()
+	m.isLoading = true
+
+	return func() tea.Msg {
+		return SendChatMsg{Content: content}
+	}
+}
+
 // View renders the chat view.
 func (m ChatModel) View() string {
 	var b strings.Builder
@@ -283,11 +302,7 @@ func (m ChatModel) View() string {
 	b.WriteString("\n\n")
 
 	// Footer - show appropriate newline hint based on terminal capability
-	newlineHint := "\\+Enter: New line"
-	if m.hasKeyboardEnhancements {
-		newlineHint = "Shift+Enter: New line"
-	}
-	footer := tui.DimStyle.Render("Enter: Submit · " + newlineHint + " · Esc: Back")
+	footer := tui.DimStyle.Render(inputHints(m.ctrlEnterSubmits, m.hasKeyboardEnhancements) + " · Esc: Back")
 	b.WriteString(footer)
 
 	// Wrap in box style
@@ -362,3 +377,11 @@ func formatMessages(messages []tui.ChatMessage) string {
 func (m *ChatModel) SetHasKeyboardEnhancements(has bool) {
 	m.hasKeyboardEnhancements = has
 }
+
+// SetSubmitKey sets the tui.submit_key mode, as HomeModel.SetSubmitKey does.
+func (m *ChatModel) SetSubmitKey(mode string) {
+	m.ctrlEnterSubmits = mode == tui.SubmitKeyCtrlEnter
+	if m.ctrlEnterSubmits {
+		m.textarea.Placeholder = "Type your message... (Ctrl+D to send)"
+	}
+}
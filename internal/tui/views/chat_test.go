@@ -0,0 +1,66 @@
+package views
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+// typeChat focuses the chat textarea and types text into it.
+func typeChat(m ChatModel, text string) ChatModel {
+	m.textarea.Focus()
+	for _, r := range text {
+		m, _ = m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return m
+}
+
+// sent returns the message cmd sends, or "" when cmd sends nothing.
+func sent(cmd tea.Cmd) string {
+	if cmd == nil {
+		return ""
+	}
+	msg, ok := cmd().(SendChatMsg)
+	if !ok {
+		return ""
+	}
+	return msg.Content
+}
+
+func TestChatEnterSendsByDefault(t *testing.T) {
+	m := NewChatModel("q1", nil, 100, 40)
+	m = typeChat(m, "why?")
+
+	m, cmd := m.Update(enterKey)
+	if got := sent(cmd); got != "why?" {
+		t.Fatalf("Enter sent %q, want %q", got, "why?")
+	}
+	if !m.isLoading || m.textarea.Value() != "" {
+		t.Errorf("after sending: loading = %v, text = %q; want loading and empty", m.isLoading, m.textarea.Value())
+	}
+}
+
+func TestChatCtrlEnterModeSends(t *testing.T) {
+	m := NewChatModel("q1", nil, 100, 40)
+	m.SetSubmitKey(tui.SubmitKeyCtrlEnter)
+	m = typeChat(m, "why?")
+
+	m, cmd := m.Update(enterKey)
+	if got := sent(cmd); got != "" {
+		t.Fatalf("Enter sent %q, want a newline", got)
+	}
+	m = typeChat(m, "and how?")
+	if m.textarea.Value() != "why?\nand how?" {
+		t.Fatalf("text = %q, want two lines", m.textarea.Value())
+	}
+
+	m, cmd = m.Update(ctrlDKey)
+	if got := sent(cmd); got != "why?\nand how?" {
+		t.Errorf("Ctrl+D sent %q, want %q", got, "why?\nand how?")
+	}
+	if len(m.messages) != 1 || m.messages[0].Content != "why?\nand how?" {
+		t.Errorf("messages = %v, want the sent message", m.messages)
+	}
+}
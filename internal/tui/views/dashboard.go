@@ -69,9 +69,11 @@ func (i SessionItem) FilterValue() string {
 
 // DashboardModel is the view model for the dashboard screen.
 type DashboardModel struct {
-	activeTab     int // 0=Architecture, 1=Learnings, 2=Sessions
+	activeTab     int // 0=Architecture, 1=Learnings, 2=Sessions, 3=Unused Exports
 	diagram       string
 	learnings     []string
+	unused        string // rendered unused exports; empty until loaded
+	unusedLoading bool   // LoadUnusedExportsCmd in flight, started by selectTab
 	sessions      []tui.SessionInfo
 	sessionsError string
 	sessionList   list.Model
@@ -79,6 +81,10 @@ type DashboardModel struct {
 	width         int
 	height        int
 
+	// renderLearnings toggles rendered markdown in the Learnings tab.
+	// Plain text is the default since rendering is more expensive.
+	renderLearnings bool
+
 	// Dependencies for loading data
 	kgClient    *graph.Client
 	store       *session.Store
@@ -160,6 +166,8 @@ func NewDashboardModel(diagram string, learnings []string, sessions []tui.Sessio
 
 // Init returns the initial command for the dashboard view.
 // It triggers loading of architecture diagram, learnings, and sessions.
+// Unused exports take many KG queries, so they are loaded by selectTab
+// when their tab is first shown.
 func (m DashboardModel) Init() tea.Cmd {
 	return tea.Batch(
 		commands.LoadDiagramCmd(m.kgClient, m.rootFile),
@@ -168,6 +176,19 @@ func (m DashboardModel) Init() tea.Cmd {
 	)
 }
 
+// selectTab makes tab the active tab and returns the command loading its
+// data, if it has not been loaded yet.
+func (m *DashboardModel) selectTab(tab int) tea.Cmd {
+	m.activeTab = tab
+	var cmd tea.Cmd
+	if tab == 3 && m.unused == "" && !m.unusedLoading {
+		m.unusedLoading = true
+		cmd = commands.LoadUnusedExportsCmd(m.kgClient, m.rootFile)
+	}
+	m.updateViewportContent()
+	return cmd
+}
+
 // Update handles messages for the dashboard view.
 func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 	var cmd tea.Cmd
@@ -178,14 +199,18 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 		switch msg.String() {
 		case "right":
 			// Cycle to next internal tab
-			m.activeTab = (m.activeTab + 1) % 3
-			m.updateViewportContent()
-			return m, nil
+			return m, m.selectTab((m.activeTab + 1) % len(dashboardTabs))
 
 		case "left":
 			// Cycle to previous internal tab
-			m.activeTab = (m.activeTab + 2) % 3 // +2 is equivalent to -1 mod 3
-			m.updateViewportContent()
+			return m, m.selectTab((m.activeTab + len(dashboardTabs) - 1) % len(dashboardTabs))
+
+		case "m":
+			// Toggle raw/rendered markdown on the learnings tab
+			if m.activeTab == 1 {
+				m.renderLearnings = !m.renderLearnings
+				m.updateViewportContent()
+			}
 			return m, nil
 
 		case "enter":
@@ -228,6 +253,18 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case tui.UnusedExportsMsg:
+		m.unusedLoading = false
+		if msg.Err != nil {
+			m.unused = "Unused exports unavailable: " + msg.Err.Error()
+		} else {
+			m.unused = formatUnusedExports(msg.Exports, msg.Skipped)
+		}
+		if m.activeTab == 3 {
+			m.updateViewportContent()
+		}
+		return m, nil
+
 	case tui.LearningsLoadMsg:
 		if msg.Err != nil {
 			m.learnings = []string{"Learnings unavailable: " + msg.Err.Error()}
@@ -258,8 +295,8 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 
 	// Pass messages to the appropriate component based on active tab
 	switch m.activeTab {
-	case 0, 1:
-		// Architecture or Learnings tab - use viewport
+	case 0, 1, 3:
+		// Architecture, Learnings, or Unused Exports tab - use viewport
 		m.viewport, cmd = m.viewport.Update(msg)
 		cmds = append(cmds, cmd)
 	case 2:
@@ -279,12 +316,44 @@ func (m *DashboardModel) updateViewportContent() {
 	case 1:
 		if len(m.learnings) == 0 {
 			m.viewport.SetContent("")
+		} else if m.renderLearnings {
+			m.viewport.SetContent(renderMarkdown(strings.Join(m.learnings, "\n\n")))
 		} else {
 			m.viewport.SetContent(strings.Join(m.learnings, "\n\n"))
 		}
+	case 3:
+		m.viewport.SetContent(m.unused)
 	}
 }
 
+// formatUnusedExports lists unused exports one per line, grouped by file,
+// noting the skipped files that were not checked.
+func formatUnusedExports(exports []tui.UnusedExport, skipped int) string {
+	var note string
+	if skipped > 0 {
+		note = fmt.Sprintf("(%d more files in the diagram not checked)", skipped)
+	}
+	if len(exports) == 0 {
+		return strings.TrimSpace("No unused exports found\n\n" + note)
+	}
+	var b strings.Builder
+	file := ""
+	for _, exp := range exports {
+		if exp.File != file {
+			if file != "" {
+				b.WriteString("\n")
+			}
+			file = exp.File
+			b.WriteString(file + "\n")
+		}
+		fmt.Fprintf(&b, "  %s (%s, line %d)\n", exp.Name, exp.Kind, exp.Line)
+	}
+	if note != "" {
+		b.WriteString("\n" + note)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
 // View renders the dashboard view.
 func (m DashboardModel) View() string {
 	var b strings.Builder
@@ -325,6 +394,14 @@ func (m DashboardModel) View() string {
 		} else {
 			b.WriteString(m.sessionList.View())
 		}
+
+	case 3:
+		// Unused exports
+		if m.unused == "" {
+			b.WriteString(tui.DimStyle.Render("Looking for unused exports..."))
+		} else {
+			b.WriteString(m.viewport.View())
+		}
 	}
 
 	b.WriteString("\n\n")
@@ -347,12 +424,14 @@ func (m DashboardModel) View() string {
 	return boxed
 }
 
+// dashboardTabs are the dashboard's tab names, in tab order.
+var dashboardTabs = []string{"Architecture", "Learnings", "Sessions", "Unused Exports"}
+
 // renderTabs renders the tab bar with active highlighting.
 func renderTabs(activeTab int) string {
-	tabs := []string{"Architecture", "Learnings", "Sessions"}
 	var rendered []string
 
-	for i, tab := range tabs {
+	for i, tab := range dashboardTabs {
 		if i == activeTab {
 			rendered = append(rendered, tui.ActiveTabStyle.Render(tab))
 		} else {
@@ -372,14 +451,25 @@ func (m DashboardModel) renderFooter() string {
 
 	// Tab-specific hints
 	switch m.activeTab {
-	case 0, 1:
-		// Architecture or Learnings - viewport controls
+	case 0:
+		// Architecture - viewport controls
 		hints = append(hints, "j/k: Scroll")
+	case 1:
+		// Learnings - viewport controls plus markdown toggle
+		hints = append(hints, "j/k: Scroll")
+		if m.renderLearnings {
+			hints = append(hints, "m: Raw text")
+		} else {
+			hints = append(hints, "m: Render markdown")
+		}
 	case 2:
 		// Sessions
 		hints = append(hints, "Enter: Load session")
 		hints = append(hints, "d: Delete session")
 		hints = append(hints, "/: Filter")
+	case 3:
+		// Unused exports - viewport controls
+		hints = append(hints, "j/k: Scroll")
 	}
 
 	// Build the hint string
@@ -0,0 +1,72 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+func TestDashboardUnusedExportsTab(t *testing.T) {
+	m := NewDashboardModel("", nil, nil, 120, 40, nil)
+	m, _ = m.Update(tui.UnusedExportsMsg{Exports: []tui.UnusedExport{
+		{File: "internal/auth/token.go", Name: "Refresh", Kind: "function", Line: 42},
+		{File: "internal/auth/token.go", Name: "Claims", Kind: "type", Line: 10},
+	}})
+
+	// Left from the first tab wraps around to the last one.
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if m.activeTab != 3 {
+		t.Fatalf("active tab = %d, want 3 (Unused Exports)", m.activeTab)
+	}
+	view := m.View()
+	for _, want := range []string{"internal/auth/token.go", "Refresh (function, line 42)", "Claims (type, line 10)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("unused exports tab missing %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if m.activeTab != 0 {
+		t.Errorf("active tab = %d after wrapping right, want 0", m.activeTab)
+	}
+}
+
+func TestDashboardLoadsUnusedExportsOnFirstView(t *testing.T) {
+	m := NewDashboardModel("", nil, nil, 120, 40, nil)
+
+	// Moving to another tab starts no load.
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if cmd != nil {
+		t.Error("switching to Learnings loaded something")
+	}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	m, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if m.activeTab != 3 || cmd == nil {
+		t.Fatalf("active tab = %d, cmd = %v; want 3 and a load command", m.activeTab, cmd)
+	}
+	if !strings.Contains(m.View(), "Looking for unused exports...") {
+		t.Errorf("tab does not show loading:\n%s", m.View())
+	}
+
+	// Leaving and coming back while loading does not query again.
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if _, again := m.Update(tea.KeyPressMsg{Code: tea.KeyLeft}); again != nil {
+		t.Error("unused exports requested twice")
+	}
+
+	m, _ = m.Update(cmd())
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if !strings.Contains(m.View(), "Unused exports unavailable: KG not connected") {
+		t.Errorf("load result not shown:\n%s", m.View())
+	}
+}
+
+func TestFormatUnusedExportsNotesSkippedFiles(t *testing.T) {
+	if got := formatUnusedExports(nil, 3); got != "No unused exports found\n\n(3 more files in the diagram not checked)" {
+		t.Errorf("formatUnusedExports = %q", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/berth-dev/berth/internal/tui"
+	"github.com/berth-dev/berth/internal/ui"
 )
 
 // ============================================================================
@@ -22,6 +23,7 @@ type ExecutionModel struct {
 	beads       []tui.BeadState
 	currentBead int
 	output      []string
+	maxOutput   int // retained output lines, 0 = config.DefaultMaxOutputLines
 	viewport    viewport.Model
 	spinner     spinner.Model
 	totalTokens int
@@ -65,6 +67,11 @@ func NewExecutionModel(beads []tui.BeadState, isParallel bool, width, height int
 	}
 }
 
+// SetMaxOutputLines caps how many lines of streamed output are retained.
+func (m *ExecutionModel) SetMaxOutputLines(n int) {
+	m.maxOutput = n
+}
+
 // Init returns the initial command for the execution view.
 func (m ExecutionModel) Init() tea.Cmd {
 	return m.spinner.Tick
@@ -144,16 +151,25 @@ func (m ExecutionModel) Update(msg tea.Msg) (ExecutionModel, tea.Cmd) {
 func (m ExecutionModel) handleOutputEvent(event tui.OutputEvent) (ExecutionModel, tea.Cmd) {
 	switch event.Type {
 	case "output", "stdout", "stderr":
-		// Append to output and update viewport
-		m.output = append(m.output, event.Content)
+		// Append to output and update viewport. Escape codes are stripped so
+		// Claude's own colors don't clash with the viewport styling.
+		m.output = tui.AppendOutputLine(m.output, ui.StripANSI(event.Content), m.maxOutput)
 		m.viewport.SetContent(strings.Join(m.output, "\n"))
 		m.viewport.GotoBottom()
 
 	case "token_update", "token":
-		m.totalTokens = event.Tokens
-		// Update current bead's token count
-		if m.currentBead >= 0 && m.currentBead < len(m.beads) {
-			m.beads[m.currentBead].TokenCount = event.Tokens
+		// Tokens is the usage since the previous update, so both counts
+		// accumulate. The bead is the event's, or the current one.
+		m.totalTokens += event.Tokens
+		bead := m.currentBead
+		for i := range m.beads {
+			if event.BeadID != "" && m.beads[i].ID == event.BeadID {
+				bead = i
+				break
+			}
+		}
+		if bead >= 0 && bead < len(m.beads) {
+			m.beads[bead].TokenCount += event.Tokens
 		}
 
 	case "complete", "status":
@@ -169,6 +185,15 @@ func (m ExecutionModel) handleOutputEvent(event tui.OutputEvent) (ExecutionModel
 			m.beads[m.currentBead].Status = "failed"
 			m.beads[m.currentBead].Duration = time.Since(m.startTime)
 		}
+
+	case "attempt":
+		for i := range m.beads {
+			if m.beads[i].ID == event.BeadID {
+				m.beads[i].Attempt = event.Attempt
+				m.beads[i].MaxAttempts = event.MaxAttempts
+				break
+			}
+		}
 	}
 
 	return m, nil
@@ -222,11 +247,12 @@ func (m ExecutionModel) View() string {
 	b.WriteString(stats)
 	b.WriteString("\n")
 
-	// Paused indicator
+	// Paused indicator. Running beads are not stopped by a pause.
 	if m.isPaused {
 		b.WriteString("\n")
 		pausedIndicator := tui.WarningStyle.Render("[ PAUSED ]")
 		b.WriteString(pausedIndicator)
+		b.WriteString(tui.DimStyle.Render(" running beads finish; no new bead starts until p is pressed again"))
 		b.WriteString("\n")
 	}
 
@@ -304,6 +330,7 @@ func (m ExecutionModel) renderSequentialView() string {
 				line = fmt.Sprintf("%s %s", m.spinner.View(), tui.SelectedStyle.Render(title))
 			}
 		}
+		line += attemptIndicator(bead)
 
 		b.WriteString(line)
 		b.WriteString("\n")
@@ -331,7 +358,7 @@ func (m ExecutionModel) renderParallelView() string {
 			}
 
 			title := truncate(bead.Title, 40)
-			line := fmt.Sprintf("  %s %s %s%s", m.spinner.View(), icon, title, progressIndicator)
+			line := fmt.Sprintf("  %s %s %s%s%s", m.spinner.View(), icon, title, progressIndicator, attemptIndicator(bead))
 			b.WriteString(line)
 			b.WriteString("\n")
 		}
@@ -353,6 +380,15 @@ func (m ExecutionModel) renderParallelView() string {
 	return b.String()
 }
 
+// attemptIndicator returns " attempt N/M" for a running bead that is on a
+// retry, so struggling beads stand out before they go stuck.
+func attemptIndicator(bead tui.BeadState) string {
+	if bead.Status != "running" || bead.Attempt < 2 {
+		return ""
+	}
+	return tui.WarningStyle.Render(fmt.Sprintf(" attempt %d/%d", bead.Attempt, bead.MaxAttempts))
+}
+
 // getStatusIcon returns the appropriate icon for a bead's status.
 func (m ExecutionModel) getStatusIcon(status string, isCurrent bool) string {
 	switch status {
@@ -0,0 +1,84 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+func TestExecutionOutputTrimsFromFront(t *testing.T) {
+	m := NewExecutionModel([]tui.BeadState{{ID: "bt-1", Title: "First"}}, false, 100, 40)
+	m.SetMaxOutputLines(5)
+
+	for i := 1; i <= 12; i++ {
+		m, _ = m.Update(tui.OutputEvent{Type: "output", Content: fmt.Sprintf("line %d", i)})
+	}
+
+	want := []string{tui.OutputElidedMarker, "line 9", "line 10", "line 11", "line 12"}
+	if len(m.output) != len(want) {
+		t.Fatalf("output = %q, want %q", m.output, want)
+	}
+	for i := range want {
+		if m.output[i] != want[i] {
+			t.Errorf("output[%d] = %q, want %q", i, m.output[i], want[i])
+		}
+	}
+}
+
+func TestExecutionOutputUnderCapUnchanged(t *testing.T) {
+	m := NewExecutionModel(nil, false, 100, 40)
+	m.SetMaxOutputLines(5)
+
+	for i := 1; i <= 5; i++ {
+		m, _ = m.Update(tui.OutputEvent{Type: "output", Content: fmt.Sprintf("line %d", i)})
+	}
+	if len(m.output) != 5 || m.output[0] != "line 1" {
+		t.Errorf("output = %q, want 5 lines starting at line 1", m.output)
+	}
+}
+
+func TestExecutionShowsAttemptOfRetryingBead(t *testing.T) {
+	m := NewExecutionModel([]tui.BeadState{
+		{ID: "bt-1", Title: "First", Status: "running"},
+		{ID: "bt-2", Title: "Second", Status: "running"},
+	}, false, 100, 40)
+
+	m, _ = m.Update(tui.OutputEvent{Type: "attempt", BeadID: "bt-1", Attempt: 1, MaxAttempts: 4})
+	m, _ = m.Update(tui.OutputEvent{Type: "attempt", BeadID: "bt-2", Attempt: 2, MaxAttempts: 4})
+
+	if got := m.beads[1]; got.Attempt != 2 || got.MaxAttempts != 4 {
+		t.Fatalf("bt-2 attempt = %d/%d, want 2/4", got.Attempt, got.MaxAttempts)
+	}
+	view := m.View()
+	if !strings.Contains(view, "attempt 2/4") {
+		t.Errorf("view does not show the retry of bt-2:\n%s", view)
+	}
+	if strings.Contains(view, "attempt 1/4") {
+		t.Errorf("view shows an attempt indicator for a first attempt:\n%s", view)
+	}
+
+	m.beads[1].Status = "success"
+	if view := m.View(); strings.Contains(view, "attempt 2/4") {
+		t.Errorf("view still shows the attempt of a finished bead:\n%s", view)
+	}
+}
+
+func TestExecutionAccumulatesTokenUpdates(t *testing.T) {
+	m := NewExecutionModel([]tui.BeadState{
+		{ID: "bt-1", Title: "First", Status: "running"},
+		{ID: "bt-2", Title: "Second", Status: "running"},
+	}, false, 100, 40)
+
+	m, _ = m.Update(tui.OutputEvent{Type: "token_update", BeadID: "bt-1", Tokens: 100})
+	m, _ = m.Update(tui.OutputEvent{Type: "token_update", BeadID: "bt-2", Tokens: 50})
+	m, _ = m.Update(tui.OutputEvent{Type: "token_update", BeadID: "bt-1", Tokens: 25})
+
+	if m.beads[0].TokenCount != 125 || m.beads[1].TokenCount != 50 {
+		t.Errorf("bead tokens = %d, %d, want 125, 50", m.beads[0].TokenCount, m.beads[1].TokenCount)
+	}
+	if view := m.View(); !strings.Contains(view, "Tokens: 175") {
+		t.Errorf("view does not show the running total:\n%s", view)
+	}
+}
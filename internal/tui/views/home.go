@@ -47,6 +47,7 @@ type HomeModel struct {
 	ctrlCPending            bool
 	escPending              bool // For ESC+CR sequence detection (terminals without native Shift+Enter)
 	hasKeyboardEnhancements bool // True if terminal supports Shift+Enter natively
+	ctrlEnterSubmits        bool // Enter inserts a newline; Ctrl+Enter or Ctrl+D submits
 }
 
 // maxBoxWidth is the maximum width for the home view box.
@@ -135,6 +136,18 @@ func (m HomeModel) Update(msg tea.Msg) (HomeModel, tea.Cmd) {
 		// Reset ESC pending on any other key
 		m.escPending = false
 
+		// ctrl-enter mode: Enter is a newline, so a paste cannot submit early
+		if m.ctrlEnterSubmits {
+			switch keyStr {
+			case tui.KeyCtrlEnter, tui.KeyCtrlD:
+				return m, m.submit()
+			case tui.KeyEnter, tui.KeyShiftEnter:
+				m.textArea.InsertString("\n")
+				m.adjustTextAreaHeight()
+				return m, nil
+			}
+		}
+
 		// Enter submits (only if not part of ESC+CR sequence)
 		if keyStr == tui.KeyEnter {
 			text := m.textArea.Value()
@@ -151,13 +164,7 @@ func (m HomeModel) Update(msg tea.Msg) (HomeModel, tea.Cmd) {
 			}
 
 			// Normal submit
-			value := strings.TrimSpace(text)
-			if value != "" {
-				return m, func() tea.Msg {
-					return SubmitTaskMsg{Description: value}
-				}
-			}
-			return m, nil
+			return m, m.submit()
 		}
 
 		// Shift+Enter inserts newline (native Kitty protocol support)
@@ -197,6 +204,18 @@ func (m HomeModel) Update(msg tea.Msg) (HomeModel, tea.Cmd) {
 	return m, cmd
 }
 
+// submit returns the command submitting the typed task, or nil while the
+// text area is blank.
+func (m HomeModel) submit() tea.Cmd {
+	value := strings.TrimSpace(m.textArea.Value())
+	if value == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		return SubmitTaskMsg{Description: value}
+	}
+}
+
 // adjustTextAreaHeight calculates and sets the textarea height based on content.
 func (m *HomeModel) adjustTextAreaHeight() {
 	content := m.textArea.Value()
@@ -280,11 +299,7 @@ func (m HomeModel) View() string {
 	} else {
 		ctrlCHint = tui.DimStyle.Render(ctrlCHint)
 	}
-	newlineHint := "\\+Enter: New line"
-	if m.hasKeyboardEnhancements {
-		newlineHint = "Shift+Enter: New line"
-	}
-	footer := tui.DimStyle.Render("Enter: Submit · "+newlineHint+" · Tab: Switch tabs · ") + ctrlCHint
+	footer := tui.DimStyle.Render(inputHints(m.ctrlEnterSubmits, m.hasKeyboardEnhancements)+" · Tab: Switch tabs · ") + ctrlCHint
 	b.WriteString(footer)
 
 	// Determine box width - use max width or screen width, whichever is smaller
@@ -320,3 +335,26 @@ func (m *HomeModel) SetCtrlCPending(pending bool) {
 func (m *HomeModel) SetHasKeyboardEnhancements(has bool) {
 	m.hasKeyboardEnhancements = has
 }
+
+// SetSubmitKey sets the tui.submit_key mode: "ctrl-enter" makes Enter insert
+// a newline and Ctrl+Enter or Ctrl+D submit; anything else keeps Enter as
+// submit.
+func (m *HomeModel) SetSubmitKey(mode string) {
+	m.ctrlEnterSubmits = mode == tui.SubmitKeyCtrlEnter
+}
+
+// inputHints describes the submit and newline keys of the home and chat
+// inputs. Ctrl+Enter and Shift+Enter are only offered when the terminal can
+// report them.
+func inputHints(ctrlEnterSubmits, hasKeyboardEnhancements bool) string {
+	if ctrlEnterSubmits {
+		if hasKeyboardEnhancements {
+			return "Ctrl+Enter: Submit · Enter: New line"
+		}
+		return "Ctrl+D: Submit · Enter: New line"
+	}
+	if hasKeyboardEnhancements {
+		return "Enter: Submit · Shift+Enter: New line"
+	}
+	return "Enter: Submit · \\+Enter: New line"
+}
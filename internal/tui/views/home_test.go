@@ -0,0 +1,73 @@
+package views
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+var (
+	enterKey      = tea.KeyPressMsg{Code: tea.KeyEnter}
+	shiftEnterKey = tea.KeyPressMsg{Code: tea.KeyEnter, Mod: tea.ModShift}
+	ctrlEnterKey  = tea.KeyPressMsg{Code: tea.KeyEnter, Mod: tea.ModCtrl}
+	ctrlDKey      = tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl}
+)
+
+// typeHome types text into the home text area.
+func typeHome(m HomeModel, text string) HomeModel {
+	for _, r := range text {
+		m, _ = m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return m
+}
+
+// submitted returns the task cmd submits, or "" when cmd submits nothing.
+func submitted(t *testing.T, cmd tea.Cmd) string {
+	t.Helper()
+	if cmd == nil {
+		return ""
+	}
+	msg, ok := cmd().(SubmitTaskMsg)
+	if !ok {
+		return ""
+	}
+	return msg.Description
+}
+
+func TestHomeEnterSubmitsByDefault(t *testing.T) {
+	m := NewHomeModel(nil, 100, 40)
+	m = typeHome(m, "add login")
+
+	m, cmd := m.Update(shiftEnterKey)
+	if got := submitted(t, cmd); got != "" {
+		t.Fatalf("Shift+Enter submitted %q, want a newline", got)
+	}
+	m = typeHome(m, "and logout")
+	if _, cmd = m.Update(enterKey); submitted(t, cmd) != "add login\nand logout" {
+		t.Errorf("Enter submitted %q, want %q", submitted(t, cmd), "add login\nand logout")
+	}
+}
+
+func TestHomeCtrlEnterModeSubmits(t *testing.T) {
+	for _, submitKey := range []tea.KeyPressMsg{ctrlEnterKey, ctrlDKey} {
+		t.Run(submitKey.String(), func(t *testing.T) {
+			m := NewHomeModel(nil, 100, 40)
+			m.SetSubmitKey(tui.SubmitKeyCtrlEnter)
+			m = typeHome(m, "add login")
+
+			m, cmd := m.Update(enterKey)
+			if got := submitted(t, cmd); got != "" {
+				t.Fatalf("Enter submitted %q, want a newline", got)
+			}
+			m = typeHome(m, "and logout")
+			if m.textArea.Value() != "add login\nand logout" {
+				t.Fatalf("text = %q, want two lines", m.textArea.Value())
+			}
+			if _, cmd = m.Update(submitKey); submitted(t, cmd) != "add login\nand logout" {
+				t.Errorf("%s submitted %q, want %q", submitKey, submitted(t, cmd), "add login\nand logout")
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"charm.land/lipgloss/v2"
 
 	"github.com/berth-dev/berth/internal/tui"
+	"github.com/berth-dev/berth/internal/understand"
 )
 
 // ============================================================================
@@ -52,6 +53,7 @@ type InterviewModel struct {
 	options        []interviewOption
 	selectedOption int
 	selectedValues map[string]bool // For multi-select: option key -> selected
+	ranking        []string        // For ordered questions: option keys, highest rank first
 
 	// Custom input
 	customInput textinput.Model
@@ -59,6 +61,14 @@ type InterviewModel struct {
 	// Submit screen state
 	submitFocused int // 0=Submit, 1=Go back
 
+	// Codebase context panel (Knowledge Graph summary), empty when hidden
+	codebaseContext  string
+	contextCollapsed bool
+
+	// Interview round, shown in each question's answer reference; 0 shows
+	// the bare question ID
+	round int
+
 	// UI state
 	escPending bool
 	width      int
@@ -67,6 +77,14 @@ type InterviewModel struct {
 
 // NewInterviewModel creates a new InterviewModel for all questions in a round.
 func NewInterviewModel(questions []tui.Question, width, height int) InterviewModel {
+	return NewInterviewModelWithAnswers(questions, nil, width, height)
+}
+
+// NewInterviewModelWithAnswers creates an InterviewModel with answers given
+// in an earlier run already filled in. Answers whose ID matches no question
+// are ignored. It opens on the first unanswered question, or on the Submit
+// screen when every question has an answer.
+func NewInterviewModelWithAnswers(questions []tui.Question, answers []tui.Answer, width, height int) InterviewModel {
 	// Create custom input
 	ti := textinput.New()
 	ti.Placeholder = "Type your answer here..."
@@ -86,7 +104,25 @@ func NewInterviewModel(questions []tui.Question, width, height int) InterviewMod
 		height:         height,
 	}
 
-	// Load first question if we have any
+	asked := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		asked[q.ID] = true
+	}
+	for _, ans := range answers {
+		if asked[ans.ID] {
+			m.answers[ans.ID] = &questionAnswer{value: ans.Value, values: ans.Values}
+		}
+	}
+	for m.currentQ < len(questions) && m.answers[questions[m.currentQ].ID] != nil {
+		m.currentQ++
+	}
+	if len(questions) > 0 && m.currentQ == len(questions) {
+		// Everything is answered: open on the Submit screen for review
+		m.currentQ = len(questions) - 1
+		m.isOnSubmit = true
+	}
+
+	// Load the current question if we have any
 	if len(questions) > 0 {
 		m.loadCurrentQuestion()
 	}
@@ -94,6 +130,20 @@ func NewInterviewModel(questions []tui.Question, width, height int) InterviewMod
 	return m
 }
 
+// SetRound sets the interview round the questions belong to, so each
+// question shows the {{r<round>.<id>}} reference later answers can use to
+// repeat its answer.
+func (m *InterviewModel) SetRound(round int) {
+	m.round = round
+}
+
+// SetCodebaseContext shows the Knowledge Graph summary in a collapsible panel
+// above the question. An empty summary hides the panel.
+func (m *InterviewModel) SetCodebaseContext(summary string) {
+	m.codebaseContext = strings.TrimSpace(summary)
+	m.contextCollapsed = false
+}
+
 // loadCurrentQuestion builds the options list for the current question
 // and restores any previously selected values.
 func (m *InterviewModel) loadCurrentQuestion() {
@@ -140,10 +190,21 @@ func (m *InterviewModel) loadCurrentQuestion() {
 	// Reset selection state
 	m.selectedOption = 0
 	m.selectedValues = make(map[string]bool)
+	m.ranking = nil
 
 	// Restore previously selected values if we have an answer for this question
 	if answer, ok := m.answers[q.ID]; ok {
-		if q.MultiSelect && len(answer.values) > 0 {
+		if q.Ordered && len(answer.values) > 0 {
+			// Restore the ranking in its saved order
+			for _, v := range answer.values {
+				for _, opt := range m.options {
+					if opt.label == v {
+						m.ranking = append(m.ranking, opt.key)
+						break
+					}
+				}
+			}
+		} else if q.MultiSelect && len(answer.values) > 0 {
 			// Restore multi-select values
 			for _, v := range answer.values {
 				// Find the option key for this value
@@ -257,8 +318,7 @@ func (m InterviewModel) updateQuestionScreen(msg tea.Msg) (InterviewModel, tea.C
 				value := strings.TrimSpace(m.customInput.Value())
 				if value != "" {
 					m.customInput.Blur()
-					m.saveAnswer(value, nil)
-					return m.advanceToNext()
+					return m.answerAndAdvance(value, nil)
 				}
 				return m, nil
 
@@ -335,8 +395,11 @@ func (m InterviewModel) updateQuestionScreen(msg tea.Msg) (InterviewModel, tea.C
 			// Navigate to next question
 			return m.navigateNext()
 
-		case " ":
-			// Space: toggle for multi-select, select for single-select
+		case " ", "space":
+			// Space: rank for ordered, toggle for multi-select, select for single-select
+			if m.isOrdered() {
+				return m.toggleRank(m.selectedOption)
+			}
 			if m.currentQ >= 0 && m.currentQ < len(m.questions) && m.questions[m.currentQ].MultiSelect {
 				return m.toggleMultiSelect()
 			}
@@ -345,9 +408,37 @@ func (m InterviewModel) updateQuestionScreen(msg tea.Msg) (InterviewModel, tea.C
 		case tui.KeyEnter:
 			return m.handleSelection()
 
+		case "c":
+			// Collapse or expand the codebase context panel
+			if m.codebaseContext != "" {
+				m.contextCollapsed = !m.contextCollapsed
+			}
+			return m, nil
+
+		case "shift+up", "K":
+			// Ordered: move the highlighted option one rank higher
+			if m.isOrdered() {
+				m.moveRank(m.selectedOption, -1)
+			}
+			return m, nil
+
+		case "shift+down", "J":
+			// Ordered: move the highlighted option one rank lower
+			if m.isOrdered() {
+				m.moveRank(m.selectedOption, 1)
+			}
+			return m, nil
+
 		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
-			// Quick navigate by number (user must press Enter to confirm)
 			idx := int(msg.String()[0] - '1')
+			// Ordered: number keys append the option to the ranking
+			if m.isOrdered() {
+				if idx >= 0 && idx < len(m.options) {
+					m.selectedOption = idx
+				}
+				return m.toggleRank(idx)
+			}
+			// Quick navigate by number (user must press Enter to confirm)
 			if idx >= 0 && idx < len(m.options) {
 				m.selectedOption = idx
 			}
@@ -413,6 +504,79 @@ func (m InterviewModel) toggleMultiSelect() (InterviewModel, tea.Cmd) {
 	return m, nil
 }
 
+// isOrdered reports whether the current question asks for a ranking.
+func (m InterviewModel) isOrdered() bool {
+	return m.currentQ >= 0 && m.currentQ < len(m.questions) && m.questions[m.currentQ].Ordered
+}
+
+// toggleRank appends the option at idx to the ranking, or removes it if it
+// is already ranked (lower-ranked options move up). Meta and custom options
+// fall through to handleSelection.
+func (m InterviewModel) toggleRank(idx int) (InterviewModel, tea.Cmd) {
+	if idx < 0 || idx >= len(m.options) {
+		return m, nil
+	}
+
+	opt := m.options[idx]
+	if opt.isMeta || opt.isCustom {
+		return m.handleSelection()
+	}
+
+	if pos := m.rankOf(opt.key); pos >= 0 {
+		m.ranking = append(m.ranking[:pos:pos], m.ranking[pos+1:]...)
+	} else {
+		m.ranking = append(m.ranking, opt.key)
+	}
+	return m, nil
+}
+
+// moveRank swaps the option at idx with its neighbour in the ranking,
+// delta -1 moving it up and +1 moving it down. Unranked options are ignored.
+func (m *InterviewModel) moveRank(idx, delta int) {
+	if idx < 0 || idx >= len(m.options) {
+		return
+	}
+	pos := m.rankOf(m.options[idx].key)
+	target := pos + delta
+	if pos < 0 || target < 0 || target >= len(m.ranking) {
+		return
+	}
+	m.ranking[pos], m.ranking[target] = m.ranking[target], m.ranking[pos]
+}
+
+// rankOf returns the 0-based rank of the option key, or -1 if unranked.
+func (m InterviewModel) rankOf(key string) int {
+	for i, k := range m.ranking {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// rankedLabels returns the labels of ranked options, highest rank first.
+func (m InterviewModel) rankedLabels() []string {
+	labels := make([]string, 0, len(m.ranking))
+	for _, key := range m.ranking {
+		for _, opt := range m.options {
+			if opt.key == key {
+				labels = append(labels, opt.label)
+				break
+			}
+		}
+	}
+	return labels
+}
+
+// formatRanking renders labels as "1. X, 2. Y".
+func formatRanking(labels []string) string {
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = fmt.Sprintf("%d. %s", i+1, l)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // handleSelection processes the currently selected option.
 func (m InterviewModel) handleSelection() (InterviewModel, tea.Cmd) {
 	if m.selectedOption < 0 || m.selectedOption >= len(m.options) {
@@ -434,8 +598,7 @@ func (m InterviewModel) handleSelection() (InterviewModel, tea.Cmd) {
 		// Submit custom value if there's content
 		value := strings.TrimSpace(m.customInput.Value())
 		if value != "" {
-			m.saveAnswer(value, nil)
-			return m.advanceToNext()
+			return m.answerAndAdvance(value, nil)
 		}
 		// No content - stay on this option (input is already focused)
 		return m, nil
@@ -443,7 +606,10 @@ func (m InterviewModel) handleSelection() (InterviewModel, tea.Cmd) {
 		// Regular option selected
 		q := m.questions[m.currentQ]
 
-		if q.MultiSelect {
+		if q.Ordered {
+			// For ordered, confirm the ranking in rank order
+			return m.answerAndAdvance("", m.rankedLabels())
+		} else if q.MultiSelect {
 			// For multi-select, confirm all selections
 			var values []string
 			for _, o := range m.options {
@@ -451,20 +617,26 @@ func (m InterviewModel) handleSelection() (InterviewModel, tea.Cmd) {
 					values = append(values, o.label)
 				}
 			}
-			m.saveAnswer("", values)
-		} else {
-			// Single select
-			m.saveAnswer(opt.label, nil)
+			return m.answerAndAdvance("", values)
 		}
-
-		return m.advanceToNext()
+		// Single select
+		return m.answerAndAdvance(opt.label, nil)
 	}
 }
 
-// saveAnswer stores the answer for the current question.
-func (m *InterviewModel) saveAnswer(value string, values []string) {
+// answerAndAdvance saves the answer for the current question, reports it
+// with an AnswerMsg so it can be persisted, and moves on.
+func (m InterviewModel) answerAndAdvance(value string, values []string) (InterviewModel, tea.Cmd) {
+	saved := m.saveAnswer(value, values)
+	m, cmd := m.advanceToNext()
+	return m, tea.Batch(saved, cmd)
+}
+
+// saveAnswer stores the answer for the current question and returns a
+// command emitting it as an AnswerMsg.
+func (m *InterviewModel) saveAnswer(value string, values []string) tea.Cmd {
 	if m.currentQ < 0 || m.currentQ >= len(m.questions) {
-		return
+		return nil
 	}
 
 	q := m.questions[m.currentQ]
@@ -472,6 +644,9 @@ func (m *InterviewModel) saveAnswer(value string, values []string) {
 		value:  value,
 		values: values,
 	}
+	return func() tea.Msg {
+		return tui.AnswerMsg{QuestionID: q.ID, Value: value, Values: values}
+	}
 }
 
 // advanceToNext moves to the next question or submit screen.
@@ -656,7 +831,7 @@ func (m InterviewModel) renderQuestionScreen() string {
 		Italic(true)
 
 	q := m.questions[m.currentQ]
-	isMultiSelect := q.MultiSelect
+	isMultiSelect := q.MultiSelect && !q.Ordered
 
 	// Navigation bar
 	navBar := m.renderNavBar()
@@ -667,13 +842,37 @@ func (m InterviewModel) renderQuestionScreen() string {
 	b.WriteString(titleStyle.Render("Understanding your requirements"))
 	b.WriteString("\n\n")
 
+	// Codebase context
+	if m.codebaseContext != "" {
+		b.WriteString(m.renderContextPanel())
+		b.WriteString("\n\n")
+	}
+
 	// Question
 	b.WriteString(questionStyle.Render(q.Text))
-	if isMultiSelect {
+	if q.Ordered {
+		b.WriteString(dimStyle.Render(" (rank in order)"))
+	} else if isMultiSelect {
 		b.WriteString(dimStyle.Render(" (multi-select)"))
 	}
+	ref := q.ID
+	if m.round > 0 {
+		ref = understand.AnswerRef(m.round, q.ID)
+	}
+	b.WriteString(dimStyle.Render(" {{" + ref + "}}"))
 	b.WriteString("\n\n")
 
+	// Current ranking for ordered questions
+	if q.Ordered {
+		if labels := m.rankedLabels(); len(labels) > 0 {
+			b.WriteString(dimStyle.Render("Ranking: "))
+			b.WriteString(formatRanking(labels))
+		} else {
+			b.WriteString(dimStyle.Render("Ranking: (none yet)"))
+		}
+		b.WriteString("\n\n")
+	}
+
 	// Track where meta options start (for separator)
 	metaStartIdx := -1
 	for i, opt := range m.options {
@@ -709,6 +908,15 @@ func (m InterviewModel) renderQuestionScreen() string {
 		line.WriteString(fmt.Sprintf("%d. ", optionNum))
 		optionNum++
 
+		// Rank slot for ordered questions (non-meta options only)
+		if q.Ordered && !opt.isMeta && !opt.isCustom {
+			if pos := m.rankOf(opt.key); pos >= 0 {
+				line.WriteString(fmt.Sprintf("[%d] ", pos+1))
+			} else {
+				line.WriteString("[ ] ")
+			}
+		}
+
 		// Checkbox for multi-select (non-meta options only)
 		if isMultiSelect && !opt.isMeta && !opt.isCustom {
 			if m.selectedValues[opt.key] {
@@ -760,12 +968,17 @@ func (m InterviewModel) renderQuestionScreen() string {
 
 	// Footer
 	var footerHint string
-	if isMultiSelect {
+	if q.Ordered {
+		footerHint = "1-9/Space to rank · Shift+arrows to reorder · Enter to confirm"
+	} else if isMultiSelect {
 		footerHint = "Space to toggle · Enter to confirm · arrows to navigate"
 	} else {
 		footerHint = "Enter to select · arrows to navigate"
 	}
 	b.WriteString(dimStyle.Render(footerHint))
+	if m.codebaseContext != "" {
+		b.WriteString(dimStyle.Render(" · c: context"))
+	}
 
 	// Esc hint - dynamic based on pending state
 	b.WriteString(" · ")
@@ -793,6 +1006,28 @@ func (m InterviewModel) renderQuestionScreen() string {
 	return boxed
 }
 
+// renderContextPanel renders the codebase context panel, or a one-line
+// placeholder when it is collapsed.
+func (m InterviewModel) renderContextPanel() string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#10B981")).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280"))
+
+	if m.contextCollapsed {
+		return headerStyle.Render("▸ Codebase context") + dimStyle.Render(" (c to expand)")
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("▾ Codebase context"))
+	b.WriteString(dimStyle.Render(" (c to collapse)"))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(m.codebaseContext))
+	return b.String()
+}
+
 // renderSubmitScreen renders the submit review screen.
 func (m InterviewModel) renderSubmitScreen() string {
 	var b strings.Builder
@@ -853,7 +1088,9 @@ func (m InterviewModel) renderSubmitScreen() string {
 		// Answer
 		if answer, ok := m.answers[q.ID]; ok {
 			b.WriteString("  -> ")
-			if q.MultiSelect && len(answer.values) > 0 {
+			if q.Ordered && len(answer.values) > 0 {
+				b.WriteString(answerStyle.Render(formatRanking(answer.values)))
+			} else if q.MultiSelect && len(answer.values) > 0 {
 				b.WriteString(answerStyle.Render(strings.Join(answer.values, ", ")))
 			} else if answer.value != "" {
 				b.WriteString(answerStyle.Render(answer.value))
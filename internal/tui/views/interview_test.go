@@ -0,0 +1,222 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+func rankQuestion() []tui.Question {
+	return []tui.Question{{
+		ID:   "q1",
+		Text: "Rank these features by priority",
+		Options: []tui.Option{
+			{Key: "1", Label: "Search"},
+			{Key: "2", Label: "Export"},
+			{Key: "3", Label: "Sharing"},
+		},
+		MultiSelect: true,
+		Ordered:     true,
+	}}
+}
+
+func pressKey(t *testing.T, m InterviewModel, key tea.KeyPressMsg) InterviewModel {
+	t.Helper()
+	m, _ = m.Update(key)
+	return m
+}
+
+func digit(r rune) tea.KeyPressMsg {
+	return tea.KeyPressMsg{Code: r, Text: string(r)}
+}
+
+func assertRanking(t *testing.T, m InterviewModel, want ...string) {
+	t.Helper()
+	got := m.rankedLabels()
+	if len(got) != len(want) {
+		t.Fatalf("ranking = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ranking[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestInterviewOrderedRankAssignment(t *testing.T) {
+	m := NewInterviewModel(rankQuestion(), 100, 40)
+
+	// Number keys append to the ranking in press order.
+	m = pressKey(t, m, digit('3'))
+	m = pressKey(t, m, digit('1'))
+	assertRanking(t, m, "Sharing", "Search")
+
+	// Pressing a ranked option again removes it; later ranks move up.
+	m = pressKey(t, m, digit('3'))
+	assertRanking(t, m, "Search")
+
+	m = pressKey(t, m, digit('2'))
+	m = pressKey(t, m, digit('3'))
+	assertRanking(t, m, "Search", "Export", "Sharing")
+
+	if got := formatRanking(m.rankedLabels()); got != "1. Search, 2. Export, 3. Sharing" {
+		t.Errorf("formatRanking() = %q", got)
+	}
+
+	// Enter confirms and the answer preserves rank order.
+	m = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	answer, ok := m.answers["q1"]
+	if !ok {
+		t.Fatal("expected answer to be saved")
+	}
+	want := []string{"Search", "Export", "Sharing"}
+	if len(answer.values) != len(want) {
+		t.Fatalf("answer values = %v, want %v", answer.values, want)
+	}
+	for i := range want {
+		if answer.values[i] != want[i] {
+			t.Errorf("answer values[%d] = %q, want %q", i, answer.values[i], want[i])
+		}
+	}
+}
+
+func TestInterviewOrderedReordering(t *testing.T) {
+	m := NewInterviewModel(rankQuestion(), 100, 40)
+
+	m = pressKey(t, m, digit('1'))
+	m = pressKey(t, m, digit('2'))
+	m = pressKey(t, m, digit('3'))
+	assertRanking(t, m, "Search", "Export", "Sharing")
+
+	// The last number pressed highlights "Sharing"; move it up twice.
+	m = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModShift})
+	assertRanking(t, m, "Search", "Sharing", "Export")
+	m = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModShift})
+	assertRanking(t, m, "Sharing", "Search", "Export")
+
+	// Already at the top: no change.
+	m = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModShift})
+	assertRanking(t, m, "Sharing", "Search", "Export")
+
+	m = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyDown, Mod: tea.ModShift})
+	assertRanking(t, m, "Search", "Sharing", "Export")
+}
+
+func TestInterviewMultiSelectUnchanged(t *testing.T) {
+	qs := rankQuestion()
+	qs[0].Ordered = false
+	m := NewInterviewModel(qs, 100, 40)
+
+	// Number keys only move the highlight for plain multi-select.
+	m = pressKey(t, m, digit('2'))
+	if m.selectedOption != 1 {
+		t.Errorf("selectedOption = %d, want 1", m.selectedOption)
+	}
+	if len(m.ranking) != 0 {
+		t.Errorf("ranking = %v, want none", m.ranking)
+	}
+
+	m = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	if !m.selectedValues["2"] {
+		t.Error("space should toggle the highlighted option")
+	}
+}
+
+func TestInterviewCodebaseContextToggle(t *testing.T) {
+	m := NewInterviewModel(rankQuestion(), 100, 40)
+	if strings.Contains(m.View(), "Codebase context") {
+		t.Fatal("context panel shown without a graph summary")
+	}
+
+	m.SetCodebaseContext("## Codebase\n- internal/auth: login handlers")
+	view := m.View()
+	if !strings.Contains(view, "Codebase context") || !strings.Contains(view, "login handlers") {
+		t.Fatalf("expanded panel missing summary:\n%s", view)
+	}
+
+	m = pressKey(t, m, digit('c'))
+	view = m.View()
+	if !strings.Contains(view, "Codebase context") || strings.Contains(view, "login handlers") {
+		t.Fatalf("collapsed panel should hide the summary:\n%s", view)
+	}
+
+	m = pressKey(t, m, digit('c'))
+	if !strings.Contains(m.View(), "login handlers") {
+		t.Error("pressing c again should expand the panel")
+	}
+}
+
+func pickQuestions() []tui.Question {
+	return []tui.Question{
+		{ID: "db", Text: "Which database?", Options: []tui.Option{{Key: "1", Label: "Postgres"}, {Key: "2", Label: "SQLite"}}},
+		{ID: "auth", Text: "Which auth?", Options: []tui.Option{{Key: "1", Label: "OAuth"}, {Key: "2", Label: "Sessions"}}},
+	}
+}
+
+func TestInterviewRestoresPriorAnswers(t *testing.T) {
+	prior := []tui.Answer{{ID: "db", Value: "SQLite"}, {ID: "gone", Value: "x"}}
+	m := NewInterviewModelWithAnswers(pickQuestions(), prior, 100, 40)
+	if m.currentQ != 1 || m.isOnSubmit {
+		t.Fatalf("currentQ = %d, isOnSubmit = %v; want the first unanswered question", m.currentQ, m.isOnSubmit)
+	}
+	if _, ok := m.answers["gone"]; ok {
+		t.Error("answer to an unknown question was restored")
+	}
+
+	// Going back shows the restored selection.
+	m.currentQ = 0
+	m.loadCurrentQuestion()
+	if m.selectedOption != 1 {
+		t.Errorf("selectedOption = %d, want the restored SQLite", m.selectedOption)
+	}
+
+	all := append(prior, tui.Answer{ID: "auth", Value: "OAuth"})
+	m = NewInterviewModelWithAnswers(pickQuestions(), all, 100, 40)
+	if !m.isOnSubmit {
+		t.Error("all questions answered; want the Submit screen")
+	}
+}
+
+func TestInterviewAnswerEmitsAnswerMsg(t *testing.T) {
+	m := NewInterviewModel(pickQuestions(), 100, 40)
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("answering returned no command")
+	}
+	var got *tui.AnswerMsg
+	msgs := []tea.Msg{cmd()}
+	if batch, ok := msgs[0].(tea.BatchMsg); ok {
+		msgs = nil
+		for _, c := range batch {
+			if c != nil {
+				msgs = append(msgs, c())
+			}
+		}
+	}
+	for _, msg := range msgs {
+		if ans, ok := msg.(tui.AnswerMsg); ok {
+			got = &ans
+		}
+	}
+	if got == nil || got.QuestionID != "db" || got.Value != "SQLite" {
+		t.Errorf("AnswerMsg = %+v, want db=SQLite", got)
+	}
+	if m.currentQ != 1 {
+		t.Errorf("currentQ = %d, want 1", m.currentQ)
+	}
+}
+
+func TestInterviewShowsAnswerReference(t *testing.T) {
+	m := NewInterviewModel(rankQuestion(), 100, 40)
+	if !strings.Contains(m.View(), "{{q1}}") {
+		t.Errorf("view without a round does not show {{q1}}:\n%s", m.View())
+	}
+	m.SetRound(2)
+	if !strings.Contains(m.View(), "{{r2.q1}}") {
+		t.Errorf("view does not show {{r2.q1}}:\n%s", m.View())
+	}
+}
@@ -0,0 +1,81 @@
+package views
+
+import (
+	"regexp"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+// Inline markdown patterns handled by renderMarkdown.
+var (
+	mdBoldRe = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdCodeRe = regexp.MustCompile("`([^`]+)`")
+	mdLinkRe = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+)
+
+var (
+	mdBoldStyle = lipgloss.NewStyle().Bold(true)
+	mdCodeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+)
+
+// renderMarkdown renders a small, commonly used subset of markdown for
+// terminal display: headings, bullet and numbered lists, block quotes,
+// fenced code blocks, bold, inline code, and links. Anything else is passed
+// through unchanged. It is intentionally lightweight so toggling the view
+// stays cheap for large learnings files.
+func renderMarkdown(src string) string {
+	lines := strings.Split(src, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, mdCodeStyle.Render("    "+line))
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			out = append(out, tui.TitleStyle.Render(renderInline(heading)))
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			out = append(out, indent+"• "+renderInline(trimmed[2:]))
+		case strings.HasPrefix(trimmed, "> "):
+			out = append(out, tui.DimStyle.Render("│ "+renderInline(trimmed[2:])))
+		default:
+			out = append(out, renderInline(line))
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// renderInline applies inline markdown styling (bold, code, links) to a line.
+func renderInline(s string) string {
+	s = mdCodeRe.ReplaceAllStringFunc(s, func(m string) string {
+		return mdCodeStyle.Render(mdCodeRe.FindStringSubmatch(m)[1])
+	})
+	s = mdBoldRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdBoldRe.FindStringSubmatch(m)
+		text := sub[1]
+		if text == "" {
+			text = sub[2]
+		}
+		return mdBoldStyle.Render(text)
+	})
+	s = mdLinkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdLinkRe.FindStringSubmatch(m)
+		return sub[1] + tui.DimStyle.Render(" ("+sub[2]+")")
+	})
+	return s
+}
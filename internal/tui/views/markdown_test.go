@@ -0,0 +1,93 @@
+package views
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var ansiRe = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// plain strips the styling renderMarkdown adds, leaving the text a user
+// reads.
+func plain(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
+
+func TestRenderMarkdownBlocks(t *testing.T) {
+	src := strings.Join([]string{
+		"## Auth learnings",
+		"- use **bcrypt** for hashes",
+		"  * nested item",
+		"> tokens expire after an hour",
+		"```go",
+		"func main() {}",
+		"```",
+		"Plain line stays as is.",
+	}, "\n")
+
+	got := strings.Split(plain(renderMarkdown(src)), "\n")
+	want := []string{
+		"Auth learnings",
+		"• use bcrypt for hashes",
+		"  • nested item",
+		"│ tokens expire after an hour",
+		"    func main() {}",
+		"Plain line stays as is.",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("rendered %d lines, want %d (fences dropped):\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if strings.TrimRight(got[i], " ") != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRenderMarkdownInline(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"run `go test` first", "run go test first"},
+		{"**bold** and __also bold__", "bold and also bold"},
+		{"see [the docs](https://example.com)", "see the docs (https://example.com)"},
+		{"a * lone star and 2*3", "a * lone star and 2*3"},
+	}
+	for _, tt := range tests {
+		if got := plain(renderInline(tt.in)); got != tt.want {
+			t.Errorf("renderInline(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	if styled := renderInline("**bold**"); styled == "bold" {
+		t.Error("bold text was not styled")
+	}
+}
+
+func TestRenderMarkdownKeepsFencedMarkup(t *testing.T) {
+	got := plain(renderMarkdown("```\n# not a heading\n- not a list\n```"))
+	want := "    # not a heading\n    - not a list"
+	if got != want {
+		t.Errorf("fenced block = %q, want markup left as code", got)
+	}
+}
+
+func TestDashboardTogglesRenderedLearnings(t *testing.T) {
+	m := NewDashboardModel("", []string{"# Notes\n- **keep** it short"}, nil, 120, 40, nil)
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if m.activeTab != 1 {
+		t.Fatalf("active tab = %d, want 1 (Learnings)", m.activeTab)
+	}
+	if view := m.View(); !strings.Contains(view, "- **keep** it short") || !strings.Contains(view, "m: Render markdown") {
+		t.Errorf("learnings should start as raw text:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	view := plain(m.View())
+	if !strings.Contains(view, "• keep it short") || strings.Contains(view, "**keep**") || !strings.Contains(view, "m: Raw text") {
+		t.Errorf("m should render the learnings as markdown:\n%s", view)
+	}
+}
@@ -24,6 +24,10 @@ type PlanModel struct {
 	expanded          map[string]bool
 	showFeedbackInput bool
 	feedbackInput     textinput.Model
+	expandTarget      string // bead being broken down; empty when rejecting
+	editTarget        string // bead being edited; empty outside edit mode
+	editField         string
+	editInput         textinput.Model
 	width             int
 	height            int
 }
@@ -35,6 +39,10 @@ func NewPlanModel(plan *tui.Plan, groups []tui.ExecutionGroup, width, height int
 	ti.CharLimit = 1000
 	ti.SetWidth(width - 10)
 
+	ei := textinput.New()
+	ei.CharLimit = 1000
+	ei.SetWidth(width - 10)
+
 	return PlanModel{
 		plan:              plan,
 		groups:            groups,
@@ -42,6 +50,7 @@ func NewPlanModel(plan *tui.Plan, groups []tui.ExecutionGroup, width, height int
 		expanded:          make(map[string]bool),
 		showFeedbackInput: false,
 		feedbackInput:     ti,
+		editInput:         ei,
 		width:             width,
 		height:            height,
 	}
@@ -56,6 +65,40 @@ func (m PlanModel) Init() tea.Cmd {
 func (m PlanModel) Update(msg tea.Msg) (PlanModel, tea.Cmd) {
 	var cmd tea.Cmd
 
+	// Handle bead edit mode
+	if m.editTarget != "" {
+		switch msg := msg.(type) {
+		case tea.KeyPressMsg:
+			switch msg.String() {
+			case tui.KeyEsc:
+				m.editTarget = ""
+				m.editInput.Blur()
+				return m, nil
+			case tui.KeyTab:
+				// Submit the typed value before moving on so it is not lost.
+				var submit tea.Cmd
+				if value := m.editInput.Value(); value != m.fieldValue(m.editTarget, m.editField) {
+					edit := tui.EditBeadMsg{BeadID: m.editTarget, Field: m.editField, Value: value}
+					submit = func() tea.Msg {
+						return edit
+					}
+				}
+				m.startEdit(m.editTarget, nextEditField(m.editField))
+				return m, submit
+			case tui.KeyEnter:
+				edit := tui.EditBeadMsg{BeadID: m.editTarget, Field: m.editField, Value: m.editInput.Value()}
+				m.editTarget = ""
+				m.editInput.Blur()
+				return m, func() tea.Msg {
+					return edit
+				}
+			}
+		}
+
+		m.editInput, cmd = m.editInput.Update(msg)
+		return m, cmd
+	}
+
 	// Handle feedback input mode
 	if m.showFeedbackInput {
 		switch msg := msg.(type) {
@@ -69,6 +112,11 @@ func (m PlanModel) Update(msg tea.Msg) (PlanModel, tea.Cmd) {
 				feedback := strings.TrimSpace(m.feedbackInput.Value())
 				m.showFeedbackInput = false
 				m.feedbackInput.Blur()
+				if beadID := m.expandTarget; beadID != "" {
+					return m, func() tea.Msg {
+						return tui.ExpandBeadMsg{BeadID: beadID, Feedback: feedback}
+					}
+				}
 				return m, func() tea.Msg {
 					return tui.RejectMsg{Feedback: feedback}
 				}
@@ -88,9 +136,28 @@ func (m PlanModel) Update(msg tea.Msg) (PlanModel, tea.Cmd) {
 				return tui.ApproveMsg{}
 			}
 		case "r":
+			m.expandTarget = ""
+			m.showFeedbackInput = true
+			m.feedbackInput.Focus()
+			return m, textinput.Blink
+		case "b":
+			// Break the selected bead down into smaller beads
+			beadID := m.getSelectedBeadID()
+			if beadID == "" {
+				return m, nil
+			}
+			m.expandTarget = beadID
 			m.showFeedbackInput = true
 			m.feedbackInput.Focus()
 			return m, textinput.Blink
+		case "e":
+			// Edit the selected bead's title, files, or dependencies
+			beadID := m.getSelectedBeadID()
+			if beadID == "" {
+				return m, nil
+			}
+			m.startEdit(beadID, tui.EditFieldTitle)
+			return m, textinput.Blink
 		case tui.KeyEnter:
 			// Toggle expansion of selected bead
 			beadID := m.getSelectedBeadID()
@@ -115,6 +182,7 @@ func (m PlanModel) Update(msg tea.Msg) (PlanModel, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.feedbackInput.SetWidth(msg.Width - 10)
+		m.editInput.SetWidth(msg.Width - 10)
 		return m, nil
 	}
 
@@ -141,6 +209,15 @@ func (m PlanModel) View() string {
 	b.WriteString(subheader)
 	b.WriteString("\n\n")
 
+	// Plan warnings (e.g. beads that declare no files)
+	if m.plan != nil && len(m.plan.Warnings) > 0 {
+		for _, w := range m.plan.Warnings {
+			b.WriteString(tui.WarningStyle.Render("⚠ " + w))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
 	// Render groups and beads
 	beadIndex := 0
 	for groupIdx, group := range m.groups {
@@ -186,6 +263,13 @@ func (m PlanModel) View() string {
 			}
 			b.WriteString("\n")
 
+			// Show dependency reasoning in explain-plan mode
+			if m.plan != nil && m.plan.Reasoning[beadID] != "" {
+				b.WriteString("    ")
+				b.WriteString(tui.DimStyle.Render("↳ " + m.plan.Reasoning[beadID]))
+				b.WriteString("\n")
+			}
+
 			// Show expanded details if this bead is expanded
 			if m.expanded[beadID] && bead != nil {
 				if bead.Description != "" {
@@ -197,6 +281,10 @@ func (m PlanModel) View() string {
 					b.WriteString("    ")
 					b.WriteString(tui.DimStyle.Render("Files: " + strings.Join(bead.Files, ", ")))
 					b.WriteString("\n")
+				} else if bead.NoFiles {
+					b.WriteString("    ")
+					b.WriteString(tui.DimStyle.Render("Files: none"))
+					b.WriteString("\n")
 				}
 			}
 
@@ -208,16 +296,29 @@ func (m PlanModel) View() string {
 	// Feedback input if showing
 	if m.showFeedbackInput {
 		b.WriteString("\n")
-		b.WriteString("Enter feedback for rejection:\n")
+		if m.expandTarget != "" {
+			b.WriteString(fmt.Sprintf("How should %s be split? (optional):\n", m.expandTarget))
+		} else {
+			b.WriteString("Enter feedback for rejection:\n")
+		}
 		b.WriteString(m.feedbackInput.View())
 		b.WriteString("\n\n")
 		b.WriteString(tui.DimStyle.Render("Enter: Submit | Esc: Cancel"))
 	}
 
+	// Bead edit input if editing
+	if m.editTarget != "" {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Edit %s of %s:\n", m.editField, m.editTarget))
+		b.WriteString(m.editInput.View())
+		b.WriteString("\n\n")
+		b.WriteString(tui.DimStyle.Render("Enter: Save | Tab: Next field | Esc: Cancel"))
+	}
+
 	b.WriteString("\n")
 
 	// Footer
-	footer := tui.DimStyle.Render("[a] Approve · [r] Reject · [↑ ↓] Navigate · [Enter] Expand")
+	footer := tui.DimStyle.Render("[a] Approve · [r] Reject · [b] Break down · [e] Edit · [↑ ↓] Navigate · [Enter] Expand")
 	b.WriteString(footer)
 
 	// Wrap in box style
@@ -238,6 +339,69 @@ func (m PlanModel) View() string {
 	return boxed
 }
 
+// SetGroups replaces the execution groups after the plan was edited,
+// keeping the same bead selected.
+func (m *PlanModel) SetGroups(groups []tui.ExecutionGroup) {
+	selected := m.getSelectedBeadID()
+	m.groups = groups
+	beadIndex := 0
+	for _, group := range groups {
+		for _, beadID := range group.BeadIDs {
+			if beadID == selected {
+				m.selectedBead = beadIndex
+				return
+			}
+			beadIndex++
+		}
+	}
+	m.selectedBead = min(m.selectedBead, max(beadIndex-1, 0))
+}
+
+// startEdit opens the edit input on field of bead beadID, filled with the
+// field's current value.
+func (m *PlanModel) startEdit(beadID, field string) {
+	m.editTarget = beadID
+	m.editField = field
+	value := m.fieldValue(beadID, field)
+	if field == tui.EditFieldTitle {
+		m.editInput.Placeholder = "Bead title"
+	} else {
+		m.editInput.Placeholder = "Comma-separated, empty for none"
+	}
+	m.editInput.SetValue(value)
+	m.editInput.CursorEnd()
+	m.editInput.Focus()
+}
+
+// fieldValue returns the current value of a bead field as shown in the edit input.
+func (m PlanModel) fieldValue(beadID, field string) string {
+	bead := m.findBead(beadID)
+	if bead == nil {
+		return ""
+	}
+	switch field {
+	case tui.EditFieldTitle:
+		return bead.Title
+	case tui.EditFieldFiles:
+		return strings.Join(bead.Files, ", ")
+	case tui.EditFieldDependsOn:
+		return strings.Join(bead.DependsOn, ", ")
+	}
+	return ""
+}
+
+// nextEditField returns the bead field Tab moves to from field.
+func nextEditField(field string) string {
+	switch field {
+	case tui.EditFieldTitle:
+		return tui.EditFieldFiles
+	case tui.EditFieldFiles:
+		return tui.EditFieldDependsOn
+	default:
+		return tui.EditFieldTitle
+	}
+}
+
 // findBead returns the BeadSpec for the given ID, or nil if not found.
 func (m PlanModel) findBead(id string) *tui.BeadSpec {
 	if m.plan == nil {
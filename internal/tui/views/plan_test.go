@@ -0,0 +1,73 @@
+package views
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+func TestPlanEditEmitsEditBeadMsg(t *testing.T) {
+	p := &tui.Plan{Beads: []tui.BeadSpec{
+		{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}},
+		{ID: "bt-2", Title: "Wire handler", DependsOn: []string{"bt-1"}},
+	}}
+	groups := []tui.ExecutionGroup{{BeadIDs: []string{"bt-1"}}, {Index: 1, BeadIDs: []string{"bt-2"}}}
+	m := NewPlanModel(p, groups, 100, 40)
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	if got := m.editInput.Value(); got != "Wire handler" {
+		t.Fatalf("edit input = %q, want the bead's title", got)
+	}
+
+	// Tab moves on to files, then dependencies, without sending unchanged fields.
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if cmd != nil {
+		t.Errorf("Tab over an unchanged title sent %+v", cmd())
+	}
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if m.editField != tui.EditFieldDependsOn || m.editInput.Value() != "bt-1" {
+		t.Fatalf("after two tabs editing %q = %q, want depends_on = bt-1", m.editField, m.editInput.Value())
+	}
+	m.editInput.SetValue("")
+
+	m, cmd = m.Update(enterKey)
+	if cmd == nil {
+		t.Fatal("Enter returned no command")
+	}
+	want := tui.EditBeadMsg{BeadID: "bt-2", Field: tui.EditFieldDependsOn, Value: ""}
+	if got, ok := cmd().(tui.EditBeadMsg); !ok || got != want {
+		t.Errorf("Enter sent %+v, want %+v", cmd(), want)
+	}
+	if m.editTarget != "" {
+		t.Error("still in edit mode after Enter")
+	}
+
+	// The edit regroups bt-2 alongside bt-1; the selection follows bt-2.
+	m.SetGroups([]tui.ExecutionGroup{{Parallel: true, BeadIDs: []string{"bt-2", "bt-1"}}})
+	if got := m.getSelectedBeadID(); got != "bt-2" {
+		t.Errorf("selected %s after regrouping, want bt-2", got)
+	}
+}
+
+func TestPlanEditTabSubmitsTypedValue(t *testing.T) {
+	p := &tui.Plan{Beads: []tui.BeadSpec{{ID: "bt-1", Title: "Add model", Files: []string{"model.go"}}}}
+	m := NewPlanModel(p, []tui.ExecutionGroup{{BeadIDs: []string{"bt-1"}}}, 100, 40)
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	m.editInput.SetValue("Add user model")
+
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if cmd == nil {
+		t.Fatal("Tab dropped the typed title")
+	}
+	want := tui.EditBeadMsg{BeadID: "bt-1", Field: tui.EditFieldTitle, Value: "Add user model"}
+	if got, ok := cmd().(tui.EditBeadMsg); !ok || got != want {
+		t.Errorf("Tab sent %+v, want %+v", cmd(), want)
+	}
+	if m.editField != tui.EditFieldFiles || m.editInput.Value() != "model.go" {
+		t.Errorf("after Tab editing %q = %q, want files = model.go", m.editField, m.editInput.Value())
+	}
+}
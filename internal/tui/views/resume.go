@@ -0,0 +1,160 @@
+// Package views provides TUI view components for the Berth application.
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/berth-dev/berth/internal/tui"
+)
+
+// ============================================================================
+// ResumeModel
+// ============================================================================
+
+// InterruptedRun describes a run that left a checkpoint behind, for the
+// resume prompt.
+type InterruptedRun struct {
+	ID        string // directory name under .berth/runs
+	Branch    string
+	Completed int
+	Failed    int
+	SavedAt   time.Time
+	LastError string
+}
+
+// ResumeModel is the view model for the "Resume interrupted run?" prompt
+// shown at startup when the latest run has a checkpoint.
+type ResumeModel struct {
+	width    int
+	height   int
+	selected int // 0 = Yes (Resume), 1 = No (Home)
+	run      InterruptedRun
+}
+
+// NewResumeModel creates a new ResumeModel for the given run.
+func NewResumeModel(width, height int, run InterruptedRun) ResumeModel {
+	return ResumeModel{
+		width:    width,
+		height:   height,
+		selected: 0, // Default to "Yes"
+		run:      run,
+	}
+}
+
+// Init returns the initial command for the resume view.
+func (m ResumeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the resume view.
+func (m ResumeModel) Update(msg tea.Msg) (ResumeModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "left", "h":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "right", "l":
+			if m.selected < 1 {
+				m.selected++
+			}
+		case "tab":
+			m.selected = (m.selected + 1) % 2
+		case "enter", " ":
+			if m.selected == 0 {
+				return m, func() tea.Msg {
+					return tui.ResumeConfirmMsg{}
+				}
+			}
+			return m, func() tea.Msg {
+				return tui.ResumeDeclineMsg{}
+			}
+		case "esc":
+			return m, func() tea.Msg {
+				return tui.ResumeDeclineMsg{}
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// View renders the resume view.
+func (m ResumeModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(tui.TitleStyle.Render("Resume interrupted run?"))
+	b.WriteString("\n\n")
+
+	b.WriteString(tui.DimStyle.Render(fmt.Sprintf("Run: %s", m.run.ID)))
+	b.WriteString("\n\n")
+
+	infoBoxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#4B5563")).
+		Padding(0, 1).
+		Foreground(lipgloss.Color("#9CA3AF"))
+
+	info := []string{
+		fmt.Sprintf("Checkpoint saved %s", m.run.SavedAt.Format("2006-01-02 15:04")),
+		fmt.Sprintf("%d completed, %d failed", m.run.Completed, m.run.Failed),
+	}
+	if m.run.Branch != "" {
+		info = append(info, fmt.Sprintf("Branch: %s", m.run.Branch))
+	}
+	if m.run.LastError != "" {
+		info = append(info, fmt.Sprintf("Last error: %s", m.run.LastError))
+	}
+	b.WriteString(infoBoxStyle.Render(strings.Join(info, "\n")))
+	b.WriteString("\n\n")
+
+	b.WriteString(tui.DimStyle.Render("Not now keeps the checkpoint for 'berth resume'."))
+	b.WriteString("\n\n")
+
+	yesStyle := lipgloss.NewStyle().
+		Padding(0, 2)
+	noStyle := lipgloss.NewStyle().
+		Padding(0, 2)
+
+	active := func(s lipgloss.Style) lipgloss.Style {
+		return s.Background(lipgloss.Color("#7C3AED")).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true)
+	}
+	inactive := func(s lipgloss.Style) lipgloss.Style {
+		return s.Foreground(lipgloss.Color("#9CA3AF"))
+	}
+	if m.selected == 0 {
+		yesStyle, noStyle = active(yesStyle), inactive(noStyle)
+	} else {
+		yesStyle, noStyle = inactive(yesStyle), active(noStyle)
+	}
+
+	buttons := lipgloss.JoinHorizontal(lipgloss.Center,
+		yesStyle.Render("Yes, resume"), "  ", noStyle.Render("Not now"))
+	b.WriteString(buttons)
+	b.WriteString("\n\n")
+
+	b.WriteString(tui.DimStyle.Render("← →: Select · Enter: Confirm · Esc: Not now"))
+
+	const maxResumeBoxWidth = 70
+	boxWidth := maxResumeBoxWidth
+	if m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+
+	return tui.BoxStyle.
+		Width(boxWidth).
+		Render(b.String())
+}
@@ -0,0 +1,14 @@
+// This file strips ANSI escape sequences from captured Claude output.
+package ui
+
+import "regexp"
+
+// ansiRe matches CSI sequences (colors, cursor movement), OSC sequences
+// (titles, hyperlinks) terminated by BEL or ST, and two-byte escapes.
+var ansiRe = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes ANSI escape sequences from s so captured Claude output
+// can be stored or re-styled without stray control codes.
+func StripANSI(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
@@ -0,0 +1,89 @@
+// This file implements a single-line spinner for blocking Claude calls in
+// headless mode.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner draws an animated status line on stdout until stopped. When stdout
+// is not a TTY it prints the message once as a plain line instead, so piped
+// output stays free of control sequences.
+type Spinner struct {
+	msg   string
+	isTTY bool
+	done  chan struct{}
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+// StartSpinner starts a spinner showing msg and the elapsed time.
+func StartSpinner(msg string) *Spinner {
+	s := &Spinner{
+		msg:   msg,
+		isTTY: term.IsTerminal(int(os.Stdout.Fd())),
+		done:  make(chan struct{}),
+	}
+	if !s.isTTY {
+		fmt.Println(msg)
+		return s
+	}
+
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+func (s *Spinner) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	start := time.Now()
+	for i := 0; ; i++ {
+		elapsed := time.Since(start).Truncate(time.Second)
+		fmt.Printf("\r\033[K%s %s (%s)", spinnerFrames[i%len(spinnerFrames)], s.msg, elapsed)
+
+		select {
+		case <-s.done:
+			fmt.Print("\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop halts the spinner and clears its line. Safe to call more than once.
+func (s *Spinner) Stop() {
+	s.once.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+	})
+}
+
+// RunWithSpinner calls fn with a context that is cancelled on Ctrl+C, showing
+// a spinner with msg while it runs. The interrupt handler is removed when fn
+// returns, so a later Ctrl+C behaves normally.
+func RunWithSpinner(msg string, fn func(ctx context.Context) (string, error)) (string, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	spinner := StartSpinner(msg)
+	out, err := fn(ctx)
+	spinner.Stop()
+
+	if ctx.Err() != nil {
+		fmt.Println("Interrupted.")
+	}
+	return out, err
+}
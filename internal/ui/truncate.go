@@ -0,0 +1,25 @@
+// This file shortens oversized documents produced by Claude.
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TruncateBytes returns s cut to at most n bytes, ending at the last line
+// break within the limit when there is one so no line is left half-written,
+// and never splitting a UTF-8 character. The second result reports whether
+// anything was cut.
+func TruncateBytes(s string, n int) (string, bool) {
+	if len(s) <= n {
+		return s, false
+	}
+	cut := s[:n]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		return cut[:i+1], true
+	}
+	for len(cut) > 0 && !utf8.RuneStart(s[len(cut)]) {
+		cut = cut[:len(cut)-1]
+	}
+	return cut, true
+}
@@ -0,0 +1,46 @@
+// chat_store.go persists requirements chat messages to the session store.
+package understand
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/berth-dev/berth/internal/session"
+)
+
+// ChatRecorder saves chat messages for one session as they happen, so the
+// discussion survives beyond the chat loop. A nil *ChatRecorder is valid and
+// records nothing.
+type ChatRecorder struct {
+	Store     *session.Store
+	SessionID string
+}
+
+// Record saves a single chat message. Failures are reported as warnings;
+// losing a chat message must not interrupt the interview.
+func (r *ChatRecorder) Record(msg ChatMessage) {
+	if r == nil || r.Store == nil || r.SessionID == "" {
+		return
+	}
+	if err := r.Store.AddMessage(r.SessionID, msg.Role, msg.Content); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save chat message: %v\n", err)
+	}
+}
+
+// History returns the chat messages previously recorded for the session.
+func (r *ChatRecorder) History() []ChatMessage {
+	if r == nil || r.Store == nil || r.SessionID == "" {
+		return nil
+	}
+	stored, err := r.Store.GetMessages(r.SessionID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load chat history: %v\n", err)
+		return nil
+	}
+
+	messages := make([]ChatMessage, 0, len(stored))
+	for _, m := range stored {
+		messages = append(messages, ChatMessage{Role: m.Role, Content: m.Content})
+	}
+	return messages
+}
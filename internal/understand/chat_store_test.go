@@ -0,0 +1,54 @@
+package understand
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/session"
+)
+
+func TestChatRecorderRoundTrip(t *testing.T) {
+	store, err := session.NewStore(filepath.Join(t.TempDir(), "sessions.db"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	sess, err := store.CreateSession("demo", "add auth")
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	recorder := &ChatRecorder{Store: store, SessionID: sess.ID}
+	want := []ChatMessage{
+		{Role: "user", Content: "Should we use JWT?"},
+		{Role: "assistant", Content: "Yes, with short-lived access tokens."},
+	}
+	for _, m := range want {
+		recorder.Record(m)
+	}
+
+	got := recorder.History()
+	if len(got) != len(want) {
+		t.Fatalf("History() returned %d messages, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("message %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Messages are scoped to their session.
+	other := &ChatRecorder{Store: store, SessionID: "other"}
+	if h := other.History(); len(h) != 0 {
+		t.Errorf("other session history = %v, want empty", h)
+	}
+}
+
+func TestChatRecorderNilIsNoop(t *testing.T) {
+	var recorder *ChatRecorder
+	recorder.Record(ChatMessage{Role: "user", Content: "hi"})
+	if h := recorder.History(); h != nil {
+		t.Errorf("nil recorder History() = %v, want nil", h)
+	}
+}
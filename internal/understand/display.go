@@ -11,7 +11,8 @@ import (
 // DisplayQuestions renders each question in the terminal and collects answers
 // from stdin. Each question shows numbered options, a "(Recommended)" suffix
 // where applicable, and an optional "Help me decide" entry. When allow_custom
-// is true, the user may type free-form text instead of a number.
+// is true, the user may type free-form text instead of a number. Each
+// question's ID is shown so later answers can reference it as {{<id>}}.
 //
 // Returns one Answer per question in the same order as the input slice.
 func DisplayQuestions(questions []Question) []Answer {
@@ -29,7 +30,7 @@ func DisplayQuestions(questions []Question) []Answer {
 // displayOneQuestion renders a single question and reads one answer.
 func displayOneQuestion(q Question, reader *bufio.Reader) Answer {
 	fmt.Println()
-	fmt.Println(q.Text)
+	fmt.Printf("%s (%s)\n", q.Text, q.ID)
 
 	// Display numbered options.
 	for i, opt := range q.Options {
@@ -4,15 +4,32 @@ package understand
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/detect"
+	"github.com/berth-dev/berth/internal/ui"
 )
 
-const claudeTimeout = 5 * time.Minute
+// defaultSpawnTimeout bounds each Claude call when understand.spawn_timeout
+// is unset.
+const defaultSpawnTimeout = 5 * time.Minute
+
+// errSpawnTimeout is wrapped by the error of a Claude call that ran past its
+// timeout.
+var errSpawnTimeout = errors.New("claude timed out")
+
+// spawnTimeout returns how long each interview Claude call may run.
+func spawnTimeout(cfg config.Config) time.Duration {
+	if cfg.Understand.SpawnTimeout > 0 {
+		return time.Duration(cfg.Understand.SpawnTimeout) * time.Second
+	}
+	return defaultSpawnTimeout
+}
 
 // claudeOutputJSON is the envelope Claude returns with --output-format json.
 type claudeOutputJSON struct {
@@ -28,10 +45,12 @@ type claudeOutputJSON struct {
 // the options of a question. It returns a short explanation recommending one
 // option. This is invoked when the user selects "Help me decide" during the
 // interview loop.
-func RunExplain(question Question, stackInfo detect.StackInfo, graphSummary string) (string, error) {
+func RunExplain(agent config.AgentConfig, question Question, stackInfo detect.StackInfo, graphSummary string) (string, error) {
 	prompt := buildExplainPrompt(question, stackInfo, graphSummary)
 
-	output, err := spawnClaude(prompt)
+	output, err := ui.RunWithSpinner("Thinking it over...", func(ctx context.Context) (string, error) {
+		return spawnClaude(ctx, agent, prompt, defaultSpawnTimeout)
+	})
 	if err != nil {
 		return "", fmt.Errorf("explain: spawn claude: %w", err)
 	}
@@ -84,13 +103,13 @@ func buildExplainPrompt(q Question, stackInfo detect.StackInfo, graphSummary str
 }
 
 // spawnClaude runs `claude -p <prompt> --output-format json --dangerously-skip-permissions`
-// and returns the result text from the JSON output envelope.
-func spawnClaude(prompt string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), claudeTimeout)
+// and returns the result text from the JSON output envelope. The call is
+// bounded by timeout and killed early if parent is cancelled.
+func spawnClaude(parent context.Context, agent config.AgentConfig, prompt string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx,
-		"claude",
+	cmd := agent.Cmd(ctx,
 		"-p", prompt,
 		"--allowedTools", "Read,Grep,Glob",
 		"--output-format", "json",
@@ -100,7 +119,7 @@ func spawnClaude(prompt string) (string, error) {
 	out, err := cmd.Output()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return "", fmt.Errorf("claude timed out after %v", claudeTimeout)
+			return "", fmt.Errorf("%w after %v", errSpawnTimeout, timeout)
 		}
 		if ctx.Err() == context.Canceled {
 			return "", fmt.Errorf("claude was canceled: parent process may have exited or been interrupted")
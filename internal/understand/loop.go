@@ -6,14 +6,18 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/berth-dev/berth/internal/config"
 	"github.com/berth-dev/berth/internal/detect"
 	"github.com/berth-dev/berth/internal/log"
+	"github.com/berth-dev/berth/internal/ui"
 )
 
 // maxRounds is a safety cap to prevent infinite interview loops.
@@ -30,6 +34,7 @@ type Question struct {
 	AllowCustom bool     `json:"allow_custom"`
 	AllowHelp   bool     `json:"allow_help"`
 	MultiSelect bool     `json:"multi_select,omitempty"` // Allow multiple selections
+	Ordered     bool     `json:"ordered,omitempty"`      // Rank selections by priority; implies multi-select
 }
 
 // Option is one selectable choice within a Question.
@@ -50,7 +55,7 @@ type Round struct {
 type Answer struct {
 	ID     string
 	Value  string   // Single value for single-select
-	Values []string // Multiple values for multi-select
+	Values []string // Multiple values for multi-select (in rank order if ordered)
 }
 
 // UnderstandResponse is the JSON schema that Claude returns each round.
@@ -81,7 +86,9 @@ type InterviewSession struct {
 	RunDir           string
 	GraphSummary     string
 	Description      string
-	currentQuestions []Question // internal, for tracking current round's questions
+	Output           chan<- string // optional; receives Claude's text and tool calls while a round is generated
+	currentQuestions []Question    // internal, for tracking current round's questions
+	deferred         []Question    // internal, questions held back by the per-round cap
 }
 
 // StartInterviewSession initializes a new interview session and returns the first
@@ -95,6 +102,18 @@ func StartInterviewSession(
 	cfg config.Config,
 	stackInfo detect.StackInfo,
 	description, runDir, graphSummary string,
+) (*InterviewSession, []Question, error) {
+	return StartInterviewSessionWithOutput(ctx, cfg, stackInfo, description, runDir, graphSummary, nil)
+}
+
+// StartInterviewSessionWithOutput is StartInterviewSession streaming Claude's
+// output to out, which becomes the session's Output for later rounds too.
+func StartInterviewSessionWithOutput(
+	ctx context.Context,
+	cfg config.Config,
+	stackInfo detect.StackInfo,
+	description, runDir, graphSummary string,
+	out chan<- string,
 ) (*InterviewSession, []Question, error) {
 	session := &InterviewSession{
 		CurrentRound:   1,
@@ -104,22 +123,19 @@ func StartInterviewSession(
 		RunDir:         runDir,
 		GraphSummary:   graphSummary,
 		Description:    description,
+		Output:         out,
 	}
-	// Note: ctx parameter is kept for future use but not stored in session.
-	// Each spawnClaude call creates its own context with timeout.
-	_ = ctx
-
 	// Build the first interview prompt.
 	prompt := BuildUnderstandPrompt(session.CurrentRound, session.PreviousRounds, stackInfo, graphSummary, description)
 
 	// Spawn Claude to generate the first set of questions.
-	output, err := spawnClaude(prompt)
+	output, err := spawnClaudeStreaming(ctx, cfg.Agent, prompt, spawnTimeout(cfg), out)
 	if err != nil {
 		return nil, nil, fmt.Errorf("start interview: %w", err)
 	}
 
 	// Parse Claude's response.
-	cleaned := cleanJSONOutput(output)
+	cleaned := cleanJSONOutput(output, cfg.Understand.TrailingJSON)
 
 	var resp UnderstandResponse
 	if err := json.Unmarshal([]byte(cleaned), &resp); err != nil {
@@ -139,8 +155,9 @@ func StartInterviewSession(
 		return nil, nil, fmt.Errorf("start interview: claude returned done=false but no questions")
 	}
 
-	session.currentQuestions = resp.Questions
-	return session, resp.Questions, nil
+	ApplyStackRecommendations(resp.Questions, stackInfo)
+	session.currentQuestions, session.deferred = takeQuestions(resp.Questions, cfg.Understand.MaxQuestionsPerRound)
+	return session, session.currentQuestions, nil
 }
 
 // ContinueInterview processes the user's answers and returns either the next set
@@ -154,14 +171,22 @@ func StartInterviewSession(
 //
 // If done is true, the requirements document has been written to disk and the
 // Requirements struct is returned. If done is false, the caller should display
-// the questions and call ContinueInterview again with the answers.
-func (s *InterviewSession) ContinueInterview(answers []Answer) ([]Question, bool, *Requirements, error) {
+// the questions and call ContinueInterview again with the answers. Canceling
+// ctx stops the in-flight Claude call.
+func (s *InterviewSession) ContinueInterview(ctx context.Context, answers []Answer) ([]Question, bool, *Requirements, error) {
 	// Store the current round with questions and answers.
 	s.PreviousRounds = append(s.PreviousRounds, Round{
 		Questions: s.currentQuestions,
 		Answers:   answers,
 	})
 
+	// Present questions deferred by the per-round cap before asking Claude
+	// for more. These batches belong to the same Claude round.
+	if len(s.deferred) > 0 {
+		s.currentQuestions, s.deferred = takeQuestions(s.deferred, s.Config.Understand.MaxQuestionsPerRound)
+		return s.currentQuestions, false, nil, nil
+	}
+
 	// Increment round count.
 	s.CurrentRound++
 
@@ -171,16 +196,16 @@ func (s *InterviewSession) ContinueInterview(answers []Answer) ([]Question, bool
 	if s.CurrentRound > maxRounds {
 		// Try one last Claude call to finalize with all accumulated answers
 		prompt := BuildUnderstandPrompt(s.CurrentRound, s.PreviousRounds, s.StackInfo, s.GraphSummary, s.Description)
-		output, err := spawnClaude(prompt)
+		output, err := spawnClaudeStreaming(ctx, s.Config.Agent, prompt, spawnTimeout(s.Config), s.Output)
 		if err != nil {
 			return nil, false, nil, fmt.Errorf("interview: max rounds reached (%d), final attempt failed: %w", maxRounds, err)
 		}
-		cleaned := cleanJSONOutput(output)
+		cleaned := cleanJSONOutput(output, s.Config.Understand.TrailingJSON)
 		var resp UnderstandResponse
 		if err := json.Unmarshal([]byte(cleaned), &resp); err != nil {
 			return nil, false, nil, fmt.Errorf("interview: max rounds reached (%d), failed to parse: %w", maxRounds, err)
 		}
-		reqs, err := finalize(resp, s.RunDir)
+		reqs, err := finalize(resp, s.RunDir, maxRequirementsBytes(s.Config))
 		if err != nil {
 			return nil, false, nil, fmt.Errorf("interview: max rounds reached (%d), failed to finalize: %w", maxRounds, err)
 		}
@@ -191,13 +216,13 @@ func (s *InterviewSession) ContinueInterview(answers []Answer) ([]Question, bool
 	prompt := BuildUnderstandPrompt(s.CurrentRound, s.PreviousRounds, s.StackInfo, s.GraphSummary, s.Description)
 
 	// Spawn Claude for the next round.
-	output, err := spawnClaude(prompt)
+	output, err := spawnClaudeStreaming(ctx, s.Config.Agent, prompt, spawnTimeout(s.Config), s.Output)
 	if err != nil {
 		return nil, false, nil, fmt.Errorf("interview round %d: %w", s.CurrentRound, err)
 	}
 
 	// Parse Claude's response.
-	cleaned := cleanJSONOutput(output)
+	cleaned := cleanJSONOutput(output, s.Config.Understand.TrailingJSON)
 
 	var resp UnderstandResponse
 	if err := json.Unmarshal([]byte(cleaned), &resp); err != nil {
@@ -206,7 +231,7 @@ func (s *InterviewSession) ContinueInterview(answers []Answer) ([]Question, bool
 
 	// If Claude signals done, build and return requirements.
 	if resp.Done {
-		reqs, err := finalize(resp, s.RunDir)
+		reqs, err := finalize(resp, s.RunDir, maxRequirementsBytes(s.Config))
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -218,14 +243,28 @@ func (s *InterviewSession) ContinueInterview(answers []Answer) ([]Question, bool
 		return nil, false, nil, fmt.Errorf("interview round %d: claude returned done=false but no questions", s.CurrentRound)
 	}
 
-	s.currentQuestions = resp.Questions
-	return resp.Questions, false, nil, nil
+	ApplyStackRecommendations(resp.Questions, s.StackInfo)
+	s.currentQuestions, s.deferred = takeQuestions(resp.Questions, s.Config.Understand.MaxQuestionsPerRound)
+	return s.currentQuestions, false, nil, nil
+}
+
+// takeQuestions splits questions into the batch to present now and the rest,
+// deferred to follow-up batches. limit <= 0 uses config.DefaultMaxQuestionsPerRound.
+func takeQuestions(questions []Question, limit int) (batch, deferred []Question) {
+	if limit <= 0 {
+		limit = config.DefaultMaxQuestionsPerRound
+	}
+	if len(questions) <= limit {
+		return questions, nil
+	}
+	return questions[:limit], questions[limit:]
 }
 
 // RunUnderstand drives the interview loop to gather requirements from the user.
 //
-// If skipUnderstand is true, the description is used directly without any
-// interview rounds. Otherwise, Claude is spawned once per round to generate
+// If requirementsFile is set, that pre-written markdown document is used as
+// the requirements as-is. If skipUnderstand is true, the description is used
+// directly without any interview rounds. Otherwise, Claude is spawned once per round to generate
 // questions, and the loop continues until Claude signals done or the safety
 // cap is reached.
 //
@@ -233,21 +272,25 @@ func (s *InterviewSession) ContinueInterview(answers []Answer) ([]Question, bool
 // where requirements.md will be written.
 //
 // The logger parameter is optional; if provided, approval choices are logged.
-func RunUnderstand(cfg config.Config, stackInfo detect.StackInfo, description string, skipUnderstand bool, runDir string, graphSummary string, logger *log.Logger) (*Requirements, error) {
+// The recorder parameter is optional; if provided, chat messages are saved to
+// the session store as they happen.
+func RunUnderstand(cfg config.Config, stackInfo detect.StackInfo, description string, skipUnderstand bool, requirementsFile string, runDir string, graphSummary string, logger *log.Logger, recorder *ChatRecorder) (*Requirements, error) {
+	if requirementsFile != "" {
+		return loadRequirementsFile(requirementsFile, runDir, maxRequirementsBytes(cfg))
+	}
 	if skipUnderstand {
-		return buildSkipRequirements(description, runDir)
+		return buildSkipRequirements(description, runDir, maxRequirementsBytes(cfg))
 	}
 
-	return runInterviewLoop(cfg, stackInfo, description, runDir, graphSummary, logger)
+	return runInterviewLoop(cfg, stackInfo, description, runDir, graphSummary, logger, recorder)
 }
 
 // buildSkipRequirements creates a Requirements directly from the raw
 // description, skipping the interview entirely.
-func buildSkipRequirements(description string, runDir string) (*Requirements, error) {
+func buildSkipRequirements(description string, runDir string, maxBytes int) (*Requirements, error) {
 	title := extractTitle(description)
-	content := fmt.Sprintf("# Requirements: %s\n\n## Description\n%s\n", title, description)
-
-	if err := writeRequirements(runDir, content); err != nil {
+	content, err := writeRequirements(runDir, fmt.Sprintf("# Requirements: %s\n\n## Description\n%s\n", title, description), maxBytes)
+	if err != nil {
 		return nil, err
 	}
 
@@ -257,12 +300,51 @@ func buildSkipRequirements(description string, runDir string) (*Requirements, er
 	}, nil
 }
 
+// loadRequirementsFile uses a requirements document written outside berth:
+// it must contain at least one markdown heading, and is copied into the run
+// directory like generated requirements.
+func loadRequirementsFile(path string, runDir string, maxBytes int) (*Requirements, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("understand: reading requirements file: %w", err)
+	}
+	if !hasHeading(string(data)) {
+		return nil, fmt.Errorf("understand: requirements file %s has no markdown heading", path)
+	}
+
+	content, err := writeRequirements(runDir, string(data), maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Requirements{
+		Title:   extractTitle(content),
+		Content: content,
+	}, nil
+}
+
+// hasHeading reports whether content has an ATX markdown heading of any level.
+func hasHeading(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level >= 1 && level <= 6 && strings.HasPrefix(line[level:], " ") {
+			return true
+		}
+	}
+	return false
+}
+
 // runInterviewLoop is the core loop that spawns Claude once per round.
 // After requirements are gathered, presents an approval gate with options:
 // accept, interview more, or chat about the plan.
-func runInterviewLoop(cfg config.Config, stackInfo detect.StackInfo, description string, runDir string, graphSummary string, logger *log.Logger) (*Requirements, error) {
+func runInterviewLoop(cfg config.Config, stackInfo detect.StackInfo, description string, runDir string, graphSummary string, logger *log.Logger, recorder *ChatRecorder) (*Requirements, error) {
 	var rounds []Round
 
+	if !cfg.Understand.HideContext {
+		printCodebaseContext(os.Stdout, graphSummary)
+	}
+
 	for round := 1; round <= maxRounds; round++ {
 		fmt.Printf("\n--- Interview Round %d ---\n", round)
 
@@ -270,14 +352,20 @@ func runInterviewLoop(cfg config.Config, stackInfo detect.StackInfo, description
 		prompt := BuildUnderstandPrompt(round, rounds, stackInfo, graphSummary, description)
 
 		// Spawn Claude to generate questions or final requirements.
-		output, err := spawnClaude(prompt)
+		output, err := ui.RunWithSpinner("Generating questions...", func(ctx context.Context) (string, error) {
+			return spawnClaude(ctx, cfg.Agent, prompt, spawnTimeout(cfg))
+		})
+		if errors.Is(err, errSpawnTimeout) && confirmRetry(err) {
+			round--
+			continue
+		}
 		if err != nil {
 			return nil, fmt.Errorf("understand round %d: %w", round, err)
 		}
 
 		// Parse Claude's response. The output might contain markdown fences
 		// or leading/trailing whitespace; try to extract valid JSON.
-		cleaned := cleanJSONOutput(output)
+		cleaned := cleanJSONOutput(output, cfg.Understand.TrailingJSON)
 
 		var resp UnderstandResponse
 		if err := json.Unmarshal([]byte(cleaned), &resp); err != nil {
@@ -286,7 +374,7 @@ func runInterviewLoop(cfg config.Config, stackInfo detect.StackInfo, description
 
 		// If Claude signals done, present approval gate before finalizing.
 		if resp.Done {
-			reqs, err := finalize(resp, runDir)
+			reqs, err := finalize(resp, runDir, maxRequirementsBytes(cfg))
 			if err != nil {
 				return nil, err
 			}
@@ -318,20 +406,22 @@ func runInterviewLoop(cfg config.Config, stackInfo detect.StackInfo, description
 				continue
 
 			case ApprovalChat:
-				chatChoice, chatMessages := runChatLoop(reqs.Content, stackInfo, graphSummary)
+				chatChoice, chatMessages := runChatLoop(cfg.Agent, reqs.Content, stackInfo, graphSummary, spawnTimeout(cfg), recorder)
 
 				// If there were chat messages, regenerate requirements with chat content.
 				if len(chatMessages) > 0 {
 					fmt.Println("\nUpdating requirements with chat discussion...")
-					updatedContent, err := regenerateRequirementsWithChat(reqs.Content, chatMessages, stackInfo, graphSummary)
+					updatedContent, err := regenerateRequirementsWithChat(cfg.Agent, reqs.Content, chatMessages, stackInfo, graphSummary, spawnTimeout(cfg))
 					if err != nil {
 						fmt.Printf("  (Warning: could not incorporate chat: %v)\n", err)
 					} else {
-						reqs.Content = updatedContent
 						reqs.Title = extractTitle(updatedContent)
-						if err := writeRequirements(runDir, updatedContent); err != nil {
+						if written, err := writeRequirements(runDir, updatedContent, maxRequirementsBytes(cfg)); err != nil {
 							fmt.Printf("  (Warning: could not update requirements file: %v)\n", err)
+						} else {
+							updatedContent = written
 						}
+						reqs.Content = updatedContent
 
 						// Show updated requirements summary.
 						fmt.Println()
@@ -380,20 +470,52 @@ func runInterviewLoop(cfg config.Config, stackInfo detect.StackInfo, description
 			fmt.Printf("\nContext: %s\n", resp.Context)
 		}
 
-		answers := displayAndCollectAnswers(resp.Questions, stackInfo, graphSummary)
+		// Present at most MaxQuestionsPerRound at a time; the rest follow
+		// before Claude is asked for the next round.
+		pending := ApplyStackRecommendations(resp.Questions, stackInfo)
+		for len(pending) > 0 {
+			var batch []Question
+			batch, pending = takeQuestions(pending, cfg.Understand.MaxQuestionsPerRound)
+			if len(pending) > 0 {
+				fmt.Printf("(%d more questions will follow)\n", len(pending))
+			}
 
-		rounds = append(rounds, Round{
-			Questions: resp.Questions,
-			Answers:   answers,
-		})
+			ask := func(qs []Question) []Answer {
+				return displayAndCollectAnswers(cfg.Agent, qs, stackInfo, graphSummary)
+			}
+			var answers []Answer
+			if cfg.Understand.AcceptDefaults {
+				answers = acceptDefaults(batch, ask)
+			} else {
+				answers = ask(batch)
+			}
+
+			rounds = append(rounds, Round{
+				Questions: batch,
+				Answers:   answers,
+			})
+		}
 	}
 
 	return nil, fmt.Errorf("understand: reached maximum rounds (%d) without completion", maxRounds)
 }
 
+// printCodebaseContext prints what the Knowledge Graph told berth about the
+// codebase, so the user knows what berth already knows before answering.
+// Nothing is printed when the summary is empty.
+func printCodebaseContext(w io.Writer, graphSummary string) {
+	summary := strings.TrimSpace(graphSummary)
+	if summary == "" {
+		return
+	}
+	fmt.Fprintln(w, "\n--- Codebase context ---")
+	fmt.Fprintln(w, summary)
+	fmt.Fprintln(w, "(set understand.hide_context to skip this section)")
+}
+
 // displayAndCollectAnswers shows questions to the user, handles "Help me
 // decide" requests, and returns the final answers.
-func displayAndCollectAnswers(questions []Question, stackInfo detect.StackInfo, graphSummary string) []Answer {
+func displayAndCollectAnswers(agent config.AgentConfig, questions []Question, stackInfo detect.StackInfo, graphSummary string) []Answer {
 	answers := DisplayQuestions(questions)
 
 	// Post-process: handle "Help me decide" selections.
@@ -411,7 +533,7 @@ func displayAndCollectAnswers(questions []Question, stackInfo detect.StackInfo,
 			}
 		}
 
-		explanation, err := RunExplain(q, stackInfo, graphSummary)
+		explanation, err := RunExplain(agent, q, stackInfo, graphSummary)
 		if err != nil {
 			fmt.Printf("\n  (Could not get explanation: %v)\n", err)
 		} else {
@@ -449,15 +571,15 @@ type ChatMessage struct {
 
 // finalize writes the requirements markdown to disk, presents the approval
 // gate, and returns the Requirements struct only if approved.
-func finalize(resp UnderstandResponse, runDir string) (*Requirements, error) {
-	content := resp.RequirementsMD
-	if content == "" {
+func finalize(resp UnderstandResponse, runDir string, maxBytes int) (*Requirements, error) {
+	if resp.RequirementsMD == "" {
 		return nil, fmt.Errorf("understand: claude signaled done but requirements_md is empty")
 	}
 
-	title := extractTitle(content)
+	title := extractTitle(resp.RequirementsMD)
 
-	if err := writeRequirements(runDir, content); err != nil {
+	content, err := writeRequirements(runDir, resp.RequirementsMD, maxBytes)
+	if err != nil {
 		return nil, err
 	}
 
@@ -521,15 +643,43 @@ func presentApprovalGate(content string) ApprovalChoice {
 	}
 }
 
+// confirmRetry reports err, a timed-out Claude call, and asks whether to
+// run the call again. Anything but yes gives up.
+func confirmRetry(err error) bool {
+	fmt.Printf("\n%v. Retry? [y/N] ", err)
+	line, readErr := bufio.NewReader(os.Stdin).ReadString('\n')
+	if readErr != nil {
+		fmt.Println()
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
 // runChatLoop allows the user to have a conversation about the plan before
 // deciding to accept or continue interviewing. It returns both the user's
 // choice and the captured chat messages for incorporation into requirements.
-func runChatLoop(content string, stackInfo detect.StackInfo, graphSummary string) (ApprovalChoice, []ChatMessage) {
+// Each message is also saved through the recorder, and any discussion already
+// recorded for the session is shown before the prompt.
+func runChatLoop(agent config.AgentConfig, content string, stackInfo detect.StackInfo, graphSummary string, timeout time.Duration, recorder *ChatRecorder) (ApprovalChoice, []ChatMessage) {
 	reader := bufio.NewReader(os.Stdin)
 	var messages []ChatMessage
 
 	fmt.Println()
 	fmt.Println("=== Chat Mode ===")
+
+	if history := recorder.History(); len(history) > 0 {
+		fmt.Println("Previous discussion:")
+		for _, m := range history {
+			if m.Role == "user" {
+				fmt.Printf("You: %s\n", m.Content)
+			} else {
+				fmt.Printf("Claude: %s\n", m.Content)
+			}
+		}
+		fmt.Println()
+	}
+
 	fmt.Println("Ask questions about the requirements or plan. Type 'done' when ready to decide.")
 	fmt.Println()
 
@@ -551,18 +701,24 @@ func runChatLoop(content string, stackInfo detect.StackInfo, graphSummary string
 		}
 
 		// Capture user message.
-		messages = append(messages, ChatMessage{Role: "user", Content: line})
+		userMsg := ChatMessage{Role: "user", Content: line}
+		messages = append(messages, userMsg)
+		recorder.Record(userMsg)
 
 		// Build a prompt to answer the user's question.
 		prompt := buildChatPrompt(content, line, stackInfo, graphSummary)
-		response, err := spawnClaude(prompt)
+		response, err := ui.RunWithSpinner("Thinking...", func(ctx context.Context) (string, error) {
+			return spawnClaude(ctx, agent, prompt, timeout)
+		})
 		if err != nil {
 			fmt.Printf("  (Error getting response: %v)\n", err)
 			continue
 		}
 
 		// Capture assistant response.
-		messages = append(messages, ChatMessage{Role: "assistant", Content: response})
+		assistantMsg := ChatMessage{Role: "assistant", Content: response}
+		messages = append(messages, assistantMsg)
+		recorder.Record(assistantMsg)
 
 		fmt.Println()
 		fmt.Printf("Claude: %s\n", response)
@@ -591,9 +747,11 @@ func runChatLoop(content string, stackInfo detect.StackInfo, graphSummary string
 
 // regenerateRequirementsWithChat takes the original requirements and chat messages
 // and spawns Claude to incorporate the chat discussion into updated requirements.
-func regenerateRequirementsWithChat(originalReqs string, chatMessages []ChatMessage, stackInfo detect.StackInfo, graphSummary string) (string, error) {
+func regenerateRequirementsWithChat(agent config.AgentConfig, originalReqs string, chatMessages []ChatMessage, stackInfo detect.StackInfo, graphSummary string, timeout time.Duration) (string, error) {
 	prompt := BuildRegeneratePrompt(originalReqs, chatMessages, stackInfo, graphSummary)
-	output, err := spawnClaude(prompt)
+	output, err := ui.RunWithSpinner("Updating requirements...", func(ctx context.Context) (string, error) {
+		return spawnClaude(ctx, agent, prompt, timeout)
+	})
 	if err != nil {
 		return "", fmt.Errorf("regenerating requirements: %w", err)
 	}
@@ -620,6 +778,17 @@ func regenerateRequirementsWithChat(originalReqs string, chatMessages []ChatMess
 	return output, nil
 }
 
+// Chat answers a message the user typed in the chat opened from the
+// interview question questionText, with the task and stack as context.
+func (s *InterviewSession) Chat(ctx context.Context, questionText, message string) (string, error) {
+	about := "Task: " + s.Description
+	if questionText != "" {
+		about += "\n\nInterview question being answered: " + questionText
+	}
+	prompt := buildChatPrompt(about, message, s.StackInfo, s.GraphSummary)
+	return spawnClaude(ctx, s.Config.Agent, prompt, spawnTimeout(s.Config))
+}
+
 // buildChatPrompt creates a prompt for answering questions about the requirements.
 func buildChatPrompt(requirements, question string, stackInfo detect.StackInfo, graphSummary string) string {
 	var sb strings.Builder
@@ -686,19 +855,38 @@ func logApprovalChoice(logger *log.Logger, choice ApprovalChoice, title string)
 	})
 }
 
+// defaultMaxRequirementsBytes caps requirements.md when
+// understand.max_requirements_bytes is unset.
+const defaultMaxRequirementsBytes = 512 * 1024
+
+// maxRequirementsBytes returns the size requirements.md is truncated past.
+func maxRequirementsBytes(cfg config.Config) int {
+	if cfg.Understand.MaxRequirementsBytes > 0 {
+		return cfg.Understand.MaxRequirementsBytes
+	}
+	return defaultMaxRequirementsBytes
+}
+
 // writeRequirements creates the run directory if needed and writes the
-// requirements markdown file.
-func writeRequirements(runDir string, content string) error {
+// requirements markdown file. Content over maxBytes is truncated with a
+// marker and a warning, so a runaway response cannot bloat the run
+// directory or the prompts of later phases. Returns the content written.
+func writeRequirements(runDir string, content string, maxBytes int) (string, error) {
 	if err := os.MkdirAll(runDir, 0755); err != nil {
-		return fmt.Errorf("understand: creating run directory: %w", err)
+		return "", fmt.Errorf("understand: creating run directory: %w", err)
+	}
+
+	if cut, truncated := ui.TruncateBytes(content, maxBytes); truncated {
+		fmt.Fprintf(os.Stderr, "Warning: requirements are %d bytes, over the %d-byte limit (understand.max_requirements_bytes); truncating\n", len(content), maxBytes)
+		content = cut + fmt.Sprintf("\n<!-- berth: truncated, %d bytes omitted (understand.max_requirements_bytes) -->\n", len(content)-len(cut))
 	}
 
 	path := filepath.Join(runDir, "requirements.md")
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-		return fmt.Errorf("understand: writing requirements: %w", err)
+		return "", fmt.Errorf("understand: writing requirements: %w", err)
 	}
 
-	return nil
+	return content, nil
 }
 
 // extractTitle attempts to pull a title from a markdown requirements document.
@@ -720,7 +908,12 @@ func extractTitle(content string) string {
 // cleanJSONOutput extracts JSON from Claude's output, handling cases where
 // the model includes explanatory text before/after the JSON or wraps it in
 // markdown code fences.
-func cleanJSONOutput(s string) string {
+//
+// When a second JSON object follows the first (the model "self-corrected"),
+// trailing selects which one is returned: "first", or "last" (the default
+// for any other value). Either way a warning is printed so the choice is
+// visible.
+func cleanJSONOutput(s string, trailing string) string {
 	s = strings.TrimSpace(s)
 
 	// Find the start of a JSON object.
@@ -735,6 +928,15 @@ func cleanJSONOutput(s string) string {
 	decoder := json.NewDecoder(strings.NewReader(s[start:]))
 	var raw json.RawMessage
 	if err := decoder.Decode(&raw); err == nil {
+		rest := s[start+int(decoder.InputOffset()):]
+		if last := lastJSONObject(rest); last != "" {
+			if trailing == "first" {
+				fmt.Fprintf(os.Stderr, "Warning: Claude's response contained more than one JSON object; using the first (understand.trailing_json: first)\n")
+				return string(raw)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: Claude's response contained more than one JSON object; using the last\n")
+			return last
+		}
 		return string(raw)
 	}
 
@@ -752,3 +954,24 @@ func cleanJSONOutput(s string) string {
 
 	return s
 }
+
+// lastJSONObject returns the last complete JSON object found in s, or ""
+// if s holds none. Braces in prose that do not start a valid object are
+// skipped.
+func lastJSONObject(s string) string {
+	var last string
+	for {
+		start := strings.Index(s, "{")
+		if start == -1 {
+			return last
+		}
+		decoder := json.NewDecoder(strings.NewReader(s[start:]))
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			s = s[start+1:]
+			continue
+		}
+		last = string(raw)
+		s = s[start+int(decoder.InputOffset()):]
+	}
+}
@@ -1,6 +1,19 @@
 package understand
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/detect"
+	"github.com/berth-dev/berth/internal/testutil"
+)
 
 func TestCleanJSONOutput(t *testing.T) {
 	tests := []struct {
@@ -52,10 +65,173 @@ func TestCleanJSONOutput(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := cleanJSONOutput(tt.input)
+			got := cleanJSONOutput(tt.input, "")
+			if got != tt.want {
+				t.Errorf("cleanJSONOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanJSONOutput_TrailingObjects(t *testing.T) {
+	single := `{"done": false, "questions": []}`
+	withProse := `{"done": true}` + "\nLet me know if {anything} else is needed."
+	twoObjects := `{"done": false}` + "\nActually, correction:\n" + `{"done": true, "requirements_md": "# Fixed"}`
+
+	tests := []struct {
+		name     string
+		input    string
+		trailing string
+		want     string
+	}{
+		{"single object", single, "", single},
+		{"object plus prose", withProse, "", `{"done": true}`},
+		{"two objects default prefers last", twoObjects, "", `{"done": true, "requirements_md": "# Fixed"}`},
+		{"two objects explicit last", twoObjects, "last", `{"done": true, "requirements_md": "# Fixed"}`},
+		{"two objects first", twoObjects, "first", `{"done": false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cleanJSONOutput(tt.input, tt.trailing)
 			if got != tt.want {
 				t.Errorf("cleanJSONOutput() = %q, want %q", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestInterviewSessionDefersQuestionsOverCap(t *testing.T) {
+	var questions []Question
+	for i := 1; i <= 7; i++ {
+		questions = append(questions, Question{
+			ID:      fmt.Sprintf("q%d", i),
+			Text:    fmt.Sprintf("Question %d?", i),
+			Options: []Option{{Key: "1", Label: "Yes"}},
+		})
+	}
+	result, err := json.Marshal(UnderstandResponse{Questions: questions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope, err := json.Marshal(map[string]any{"type": "result", "result": string(result)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fake claude prints the over-cap round and counts its invocations.
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls")
+	if err := os.WriteFile(filepath.Join(bin, "response.json"), envelope, 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.FakeCommand(t, "claude", fmt.Sprintf("echo x >> %q\ncat %q\n", calls, filepath.Join(bin, "response.json")))
+
+	cfg := config.Config{Understand: config.UnderstandConfig{MaxQuestionsPerRound: 3}}
+	session, first, err := StartInterviewSession(context.Background(), cfg, detect.StackInfo{}, "add auth", t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("StartInterviewSession: %v", err)
+	}
+	if len(first) != 3 || first[0].ID != "q1" {
+		t.Fatalf("first batch = %d questions, want q1..q3", len(first))
+	}
+
+	// Deferred questions come next without another Claude call.
+	second, done, _, err := session.ContinueInterview(context.Background(), []Answer{{ID: "q1", Value: "Yes"}})
+	if err != nil || done {
+		t.Fatalf("ContinueInterview: done=%v err=%v", done, err)
+	}
+	if len(second) != 3 || second[0].ID != "q4" {
+		t.Fatalf("second batch = %v, want q4..q6", second)
+	}
+	third, _, _, err := session.ContinueInterview(context.Background(), nil)
+	if err != nil || len(third) != 1 || third[0].ID != "q7" {
+		t.Fatalf("third batch = %v (err %v), want q7", third, err)
+	}
+
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "x"); n != 1 {
+		t.Errorf("claude called %d times, want 1", n)
+	}
+	if session.CurrentRound != 1 {
+		t.Errorf("CurrentRound = %d, want deferred batches to stay in round 1", session.CurrentRound)
+	}
+}
+
+func TestPrintCodebaseContext(t *testing.T) {
+	var buf bytes.Buffer
+	printCodebaseContext(&buf, "  \n")
+	if buf.Len() != 0 {
+		t.Errorf("empty summary printed %q", buf.String())
+	}
+
+	printCodebaseContext(&buf, "## Codebase\n- internal/auth: login handlers\n")
+	out := buf.String()
+	if !strings.Contains(out, "--- Codebase context ---") || !strings.Contains(out, "login handlers") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestRunUnderstandRequirementsFile(t *testing.T) {
+	dir := t.TempDir()
+	runDir := filepath.Join(dir, "run")
+	path := filepath.Join(dir, "oauth.md")
+	content := "# Requirements: Add OAuth login\n\n## Scope\n- Google provider only\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file wins over skipUnderstand and the description.
+	reqs, err := RunUnderstand(config.Config{}, detect.StackInfo{}, "ignored", true, path, runDir, "", nil, nil)
+	if err != nil {
+		t.Fatalf("RunUnderstand: %v", err)
+	}
+	if reqs.Title != "Add OAuth login" {
+		t.Errorf("Title = %q, want %q", reqs.Title, "Add OAuth login")
+	}
+	if reqs.Content != content {
+		t.Errorf("Content = %q, want the file unchanged", reqs.Content)
+	}
+	written, err := os.ReadFile(filepath.Join(runDir, "requirements.md"))
+	if err != nil || string(written) != content {
+		t.Errorf("run dir requirements.md = %q, %v; want the file copied", written, err)
+	}
+
+	if err := os.WriteFile(path, []byte("just some notes\n#hashtag\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RunUnderstand(config.Config{}, detect.StackInfo{}, "", false, path, runDir, "", nil, nil); err == nil || !strings.Contains(err.Error(), "no markdown heading") {
+		t.Errorf("file without a heading: err = %v, want a missing heading error", err)
+	}
+}
+
+func TestWriteRequirementsTruncatesOverCap(t *testing.T) {
+	runDir := t.TempDir()
+	content := "# Requirements: Login\n\n## Scope\n" + strings.Repeat("- é\n", 50)
+
+	written, err := writeRequirements(runDir, content, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(runDir, "requirements.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != written {
+		t.Errorf("returned content %q differs from requirements.md %q", written, data)
+	}
+	body, marker, ok := strings.Cut(written, "<!-- berth: truncated")
+	if !ok {
+		t.Fatalf("requirements = %q, want a truncation marker", written)
+	}
+	if len(body) > 41 || !strings.HasSuffix(body, "\n\n") || !strings.Contains(marker, "understand.max_requirements_bytes") {
+		t.Errorf("requirements = %q, want at most 40 bytes of whole lines then the marker", written)
+	}
+
+	if written, _ := writeRequirements(runDir, "# Short\n", 40); written != "# Short\n" {
+		t.Errorf("content under the cap = %q, want it unchanged", written)
+	}
+}
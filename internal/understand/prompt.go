@@ -3,6 +3,7 @@ package understand
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/berth-dev/berth/internal/detect"
@@ -12,6 +13,8 @@ import (
 // interview round. The first round includes the task description, stack info,
 // and Knowledge Graph summary. Subsequent rounds accumulate all previous Q&A
 // history so Claude has full context when generating follow-up questions.
+// Answers may reference earlier answers as {{r<round>.<question id>}} or
+// {{<question id>}}; see expandAnswerRefs.
 func BuildUnderstandPrompt(round int, previousRounds []Round, stackInfo detect.StackInfo, graphSummary string, description string) string {
 	var sb strings.Builder
 
@@ -39,6 +42,7 @@ func BuildUnderstandPrompt(round int, previousRounds []Round, stackInfo detect.S
 	// Previous rounds (accumulated Q&A history).
 	if len(previousRounds) > 0 {
 		sb.WriteString("## Previous Interview Rounds\n")
+		prior := make(map[string]string) // answer reference -> expanded answer
 		for i, r := range previousRounds {
 			sb.WriteString(fmt.Sprintf("### Round %d\n", i+1))
 			for _, q := range r.Questions {
@@ -46,7 +50,10 @@ func BuildUnderstandPrompt(round int, previousRounds []Round, stackInfo detect.S
 				// Find the corresponding answer.
 				for _, a := range r.Answers {
 					if a.ID == q.ID {
-						sb.WriteString(fmt.Sprintf("**A:** %s\n", a.Value))
+						value := expandAnswerRefs(a.Value, prior)
+						prior[AnswerRef(i+1, a.ID)] = value
+						prior[a.ID] = value
+						sb.WriteString(fmt.Sprintf("**A:** %s\n", value))
 						break
 					}
 				}
@@ -70,6 +77,35 @@ func BuildUnderstandPrompt(round int, previousRounds []Round, stackInfo detect.S
 	return sb.String()
 }
 
+// answerRef matches a reference to an earlier answer, e.g. "{{r1.q1}}" or
+// "{{q1}}".
+var answerRef = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+
+// AnswerRef returns the reference an answer can use for the answer to
+// question id of round, e.g. "r1.q1".
+func AnswerRef(round int, id string) string {
+	return fmt.Sprintf("r%d.%s", round, id)
+}
+
+// expandAnswerRefs replaces each {{<reference>}} in value with the answer
+// given to that question in an earlier round or earlier in the same round,
+// so a custom answer can restate a prior choice without retyping it.
+// Claude numbers the questions of every round afresh, so a reference is
+// best qualified with its round, as in {{r1.q1}}; a bare {{q1}} refers to
+// the latest q1 answered. References to questions not answered yet are left
+// as typed.
+func expandAnswerRefs(value string, prior map[string]string) string {
+	if !strings.Contains(value, "{{") {
+		return value
+	}
+	return answerRef.ReplaceAllStringFunc(value, func(ref string) string {
+		if answer, ok := prior[answerRef.FindStringSubmatch(ref)[1]]; ok {
+			return answer
+		}
+		return ref
+	})
+}
+
 // formatStackInfo produces a human-readable summary of the detected stack.
 func formatStackInfo(s detect.StackInfo) string {
 	if s.Language == "" && s.Framework == "" {
@@ -135,6 +171,8 @@ If you need more information, respond with:
   ]
 }
 
+A question may set "multi_select": true to accept several options. For priority decisions, also set "ordered": true so the user ranks the options they pick; their answer lists them most important first.
+
 If you have enough information, respond with:
 {
   "done": true,
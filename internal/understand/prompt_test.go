@@ -0,0 +1,61 @@
+package understand
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/detect"
+)
+
+func TestBuildUnderstandPromptExpandsAnswerRefs(t *testing.T) {
+	rounds := []Round{
+		{
+			Questions: []Question{{ID: "q1", Text: "Which database?"}},
+			Answers:   []Answer{{ID: "q1", Value: "PostgreSQL"}},
+		},
+		{
+			Questions: []Question{
+				{ID: "q2", Text: "Where are sessions stored?"},
+				{ID: "q3", Text: "And rate limits?"},
+			},
+			Answers: []Answer{
+				{ID: "q2", Value: "Same as {{q1}}, in a sessions table"},
+				{ID: "q3", Value: "Like {{ q2 }}; {{q9}} is not asked yet"},
+			},
+		},
+	}
+
+	prompt := BuildUnderstandPrompt(3, rounds, detect.StackInfo{}, "", "Add login")
+
+	for _, want := range []string{
+		"**A:** PostgreSQL\n",
+		"**A:** Same as PostgreSQL, in a sessions table\n",
+		"**A:** Like Same as PostgreSQL, in a sessions table; {{q9}} is not asked yet\n",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestBuildUnderstandPromptRoundQualifiedRefs(t *testing.T) {
+	// Claude reuses q1 in every round.
+	rounds := []Round{
+		{
+			Questions: []Question{{ID: "q1", Text: "Which database?"}},
+			Answers:   []Answer{{ID: "q1", Value: "PostgreSQL"}},
+		},
+		{
+			Questions: []Question{{ID: "q1", Text: "Which cache?"}, {ID: "q2", Text: "Where are sessions stored?"}},
+			Answers: []Answer{
+				{ID: "q1", Value: "Redis"},
+				{ID: "q2", Value: "In {{r1.q1}}, cached in {{r2.q1}}; latest is {{q1}}, {{r3.q1}} not asked yet"},
+			},
+		},
+	}
+
+	prompt := BuildUnderstandPrompt(3, rounds, detect.StackInfo{}, "", "Add login")
+	if want := "**A:** In PostgreSQL, cached in Redis; latest is Redis, {{r3.q1}} not asked yet\n"; !strings.Contains(prompt, want) {
+		t.Errorf("prompt missing %q:\n%s", want, prompt)
+	}
+}
@@ -0,0 +1,180 @@
+// recommend.go marks interview options that match the detected stack as
+// recommended, so the obvious choice is highlighted even when Claude did
+// not flag it.
+package understand
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/berth-dev/berth/internal/detect"
+)
+
+// ApplyStackRecommendations marks as recommended the option of each question
+// that names the detected framework, language, package manager, or test
+// runner (e.g. "pytest" for a TestCmd of "pytest"). A question is left alone
+// when Claude already recommended one of its options, or when no option or
+// more than one matches. Recommendations are only ever added. questions is
+// modified in place and returned.
+func ApplyStackRecommendations(questions []Question, stackInfo detect.StackInfo) []Question {
+	terms := stackTerms(stackInfo)
+	if len(terms) == 0 {
+		return questions
+	}
+
+	for qi := range questions {
+		q := &questions[qi]
+		match := -1
+		for oi, o := range q.Options {
+			if o.Recommended {
+				match = -1
+				break
+			}
+			if !namesAny(o.Label, terms) {
+				continue
+			}
+			if match >= 0 {
+				match = -1 // ambiguous
+				break
+			}
+			match = oi
+		}
+		if match >= 0 {
+			q.Options[match].Recommended = true
+		}
+	}
+	return questions
+}
+
+// commonWordTerms maps stack names that are also ordinary English words,
+// which option labels use in passing ("Go with...", "Next steps"), to the
+// spellings that can only mean the stack.
+var commonWordTerms = map[string][]string{
+	"go":      {"golang"},
+	"next":    {"next.js", "nextjs"},
+	"express": {"express.js", "expressjs"},
+}
+
+// stackTerms returns the lowercased names the stack is known by. Names that
+// are common words are replaced by their unambiguous spellings, and other
+// names shorter than three letters are dropped.
+func stackTerms(s detect.StackInfo) map[string]bool {
+	terms := make(map[string]bool)
+	add := func(t string) {
+		t = strings.ToLower(t)
+		if forms, ok := commonWordTerms[t]; ok {
+			for _, f := range forms {
+				terms[f] = true
+			}
+			return
+		}
+		if len(t) >= 3 {
+			terms[t] = true
+		}
+	}
+	for _, t := range []string{s.Framework, s.Language, s.PackageManager} {
+		if t != "" {
+			add(t)
+		}
+	}
+	// The test runner is the command's first real word: "pytest" in
+	// "pytest -q", "vitest" in "npx vitest run", nothing in "pnpm test".
+	for _, word := range strings.Fields(s.TestCmd) {
+		word = strings.ToLower(word)
+		if word == "npx" || word == strings.ToLower(s.PackageManager) || strings.HasPrefix(word, "-") {
+			continue
+		}
+		if word != "test" && word != "run" {
+			add(word)
+		}
+		break
+	}
+	return terms
+}
+
+// labelWords splits s into its lowercased words, dropping punctuation.
+func labelWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// namesAny reports whether label contains one of terms as whole words,
+// ignoring case and punctuation ("Next.js" names "next.js").
+func namesAny(label string, terms map[string]bool) bool {
+	words := labelWords(label)
+	for term := range terms {
+		tw := labelWords(term)
+		if len(tw) == 0 {
+			continue
+		}
+		for i := 0; i+len(tw) <= len(words); i++ {
+			if slices.Equal(words[i:i+len(tw)], tw) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DefaultAnswer answers q with its recommended option, or its first option
+// when none is recommended. Multi-select questions get every recommended
+// option; ordered questions get every option, the recommended ones first.
+// It reports false for a question without options, which needs typed
+// input.
+func DefaultAnswer(q Question) (Answer, bool) {
+	if len(q.Options) == 0 {
+		return Answer{}, false
+	}
+	var picked, rest []string
+	for _, o := range q.Options {
+		if o.Recommended {
+			picked = append(picked, o.Label)
+		} else {
+			rest = append(rest, o.Label)
+		}
+	}
+	if q.Ordered {
+		return Answer{ID: q.ID, Values: append(picked, rest...)}, true
+	}
+	if len(picked) == 0 {
+		picked = []string{q.Options[0].Label}
+	}
+	if q.MultiSelect {
+		return Answer{ID: q.ID, Values: picked}, true
+	}
+	return Answer{ID: q.ID, Value: picked[0]}, true
+}
+
+// acceptDefaults answers every question with DefaultAnswer, asking via ask
+// only for those without options. Answers keep the order of questions.
+func acceptDefaults(questions []Question, ask func([]Question) []Answer) []Answer {
+	answers := make([]Answer, len(questions))
+	var typed []Question
+	var typedIdx []int
+	for i, q := range questions {
+		if a, ok := DefaultAnswer(q); ok {
+			fmt.Printf("\n%s\n  > %s (default)\n", q.Text, answerText(a))
+			answers[i] = a
+			continue
+		}
+		typed = append(typed, q)
+		typedIdx = append(typedIdx, i)
+	}
+	if len(typed) > 0 {
+		for j, a := range ask(typed) {
+			answers[typedIdx[j]] = a
+		}
+	}
+	return answers
+}
+
+// answerText renders an answer's selection for display.
+func answerText(a Answer) string {
+	if len(a.Values) > 0 {
+		return strings.Join(a.Values, ", ")
+	}
+	return a.Value
+}
@@ -0,0 +1,153 @@
+package understand
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/berth-dev/berth/internal/detect"
+)
+
+func recommended(q Question) []string {
+	var keys []string
+	for _, o := range q.Options {
+		if o.Recommended {
+			keys = append(keys, o.Key)
+		}
+	}
+	return keys
+}
+
+func TestApplyStackRecommendations(t *testing.T) {
+	stack := detect.StackInfo{
+		Language:       "typescript",
+		Framework:      "next",
+		PackageManager: "pnpm",
+		TestCmd:        "pnpm vitest run",
+	}
+	questions := []Question{
+		{ID: "q1", Text: "Which test framework?", Options: []Option{
+			{Key: "a", Label: "Jest"},
+			{Key: "b", Label: "Vitest"},
+			{Key: "c", Label: "Mocha"},
+		}},
+		{ID: "q2", Text: "Where should the page live?", Options: []Option{
+			{Key: "a", Label: "A new Next.js route"},
+			{Key: "b", Label: "A standalone Express server"},
+		}},
+		{ID: "q3", Text: "Which test framework?", Options: []Option{
+			{Key: "a", Label: "Jest", Recommended: true},
+			{Key: "b", Label: "Vitest"},
+		}},
+		{ID: "q4", Text: "How should errors be shown?", Options: []Option{
+			{Key: "a", Label: "Toast"},
+			{Key: "b", Label: "Inline message"},
+		}},
+		{ID: "q5", Text: "Which package to add it to?", Options: []Option{
+			{Key: "a", Label: "A TypeScript util in the pnpm workspace root"},
+			{Key: "b", Label: "A TypeScript util in packages/shared"},
+		}},
+		{ID: "q6", Text: "What should happen after the import?", Options: []Option{
+			{Key: "a", Label: "Show the next steps"},
+			{Key: "b", Label: "Go back to the list"},
+		}},
+	}
+
+	got := ApplyStackRecommendations(questions, stack)
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{"q1", []string{"b"}}, // matches the test runner
+		{"q2", []string{"a"}}, // "Next.js" names the framework
+		{"q3", []string{"a"}}, // Claude's recommendation is kept, nothing added
+		{"q4", nil},           // nothing matches
+		{"q5", nil},           // both match: ambiguous
+		{"q6", nil},           // "next" as an ordinary word
+	}
+	for i, tt := range tests {
+		if keys := recommended(got[i]); !reflect.DeepEqual(keys, tt.want) {
+			t.Errorf("%s recommended = %v, want %v", tt.id, keys, tt.want)
+		}
+	}
+}
+
+func TestApplyStackRecommendationsIgnoresCommonWords(t *testing.T) {
+	stack := detect.StackInfo{Language: "go", Framework: "gin", TestCmd: "go test ./..."}
+	questions := []Question{
+		{ID: "q1", Text: "How should the handler be added?", Options: []Option{
+			{Key: "a", Label: "Go with a new file"},
+			{Key: "b", Label: "Extend the existing handler"},
+		}},
+		{ID: "q2", Text: "Which implementation?", Options: []Option{
+			{Key: "a", Label: "A Golang CLI"},
+			{Key: "b", Label: "A shell script"},
+		}},
+	}
+	ApplyStackRecommendations(questions, stack)
+	if keys := recommended(questions[0]); len(keys) != 0 {
+		t.Errorf("q1 recommended = %v, want none for \"Go\" as a verb", keys)
+	}
+	if keys := recommended(questions[1]); !reflect.DeepEqual(keys, []string{"a"}) {
+		t.Errorf("q2 recommended = %v, want [a] for \"Golang\"", keys)
+	}
+}
+
+func TestApplyStackRecommendationsUnknownStack(t *testing.T) {
+	questions := []Question{{ID: "q1", Options: []Option{{Key: "a", Label: "Go"}}}}
+	ApplyStackRecommendations(questions, detect.StackInfo{})
+	if keys := recommended(questions[0]); len(keys) != 0 {
+		t.Errorf("recommended = %v with no stack detected, want none", keys)
+	}
+}
+
+func TestDefaultAnswer(t *testing.T) {
+	tests := []struct {
+		name string
+		q    Question
+		want Answer
+	}{
+		{"recommended", Question{ID: "q1", Options: []Option{{Label: "Jest"}, {Label: "Vitest", Recommended: true}}}, Answer{ID: "q1", Value: "Vitest"}},
+		{"first without recommendation", Question{ID: "q2", Options: []Option{{Label: "REST"}, {Label: "GraphQL"}}}, Answer{ID: "q2", Value: "REST"}},
+		{"multi-select", Question{ID: "q3", MultiSelect: true, Options: []Option{{Label: "A", Recommended: true}, {Label: "B"}, {Label: "C", Recommended: true}}}, Answer{ID: "q3", Values: []string{"A", "C"}}},
+		{"ordered", Question{ID: "q5", Ordered: true, Options: []Option{{Label: "Speed"}, {Label: "Cost"}, {Label: "Safety", Recommended: true}}}, Answer{ID: "q5", Values: []string{"Safety", "Speed", "Cost"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DefaultAnswer(tt.q)
+			if !ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DefaultAnswer = %+v, %v; want %+v", got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := DefaultAnswer(Question{ID: "q4", AllowCustom: true}); ok {
+		t.Error("DefaultAnswer answered a question without options")
+	}
+}
+
+func TestAcceptDefaultsAsksOnlyFreeFormQuestions(t *testing.T) {
+	questions := []Question{
+		{ID: "q1", Options: []Option{{Label: "Yes", Recommended: true}, {Label: "No"}}},
+		{ID: "q2", Text: "Name the table?", AllowCustom: true},
+		{ID: "q3", Options: []Option{{Label: "Postgres"}}},
+	}
+
+	var asked []string
+	answers := acceptDefaults(questions, func(qs []Question) []Answer {
+		var out []Answer
+		for _, q := range qs {
+			asked = append(asked, q.ID)
+			out = append(out, Answer{ID: q.ID, Value: "users"})
+		}
+		return out
+	})
+
+	if !reflect.DeepEqual(asked, []string{"q2"}) {
+		t.Errorf("asked %v, want only q2", asked)
+	}
+	want := []Answer{{ID: "q1", Value: "Yes"}, {ID: "q2", Value: "users"}, {ID: "q3", Value: "Postgres"}}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("answers = %+v, want %+v", answers, want)
+	}
+}
@@ -0,0 +1,138 @@
+// stream.go streams Claude's output during an interview round so a TUI can
+// show what the model is doing while it generates questions.
+package understand
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/berth-dev/berth/internal/config"
+)
+
+// streamLine is the subset of a Claude stream-json line needed to follow an
+// interview round: assistant text and tool calls, and the final result.
+type streamLine struct {
+	Type    string `json:"type"`
+	Message struct {
+		Content []struct {
+			Type  string          `json:"type"`
+			Text  string          `json:"text"`
+			Name  string          `json:"name"`
+			Input json.RawMessage `json:"input"`
+		} `json:"content"`
+	} `json:"message"`
+	Result  string `json:"result"`
+	IsError bool   `json:"is_error"`
+}
+
+// spawnClaudeStreaming is spawnClaude with stream-json output: the model's
+// text and tool calls are sent to out as they arrive, and the result text is
+// returned once Claude exits. With a nil out it is spawnClaude. Sends never
+// block the round; output the receiver cannot keep up with is dropped.
+func spawnClaudeStreaming(parent context.Context, agent config.AgentConfig, prompt string, timeout time.Duration, out chan<- string) (string, error) {
+	if out == nil {
+		return spawnClaude(parent, agent, prompt, timeout)
+	}
+
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	cmd := agent.Cmd(ctx,
+		"-p", prompt,
+		"--allowedTools", "Read,Grep,Glob",
+		"--output-format", "stream-json",
+		"--verbose",
+		"--dangerously-skip-permissions",
+	)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", fmt.Errorf("running claude: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("running claude: %w", err)
+	}
+
+	var result *streamLine
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var line streamLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		switch line.Type {
+		case "assistant":
+			for _, text := range describeAssistant(line) {
+				sendOutput(out, text)
+			}
+		case "result":
+			result = &line
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("%w after %v", errSpawnTimeout, timeout)
+		}
+		if ctx.Err() == context.Canceled {
+			return "", fmt.Errorf("claude was canceled: parent process may have exited or been interrupted")
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("claude exited %d: %s", exitErr.ExitCode(), stderr.String())
+		}
+		return "", fmt.Errorf("running claude: %w", err)
+	}
+
+	if result == nil {
+		return "", fmt.Errorf("parsing claude output: no result in stream")
+	}
+	if result.IsError {
+		return "", fmt.Errorf("claude returned error: %s", result.Result)
+	}
+	return strings.TrimSpace(result.Result), nil
+}
+
+// describeAssistant renders an assistant message's text blocks as-is and its
+// tool calls as one line each, e.g. "Read internal/auth/login.go".
+func describeAssistant(line streamLine) []string {
+	var texts []string
+	for _, block := range line.Message.Content {
+		switch block.Type {
+		case "text":
+			if t := strings.TrimSpace(block.Text); t != "" {
+				texts = append(texts, t)
+			}
+		case "tool_use":
+			var input struct {
+				FilePath string `json:"file_path"`
+				Pattern  string `json:"pattern"`
+				Path     string `json:"path"`
+			}
+			_ = json.Unmarshal(block.Input, &input)
+			target := input.FilePath
+			if target == "" {
+				target = input.Pattern
+			}
+			if target == "" {
+				target = input.Path
+			}
+			texts = append(texts, strings.TrimSpace(block.Name+" "+target))
+		}
+	}
+	return texts
+}
+
+// sendOutput delivers text to out without holding up the round.
+func sendOutput(out chan<- string, text string) {
+	select {
+	case out <- text:
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,107 @@
+package understand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/berth-dev/berth/internal/config"
+	"github.com/berth-dev/berth/internal/testutil"
+)
+
+func TestSpawnClaudeStreamingSendsOutput(t *testing.T) {
+	stream := `{"type":"system","subtype":"init"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Checking how auth works."},{"type":"tool_use","name":"Read","input":{"file_path":"internal/auth.go"}}]}}
+not json
+{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Grep","input":{"pattern":"Login"}}]}}
+{"type":"result","result":"  {\"done\": false}  ","is_error":false}
+`
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "stream.jsonl"), []byte(stream), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.FakeCommand(t, "claude", fmt.Sprintf("cat %q\n", filepath.Join(bin, "stream.jsonl")))
+
+	out := make(chan string, 10)
+	result, err := spawnClaudeStreaming(context.Background(), config.AgentConfig{}, "prompt", defaultSpawnTimeout, out)
+	if err != nil {
+		t.Fatalf("spawnClaudeStreaming: %v", err)
+	}
+	if result != `{"done": false}` {
+		t.Errorf("result = %q, want the trimmed result text", result)
+	}
+
+	close(out)
+	var got []string
+	for text := range out {
+		got = append(got, text)
+	}
+	want := []string{"Checking how auth works.", "Read internal/auth.go", "Grep Login"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("streamed %q, want %q", got, want)
+	}
+}
+
+func TestSpawnClaudeStreamingReportsError(t *testing.T) {
+	testutil.FakeCommand(t, "claude", "echo '{\"type\":\"result\",\"result\":\"rate limited\",\"is_error\":true}'\n")
+
+	if _, err := spawnClaudeStreaming(context.Background(), config.AgentConfig{}, "prompt", defaultSpawnTimeout, make(chan string, 1)); err == nil {
+		t.Error("spawnClaudeStreaming succeeded on an error result")
+	}
+}
+
+func TestSpawnClaudeTimesOut(t *testing.T) {
+	testutil.FakeCommand(t, "claude", "exec sleep 10\n")
+
+	for _, out := range []chan string{nil, make(chan string, 1)} {
+		start := time.Now()
+		_, err := spawnClaudeStreaming(context.Background(), config.AgentConfig{}, "prompt", 200*time.Millisecond, out)
+		if !errors.Is(err, errSpawnTimeout) {
+			t.Errorf("streaming %v: error = %v, want a timeout", out != nil, err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("streaming %v: returned after %v, want soon after the timeout", out != nil, elapsed)
+		}
+	}
+
+	cfg := *config.DefaultConfig()
+	if got := spawnTimeout(cfg); got != defaultSpawnTimeout {
+		t.Errorf("default spawn timeout = %v, want %v", got, defaultSpawnTimeout)
+	}
+	cfg.Understand.SpawnTimeout = 30
+	if got := spawnTimeout(cfg); got != 30*time.Second {
+		t.Errorf("spawn timeout = %v, want 30s", got)
+	}
+}
+
+func TestSpawnClaudeUsesConfiguredAgent(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	script := fmt.Sprintf("echo \"$@\" >> %q\necho '{\"type\":\"result\",\"result\":\"ok\"}'\n", argsFile)
+	agent := config.AgentConfig{Command: testutil.FakeScript(t, "claude-wrapper", script), ExtraArgs: []string{"--add-dir", "/shared"}}
+
+	if _, err := spawnClaude(context.Background(), agent, "prompt", defaultSpawnTimeout); err != nil {
+		t.Fatalf("spawnClaude: %v", err)
+	}
+	if _, err := spawnClaudeStreaming(context.Background(), agent, "prompt", defaultSpawnTimeout, make(chan string, 1)); err != nil {
+		t.Fatalf("spawnClaudeStreaming: %v", err)
+	}
+
+	data, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("configured agent was not run: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("agent ran %d times, want 2: %q", len(lines), lines)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "-p prompt ") || !strings.HasSuffix(line, "--add-dir /shared") {
+			t.Errorf("agent args = %q, want the default args followed by the extra args", line)
+		}
+	}
+}
@@ -33,6 +33,12 @@ export interface CalleeResult {
   line: number;
 }
 
+export interface CallPathStep {
+  name: string;
+  file: string;
+  line: number;
+}
+
 export interface DependentResult {
   file: string;
   symbols_used: string[];
@@ -233,6 +239,34 @@ export class CodeGraphDB {
     return this.db.prepare(query).all(...params) as CalleeResult[];
   }
 
+  getCallPath(from: string, to: string, maxDepth = 10): CallPathStep[] {
+    // Breadth-first search over callees, so the first path found is shortest
+    const parent = new Map<string, string>([[from, '']]);
+    let frontier = [from];
+    for (let depth = 0; depth < maxDepth && frontier.length > 0 && !parent.has(to); depth++) {
+      const next: string[] = [];
+      for (const name of frontier) {
+        for (const callee of this.getCallees(name, undefined, 100)) {
+          if (!parent.has(callee.callee)) {
+            parent.set(callee.callee, name);
+            next.push(callee.callee);
+          }
+        }
+      }
+      frontier = next;
+    }
+    if (!parent.has(to) || this.getSymbolDefinition(from) === null) {
+      return [];
+    }
+
+    const path: CallPathStep[] = [];
+    for (let name = to; name !== ''; name = parent.get(name)!) {
+      const def = this.getSymbolDefinition(name);
+      path.unshift({ name, file: def?.file ?? '', line: def?.line ?? 0 });
+    }
+    return path;
+  }
+
   getDependents(filePath: string, limit = 20): DependentResult[] {
     const rows = this.db.prepare(`
       SELECT source_file AS file, imported_names
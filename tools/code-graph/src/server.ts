@@ -5,7 +5,7 @@ import {
 } from '@modelcontextprotocol/sdk/types.js';
 import type { CallToolResult } from '@modelcontextprotocol/sdk/types.js';
 import { CodeGraphDB } from './db.js';
-import { buildGraph, updateFiles } from './graph.js';
+import { buildGraph, removeFiles, updateFiles } from './graph.js';
 
 export function createServer(db: CodeGraphDB, projectRoot: string): Server {
   const server = new Server(
@@ -70,6 +70,35 @@ See also: get_callers for the reverse direction.`,
           required: ['symbol_name'],
         },
       },
+      {
+        name: 'get_call_path',
+        description: `HOW does one function reach another? Shortest call path from -> to.
+
+Use this to trace how a function connects to an entry point, e.g. before changing
+behaviour that only some call chains exercise.
+
+Example input: { "from": "main", "to": "validateUser" }
+Example output: {
+  "path": [
+    { "name": "main", "file": "src/index.ts", "line": 3 },
+    { "name": "loginHandler", "file": "src/routes/auth.ts", "line": 40 },
+    { "name": "validateUser", "file": "src/auth/validate.ts", "line": 15 }
+  ]
+}
+
+path is empty when "to" is not reachable from "from" within max_depth calls.
+All query tools are safe to retry (idempotent).
+See also: get_callers and get_callees for a single hop.`,
+        inputSchema: {
+          type: 'object' as const,
+          properties: {
+            from: { type: 'string', description: 'Function the path starts at' },
+            to: { type: 'string', description: 'Function the path ends at' },
+            max_depth: { type: 'number', description: 'Max calls to follow (default 10)' },
+          },
+          required: ['from', 'to'],
+        },
+      },
       {
         name: 'get_dependents',
         description: `WHAT breaks if this file changes? Broader than get_callers -- includes transitive deps.
@@ -266,6 +295,25 @@ Example output: { "reindexed_count": 2 }`,
           required: ['file_paths'],
         },
       },
+      {
+        name: 'remove_files',
+        description: `Drop the specified files from the graph (deleted or renamed away).
+Called by the Berth Go binary between beads after git commits. NOT available to executor Claude.
+
+Example input: { "file_paths": ["src/auth/legacy.ts"] }
+Example output: { "removed_count": 1 }`,
+        inputSchema: {
+          type: 'object' as const,
+          properties: {
+            file_paths: {
+              type: 'array',
+              items: { type: 'string' },
+              description: 'List of file paths (relative to project root) to remove',
+            },
+          },
+          required: ['file_paths'],
+        },
+      },
       {
         name: 'reindex',
         description: `Force full reindex of entire codebase. Called by Berth Go binary only.
@@ -302,6 +350,14 @@ Example output: { "file_count": 142, "symbol_count": 1893, "reference_count": 45
         return jsonResult({ callees });
       }
 
+      case 'get_call_path': {
+        const from = args?.from as string;
+        const to = args?.to as string;
+        const maxDepth = (args?.max_depth as number) ?? 10;
+        const path = db.getCallPath(from, to, maxDepth);
+        return jsonResult({ path });
+      }
+
       case 'get_dependents': {
         const filePath = args?.file_path as string;
         const limit = (args?.limit as number) ?? 20;
@@ -407,6 +463,12 @@ Example output: { "file_count": 142, "symbol_count": 1893, "reference_count": 45
         return jsonResult(result);
       }
 
+      case 'remove_files': {
+        const filePaths = args?.file_paths as string[];
+        removeFiles(filePaths, projectRoot, db);
+        return jsonResult({ removed_count: filePaths.length });
+      }
+
       case 'reindex': {
         const result = buildGraph(projectRoot, db);
         return jsonResult(result);